@@ -2,52 +2,409 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/cheezecakee/logr"
-	"github.com/joho/godotenv"
 
 	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
 	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/postgres"
 	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/jwt"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/mail"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/oidc"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/s3"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/filesystem"
+	"github.com/cheezecakee/fitrkr-athena/internal/buildinfo"
+	"github.com/cheezecakee/fitrkr-athena/internal/config"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/exports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/jobs"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/photos"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+	"github.com/cheezecakee/fitrkr-athena/internal/logging"
+	"github.com/cheezecakee/fitrkr-athena/internal/telemetry"
 )
 
 func main() {
-	logr.Init(&logr.PlainTextFormatter{}, logr.LevelInfo, nil)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		// Migrations only need the database, not the settings of the server
+		cfg, err := config.LoadMigrate()
+		if err != nil {
+			exitConfig(err)
+		}
+		logging.Init(cfg.LogFormat, cfg.LogLevel)
 
-	if err := godotenv.Load(); err != nil {
-		logr.Get().Errorf("No .env file found: %v", err)
+		if err := runMigrate(context.Background(), cfg.DB, os.Args[2:]); err != nil {
+			logr.Get().Errorf("migrate: %v", err)
+			os.Exit(1)
+		}
+		return
 	}
 
-	secretKey := os.Getenv("JWT_SECRET")
-	if secretKey == "" {
-		logr.Get().Error("JWT_SECRET environment variable not set")
+	cfg, err := config.Load()
+	if err != nil {
+		exitConfig(err)
+	}
+
+	logging.Init(cfg.LogFormat, cfg.LogLevel)
+
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		fmt.Print(cfg)
+		return
+	}
+
+	if err := run(cfg); err != nil {
+		logr.Get().Errorf("%v", err)
+		os.Exit(1)
+	}
+}
+
+// exitConfig reports a config error on stderr, the logger takes its level
+// and format from the config so it is not set up yet.
+func exitConfig(err error) {
+	fmt.Fprintln(os.Stderr, logging.Redact(fmt.Sprintf("failed to load config: %v", err)))
+	os.Exit(1)
+}
+
+// run serves until SIGINT or SIGTERM. On the way out the server drains,
+// then the background workers stop and the database closes last.
+func run(cfg *config.Config) error {
+	logr.Get().Infof("effective config:\n%s", cfg)
+
+	// The first signal starts the shutdown, a second one skips the drain delay
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	go func() {
+		<-signals
+		stop()
+	}()
+
+	tel, err := telemetry.Setup(ctx, telemetry.Config{
+		ServiceName:    cfg.Telemetry.ServiceName,
+		ServiceVersion: buildinfo.Get().Version,
+		Exporter:       cfg.Telemetry.Exporter,
+		OTLPEndpoint:   cfg.Telemetry.OTLPEndpoint,
+		SampleRatio:    cfg.Telemetry.SampleRatio,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to init telemetry: %w", err)
+	}
+
+	db, err := postgres.NewPostgresConn(dbConfig(cfg.DB))
+	if err != nil {
+		tel.Shutdown(context.Background())
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	jwtManager, err := newJWTManager(cfg.JWT)
+	if err != nil {
+		db.Close()
+		tel.Shutdown(context.Background())
+		return fmt.Errorf("failed to init jwt manager: %w", err)
+	}
+
+	queue, err := filesystem.NewJobQueue(cfg.Jobs.Dir)
+	if err != nil {
+		db.Close()
+		tel.Shutdown(context.Background())
+		return fmt.Errorf("failed to open job queue: %w", err)
+	}
+	worker := jobs.NewWorker(queue, jobs.WithPollInterval(cfg.Jobs.PollInterval))
+
+	server, services, err := newApp(cfg, db, jwtManager, tel, queue, worker, signals)
+	if err != nil {
+		db.Close()
+		tel.Shutdown(context.Background())
+		return err
 	}
-	jwtManager := jwt.NewJWTManager(secretKey, 0)
 
-	db := postgres.NewPostgresConn()
-	defer db.Close()
+	// Hooks run last to first, telemetry flushes after everything else
+	server.OnShutdown(func(ctx context.Context) error {
+		logr.Get().Info("Flushing telemetry...")
+		return tel.Shutdown(ctx)
+	})
+	server.OnShutdown(func(ctx context.Context) error {
+		logr.Get().Info("Closing database...")
+		return db.Close()
+	})
 
+	workers, stopWorkers := context.WithCancel(context.Background())
+	background := map[string]<-chan struct{}{
+		"key rotation":  jwtManager.StartRotation(workers, cfg.JWT.RotationInterval),
+		"job worker":    worker.Start(workers),
+		"export purge":  exports.StartPurge(workers, services.exports, cfg.Exports.PurgeInterval),
+		"account purge": users.StartPurge(workers, services.users, cfg.Accounts.PurgeInterval),
+	}
+	server.OnShutdown(func(ctx context.Context) error {
+		stopWorkers()
+		for name, done := range background {
+			select {
+			case <-done:
+			case <-ctx.Done():
+				return fmt.Errorf("%s did not stop: %w", name, ctx.Err())
+			}
+		}
+		return nil
+	})
+
+	logr.Get().Info("Starting server...")
+	if err := server.Run(ctx); err != nil {
+		return err
+	}
+
+	logr.Get().Info("Server stopped")
+	return nil
+}
+
+// backgroundServices are the services run on a schedule besides serving
+// requests.
+type backgroundServices struct {
+	users   users.UserService
+	exports exports.ExportService
+}
+
+func newApp(cfg *config.Config, db *sql.DB, jwtManager *jwt.JWTManager, tel *telemetry.Telemetry, queue ports.JobQueue, worker *jobs.Worker, signals <-chan os.Signal) (*web.App, backgroundServices, error) {
 	userRepo, err := postgres.NewUserRepo(db)
 	if err != nil {
-		logr.Get().Errorf("failed to init postgres user repo: %v", err)
+		return nil, backgroundServices{}, fmt.Errorf("failed to init postgres user repo: %w", err)
 	}
+
 	authRepo, err := postgres.NewAuthRepo(db)
 	if err != nil {
-		logr.Get().Errorf("failed to init postgres auth repo: %v", err)
+		return nil, backgroundServices{}, fmt.Errorf("failed to init postgres auth repo: %w", err)
+	}
+
+	identityRepo, err := postgres.NewIdentityRepo(db)
+	if err != nil {
+		return nil, backgroundServices{}, fmt.Errorf("failed to init postgres identity repo: %w", err)
 	}
 
-	userService := users.NewService(userRepo)
-	authService := auth.NewService(authRepo, userRepo)
+	apiKeyRepo, err := postgres.NewAPIKeyRepo(db)
+	if err != nil {
+		return nil, backgroundServices{}, fmt.Errorf("failed to init postgres api key repo: %w", err)
+	}
 
-	server := web.NewApp(
-		userService,
-		authService,
-		jwtManager,
-		web.WithPort(8000))
+	auditRepo, err := postgres.NewAuditRepo(db)
+	if err != nil {
+		return nil, backgroundServices{}, fmt.Errorf("failed to init postgres audit repo: %w", err)
+	}
 
-	logr.Get().Info("Starting server...")
-	server.Run()
+	exportRepo, err := postgres.NewExportRepo(db)
+	if err != nil {
+		return nil, backgroundServices{}, fmt.Errorf("failed to init postgres export repo: %w", err)
+	}
+
+	photoRepo, err := postgres.NewPhotoRepo(db)
+	if err != nil {
+		return nil, backgroundServices{}, fmt.Errorf("failed to init postgres photo repo: %w", err)
+	}
+
+	store, err := objectStore(cfg.Storage)
+	if err != nil {
+		return nil, backgroundServices{}, fmt.Errorf("failed to init object store: %w", err)
+	}
+
+	attemptRepo, err := loginAttemptRepo(cfg.Lockout, db)
+	if err != nil {
+		return nil, backgroundServices{}, fmt.Errorf("failed to init login attempt repo: %w", err)
+	}
+
+	exportService := exports.NewTracedService(exports.NewService(exportRepo, store, queue, exports.Sources{
+		Users:      userRepo,
+		Sessions:   authRepo,
+		Identities: identityRepo,
+		APIKeys:    apiKeyRepo,
+		AuditLog:   auditRepo,
+		Photos:     photoRepo,
+	}, exports.WithTTL(cfg.Exports.TTL)), tel.TracerProvider)
+	worker.Handle(exports.JobKind, exports.JobHandler(exportService))
+	photoService := photos.NewTracedService(photos.NewService(photoRepo, store,
+		photos.WithMaxSize(int64(cfg.Photos.MaxSize))), tel.TracerProvider)
+	// Archives and photos are deleted before the accounts they belong to are
+	// purged
+	userService := users.NewTracedService(users.NewService(userRepo, postgres.NewUnitOfWork(db),
+		users.WithAuditLog(auditRepo),
+		users.WithDataPurgers(exportService, photoService),
+		users.WithAvatars(photoService)), tel.TracerProvider)
+	authService := auth.NewTracedService(auth.NewService(authRepo, userRepo,
+		auth.WithExternalLogin(identityRepo, userService, identityProviders(cfg.OAuth)...),
+		auth.WithAPIKeys(apiKeyRepo),
+		auth.WithAuditLog(auditRepo),
+		auth.WithLockout(attemptRepo, mailer(cfg.SMTP), cfg.Lockout.UnlockURL),
+		auth.WithRefreshTokenTTL(cfg.JWT.RefreshTokenTTL)), tel.TracerProvider)
+	adminService := admin.NewTracedService(admin.NewService(userRepo, authRepo, auditRepo, postgres.NewUnitOfWork(db), userService), tel.TracerProvider)
+
+	proxies, err := middleware.ParseTrustedProxies(cfg.Server.TrustedProxies)
+	if err != nil {
+		return nil, backgroundServices{}, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
+	opts := []web.AppOption{
+		web.WithPort(cfg.Port),
+		web.WithTrustedProxies(proxies),
+		web.WithCORS(middleware.CORSPolicy{
+			AllowedOrigins: cfg.CORS.AllowedOrigins,
+			AllowedMethods: cfg.CORS.AllowedMethods,
+			AllowedHeaders: cfg.CORS.AllowedHeaders,
+			ExposedHeaders: cfg.CORS.ExposedHeaders,
+			MaxAge:         cfg.CORS.MaxAge,
+		}),
+		web.WithCookiePolicy(middleware.CookiePolicy{
+			Secure:   cfg.Cookies.Secure,
+			SameSite: cfg.Cookies.SameSite,
+			Domain:   cfg.Cookies.Domain,
+		}),
+		web.WithTokenTTLs(cfg.JWT.AccessTokenTTL, cfg.JWT.RefreshTokenTTL),
+		web.WithOAuthFlowKey([]byte(cfg.OAuth.FlowSecret)),
+		web.WithTimeouts(web.Timeouts{
+			ReadHeader: cfg.Server.ReadHeaderTimeout,
+			Read:       cfg.Server.ReadTimeout,
+			Write:      cfg.Server.WriteTimeout,
+			Idle:       cfg.Server.IdleTimeout,
+			Shutdown:   cfg.Server.ShutdownTimeout,
+			Drain:      cfg.Server.DrainDelay,
+		}),
+		web.WithReadinessCheck("database", func(ctx context.Context) (string, error) {
+			return "", db.PingContext(ctx)
+		}),
+		web.WithReadinessCheck("migrations", migrationsCheck(db)),
+		web.WithTelemetry(tel.TracerProvider, tel.MeterProvider),
+		web.WithInterrupts(signals),
+	}
+	if cfg.AccessLog {
+		opts = append(opts, web.WithAccessLog(os.Stdout))
+	}
+	if tel.MetricsHandler != nil {
+		opts = append(opts, web.WithMetricsHandler(tel.MetricsHandler))
+	}
+	if cfg.Server.TLSCertFile != "" {
+		opts = append(opts, web.WithTLS(cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile))
+	}
+
+	server, err := web.NewApp(userService, authService, adminService, exportService, photoService, jwtManager, opts...)
+	if err != nil {
+		return nil, backgroundServices{}, fmt.Errorf("failed to init server: %w", err)
+	}
+
+	return server, backgroundServices{users: userService, exports: exportService}, nil
+}
+
+func dbConfig(cfg config.DBConfig) postgres.Config {
+	return postgres.Config{
+		ConnString:      cfg.ConnString,
+		MaxOpenConns:    cfg.MaxOpenConns,
+		MaxIdleConns:    cfg.MaxIdleConns,
+		ConnMaxLifetime: cfg.ConnMaxLifetime,
+		ConnMaxIdleTime: cfg.ConnMaxIdleTime,
+	}
+}
+
+// newJWTManager signs with the configured method. HS256 is kept for local
+// development and refuses to start without a secret. Without a key directory
+// the keys are generated and rotated in memory, which config only allows in
+// development.
+func newJWTManager(cfg config.JWTConfig) (*jwt.JWTManager, error) {
+	method, err := jwt.NewSigningMethod(cfg.SigningMethod)
+	if err != nil {
+		return nil, fmt.Errorf("%q: %w", cfg.SigningMethod, err)
+	}
+
+	if method == jwt.HS256 {
+		return jwt.NewHS256Manager(cfg.Secret, jwt.WithExpiresIn(cfg.AccessTokenTTL))
+	}
+
+	if cfg.KeyDir == "" {
+		return jwt.NewJWTManager(method, jwt.WithExpiresIn(cfg.AccessTokenTTL))
+	}
+
+	keys, err := jwt.NewKeySetFromDir(cfg.KeyDir, cfg.AccessTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return jwt.NewJWTManager(keys.Method(), jwt.WithKeySet(keys), jwt.WithExpiresIn(cfg.AccessTokenTTL))
+}
+
+// objectStore keeps files under the storage directory unless the backend is
+// s3.
+func objectStore(cfg config.StorageConfig) (ports.ObjectStore, error) {
+	if cfg.Backend == "s3" {
+		return s3.NewObjectStore(s3.Config{
+			Endpoint:        cfg.S3.Endpoint,
+			Region:          cfg.S3.Region,
+			Bucket:          cfg.S3.Bucket,
+			AccessKeyID:     cfg.S3.AccessKeyID,
+			SecretAccessKey: cfg.S3.SecretAccessKey,
+			PathStyle:       cfg.S3.PathStyle,
+		})
+	}
+
+	return filesystem.NewObjectStore(cfg.Dir)
+}
+
+// loginAttemptRepo keeps login counters in Postgres unless the store is
+// memory, which suits a single local instance.
+func loginAttemptRepo(cfg config.LockoutConfig, db *sql.DB) (ports.LoginAttemptRepo, error) {
+	if cfg.AttemptStore == "memory" {
+		return memory.NewLoginAttemptRepo(), nil
+	}
+
+	return postgres.NewLoginAttemptRepo(db)
+}
+
+// mailer sends through SMTP when a host is set and logs messages otherwise.
+func mailer(cfg config.SMTPConfig) ports.Mailer {
+	if cfg.Host == "" {
+		return mail.NewLogMailer()
+	}
+
+	smtpMailer, err := mail.NewSMTPMailer(mail.SMTPConfig{
+		Host:     cfg.Host,
+		Port:     cfg.Port,
+		Username: cfg.Username,
+		Password: cfg.Password,
+		From:     cfg.From,
+	})
+	if err != nil {
+		logr.Get().Errorf("failed to init smtp mailer, logging mail instead: %v", err)
+		return mail.NewLogMailer()
+	}
+	return smtpMailer
+}
+
+// identityProviders enables every provider that has a client id configured.
+func identityProviders(cfg config.OAuthConfig) []ports.IdentityProvider {
+	var providers []ports.IdentityProvider
+
+	if cfg.Google.ClientID != "" {
+		google, err := oidc.Google(cfg.Google.ClientID, cfg.Google.ClientSecret, cfg.RedirectBaseURL+"/google/callback")
+		if err != nil {
+			logr.Get().Errorf("failed to init google provider: %v", err)
+		} else {
+			providers = append(providers, google)
+		}
+	}
+
+	if cfg.Apple.ClientID != "" {
+		apple, err := oidc.Apple(cfg.Apple.ClientID, cfg.Apple.ClientSecret, cfg.RedirectBaseURL+"/apple/callback")
+		if err != nil {
+			logr.Get().Errorf("failed to init apple provider: %v", err)
+		} else {
+			providers = append(providers, apple)
+		}
+	}
+
+	return providers
 }
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/cheezecakee/logr"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/postgres"
+	"github.com/cheezecakee/fitrkr-athena/internal/config"
+)
+
+const migrateUsage = "usage: web migrate up | down [steps] | status"
+
+// runMigrate handles `web migrate`, which brings a database to the schema
+// embedded in the binary without starting the server.
+func runMigrate(ctx context.Context, cfg config.DBConfig, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	migrations, err := postgres.Migrations()
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	db, err := postgres.NewPostgresConn(dbConfig(cfg))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator := postgres.NewMigrator(db, migrations)
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		logr.Get().Infof("applied %d migration(s)", len(applied))
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil {
+				return fmt.Errorf("invalid steps %q: %w", args[1], err)
+			}
+		}
+
+		rolledBack, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		logr.Get().Infof("rolled back %d migration(s)", len(rolledBack))
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		return w.Flush()
+
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}
+
+// migrationsCheck keeps an instance out of rotation until the schema it was
+// built for is in place.
+func migrationsCheck(db *sql.DB) web.ReadinessCheck {
+	migrations, loadErr := postgres.Migrations()
+	migrator := postgres.NewMigrator(db, migrations)
+
+	return func(ctx context.Context) (string, error) {
+		if loadErr != nil {
+			return "", loadErr
+		}
+
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			return "", err
+		}
+		if len(pending) > 0 {
+			return "", fmt.Errorf("%d pending, run `migrate up`", len(pending))
+		}
+
+		return fmt.Sprintf("up to date at %04d", migrations[len(migrations)-1].Version), nil
+	}
+}
@@ -5,4 +5,8 @@ type ContextKey string
 
 const (
 	AuthenticatedUserKey ContextKey = "authenticated_user"
+	AuthSchemeKey        ContextKey = "auth_scheme"
+	ResponseWriterKey    ContextKey = "response_writer"
+	ClientIPKey          ContextKey = "client_ip"
+	RequestIDKey         ContextKey = "request_id"
 )
@@ -0,0 +1,87 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cheezecakee/logr"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/buildinfo"
+	"github.com/cheezecakee/fitrkr-athena/pkg/web"
+)
+
+const defaultReadinessTimeout = 2 * time.Second
+
+// ReadinessCheck reports whether a dependency is usable, the detail
+// describes its state when it is. Errors are only logged, /readyz is not
+// authenticated and driver errors name hosts, databases and users.
+type ReadinessCheck func(ctx context.Context) (detail string, err error)
+
+type checkResult struct {
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type readiness struct {
+	Status string                 `json:"status"`
+	Checks map[string]checkResult `json:"checks,omitempty"`
+}
+
+// healthz only tells the process is up, it never looks at dependencies so a
+// database outage does not get every instance restarted.
+func (a *App) healthz(w http.ResponseWriter, r *http.Request) {
+	web.Response(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// readyz runs every readiness check concurrently within the readiness
+// timeout. It fails as soon as shutdown starts, so load balancers stop
+// sending traffic while the server drains.
+func (a *App) readyz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+
+	if a.draining.Load() {
+		web.Response(w, http.StatusServiceUnavailable, readiness{Status: "shutting_down"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), a.readinessTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	resp := readiness{Status: "ready", Checks: make(map[string]checkResult, len(a.readinessChecks))}
+	status := http.StatusOK
+
+	for name, check := range a.readinessChecks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result := checkResult{Status: "ok"}
+			detail, err := check(ctx)
+			result.Detail = detail
+			if err != nil {
+				logr.Get().Errorf("readiness check %s failed: %v", name, err)
+				result.Status = "failed"
+				result.Detail = "unavailable"
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			resp.Checks[name] = result
+			if err != nil {
+				resp.Status = "unavailable"
+				status = http.StatusServiceUnavailable
+			}
+		}()
+	}
+	wg.Wait()
+
+	web.Response(w, status, resp)
+}
+
+func (a *App) version(w http.ResponseWriter, r *http.Request) {
+	web.Response(w, http.StatusOK, buildinfo.Get())
+}
@@ -0,0 +1,148 @@
+package web_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web"
+	"github.com/cheezecakee/fitrkr-athena/internal/buildinfo"
+)
+
+type readiness struct {
+	Status string `json:"status"`
+	Checks map[string]struct {
+		Status string `json:"status"`
+		Detail string `json:"detail"`
+	} `json:"checks"`
+}
+
+func get(t *testing.T, handler http.Handler, path string, into any) int {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	if into != nil {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), into))
+	}
+	return rec.Code
+}
+
+func TestHealthz(t *testing.T) {
+	app := newApp(t, web.WithReadinessCheck("database", func(ctx context.Context) (string, error) {
+		return "", errors.New("connection refused")
+	}))
+
+	assert.Equal(t, http.StatusOK, get(t, app.Handler(), "/healthz", nil))
+}
+
+func TestReadyz(t *testing.T) {
+	ok := func(ctx context.Context) (string, error) { return "up to date at 0008", nil }
+	failing := func(ctx context.Context) (string, error) {
+		return "", errors.New("failed to connect to `user=athena database=athena`: db.internal:5432")
+	}
+	slow := func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+
+	tests := []struct {
+		name       string
+		checks     map[string]web.ReadinessCheck
+		wantStatus int
+		wantChecks map[string]string
+	}{
+		{
+			name:       "no checks",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "every check passes",
+			checks:     map[string]web.ReadinessCheck{"database": ok, "migrations": ok},
+			wantStatus: http.StatusOK,
+			wantChecks: map[string]string{"database": "ok", "migrations": "ok"},
+		},
+		{
+			name:       "one check fails",
+			checks:     map[string]web.ReadinessCheck{"database": ok, "migrations": failing},
+			wantStatus: http.StatusServiceUnavailable,
+			wantChecks: map[string]string{"database": "ok", "migrations": "failed"},
+		},
+		{
+			name:       "check times out",
+			checks:     map[string]web.ReadinessCheck{"database": slow},
+			wantStatus: http.StatusServiceUnavailable,
+			wantChecks: map[string]string{"database": "failed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []web.AppOption{web.WithReadinessTimeout(50 * time.Millisecond)}
+			for name, check := range tt.checks {
+				opts = append(opts, web.WithReadinessCheck(name, check))
+			}
+			app := newApp(t, opts...)
+
+			var body readiness
+			status := get(t, app.Handler(), "/readyz", &body)
+
+			assert.Equal(t, tt.wantStatus, status)
+			for name, want := range tt.wantChecks {
+				assert.Equal(t, want, body.Checks[name].Status, name)
+				if want == "failed" {
+					assert.Equal(t, "unavailable", body.Checks[name].Detail, name)
+				}
+			}
+		})
+	}
+}
+
+func TestReadyz_FailsWhileDraining(t *testing.T) {
+	port := freePort(t)
+	app := newApp(t, web.WithPort(port), web.WithTimeouts(web.Timeouts{Drain: 300 * time.Millisecond}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- app.Run(ctx) }()
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	waitForServer(t, url+"/readyz")
+	cancel()
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(url + "/readyz")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusServiceUnavailable
+	}, time.Second, 10*time.Millisecond)
+
+	resp, err := http.Get(url + "/healthz")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected liveness to hold while draining")
+
+	require.NoError(t, <-done)
+}
+
+func TestVersion(t *testing.T) {
+	app := newApp(t)
+
+	var info buildinfo.Info
+	status := get(t, app.Handler(), "/version", &info)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, buildinfo.Version, info.Version)
+	assert.NotEmpty(t, info.GoVersion)
+}
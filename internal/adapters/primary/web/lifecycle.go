@@ -0,0 +1,123 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/cheezecakee/logr"
+)
+
+// Timeouts bound how long a client may hold a connection, and how long
+// in-flight requests get to finish on shutdown.
+type Timeouts struct {
+	ReadHeader time.Duration
+	Read       time.Duration
+	Write      time.Duration
+	Idle       time.Duration
+	Shutdown   time.Duration
+	// Drain is how long /readyz fails before the server stops accepting
+	// connections, at least one probe period so load balancers notice.
+	Drain time.Duration
+}
+
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		ReadHeader: 5 * time.Second,
+		Read:       15 * time.Second,
+		Write:      30 * time.Second,
+		Idle:       2 * time.Minute,
+		Shutdown:   20 * time.Second,
+		Drain:      5 * time.Second,
+	}
+}
+
+// ShutdownFunc releases a resource once the server stopped taking requests.
+type ShutdownFunc func(ctx context.Context) error
+
+// OnShutdown registers fn to run after the server drained. Functions run
+// last registered first, so resources close in the reverse order they were
+// opened, and share the shutdown deadline.
+func (a *App) OnShutdown(fn ShutdownFunc) {
+	a.shutdown = append(a.shutdown, fn)
+}
+
+// Run serves until ctx is done, then fails readiness for the drain delay,
+// drains in-flight requests and runs the shutdown functions. A signal on the
+// interrupts of WithInterrupts cuts the drain delay short. It returns early if
+// the server can not start, after still running the shutdown functions.
+func (a *App) Run(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", a.port),
+		Handler:           a.chi,
+		ReadHeaderTimeout: a.timeouts.ReadHeader,
+		ReadTimeout:       a.timeouts.Read,
+		WriteTimeout:      a.timeouts.Write,
+		IdleTimeout:       a.timeouts.Idle,
+		// Requests in flight keep a context that outlives a cancelled ctx, so
+		// they can finish while the server drains
+		BaseContext: func(net.Listener) context.Context { return context.WithoutCancel(ctx) },
+	}
+
+	ln, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return errors.Join(fmt.Errorf("failed to listen on %s: %w", server.Addr, err), a.stop(nil))
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if a.tlsCertFile != "" {
+			serveErr <- server.ServeTLS(ln, a.tlsCertFile, a.tlsKeyFile)
+		} else {
+			serveErr <- server.Serve(ln)
+		}
+	}()
+
+	logr.Get().Infof("Listening on %s", ln.Addr())
+
+	select {
+	case err := <-serveErr:
+		return errors.Join(fmt.Errorf("server stopped: %w", err), a.stop(nil))
+	case <-ctx.Done():
+		logr.Get().Info("Shutting down...")
+		a.draining.Store(true)
+
+		drain := time.NewTimer(a.timeouts.Drain)
+		defer drain.Stop()
+		select {
+		case <-drain.C:
+		case <-a.interrupts:
+			logr.Get().Info("Interrupted again, skipping the drain delay")
+		}
+
+		return a.stop(server)
+	}
+}
+
+// stop drains server, if it started, then runs the shutdown functions
+// within the shutdown timeout.
+func (a *App) stop(server *http.Server) error {
+	ctx, cancel := context.WithTimeout(context.Background(), a.timeouts.Shutdown)
+	defer cancel()
+
+	var errs []error
+	if server != nil {
+		if err := server.Shutdown(ctx); err != nil {
+			// Out of time, cut the requests still running
+			server.Close()
+			errs = append(errs, fmt.Errorf("failed to drain server: %w", err))
+		}
+	}
+
+	for _, fn := range slices.Backward(a.shutdown) {
+		if err := fn(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
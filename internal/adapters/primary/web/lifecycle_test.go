@@ -0,0 +1,165 @@
+package web_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/jwt"
+)
+
+func TestMain(m *testing.M) {
+	logr.Init(&logr.PlainTextFormatter{}, logr.LevelInfo, nil)
+
+	os.Exit(m.Run())
+}
+
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+func newApp(t *testing.T, opts ...web.AppOption) *web.App {
+	t.Helper()
+
+	jwtManager, err := jwt.NewHS256Manager("test-secret")
+	require.NoError(t, err)
+
+	// No drain delay unless a test asks for one
+	opts = append([]web.AppOption{web.WithTimeouts(web.Timeouts{Drain: time.Millisecond})}, opts...)
+
+	app, err := web.NewApp(nil, nil, nil, nil, nil, jwtManager, opts...)
+	require.NoError(t, err)
+
+	return app
+}
+
+func waitForServer(t *testing.T, url string) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(url)
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestRun_ShutsDownInReverseOrder(t *testing.T) {
+	port := freePort(t)
+	app := newApp(t, web.WithPort(port))
+
+	var order []string
+	app.OnShutdown(func(ctx context.Context) error {
+		order = append(order, "database")
+		return nil
+	})
+	app.OnShutdown(func(ctx context.Context) error {
+		order = append(order, "workers")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- app.Run(ctx) }()
+
+	waitForServer(t, fmt.Sprintf("http://127.0.0.1:%d/.well-known/jwks.json", port))
+	cancel()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+
+	assert.Equal(t, []string{"workers", "database"}, order)
+
+	_, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/.well-known/jwks.json", port))
+	assert.Error(t, err, "expected the server to stop listening")
+}
+
+func TestRun_ReportsShutdownErrors(t *testing.T) {
+	app := newApp(t, web.WithPort(freePort(t)))
+
+	errClose := errors.New("close failed")
+	app.OnShutdown(func(ctx context.Context) error { return errClose })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := app.Run(ctx)
+	assert.ErrorIs(t, err, errClose)
+}
+
+func TestRun_ReleasesResourcesWhenListenFails(t *testing.T) {
+	ln, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	app := newApp(t, web.WithPort(ln.Addr().(*net.TCPAddr).Port))
+
+	closed := false
+	app.OnShutdown(func(ctx context.Context) error {
+		closed = true
+		return nil
+	})
+
+	err = app.Run(context.Background())
+	assert.Error(t, err)
+	assert.True(t, closed, "expected shutdown functions to run")
+}
+
+func TestWithTimeouts_KeepsDefaultsForZeroFields(t *testing.T) {
+	// Only observable through the server, a zero shutdown timeout would
+	// leave no time for the shutdown functions
+	app := newApp(t, web.WithPort(freePort(t)), web.WithTimeouts(web.Timeouts{Read: time.Second, Drain: time.Millisecond}))
+
+	var deadline time.Time
+	app.OnShutdown(func(ctx context.Context) error {
+		deadline, _ = ctx.Deadline()
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.NoError(t, app.Run(ctx))
+	assert.WithinDuration(t, time.Now().Add(web.DefaultTimeouts().Shutdown), deadline, time.Second)
+}
+
+func TestRun_SecondSignalSkipsDrain(t *testing.T) {
+	interrupts := make(chan os.Signal, 1)
+	app := newApp(t, web.WithPort(freePort(t)), web.WithTimeouts(web.Timeouts{Drain: time.Hour}), web.WithInterrupts(interrupts))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- app.Run(ctx) }()
+	interrupts <- os.Interrupt
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server kept draining after a second signal")
+	}
+}
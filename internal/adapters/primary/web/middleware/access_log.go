@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/logging"
+)
+
+// AccessLog writes one JSON line per request. Only the path is logged, query
+// strings can carry tokens. The client IP is resolved through the same
+// trusted proxies as the audit log and the rate limiter.
+type AccessLog struct {
+	mu        sync.Mutex
+	out       io.Writer
+	proxies   TrustedProxies
+	formatter logr.Formatter
+}
+
+func NewAccessLog(out io.Writer, proxies TrustedProxies) *AccessLog {
+	return &AccessLog{
+		out:       out,
+		proxies:   proxies,
+		formatter: logging.NewFormatter(logging.JSON),
+	}
+}
+
+func (a *AccessLog) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		ctx, holder := withRoute(r.Context())
+		rec := newStatusRecorder(w)
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		meta := logr.NewMetadata()
+		meta.Add("request_id", GetRequestID(ctx))
+		meta.Add("method", r.Method)
+		meta.Add("route", routeOf(ctx, holder))
+		meta.Add("path", r.URL.Path)
+		meta.Add("status", rec.status)
+		meta.Add("bytes", rec.bytes)
+		meta.Add("duration_ms", float64(time.Since(start).Microseconds())/1000)
+		meta.Add("client_ip", a.proxies.ClientIP(r))
+		meta.Add("user_agent", r.UserAgent())
+		if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+			meta.Add("trace_id", sc.TraceID().String())
+		}
+
+		entry := logr.NewEntry(accessLevel(rec.status), logr.LayerHTTP, "request", *meta)
+		line := a.formatter.Format(*entry)
+
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		fmt.Fprintln(a.out, line)
+	})
+}
+
+func accessLevel(status int) logr.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return logr.LevelError
+	case status >= http.StatusBadRequest:
+		return logr.LevelWarn
+	default:
+		return logr.LevelInfo
+	}
+}
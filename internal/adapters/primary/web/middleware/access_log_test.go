@@ -0,0 +1,73 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+)
+
+type accessLine struct {
+	Level    string `json:"level"`
+	Message  string `json:"message"`
+	Metadata struct {
+		Data map[string]any `json:"data"`
+	} `json:"metadata"`
+}
+
+func TestAccessLog(t *testing.T) {
+	var out bytes.Buffer
+
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(middleware.NewAccessLog(&out, nil).Handler)
+	r.Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("missing"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42?token=s3cr3t", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-1")
+	req.Header.Set("Authorization", "Bearer s3cr3t")
+	req.Header.Set("Cookie", "session=s3cr3t")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.NotContains(t, out.String(), "s3cr3t")
+
+	var line accessLine
+	require.NoError(t, json.Unmarshal(out.Bytes(), &line))
+
+	data := line.Metadata.Data
+	assert.Equal(t, "WARN", line.Level)
+	assert.Equal(t, "request", line.Message)
+	assert.Equal(t, "req-1", data["request_id"])
+	assert.Equal(t, "/users/{id}", data["route"])
+	assert.Equal(t, "/users/42", data["path"])
+	assert.Equal(t, float64(http.StatusNotFound), data["status"])
+	assert.Equal(t, float64(len("missing")), data["bytes"])
+	assert.Contains(t, data, "duration_ms")
+}
+
+func TestAccessLog_ClientIPThroughTrustedProxy(t *testing.T) {
+	proxies, err := middleware.ParseTrustedProxies([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	handler := middleware.NewAccessLog(&out, proxies).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.5:51000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.9")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var line accessLine
+	require.NoError(t, json.Unmarshal(out.Bytes(), &line))
+	assert.Equal(t, "203.0.113.7", line.Metadata.Data["client_ip"])
+}
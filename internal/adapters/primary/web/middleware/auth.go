@@ -3,50 +3,96 @@ package middleware
 
 import (
 	"context"
-	"net/http"
+	"errors"
 
 	"github.com/cheezecakee/logr"
 
-	webctx "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/context"
 	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/jwt"
+	domain "github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
 )
 
+var (
+	ErrInvalidToken      = errors.New("invalid token")
+	ErrInvalidAPIKey     = errors.New("invalid api key")
+	ErrInsufficientScope = errors.New("insufficient scope")
+)
+
+// APIKeyAuthenticator resolves personal API keys to their owner.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, req auth.AuthenticateAPIKeyReq) (auth.AuthenticateAPIKeyResp, error)
+}
+
 type Middleware struct {
 	jwtManager jwt.JWT
+	apiKeys    APIKeyAuthenticator
+	limiter    *RateLimiter
+	proxies    TrustedProxies
+
+	cors      CORSPolicy
+	corsRules corsRules
 }
 
-func NewMiddleware(jwtManager jwt.JWT) *Middleware {
-	return &Middleware{
+type Option func(m *Middleware)
+
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(m *Middleware) { m.limiter = limiter }
+}
+
+// WithTrustedProxies reads the client address from the forwarded headers of
+// requests that arrive through proxies.
+func WithTrustedProxies(proxies TrustedProxies) Option {
+	return func(m *Middleware) { m.proxies = proxies }
+}
+
+func NewMiddleware(jwtManager jwt.JWT, apiKeys APIKeyAuthenticator, opts ...Option) *Middleware {
+	m := &Middleware{
 		jwtManager: jwtManager,
+		apiKeys:    apiKeys,
+
+		cors: DefaultCORSPolicy(),
 	}
+
+	for _, applyOption := range opts {
+		applyOption(m)
+	}
+
+	m.corsRules = compileCORS(m.cors)
+
+	return m
 }
 
-func (m *Middleware) IsAuthenticated() func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Log request details for debugging remove in production
-			logr.Get().Debugf("auth check for %s %s", r.Method, r.URL.Path)
-			logr.Get().Debugf("request headers: %v", r.Header)
-
-			// Extract token from either Bearer header or cookies
-			token, err := ExtractToken(r, Session)
-			if err != nil {
-				logr.Get().Errorf("token extraction failed: %v", err)
-				http.Error(w, "unauthorized: "+err.Error(), http.StatusUnauthorized)
-				return
-			}
-
-			authUser, err := m.jwtManager.ValidateJWT(token)
-			if err != nil {
-				logr.Get().Infof("JWT validation failed: %v", err)
-				http.Error(w, "unauthorized: invalid token", http.StatusUnauthorized)
-				return
-			}
-
-			logr.Get().Debugf("JWT validation successful for user ID: %s", authUser.UserID)
-
-			ctx := context.WithValue(r.Context(), webctx.AuthenticatedUserKey, authUser)
-			next.ServeHTTP(w, r.WithContext(ctx))
-		})
+// Authenticate resolves a credential to the user behind it. API keys must
+// hold every scope in scopes and are refused when scopes is empty.
+func (m *Middleware) Authenticate(ctx context.Context, cred Credential, scopes ...domain.Scope) (*jwt.AuthenticatedUser, error) {
+	if cred.Scheme != SchemeAPIKey {
+		authUser, err := m.jwtManager.ValidateJWT(cred.Value)
+		if err != nil {
+			logr.Get().Infof("JWT validation failed: %v", err)
+			return nil, ErrInvalidToken
+		}
+
+		logr.Get().Debugf("authenticated user %s via %s", authUser.UserID, cred.Scheme)
+		return authUser, nil
 	}
+
+	resp, err := m.apiKeys.AuthenticateAPIKey(ctx, auth.AuthenticateAPIKeyReq{Key: cred.Value})
+	if err != nil {
+		logr.Get().Infof("api key authentication failed: %v", err)
+		return nil, ErrInvalidAPIKey
+	}
+
+	if len(scopes) == 0 || !resp.Scopes.ContainsAll(scopes...) {
+		logr.Get().Infof("api key for user %s lacks scopes %v", resp.UserID, scopes)
+		return nil, ErrInsufficientScope
+	}
+
+	logr.Get().Debugf("authenticated user %s via %s", resp.UserID, cred.Scheme)
+
+	return &jwt.AuthenticatedUser{
+		UserID: resp.UserID,
+		Roles:  user.StringsToRoles(resp.Roles),
+		Scopes: resp.Scopes,
+	}, nil
 }
@@ -0,0 +1,110 @@
+package middleware_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/jwt"
+	domain "github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+)
+
+const validKey = "fk_0123456789ab_secret"
+
+type stubAPIKeys struct {
+	userID uuid.UUID
+	scopes domain.Scopes
+}
+
+func (s stubAPIKeys) AuthenticateAPIKey(ctx context.Context, req auth.AuthenticateAPIKeyReq) (auth.AuthenticateAPIKeyResp, error) {
+	if req.Key != validKey {
+		return auth.AuthenticateAPIKeyResp{}, auth.ErrInvalidAPIKey
+	}
+	return auth.AuthenticateAPIKeyResp{UserID: s.userID, Roles: []string{"user"}, Scopes: s.scopes}, nil
+}
+
+func TestAuthenticate(t *testing.T) {
+	userID := uuid.New()
+
+	jwtManager, err := jwt.NewHS256Manager("test-secret")
+	require.NoError(t, err)
+
+	token, err := jwtManager.MakeJWT(userID, []string{"user"})
+	require.NoError(t, err)
+
+	m := middleware.NewMiddleware(jwtManager, stubAPIKeys{userID: userID, scopes: domain.Scopes{domain.ScopeStatsRead}})
+
+	tests := []struct {
+		name       string
+		cred       middleware.Credential
+		scopes     []domain.Scope
+		wantErr    error
+		wantScopes domain.Scopes
+	}{
+		{
+			name: "session cookie",
+			cred: middleware.Credential{Scheme: middleware.SchemeSession, Value: token},
+		},
+		{
+			name: "bearer token",
+			cred: middleware.Credential{Scheme: middleware.SchemeBearer, Value: token},
+		},
+		{
+			name:       "api key with required scope",
+			cred:       middleware.Credential{Scheme: middleware.SchemeAPIKey, Value: validKey},
+			scopes:     []domain.Scope{domain.ScopeStatsRead},
+			wantScopes: domain.Scopes{domain.ScopeStatsRead},
+		},
+		{
+			name:    "api key missing scope",
+			cred:    middleware.Credential{Scheme: middleware.SchemeAPIKey, Value: validKey},
+			scopes:  []domain.Scope{domain.ScopeWorkoutsWrite},
+			wantErr: middleware.ErrInsufficientScope,
+		},
+		{
+			name:    "api key on session only operation",
+			cred:    middleware.Credential{Scheme: middleware.SchemeAPIKey, Value: validKey},
+			wantErr: middleware.ErrInsufficientScope,
+		},
+		{
+			name:    "invalid api key",
+			cred:    middleware.Credential{Scheme: middleware.SchemeAPIKey, Value: "fk_0123456789ab_wrong"},
+			scopes:  []domain.Scope{domain.ScopeStatsRead},
+			wantErr: middleware.ErrInvalidAPIKey,
+		},
+		{
+			name:    "invalid bearer token",
+			cred:    middleware.Credential{Scheme: middleware.SchemeBearer, Value: "not-a-jwt"},
+			wantErr: middleware.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.Authenticate(context.Background(), tt.cred, tt.scopes...)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, userID, got.UserID)
+			assert.Equal(t, tt.wantScopes, got.Scopes)
+		})
+	}
+}
+
+func TestMain(m *testing.M) {
+	logr.Init(&logr.PlainTextFormatter{}, logr.LevelInfo, nil)
+
+	exitCode := m.Run()
+
+	os.Exit(exitCode)
+}
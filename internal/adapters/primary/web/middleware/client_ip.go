@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// TrustedProxies are the load balancers and ingresses allowed to tell the
+// address of the client through X-Forwarded-For or Forwarded. Without any,
+// the client is the direct peer.
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies reads CIDRs, a bare address trusts that host only.
+func ParseTrustedProxies(cidrs []string) (TrustedProxies, error) {
+	proxies := make(TrustedProxies, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if !strings.Contains(cidr, "/") {
+			addr, err := netip.ParseAddr(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not an address or CIDR", cidr)
+			}
+			proxies = append(proxies, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an address or CIDR", cidr)
+		}
+		proxies = append(proxies, prefix.Masked())
+	}
+	return proxies, nil
+}
+
+// ClientIP follows the forwarded addresses from the direct peer back for as
+// long as they are trusted proxies. Anything left of the first untrusted
+// address was written by the client and is ignored.
+func (p TrustedProxies) ClientIP(r *http.Request) string {
+	peer := ClientIP(r)
+	if !p.trusts(peer) {
+		return peer
+	}
+
+	client := peer
+	hops := forwardedFor(r.Header)
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(hops[i])
+		if err != nil {
+			// unknown or obfuscated, nothing before it can be trusted
+			break
+		}
+
+		client = addr.Unmap().String()
+		if !p.contains(addr) {
+			break
+		}
+	}
+	return client
+}
+
+func (p TrustedProxies) trusts(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	return err == nil && p.contains(addr)
+}
+
+func (p TrustedProxies) contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range p {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP is the address of the direct peer. Forwarded headers are only
+// trusted through TrustedProxies since any client can set them.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// forwardedFor lists the hops of the Forwarded header, or of
+// X-Forwarded-For without one, closest to the client first.
+func forwardedFor(h http.Header) []string {
+	var hops []string
+
+	if forwarded := h.Values("Forwarded"); len(forwarded) > 0 {
+		for _, element := range strings.Split(strings.Join(forwarded, ","), ",") {
+			for _, pair := range strings.Split(element, ";") {
+				name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok && strings.EqualFold(name, "for") {
+					hops = append(hops, hostOf(value))
+				}
+			}
+		}
+		return hops
+	}
+
+	for _, hop := range strings.Split(strings.Join(h.Values("X-Forwarded-For"), ","), ",") {
+		if hop = strings.TrimSpace(hop); hop != "" {
+			hops = append(hops, hostOf(hop))
+		}
+	}
+	return hops
+}
+
+// hostOf drops the quotes, brackets and port some proxies add.
+func hostOf(value string) string {
+	value = strings.Trim(strings.TrimSpace(value), `"`)
+
+	if strings.HasPrefix(value, "[") {
+		host, _, _ := strings.Cut(value[1:], "]")
+		return host
+	}
+	if strings.Count(value, ":") == 1 {
+		host, _, _ := strings.Cut(value, ":")
+		return host
+	}
+	return value
+}
@@ -0,0 +1,96 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+)
+
+func TestTrustedProxies_ClientIP(t *testing.T) {
+	proxies, err := middleware.ParseTrustedProxies([]string{"10.0.0.0/8", "2001:db8::1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		header     http.Header
+		want       string
+	}{
+		{
+			name:       "direct client",
+			remoteAddr: "203.0.113.7:51000",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "forwarded headers of an untrusted peer are ignored",
+			remoteAddr: "203.0.113.7:51000",
+			header:     http.Header{"X-Forwarded-For": {"198.51.100.1"}},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "client behind a trusted proxy",
+			remoteAddr: "10.0.0.5:51000",
+			header:     http.Header{"X-Forwarded-For": {"198.51.100.1"}},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "addresses set by the client are skipped",
+			remoteAddr: "10.0.0.5:51000",
+			header:     http.Header{"X-Forwarded-For": {"1.2.3.4, 198.51.100.1", "10.1.1.1"}},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "trusted proxy without forwarded headers",
+			remoteAddr: "10.0.0.5:51000",
+			want:       "10.0.0.5",
+		},
+		{
+			name:       "forwarded header wins over x-forwarded-for",
+			remoteAddr: "[2001:db8::1]:443",
+			header: http.Header{
+				"Forwarded":       {`for=192.0.2.60;proto=https, for="[2001:db8:cafe::17]:4711"`},
+				"X-Forwarded-For": {"1.2.3.4"},
+			},
+			want: "2001:db8:cafe::17",
+		},
+		{
+			name:       "obfuscated hop stops the walk",
+			remoteAddr: "10.0.0.5:51000",
+			header:     http.Header{"Forwarded": {"for=198.51.100.1, for=_hidden"}},
+			want:       "10.0.0.5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for key, values := range tt.header {
+				req.Header[key] = values
+			}
+
+			assert.Equal(t, tt.want, proxies.ClientIP(req))
+		})
+	}
+}
+
+func TestTrustedProxies_NoneTrusted(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.5:51000"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+
+	assert.Equal(t, "10.0.0.5", middleware.TrustedProxies(nil).ClientIP(req))
+}
+
+func TestParseTrustedProxies_Rejects(t *testing.T) {
+	_, err := middleware.ParseTrustedProxies([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+
+	_, err = middleware.ParseTrustedProxies([]string{"proxy.internal"})
+	assert.Error(t, err)
+}
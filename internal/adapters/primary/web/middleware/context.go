@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	webctx "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/context"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+)
+
+// RequestContext stores the response writer and client IP in the request
+// context for handlers that only receive the context, such as the generated
+// API handlers setting cookies. The client is also passed on to the audit log.
+func (m *Middleware) RequestContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := m.proxies.ClientIP(r)
+		ctx := context.WithValue(r.Context(), webctx.ResponseWriterKey, w)
+		ctx = context.WithValue(ctx, webctx.ClientIPKey, ip)
+		ctx = audit.ContextWithClient(ctx, audit.Client{IP: ip, UserAgent: r.UserAgent()})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
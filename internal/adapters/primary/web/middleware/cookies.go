@@ -3,6 +3,7 @@ package middleware
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/cheezecakee/logr"
 )
@@ -12,16 +13,100 @@ type Cookie string
 const (
 	Session      Cookie = "session"
 	RefreshToken Cookie = "refresh_token"
+	// CSRFToken is readable by scripts, they echo it in the X-CSRF-Token
+	// header.
+	CSRFToken Cookie = "csrf_token"
+	// OAuthFlow carries an external login from the redirect to the provider
+	// to its callback.
+	OAuthFlow Cookie = "oauth_flow"
 )
 
 var ErrTokenNotFound = errors.New("cookie not found")
 
+// RefreshTokenPath scopes the refresh token to the auth routes that need it,
+// refresh and logout.
+const RefreshTokenPath = "/api/v1/auth"
+
+// OAuthFlowPath scopes the flow cookie to the external login routes.
+const OAuthFlowPath = "/api/v1/auth/oauth"
+
+// CookiePolicy sets the attributes of the session, refresh token and CSRF
+// cookies, so issuing and clearing them always agree. The tokens are
+// HttpOnly, scripts never need them.
+type CookiePolicy struct {
+	// Secure keeps the cookies off plain HTTP. Only local development
+	// should turn it off.
+	Secure   bool
+	SameSite http.SameSite
+	// Domain shares the cookies with subdomains, empty keeps them on the
+	// host that set them.
+	Domain string
+}
+
+func DefaultCookiePolicy() CookiePolicy {
+	return CookiePolicy{Secure: true, SameSite: http.SameSiteLaxMode}
+}
+
+// Cookie returns cookie holding value for ttl.
+func (p CookiePolicy) Cookie(cookie Cookie, value string, ttl time.Duration) *http.Cookie {
+	c := p.base(cookie)
+	c.Value = value
+	c.Expires = time.Now().Add(ttl)
+	c.MaxAge = int(ttl.Seconds())
+	return c
+}
+
+// Expired returns a cookie that deletes cookie, it must match the domain and
+// path the cookie was set with.
+func (p CookiePolicy) Expired(cookie Cookie) *http.Cookie {
+	c := p.base(cookie)
+	c.MaxAge = -1
+	return c
+}
+
+func (p CookiePolicy) base(cookie Cookie) *http.Cookie {
+	path, sameSite := "/", p.SameSite
+	switch cookie {
+	case RefreshToken:
+		path = RefreshTokenPath
+	case OAuthFlow:
+		path = OAuthFlowPath
+		// Survives providers that post the callback cross site (Apple
+		// form_post), browsers only accept None on secure cookies
+		if p.Secure {
+			sameSite = http.SameSiteNoneMode
+		}
+	}
+
+	return &http.Cookie{
+		Name:     string(cookie),
+		Path:     path,
+		Domain:   p.Domain,
+		HttpOnly: cookie != CSRFToken,
+		Secure:   p.Secure,
+		SameSite: sameSite,
+	}
+}
+
 func ExtractToken(r *http.Request, cookie Cookie) (string, error) {
 	if c, err := r.Cookie(string(cookie)); err == nil && c.Value != "" {
 		logr.Get().Debugf("found token in cookie: %s", cookie)
 		return c.Value, nil
 	}
 
-	logr.Get().Info("no token found in cookies")
+	logr.Get().Debugf("no token found in cookie: %s", cookie)
 	return "", ErrTokenNotFound
 }
+
+type Scheme string
+
+const (
+	SchemeSession Scheme = "session"
+	SchemeBearer  Scheme = "Bearer"
+	SchemeAPIKey  Scheme = "ApiKey"
+)
+
+type Credential struct {
+	Scheme Scheme
+	Value  string
+}
@@ -0,0 +1,60 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+)
+
+func TestCookiePolicy(t *testing.T) {
+	policy := middleware.CookiePolicy{Secure: true, SameSite: http.SameSiteStrictMode, Domain: "fitrkr.com"}
+
+	session := policy.Cookie(middleware.Session, "token", 15*time.Minute)
+	assert.Equal(t, "session", session.Name)
+	assert.Equal(t, "token", session.Value)
+	assert.Equal(t, "/", session.Path)
+	assert.Equal(t, "fitrkr.com", session.Domain)
+	assert.True(t, session.HttpOnly)
+	assert.True(t, session.Secure)
+	assert.Equal(t, http.SameSiteStrictMode, session.SameSite)
+	assert.Equal(t, 900, session.MaxAge)
+
+	refresh := policy.Cookie(middleware.RefreshToken, "refresh", time.Hour)
+	assert.Equal(t, middleware.RefreshTokenPath, refresh.Path)
+	assert.True(t, refresh.HttpOnly)
+
+	// Clearing only works with the same name, domain and path
+	for _, cookie := range []middleware.Cookie{middleware.Session, middleware.RefreshToken} {
+		set, cleared := policy.Cookie(cookie, "v", time.Minute), policy.Expired(cookie)
+		assert.Equal(t, set.Path, cleared.Path)
+		assert.Equal(t, set.Domain, cleared.Domain)
+		assert.Empty(t, cleared.Value)
+		assert.Negative(t, cleared.MaxAge)
+	}
+}
+
+func TestCookiePolicy_OAuthFlow(t *testing.T) {
+	policy := middleware.CookiePolicy{Secure: true, SameSite: http.SameSiteStrictMode, Domain: "fitrkr.com"}
+
+	flow := policy.Cookie(middleware.OAuthFlow, "flow", 10*time.Minute)
+	assert.Equal(t, middleware.OAuthFlowPath, flow.Path)
+	assert.Equal(t, "fitrkr.com", flow.Domain)
+	assert.True(t, flow.HttpOnly)
+	assert.Equal(t, http.SameSiteNoneMode, flow.SameSite, "expected the flow to survive cross site callbacks")
+	assert.Equal(t, flow.Path, policy.Expired(middleware.OAuthFlow).Path)
+
+	insecure := middleware.CookiePolicy{SameSite: http.SameSiteLaxMode}
+	assert.Equal(t, http.SameSiteLaxMode, insecure.Cookie(middleware.OAuthFlow, "flow", time.Minute).SameSite)
+}
+
+func TestCookiePolicy_AlwaysHttpOnly(t *testing.T) {
+	var insecure middleware.CookiePolicy
+
+	cookie := insecure.Cookie(middleware.Session, "token", time.Minute)
+	assert.True(t, cookie.HttpOnly)
+	assert.False(t, cookie.Secure)
+}
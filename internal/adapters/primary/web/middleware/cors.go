@@ -1,54 +1,239 @@
 package middleware
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cheezecakee/logr"
 )
 
+var ErrInvalidOrigin = errors.New("invalid origin")
+
+// CORSPolicy lists what cross origin browsers may do. Origins are
+// scheme://host[:port], a host starting with *. matches any of its
+// subdomains but not the domain itself.
+type CORSPolicy struct {
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	// ExposedHeaders are the response headers scripts may read.
+	ExposedHeaders []string
+	// MaxAge is how long browsers cache a preflight.
+	MaxAge time.Duration
+}
+
+// DefaultAllowedOrigins are the local clients allowed when no origins are
+// configured.
+func DefaultAllowedOrigins() []string {
+	return []string{
+		"http://localhost:8000", // OpenApi port
+		"http://localhost",
+		"capacitor://localhost", // For Capacitor apps
+		"ionic://localhost",     // For Ionic apps
+	}
+}
+
+func DefaultCORSPolicy() CORSPolicy {
+	return CORSPolicy{
+		AllowedOrigins: DefaultAllowedOrigins(),
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
+		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-Requested-With", RequestIDHeader, CSRFHeader},
+		ExposedHeaders: []string{
+			RequestIDHeader, "Retry-After",
+			"RateLimit-Policy", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset",
+		},
+		MaxAge: 24 * time.Hour,
+	}
+}
+
+// WithCORSPolicy replaces the default policy, empty lists keep their
+// defaults.
+func WithCORSPolicy(policy CORSPolicy) Option {
+	return func(m *Middleware) {
+		defaults := DefaultCORSPolicy()
+		if len(policy.AllowedOrigins) == 0 {
+			policy.AllowedOrigins = defaults.AllowedOrigins
+		}
+		if len(policy.AllowedMethods) == 0 {
+			policy.AllowedMethods = defaults.AllowedMethods
+		}
+		if len(policy.AllowedHeaders) == 0 {
+			policy.AllowedHeaders = defaults.AllowedHeaders
+		}
+		if len(policy.ExposedHeaders) == 0 {
+			policy.ExposedHeaders = defaults.ExposedHeaders
+		}
+		if policy.MaxAge <= 0 {
+			policy.MaxAge = defaults.MaxAge
+		}
+		m.cors = policy
+	}
+}
+
+type originPattern struct {
+	scheme string
+	host   string
+	port   string
+	// wildcard matches subdomains of host
+	wildcard bool
+}
+
+// ValidateOrigin checks an allowed origin, such as https://*.fitrkr.com.
+func ValidateOrigin(origin string) error {
+	_, err := parseOrigin(origin)
+	return err
+}
+
+func parseOrigin(origin string) (originPattern, error) {
+	scheme, rest, ok := strings.Cut(origin, "://")
+	if !ok || scheme == "" || rest == "" || strings.ContainsAny(rest, "/?#@") {
+		return originPattern{}, fmt.Errorf("%w: %q", ErrInvalidOrigin, origin)
+	}
+
+	p := originPattern{scheme: strings.ToLower(scheme)}
+	if after, found := strings.CutPrefix(rest, "*."); found {
+		p.wildcard = true
+		rest = after
+	}
+
+	u, err := url.Parse(p.scheme + "://" + rest)
+	if err != nil || u.Hostname() == "" || strings.Contains(u.Hostname(), "*") {
+		return originPattern{}, fmt.Errorf("%w: %q", ErrInvalidOrigin, origin)
+	}
+	p.host = strings.ToLower(u.Hostname())
+	p.port = u.Port()
+
+	return p, nil
+}
+
+func (p originPattern) matches(origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || !strings.EqualFold(u.Scheme, p.scheme) || u.Port() != p.port {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if p.wildcard {
+		return strings.HasSuffix(host, "."+p.host)
+	}
+	return host == p.host
+}
+
+// corsRules is the policy compiled once by NewMiddleware.
+type corsRules struct {
+	origins        []originPattern
+	methods        []string
+	headers        []string
+	allowedMethods string
+	allowedHeaders string
+	exposedHeaders string
+	maxAge         string
+}
+
+func compileCORS(policy CORSPolicy) corsRules {
+	rules := corsRules{
+		methods:        policy.AllowedMethods,
+		allowedMethods: strings.Join(policy.AllowedMethods, ", "),
+		allowedHeaders: strings.Join(policy.AllowedHeaders, ", "),
+		exposedHeaders: strings.Join(policy.ExposedHeaders, ", "),
+		maxAge:         strconv.Itoa(int(policy.MaxAge.Seconds())),
+	}
+
+	for _, header := range policy.AllowedHeaders {
+		rules.headers = append(rules.headers, http.CanonicalHeaderKey(header))
+	}
+
+	for _, origin := range policy.AllowedOrigins {
+		pattern, err := parseOrigin(origin)
+		if err != nil {
+			// Config validates origins, this only guards direct callers
+			logr.Get().Errorf("ignoring cors origin: %v", err)
+			continue
+		}
+		rules.origins = append(rules.origins, pattern)
+	}
+
+	return rules
+}
+
+func (c corsRules) allowsOrigin(origin string) bool {
+	return slices.ContainsFunc(c.origins, func(p originPattern) bool { return p.matches(origin) })
+}
+
+// allowsPreflight checks the method and headers a preflight asks for.
+func (c corsRules) allowsPreflight(r *http.Request) bool {
+	if !slices.Contains(c.methods, r.Header.Get("Access-Control-Request-Method")) {
+		return false
+	}
+
+	for _, header := range strings.Split(r.Header.Get("Access-Control-Request-Headers"), ",") {
+		header = strings.TrimSpace(header)
+		if header != "" && !slices.Contains(c.headers, http.CanonicalHeaderKey(header)) {
+			return false
+		}
+	}
+	return true
+}
+
+// CORS echoes allowed origins back with credentials allowed. Requests from
+// other origins get no CORS headers at all, so the browser blocks them.
 func (m *Middleware) CORS(next http.Handler) http.Handler {
+	rules := m.corsRules
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Allow multiple origins - add your actual Flutter app URLs
-		allowedOrigins := []string{
-			"http://localhost:8000", // OpenApi port
-			"http://localhost",
-			"capacitor://localhost", // For Capacitor apps
-			"ionic://localhost",     // For Ionic apps
+		origin := r.Header.Get("Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+		// Responses depend on the origin, caches must not share them
+		w.Header().Add("Vary", "Origin")
+		if preflight {
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
 		}
 
-		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
 
-		// Debug logging - remove in production
-		logr.Get().Infof("Request Origin: %s", origin)
-		logr.Get().Infof("Request Method: %s", r.Method)
-		logr.Get().Infof("Request Headers: %v", r.Header)
+		if !rules.allowsOrigin(origin) {
+			logr.Get().Debugf("origin not allowed: %s", origin)
+			if preflight {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
 
-		if slices.Contains(allowedOrigins, origin) {
-			w.Header().Set("Access-Control-Allow-Origin", origin)
-			logr.Get().Infof("Origin allowed: %s", origin)
-		} else if origin == "" {
-			// For local API requests (same origin, like Stoplight UI)
-			// Allow localhost explicitly instead of "*"
-			w.Header().Set("Access-Control-Allow-Origin", "http://localhost:8000")
-			logr.Get().Info("No Origin header, using default localhost:8000")
-		} else {
-			logr.Get().Infof("Origin not allowed: %s", origin)
-			// Might want to still set some CORS headers for the error response
-			w.Header().Set("Access-Control-Allow-Origin", "null")
-		}
-
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, Accept")
-		w.Header().Set("Access-Control-Allow-Credentials", "true")
-		w.Header().Set("Access-Control-Max-Age", "86400") // Cache preflight for 24 hours
+		if preflight {
+			if !rules.allowsPreflight(r) {
+				logr.Get().Debugf("preflight not allowed: %s %s", origin, r.Header.Get("Access-Control-Request-Method"))
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
 
-		if r.Method == http.MethodOptions {
-			logr.Get().Infof("Handling OPTIONS preflight request from: %s", origin)
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			w.Header().Set("Access-Control-Allow-Methods", rules.allowedMethods)
+			w.Header().Set("Access-Control-Allow-Headers", rules.allowedHeaders)
+			w.Header().Set("Access-Control-Max-Age", rules.maxAge)
 			w.WriteHeader(http.StatusNoContent)
 			return
 		}
 
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		if rules.exposedHeaders != "" {
+			w.Header().Set("Access-Control-Expose-Headers", rules.exposedHeaders)
+		}
+
 		next.ServeHTTP(w, r)
 	})
 }
@@ -0,0 +1,115 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+)
+
+func corsHandler(policy middleware.CORSPolicy) http.Handler {
+	m := middleware.NewMiddleware(nil, nil, middleware.WithCORSPolicy(policy))
+	return m.CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestCORS_Origins(t *testing.T) {
+	h := corsHandler(middleware.CORSPolicy{
+		AllowedOrigins: []string{"https://app.fitrkr.com", "https://*.fitrkr.dev", "http://localhost:3000"},
+	})
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{origin: "https://app.fitrkr.com", allowed: true},
+		{origin: "https://APP.fitrkr.com", allowed: true},
+		{origin: "https://evil.com"},
+		{origin: "http://app.fitrkr.com"},
+		{origin: "https://preview-1.fitrkr.dev", allowed: true},
+		{origin: "https://a.b.fitrkr.dev", allowed: true},
+		{origin: "https://fitrkr.dev"},
+		{origin: "https://evilfitrkr.dev"},
+		{origin: "http://localhost:3000", allowed: true},
+		{origin: "http://localhost:4000"},
+		{origin: "null"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Origin", tt.origin)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Header().Values("Vary"), "Origin")
+			if tt.allowed {
+				assert.Equal(t, tt.origin, rec.Header().Get("Access-Control-Allow-Origin"))
+				assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+			} else {
+				assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+			}
+		})
+	}
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	h := corsHandler(middleware.CORSPolicy{
+		AllowedOrigins: []string{"https://app.fitrkr.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders: []string{"Content-Type", "Authorization"},
+	})
+
+	tests := []struct {
+		name    string
+		origin  string
+		method  string
+		headers string
+		allowed bool
+	}{
+		{name: "allowed", origin: "https://app.fitrkr.com", method: http.MethodPost, headers: "content-type, authorization", allowed: true},
+		{name: "method not allowed", origin: "https://app.fitrkr.com", method: http.MethodDelete},
+		{name: "header not allowed", origin: "https://app.fitrkr.com", method: http.MethodPost, headers: "X-Custom"},
+		{name: "origin not allowed", origin: "https://evil.com", method: http.MethodPost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, "/", nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", tt.method)
+			if tt.headers != "" {
+				req.Header.Set("Access-Control-Request-Headers", tt.headers)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			if tt.allowed {
+				assert.Equal(t, tt.origin, rec.Header().Get("Access-Control-Allow-Origin"))
+				assert.Equal(t, "GET, POST", rec.Header().Get("Access-Control-Allow-Methods"))
+				assert.Equal(t, "Content-Type, Authorization", rec.Header().Get("Access-Control-Allow-Headers"))
+				assert.Equal(t, "86400", rec.Header().Get("Access-Control-Max-Age"))
+			} else {
+				assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+			}
+		})
+	}
+}
+
+func TestValidateOrigin(t *testing.T) {
+	for _, origin := range []string{"https://fitrkr.com", "https://*.fitrkr.com", "http://localhost:3000", "capacitor://localhost"} {
+		assert.NoError(t, middleware.ValidateOrigin(origin), origin)
+	}
+
+	for _, origin := range []string{"fitrkr.com", "https://fitrkr.com/app", "https://*", "*", "https://a.*.fitrkr.com", "https://user@fitrkr.com"} {
+		err := middleware.ValidateOrigin(origin)
+		assert.True(t, errors.Is(err, middleware.ErrInvalidOrigin), "expected %q to be invalid, got %v", origin, err)
+	}
+}
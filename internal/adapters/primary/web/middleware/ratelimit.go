@@ -0,0 +1,279 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	webctx "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/context"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/jwt"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+)
+
+const planCacheTTL = time.Minute
+
+var ErrRateLimited = errors.New("too many requests")
+
+// Limit is a token bucket holding Burst tokens that refill evenly over Per.
+type Limit struct {
+	Burst int
+	Per   time.Duration
+}
+
+func (l Limit) rate() float64 {
+	return float64(l.Burst) / l.Per.Seconds()
+}
+
+// RateLimitPolicy is the limit of one route group. Anonymous callers and
+// plans without an entry in Plans get Default.
+type RateLimitPolicy struct {
+	Default Limit
+	Plans   map[user.Plan]Limit
+}
+
+func (p RateLimitPolicy) limitFor(plan user.Plan) Limit {
+	if limit, ok := p.Plans[plan]; ok {
+		return limit
+	}
+	return p.Default
+}
+
+// RateLimits maps route group names to their policy.
+type RateLimits map[string]RateLimitPolicy
+
+func DefaultRateLimits() RateLimits {
+	return RateLimits{
+		"auth": {
+			Default: Limit{Burst: 10, Per: time.Minute},
+		},
+		"user": {
+			Default: Limit{Burst: 60, Per: time.Minute},
+			Plans: map[user.Plan]Limit{
+				user.Premium: {Burst: 300, Per: time.Minute},
+			},
+		},
+	}
+}
+
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int
+	Reset      time.Duration // until the bucket is full again
+	RetryAfter time.Duration // until the next token, when not allowed
+}
+
+// RateLimitStore keeps the buckets. Take must refill and take a token as one
+// atomic step so concurrent requests cannot overspend.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (RateLimitResult, error)
+}
+
+// PlanResolver looks up the plan of an authenticated user.
+type PlanResolver func(ctx context.Context, userID uuid.UUID) (user.Plan, error)
+
+type cachedPlan struct {
+	plan      user.Plan
+	expiresAt time.Time
+}
+
+type RateLimiter struct {
+	store  RateLimitStore
+	plans  PlanResolver
+	limits RateLimits
+
+	mu        sync.Mutex
+	planCache map[uuid.UUID]cachedPlan
+}
+
+func NewRateLimiter(store RateLimitStore, plans PlanResolver, limits RateLimits) *RateLimiter {
+	return &RateLimiter{
+		store:     store,
+		plans:     plans,
+		limits:    limits,
+		planCache: make(map[uuid.UUID]cachedPlan),
+	}
+}
+
+// RateLimitedError is returned once the bucket of the caller is empty.
+type RateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return ErrRateLimited.Error()
+}
+
+func (e *RateLimitedError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// ThrottleRequest is Throttle for the request in ctx, its client IP and
+// response writer are put there by RequestContext.
+func (m *Middleware) ThrottleRequest(ctx context.Context, group string) error {
+	header := http.Header{}
+	if w, ok := ctx.Value(webctx.ResponseWriterKey).(http.ResponseWriter); ok {
+		header = w.Header()
+	}
+
+	ip, _ := ctx.Value(webctx.ClientIPKey).(string)
+
+	return m.Throttle(ctx, ip, group, header)
+}
+
+// Throttle takes a token from the bucket of the caller in ctx, or of ip for
+// anonymous callers, and sets the RateLimit headers on h. It returns a
+// *RateLimitedError once the bucket is empty.
+func (m *Middleware) Throttle(ctx context.Context, ip, group string, h http.Header) error {
+	if m.limiter == nil {
+		return nil
+	}
+
+	policy, ok := m.limiter.limits[group]
+	if !ok {
+		return nil
+	}
+
+	key, limit := m.limiter.keyAndLimit(ctx, ip, group, policy)
+
+	result, err := m.limiter.store.Take(ctx, key, limit, time.Now())
+	if err != nil {
+		// Limiting is best effort, a broken store must not take the API down
+		logr.Get().Errorf("rate limit store failed: %v", err)
+		return nil
+	}
+
+	h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Burst, int(limit.Per.Seconds())))
+	h.Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+	h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+	if !result.Allowed {
+		logr.Get().Infof("rate limit exceeded for %s", key)
+		h.Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+		return &RateLimitedError{RetryAfter: result.RetryAfter}
+	}
+
+	return nil
+}
+
+func (l *RateLimiter) keyAndLimit(ctx context.Context, ip, group string, policy RateLimitPolicy) (string, Limit) {
+	authUser, ok := ctx.Value(webctx.AuthenticatedUserKey).(*jwt.AuthenticatedUser)
+	if !ok || authUser == nil {
+		return group + ":ip:" + ip, policy.Default
+	}
+
+	return group + ":user:" + authUser.UserID.String(), policy.limitFor(l.planFor(ctx, authUser.UserID))
+}
+
+// planFor caches plans briefly, an upgrade takes effect within a minute.
+func (l *RateLimiter) planFor(ctx context.Context, userID uuid.UUID) user.Plan {
+	if l.plans == nil {
+		return user.Basic
+	}
+
+	now := time.Now()
+
+	l.mu.Lock()
+	cached, ok := l.planCache[userID]
+	l.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.plan
+	}
+
+	plan, err := l.plans(ctx, userID)
+	if err != nil {
+		logr.Get().Errorf("failed to resolve plan for rate limit: %v", err)
+		plan = user.Basic
+	}
+
+	l.mu.Lock()
+	for id, c := range l.planCache {
+		if now.After(c.expiresAt) {
+			delete(l.planCache, id)
+		}
+	}
+	l.planCache[userID] = cachedPlan{plan: plan, expiresAt: now.Add(planCacheTTL)}
+	l.mu.Unlock()
+
+	return plan
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	fullAt  time.Time
+}
+
+// MemoryRateLimitStore keeps buckets in process, for tests and single
+// instance deployments.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastPrune time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{buckets: make(map[string]*bucket)}
+}
+
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(now)
+
+	rate := limit.rate()
+	burst := float64(limit.Burst)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, updated: now}
+		s.buckets[key] = b
+	}
+
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed*rate)
+		b.updated = now
+	}
+
+	result := RateLimitResult{}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	}
+
+	result.Remaining = int(b.tokens)
+	result.Reset = time.Duration((burst - b.tokens) / rate * float64(time.Second))
+	b.fullAt = now.Add(result.Reset)
+
+	return result, nil
+}
+
+// prune drops buckets that have refilled completely, they are identical to
+// a fresh bucket.
+func (s *MemoryRateLimitStore) prune(now time.Time) {
+	if now.Sub(s.lastPrune) < time.Minute {
+		return
+	}
+	s.lastPrune = now
+
+	for key, b := range s.buckets {
+		if now.After(b.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+}
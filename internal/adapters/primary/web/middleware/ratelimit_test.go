@@ -0,0 +1,191 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	webctx "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/context"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/jwt"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+)
+
+func TestMemoryRateLimitStore_TokenBucket(t *testing.T) {
+	store := middleware.NewMemoryRateLimitStore()
+	limit := middleware.Limit{Burst: 3, Per: 3 * time.Second}
+	ctx := context.Background()
+	now := time.Now()
+
+	for i := 2; i >= 0; i-- {
+		result, err := store.Take(ctx, "k", limit, now)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, i, result.Remaining)
+	}
+
+	result, err := store.Take(ctx, "k", limit, now)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, time.Second, result.RetryAfter)
+	assert.Equal(t, 3*time.Second, result.Reset)
+
+	// One token refills per second
+	result, _ = store.Take(ctx, "k", limit, now.Add(time.Second))
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	// Buckets never hold more than the burst
+	result, _ = store.Take(ctx, "k", limit, now.Add(time.Hour))
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 2, result.Remaining)
+
+	// Keys do not share buckets
+	result, _ = store.Take(ctx, "other", limit, now)
+	assert.Equal(t, 2, result.Remaining)
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit middleware.Limit, now time.Time) (middleware.RateLimitResult, error) {
+	return middleware.RateLimitResult{}, errors.New("store down")
+}
+
+func newLimitedHandler(store middleware.RateLimitStore, plans middleware.PlanResolver, opts ...middleware.Option) http.Handler {
+	limits := middleware.RateLimits{
+		"user": {
+			Default: middleware.Limit{Burst: 2, Per: time.Minute},
+			Plans:   map[user.Plan]middleware.Limit{user.Premium: {Burst: 5, Per: time.Minute}},
+		},
+	}
+
+	m := middleware.NewMiddleware(nil, nil,
+		append(opts, middleware.WithRateLimiter(middleware.NewRateLimiter(store, plans, limits)))...)
+
+	// Wired like the API, which throttles from inside the generated server
+	return m.RequestContext(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := m.ThrottleRequest(r.Context(), "user"); err != nil {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+}
+
+func limitedRequest(remoteAddr string, userID *uuid.UUID) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/user", nil)
+	req.RemoteAddr = remoteAddr
+	if userID != nil {
+		ctx := context.WithValue(req.Context(), webctx.AuthenticatedUserKey, &jwt.AuthenticatedUser{UserID: *userID})
+		req = req.WithContext(ctx)
+	}
+	return req
+}
+
+func TestThrottleRequest(t *testing.T) {
+	basicUser, premiumUser := uuid.New(), uuid.New()
+	plans := func(ctx context.Context, userID uuid.UUID) (user.Plan, error) {
+		if userID == premiumUser {
+			return user.Premium, nil
+		}
+		return user.Basic, nil
+	}
+
+	t.Run("anonymous callers are limited by ip", func(t *testing.T) {
+		handler := newLimitedHandler(middleware.NewMemoryRateLimitStore(), plans)
+
+		for range 2 {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, limitedRequest("192.0.2.1:5000", nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, limitedRequest("192.0.2.1:6000", nil))
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "2;w=60", rec.Header().Get("RateLimit-Policy"))
+		assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+		assert.Equal(t, "60", rec.Header().Get("RateLimit-Reset"))
+
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, limitedRequest("192.0.2.2:5000", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("clients behind a trusted proxy do not share a bucket", func(t *testing.T) {
+		proxies, err := middleware.ParseTrustedProxies([]string{"10.0.0.0/8"})
+		require.NoError(t, err)
+		handler := newLimitedHandler(middleware.NewMemoryRateLimitStore(), plans, middleware.WithTrustedProxies(proxies))
+
+		forwarded := func(client string) *http.Request {
+			req := limitedRequest("10.0.0.5:443", nil)
+			req.Header.Set("X-Forwarded-For", client)
+			return req
+		}
+
+		for range 2 {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, forwarded("198.51.100.1"))
+			assert.Equal(t, http.StatusOK, rec.Code)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, forwarded("198.51.100.1"))
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, forwarded("198.51.100.2"))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("users are limited by id and plan", func(t *testing.T) {
+		handler := newLimitedHandler(middleware.NewMemoryRateLimitStore(), plans)
+
+		allowed := func(userID uuid.UUID, addr string) int {
+			n := 0
+			for range 10 {
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, limitedRequest(addr, &userID))
+				if rec.Code == http.StatusOK {
+					n++
+				}
+			}
+			return n
+		}
+
+		// Same address, separate budgets
+		assert.Equal(t, 2, allowed(basicUser, "192.0.2.1:5000"))
+		assert.Equal(t, 5, allowed(premiumUser, "192.0.2.1:5000"))
+	})
+
+	t.Run("plan lookup failures fall back to the default", func(t *testing.T) {
+		failing := func(ctx context.Context, userID uuid.UUID) (user.Plan, error) {
+			return "", errors.New("db down")
+		}
+		handler := newLimitedHandler(middleware.NewMemoryRateLimitStore(), failing)
+
+		codes := make([]int, 0, 3)
+		for range 3 {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, limitedRequest("192.0.2.1:5000", &premiumUser))
+			codes = append(codes, rec.Code)
+		}
+		assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+	})
+
+	t.Run("store failures let requests through", func(t *testing.T) {
+		handler := newLimitedHandler(failingStore{}, plans)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, limitedRequest("192.0.2.1:5000", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+	})
+}
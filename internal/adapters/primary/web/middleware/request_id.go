@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+
+	webctx "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/context"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID keeps IDs set by a proxy, anything that could break a log
+// line is replaced.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID tags the request with the ID from the X-Request-ID header, or a
+// new one, and echoes it in the response.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = uuid.NewString()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		ctx := context.WithValue(r.Context(), webctx.RequestIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// GetRequestID returns the ID RequestID gave the request, or "" outside one.
+func GetRequestID(ctx context.Context) string {
+	id, _ := ctx.Value(webctx.RequestIDKey).(string)
+	return id
+}
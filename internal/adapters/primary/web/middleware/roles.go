@@ -1,7 +1,8 @@
 package middleware
 
 import (
-	"net/http"
+	"context"
+	"errors"
 
 	"github.com/cheezecakee/logr"
 
@@ -10,47 +11,22 @@ import (
 	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
 )
 
-func RequireAdmin() func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			authUser, ok := r.Context().Value(webctx.AuthenticatedUserKey).(*jwt.AuthenticatedUser)
-			logr.Get().Debugf("RequireAdmin: userCtx extraction - ok: %v, userCtx: %+v", ok, authUser)
-			if !ok || authUser == nil {
-				logr.Get().Errorf("failed to authorize, admin only")
-				http.Error(w, "unauthorized", http.StatusUnauthorized)
-				return
-			}
+var ErrForbidden = errors.New("forbidden")
 
-			if authUser.Roles.Contains(user.RoleAdmin) {
-				logr.Get().Info("admin access granted")
-				next.ServeHTTP(w, r)
-				return
-			}
-
-			logr.Get().Error("user access denied, admin only")
-			http.Error(w, "forbidden: admin only", http.StatusForbidden)
-		})
+// Authorize checks that the user in ctx holds perm through one of their
+// roles. Roles come from the access token, so changes apply once the token is
+// refreshed.
+func Authorize(ctx context.Context, perm user.Permission) error {
+	authUser, ok := ctx.Value(webctx.AuthenticatedUserKey).(*jwt.AuthenticatedUser)
+	if !ok || authUser == nil {
+		logr.Get().Errorf("failed to authorize %s, login required", perm)
+		return ErrInvalidToken
 	}
-}
-
-func RequireAnyRole(roles ...string) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			authUser, ok := r.Context().Value(webctx.AuthenticatedUserKey).(*jwt.AuthenticatedUser)
-			if !ok || authUser == nil {
-				logr.Get().Error("failed to authorize, login required")
-				http.Error(w, "unauthorized", http.StatusUnauthorized)
-				return
-			}
 
-			if authUser.Roles.ContainsAny(user.RoleAdmin, user.RoleModerator, user.RoleUser) {
-				logr.Get().Info("access granted")
-				next.ServeHTTP(w, r)
-				return
-			}
-
-			logr.Get().Error("user acces denied, login required")
-			http.Error(w, "forbidden: insufficient privileges", http.StatusForbidden)
-		})
+	if !authUser.Roles.Can(perm) {
+		logr.Get().Infof("user %s denied %s with roles %v", authUser.UserID, perm, authUser.Roles)
+		return ErrForbidden
 	}
+
+	return nil
 }
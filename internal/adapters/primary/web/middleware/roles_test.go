@@ -0,0 +1,42 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	webctx "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/context"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/jwt"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+)
+
+func withUser(roles ...user.Role) context.Context {
+	return context.WithValue(context.Background(), webctx.AuthenticatedUserKey, &jwt.AuthenticatedUser{
+		UserID: uuid.New(),
+		Roles:  roles,
+	})
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		perm user.Permission
+		want error
+	}{
+		{name: "admin manages roles", ctx: withUser(user.RoleUser, user.RoleAdmin), perm: user.PermUsersManageRoles},
+		{name: "moderator reads users", ctx: withUser(user.RoleUser, user.RoleModerator), perm: user.PermUsersReadAny},
+		{name: "user is forbidden", ctx: withUser(user.RoleUser), perm: user.PermUsersReadAny, want: middleware.ErrForbidden},
+		{name: "moderator cannot manage roles", ctx: withUser(user.RoleModerator), perm: user.PermUsersManageRoles, want: middleware.ErrForbidden},
+		{name: "anonymous", ctx: context.Background(), perm: user.PermUsersReadAny, want: middleware.ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, middleware.Authorize(tt.ctx, tt.perm), tt.want)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// unmatchedRoute labels requests no route matched, so probing random paths
+// does not grow the metric cardinality.
+const unmatchedRoute = "unmatched"
+
+type routeKey struct{}
+
+// SetRoute names the route of the request for spans, metrics and access
+// logs. Chi routes are named from their pattern, routers mounted under chi
+// call it with their own template.
+func SetRoute(ctx context.Context, route string) {
+	if holder, ok := ctx.Value(routeKey{}).(*string); ok {
+		*holder = route
+	}
+}
+
+// withRoute gives the request somewhere to name its route, reusing the one
+// an outer middleware already added.
+func withRoute(ctx context.Context) (context.Context, *string) {
+	if holder, ok := ctx.Value(routeKey{}).(*string); ok {
+		return ctx, holder
+	}
+	holder := new(string)
+	return context.WithValue(ctx, routeKey{}, holder), holder
+}
+
+// routeOf reads the route once the request has been served, chi fills its
+// pattern in while routing.
+func routeOf(ctx context.Context, holder *string) string {
+	if *holder != "" {
+		return *holder
+	}
+	if rctx := chi.RouteContext(ctx); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return unmatchedRoute
+}
+
+// statusRecorder remembers the status and size of the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func newStatusRecorder(w http.ResponseWriter) *statusRecorder {
+	return &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web"
+
+// Telemetry traces every request and records RED metrics per route: the
+// request count, the server errors and the duration.
+type Telemetry struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+
+	requests metric.Int64Counter
+	errors   metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+func NewTelemetry(tp trace.TracerProvider, mp metric.MeterProvider) (*Telemetry, error) {
+	meter := mp.Meter(instrumentationName)
+
+	requests, err := meter.Int64Counter("http.server.requests",
+		metric.WithDescription("Number of HTTP requests served."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+
+	serverErrors, err := meter.Int64Counter("http.server.errors",
+		metric.WithDescription("Number of HTTP requests answered with a server error."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram("http.server.request.duration",
+		metric.WithDescription("Duration of HTTP server requests."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Telemetry{
+		tracer:     tp.Tracer(instrumentationName),
+		propagator: otel.GetTextMapPropagator(),
+		requests:   requests,
+		errors:     serverErrors,
+		duration:   duration,
+	}, nil
+}
+
+func (t *Telemetry) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		ctx := t.propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := t.tracer.Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLPath(r.URL.Path),
+			))
+		defer span.End()
+
+		ctx, holder := withRoute(ctx)
+
+		rec := newStatusRecorder(w)
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		route := routeOf(ctx, holder)
+
+		attrs := []attribute.KeyValue{
+			semconv.HTTPRequestMethodKey.String(r.Method),
+			semconv.HTTPRoute(route),
+			semconv.HTTPResponseStatusCode(rec.status),
+		}
+
+		span.SetName(fmt.Sprintf("%s %s", r.Method, route))
+		span.SetAttributes(attrs[1:]...)
+		if rec.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rec.status))
+			attrs = append(attrs, semconv.ErrorTypeKey.String(strconv.Itoa(rec.status)))
+			t.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+
+		opt := metric.WithAttributes(attrs...)
+		t.requests.Add(ctx, 1, opt)
+		t.duration.Record(ctx, time.Since(start).Seconds(), opt)
+	})
+}
@@ -0,0 +1,132 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+)
+
+func newTelemetryRouter(t *testing.T) (http.Handler, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+
+	telemetry, err := middleware.NewTelemetry(
+		sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	r.Use(telemetry.Handler)
+	r.Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	r.Get("/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	r.Get("/mounted/*", func(w http.ResponseWriter, r *http.Request) {
+		middleware.SetRoute(r.Context(), "/mounted/{name}")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	return r, exporter, reader
+}
+
+func serve(h http.Handler, path string) {
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+}
+
+func TestTelemetry_SpansNamedAfterRoute(t *testing.T) {
+	router, exporter, _ := newTelemetryRouter(t)
+
+	serve(router, "/users/42")
+	serve(router, "/mounted/anything")
+	serve(router, "/nowhere")
+	serve(router, "/broken")
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 4)
+
+	assert.Equal(t, "GET /users/{id}", spans[0].Name)
+	assert.Equal(t, "GET /mounted/{name}", spans[1].Name)
+	assert.Equal(t, "GET unmatched", spans[2].Name)
+	assert.Equal(t, "GET /broken", spans[3].Name)
+
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Equal(t, codes.Unset, spans[2].Status.Code, "client errors are not span errors")
+	assert.Equal(t, codes.Error, spans[3].Status.Code)
+}
+
+func TestTelemetry_JoinsIncomingTrace(t *testing.T) {
+	// Setup installs the propagator in the app
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator()) })
+
+	router, exporter, _ := newTelemetryRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+}
+
+func TestTelemetry_REDMetrics(t *testing.T) {
+	router, _, reader := newTelemetryRouter(t)
+
+	serve(router, "/users/1")
+	serve(router, "/users/2")
+	serve(router, "/broken")
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	metrics := map[string]metricdata.Aggregation{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m.Data
+	}
+
+	requests := metrics["http.server.requests"].(metricdata.Sum[int64])
+	assert.Equal(t, int64(2), counterFor(requests, "/users/{id}"))
+	assert.Equal(t, int64(1), counterFor(requests, "/broken"))
+
+	serverErrors := metrics["http.server.errors"].(metricdata.Sum[int64])
+	assert.Equal(t, int64(0), counterFor(serverErrors, "/users/{id}"))
+	assert.Equal(t, int64(1), counterFor(serverErrors, "/broken"))
+
+	duration := metrics["http.server.request.duration"].(metricdata.Histogram[float64])
+	var observed uint64
+	for _, dp := range duration.DataPoints {
+		observed += dp.Count
+	}
+	assert.Equal(t, uint64(3), observed)
+}
+
+func counterFor(sum metricdata.Sum[int64], route string) int64 {
+	var total int64
+	for _, dp := range sum.DataPoints {
+		if v, ok := dp.Attributes.Value(attribute.Key("http.route")); ok && v.AsString() == route {
+			total += dp.Value
+		}
+	}
+	return total
+}
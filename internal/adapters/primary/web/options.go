@@ -1,7 +1,124 @@
 package web
 
+import (
+	"cmp"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+)
+
 type AppOption func(a *App)
 
 func WithPort(port int) AppOption {
 	return func(a *App) { a.port = port }
 }
+
+// WithRateLimitStore shares rate limit buckets between instances, the
+// default store only counts requests of this process.
+func WithRateLimitStore(store middleware.RateLimitStore) AppOption {
+	return func(a *App) { a.rateLimitStore = store }
+}
+
+// WithRateLimits replaces the per route group limits.
+func WithRateLimits(limits middleware.RateLimits) AppOption {
+	return func(a *App) { a.rateLimits = limits }
+}
+
+// WithTrustedProxies sets the proxies whose forwarded headers name the
+// client, for rate limits, login lockouts and the audit log.
+func WithTrustedProxies(proxies middleware.TrustedProxies) AppOption {
+	return func(a *App) { a.proxies = proxies }
+}
+
+// WithCORS sets what cross origin browsers may do, empty fields keep the
+// local development defaults.
+func WithCORS(policy middleware.CORSPolicy) AppOption {
+	return func(a *App) { a.cors = policy }
+}
+
+// WithCookiePolicy sets the attributes of the session and refresh cookies.
+func WithCookiePolicy(policy middleware.CookiePolicy) AppOption {
+	return func(a *App) { a.cookies = policy }
+}
+
+// WithTokenTTLs sets the lifetimes of the session and refresh cookies.
+func WithTokenTTLs(session, refreshToken time.Duration) AppOption {
+	return func(a *App) {
+		a.sessionTTL = session
+		a.refreshTokenTTL = refreshToken
+	}
+}
+
+// WithOAuthFlowKey MACs the cookie that carries an external login between
+// the redirect and the callback, replicas must share it.
+func WithOAuthFlowKey(key []byte) AppOption {
+	return func(a *App) { a.oauthFlowKey = key }
+}
+
+// WithTimeouts replaces the server timeouts, zero fields keep their default.
+func WithTimeouts(timeouts Timeouts) AppOption {
+	return func(a *App) {
+		defaults := DefaultTimeouts()
+		a.timeouts = Timeouts{
+			ReadHeader: cmp.Or(timeouts.ReadHeader, defaults.ReadHeader),
+			Read:       cmp.Or(timeouts.Read, defaults.Read),
+			Write:      cmp.Or(timeouts.Write, defaults.Write),
+			Idle:       cmp.Or(timeouts.Idle, defaults.Idle),
+			Shutdown:   cmp.Or(timeouts.Shutdown, defaults.Shutdown),
+			Drain:      cmp.Or(timeouts.Drain, defaults.Drain),
+		}
+	}
+}
+
+// WithInterrupts lets a signal received while draining skip the rest of the
+// drain delay, for an impatient second Ctrl-C.
+func WithInterrupts(signals <-chan os.Signal) AppOption {
+	return func(a *App) { a.interrupts = signals }
+}
+
+// WithTLS serves HTTPS with the given certificate and key files.
+func WithTLS(certFile, keyFile string) AppOption {
+	return func(a *App) {
+		a.tlsCertFile = certFile
+		a.tlsKeyFile = keyFile
+	}
+}
+
+// WithReadinessCheck adds a check /readyz runs, under name in its report.
+func WithReadinessCheck(name string, check ReadinessCheck) AppOption {
+	return func(a *App) { a.readinessChecks[name] = check }
+}
+
+// WithReadinessTimeout bounds how long /readyz waits for its checks.
+func WithReadinessTimeout(timeout time.Duration) AppOption {
+	return func(a *App) {
+		if timeout > 0 {
+			a.readinessTimeout = timeout
+		}
+	}
+}
+
+// WithTelemetry reports spans and metrics to the given providers instead of
+// the global ones.
+func WithTelemetry(tp trace.TracerProvider, mp metric.MeterProvider) AppOption {
+	return func(a *App) {
+		a.tracerProvider = tp
+		a.meterProvider = mp
+	}
+}
+
+// WithMetricsHandler serves a metrics scrape endpoint at /metrics.
+func WithMetricsHandler(handler http.Handler) AppOption {
+	return func(a *App) { a.metricsHandler = handler }
+}
+
+// WithAccessLog writes a JSON line per request to out.
+func WithAccessLog(out io.Writer) AppOption {
+	return func(a *App) { a.accessLog = out }
+}
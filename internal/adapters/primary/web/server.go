@@ -2,52 +2,147 @@
 package web
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
 	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1"
 	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/handlers"
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
 	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/jwt"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/exports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/photos"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
 )
 
 type App struct {
 	chi        *chi.Mux
-	registry   *handlers.HandlerResgistry
+	handler    *handlers.Handler
 	middleware *middleware.Middleware
 	jwtManager jwt.JWT
 	port       int
+
+	rateLimitStore middleware.RateLimitStore
+	rateLimits     middleware.RateLimits
+	proxies        middleware.TrustedProxies
+
+	cors            middleware.CORSPolicy
+	cookies         middleware.CookiePolicy
+	sessionTTL      time.Duration
+	refreshTokenTTL time.Duration
+	oauthFlowKey    []byte
+
+	timeouts    Timeouts
+	tlsCertFile string
+	tlsKeyFile  string
+	shutdown    []ShutdownFunc
+
+	readinessChecks  map[string]ReadinessCheck
+	readinessTimeout time.Duration
+	draining         atomic.Bool
+	interrupts       <-chan os.Signal
+
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	metricsHandler http.Handler
+	accessLog      io.Writer
 }
 
-func NewApp(userService users.UserService, authService auth.AuthService, jwtManager jwt.JWT, opts ...AppOption) *App {
+func NewApp(userService users.UserService, authService auth.AuthService, adminService admin.AdminService, exportService exports.ExportService, photoService photos.PhotoService, jwtManager jwt.JWT, opts ...AppOption) (*App, error) {
 	app := &App{
-		port:       8000,
-		chi:        chi.NewRouter(),
-		jwtManager: jwtManager,
+		port:           8000,
+		chi:            chi.NewRouter(),
+		jwtManager:     jwtManager,
+		rateLimitStore: middleware.NewMemoryRateLimitStore(),
+		rateLimits:     middleware.DefaultRateLimits(),
+		cors:           middleware.DefaultCORSPolicy(),
+		cookies:        middleware.DefaultCookiePolicy(),
+		timeouts:       DefaultTimeouts(),
+
+		readinessChecks:  make(map[string]ReadinessCheck),
+		readinessTimeout: defaultReadinessTimeout,
+
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
 	}
 
 	for _, applyOption := range opts {
 		applyOption(app)
 	}
 
-	app.middleware = middleware.NewMiddleware(jwtManager)
-	app.registry = handlers.NewHandlerRegistry(userService, authService, jwtManager, *app.middleware)
+	limiter := middleware.NewRateLimiter(app.rateLimitStore, planResolver(userService), app.rateLimits)
+	app.middleware = middleware.NewMiddleware(jwtManager, authService,
+		middleware.WithRateLimiter(limiter),
+		middleware.WithTrustedProxies(app.proxies),
+		middleware.WithCORSPolicy(app.cors))
+	app.handler = handlers.NewHandler(userService, authService, adminService, exportService, photoService, jwtManager,
+		handlers.WithCookiePolicy(app.cookies),
+		handlers.WithTokenTTLs(app.sessionTTL, app.refreshTokenTTL),
+		handlers.WithOAuthFlowKey(app.oauthFlowKey))
+
+	telemetry, err := middleware.NewTelemetry(app.tracerProvider, app.meterProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create telemetry middleware: %w", err)
+	}
+
+	apiServer, err := v1.NewServer(app.handler, app.middleware,
+		api.WithTracerProvider(app.tracerProvider),
+		api.WithMeterProvider(app.meterProvider))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api server: %w", err)
+	}
 
 	fs := http.FileServer(http.Dir("internal/adapters/primary/web/docs"))
 
-	app.chi.Use(app.registry.CORS)
+	app.chi.Use(middleware.RequestID)
+	app.chi.Use(telemetry.Handler)
+	if app.accessLog != nil {
+		app.chi.Use(middleware.NewAccessLog(app.accessLog, app.proxies).Handler)
+	}
+	app.chi.Use(app.middleware.CORS)
+
+	// Probes and build info stay outside the API, unauthenticated and not
+	// rate limited
+	app.chi.Get("/healthz", app.healthz)
+	app.chi.Get("/readyz", app.readyz)
+	app.chi.Get("/version", app.version)
+	if app.metricsHandler != nil {
+		app.chi.Handle("/metrics", app.metricsHandler)
+	}
+
 	app.chi.Handle("/api/v1/docs/*", http.StripPrefix("/api/v1/docs/", fs))
+	app.chi.Get("/.well-known/jwks.json", app.handler.JWKS)
+
+	app.chi.Mount(v1.Prefix, apiServer)
 
-	app.chi.Mount("/api/v1", v1.RegisterRoutes(app.registry))
+	return app, nil
+}
 
-	return app
+func planResolver(userService users.UserService) middleware.PlanResolver {
+	return func(ctx context.Context, userID uuid.UUID) (user.Plan, error) {
+		resp, err := userService.GetSubscription(ctx, users.GetSubscriptionReq{ID: userID.String()})
+		if err != nil {
+			return user.Basic, err
+		}
+		return resp.Subscription.EffectivePlan(), nil
+	}
 }
 
-func (a *App) Run() error {
-	addr := fmt.Sprintf(":%d", a.port)
-	return http.ListenAndServe(addr, a.chi)
+// Handler is the router Run serves, for embedding the app in another server.
+func (a *App) Handler() http.Handler {
+	return a.chi
 }
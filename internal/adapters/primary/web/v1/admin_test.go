@@ -0,0 +1,121 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
+)
+
+func TestAdmin_SearchUsers(t *testing.T) {
+	srv := newTestServer(t, nil)
+	ctx := context.Background()
+
+	moderator, _ := srv.clientWithRoles(t, "user", "moderator")
+	member, _ := srv.clientWithRoles(t, "user")
+
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	suspendedAt := after.Add(time.Hour)
+	srv.admin.On("SearchUsers", mock.Anything, mock.MatchedBy(func(req admin.SearchUsersReq) bool {
+		return req.Email == "example.com" && req.CreatedAfter.Equal(after) && req.CreatedBefore == nil &&
+			req.Limit == 10 && req.Offset == 20
+	})).Return(&admin.SearchUsersResp{
+		Users: []admin.UserSummary{{
+			ID:       uuid.New(),
+			Username: "janedoe",
+			Email:    "jane.doe@example.com",
+			FullName: "Jane Doe",
+			Roles:    user.Roles{user.RoleUser},
+			Status:   user.AccountStatus{SuspendedAt: &suspendedAt, SuspensionReason: "spam"},
+		}},
+		Total:  21,
+		Limit:  10,
+		Offset: 20,
+	}, nil)
+
+	page, err := moderator.SearchUsers(ctx, api.SearchUsersParams{
+		Email:        api.NewOptString("example.com"),
+		CreatedAfter: api.NewOptDateTime(after),
+		Limit:        api.NewOptInt(10),
+		Offset:       api.NewOptInt(20),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 21, page.Total)
+	require.Len(t, page.Users, 1)
+	assert.Equal(t, "janedoe", page.Users[0].Username)
+	assert.Equal(t, "spam", page.Users[0].SuspensionReason.Or(""))
+	assert.True(t, page.Users[0].EmailVerifiedAt.IsNull())
+
+	_, err = member.SearchUsers(ctx, api.SearchUsersParams{})
+	assert.Equal(t, http.StatusForbidden, statusOf(t, err))
+
+	srv.admin.AssertExpectations(t)
+}
+
+func TestAdmin_SuspendUser(t *testing.T) {
+	srv := newTestServer(t, nil)
+	ctx := context.Background()
+
+	adminClient, adminID := srv.clientWithRoles(t, "user", "admin")
+	moderator, _ := srv.clientWithRoles(t, "user", "moderator")
+	targetID := uuid.New()
+
+	now := time.Now().UTC()
+	onTarget := admin.UserActionReq{ActorID: adminID, UserID: targetID}
+	srv.admin.On("SuspendUser", mock.Anything, admin.SuspendUserReq{UserActionReq: onTarget, Reason: "chargeback fraud"}).
+		Return(nil)
+	srv.admin.On("SuspendUser", mock.Anything, admin.SuspendUserReq{UserActionReq: onTarget, Reason: " "}).
+		Return(user.ErrEmptySuspensionReason)
+	srv.admin.On("GetUser", mock.Anything, admin.GetUserReq{UserID: targetID}).
+		Return(&admin.GetUserResp{User: user.User{
+			ID:     targetID,
+			Roles:  user.Roles{user.RoleUser},
+			Status: user.AccountStatus{SuspendedAt: &now, SuspensionReason: "chargeback fraud"},
+		}}, nil)
+
+	detail, err := adminClient.SuspendUser(ctx, &api.SuspendUserReq{Reason: "chargeback fraud"}, api.SuspendUserParams{ID: targetID})
+	require.NoError(t, err)
+	assert.Equal(t, "chargeback fraud", detail.User.SuspensionReason.Or(""))
+	assert.True(t, detail.User.SuspendedAt.IsSet())
+
+	_, err = adminClient.SuspendUser(ctx, &api.SuspendUserReq{Reason: " "}, api.SuspendUserParams{ID: targetID})
+	problem := problemOf(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, problem.StatusCode)
+
+	// Moderators can look users up but not act on them
+	_, err = moderator.SuspendUser(ctx, &api.SuspendUserReq{Reason: "spam"}, api.SuspendUserParams{ID: targetID})
+	assert.Equal(t, http.StatusForbidden, statusOf(t, err))
+
+	srv.admin.AssertExpectations(t)
+}
+
+func TestAdmin_ResetPasswordAndForceLogout(t *testing.T) {
+	srv := newTestServer(t, nil)
+	ctx := context.Background()
+
+	adminClient, adminID := srv.clientWithRoles(t, "user", "admin")
+	targetID := uuid.New()
+
+	onTarget := admin.UserActionReq{ActorID: adminID, UserID: targetID}
+	srv.admin.On("ResetPassword", mock.Anything, onTarget).
+		Return(&admin.ResetPasswordResp{TemporaryPassword: "k7#Qm2vX9p!Lr4Tz8wNa"}, nil)
+	srv.admin.On("ForceLogout", mock.Anything, onTarget).Return(nil)
+
+	resp, err := adminClient.ResetUserPassword(ctx, api.ResetUserPasswordParams{ID: targetID})
+	require.NoError(t, err)
+	assert.Equal(t, "k7#Qm2vX9p!Lr4Tz8wNa", resp.TemporaryPassword)
+
+	err = adminClient.ForceLogoutUser(ctx, api.ForceLogoutUserParams{ID: targetID})
+	require.NoError(t, err)
+
+	srv.admin.AssertExpectations(t)
+}
@@ -0,0 +1,90 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+)
+
+func TestAudit_SearchAuditEvents(t *testing.T) {
+	srv := newTestServer(t, nil)
+	ctx := context.Background()
+
+	adminClient, adminID := srv.clientWithRoles(t, "user", "admin")
+	moderator, _ := srv.clientWithRoles(t, "user", "moderator")
+	targetID := uuid.New()
+
+	// Limit is the spec default
+	srv.admin.On("SearchAuditEvents", mock.Anything, admin.SearchAuditEventsReq{
+		TargetID: &targetID,
+		Action:   string(audit.ActionAdminUserSuspended),
+		Limit:    20,
+	}).Return(&admin.SearchAuditEventsResp{
+		Events: []audit.Event{{
+			ID:        uuid.New(),
+			Action:    audit.ActionAdminUserSuspended,
+			ActorID:   adminID,
+			TargetID:  targetID,
+			Metadata:  map[string]string{"reason": "spam"},
+			IP:        "203.0.113.7",
+			CreatedAt: time.Now(),
+		}},
+		Total: 1,
+		Limit: 20,
+	}, nil)
+
+	page, err := adminClient.SearchAuditEvents(ctx, api.SearchAuditEventsParams{
+		TargetID: api.NewOptUUID(targetID),
+		Action:   api.NewOptString(string(audit.ActionAdminUserSuspended)),
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Events, 1)
+	assert.Equal(t, adminID, page.Events[0].ActorID)
+	assert.Equal(t, "spam", page.Events[0].Metadata["reason"])
+	assert.Equal(t, "203.0.113.7", page.Events[0].IP.Or(""))
+	assert.True(t, page.Events[0].UserAgent.IsNull())
+
+	// Reading users does not extend to the audit log
+	_, err = moderator.SearchAuditEvents(ctx, api.SearchAuditEventsParams{})
+	assert.Equal(t, http.StatusForbidden, statusOf(t, err))
+
+	srv.admin.AssertExpectations(t)
+}
+
+func TestAudit_SecurityActivity(t *testing.T) {
+	srv := newTestServer(t, nil)
+	ctx := context.Background()
+
+	client, userID := srv.clientWithRoles(t, "user")
+
+	// The request's client reaches the core for whatever it records
+	srv.auth.On("SecurityActivity", mock.MatchedBy(func(ctx context.Context) bool {
+		return audit.ClientFromContext(ctx).IP != ""
+	}), auth.SecurityActivityReq{UserID: userID, Limit: 5}).Return(auth.SecurityActivityResp{Events: []audit.Event{
+		{ID: uuid.New(), Action: audit.ActionAdminSessionsRevoked, ActorID: uuid.New(), TargetID: userID, CreatedAt: time.Now()},
+		{ID: uuid.New(), Action: audit.ActionLoginSucceeded, ActorID: userID, TargetID: userID, IP: "203.0.113.7", CreatedAt: time.Now()},
+	}}, nil)
+
+	events, err := client.GetSecurityActivity(ctx, api.GetSecurityActivityParams{Limit: api.NewOptInt(5)})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.True(t, events[0].ByAdmin)
+	assert.True(t, events[0].IP.IsNull())
+	assert.NotNil(t, events[0].Metadata)
+	assert.False(t, events[1].ByAdmin)
+	assert.Equal(t, "203.0.113.7", events[1].IP.Or(""))
+
+	srv.auth.AssertExpectations(t)
+}
@@ -0,0 +1,658 @@
+package v1_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+	v1 "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1"
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	domain "github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+func problemOf(t *testing.T, err error) *api.ProblemStatusCode {
+	t.Helper()
+
+	var problem *api.ProblemStatusCode
+	require.ErrorAs(t, err, &problem)
+	return problem
+}
+
+func statusOf(t *testing.T, err error) int {
+	t.Helper()
+
+	return problemOf(t, err).StatusCode
+}
+
+func testUser(id uuid.UUID) *users.GetUserResp {
+	return &users.GetUserResp{
+		ID:        id,
+		Username:  "janedoe",
+		Email:     "jane.doe@example.com",
+		FullName:  "Jane Doe",
+		Roles:     user.Roles{user.RoleUser},
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		UpdatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func TestContract_CreateUser(t *testing.T) {
+	srv := newTestServer(t, nil)
+	client, _ := srv.client(t, credentials{})
+	ctx := context.Background()
+
+	userID := uuid.New()
+	srv.users.On("CreateAccount", mock.Anything, users.CreateAccountReq{
+		Username:  "janedoe",
+		Email:     "jane.doe@example.com",
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Password:  "SecurePass123!",
+	}).Return(&users.CreateAccountResp{UserID: userID.String()}, nil).Once()
+
+	resp, err := client.CreateUser(ctx, &api.CreateUserReq{
+		Username:  "janedoe",
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Email:     "jane.doe@example.com",
+		Password:  "SecurePass123!",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, userID, resp.UserID)
+	srv.users.AssertExpectations(t)
+}
+
+func TestContract_CreateUserIgnoresRoles(t *testing.T) {
+	srv := newTestServer(t, nil)
+
+	// Without roles the service creates a regular user
+	srv.users.On("CreateAccount", mock.Anything, users.CreateAccountReq{
+		Username:  "janedoe",
+		Email:     "jane.doe@example.com",
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Password:  "SecurePass123!",
+	}).Return(&users.CreateAccountResp{UserID: uuid.New().String()}, nil).Once()
+
+	body := `{"username":"janedoe","first_name":"Jane","last_name":"Doe","email":"jane.doe@example.com","password":"SecurePass123!","roles":["admin"]}`
+	resp, err := http.Post(srv.URL+v1.Prefix+"/user", "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	srv.users.AssertExpectations(t)
+}
+
+func TestContract_RejectsRequestsOutsideTheSpec(t *testing.T) {
+	srv := newTestServer(t, nil)
+
+	// Required fields are enforced before the service is called
+	resp, err := http.Post(srv.URL+v1.Prefix+"/user", "application/json", strings.NewReader(`{"username":"janedoe"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+
+	var body api.Problem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "invalid_request", body.Code)
+	assert.Equal(t, http.StatusBadRequest, body.Status)
+	srv.users.AssertNotCalled(t, "CreateAccount", mock.Anything, mock.Anything)
+
+	resp, err = http.Get(srv.URL + v1.Prefix + "/nope")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+}
+
+func TestContract_Problems(t *testing.T) {
+	srv := newTestServer(t, nil)
+	client, _ := srv.client(t, credentials{})
+	ctx := context.Background()
+
+	userID := uuid.New()
+	token, err := srv.jwtManager.MakeJWT(userID, []string{"user"})
+	require.NoError(t, err)
+	authed, _ := srv.client(t, credentials{session: token})
+
+	srv.users.On("CreateAccount", mock.Anything, mock.MatchedBy(func(req users.CreateAccountReq) bool { return req.Username == "taken" })).
+		Return(nil, users.ErrDuplicateUsername)
+	srv.users.On("CreateAccount", mock.Anything, mock.MatchedBy(func(req users.CreateAccountReq) bool { return req.Username == "x" })).
+		Return(nil, fmt.Errorf("invalid username: %w", user.ErrUsernameTooShort))
+	srv.users.On("CreateAccount", mock.Anything, mock.MatchedBy(func(req users.CreateAccountReq) bool { return req.Username == "y" })).
+		Return(nil, fmt.Errorf("invalid account: %w", &user.ValidationError{Fields: []user.FieldError{
+			{Field: "username", Err: user.ErrUsernameTooShort},
+			{Field: "password", Err: user.ErrPasswordNoDigit},
+		}}))
+	srv.users.On("GetByUsername", mock.Anything, mock.Anything).Return(nil, users.ErrUserNotFound)
+	srv.users.On("GetByID", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	newUser := func(username string) *api.CreateUserReq {
+		return &api.CreateUserReq{Username: username, FirstName: "Jane", LastName: "Doe", Email: "jane.doe@example.com", Password: "SecurePass123!"}
+	}
+
+	tests := []struct {
+		name   string
+		call   func() error
+		status int
+		code   string
+		fields []api.FieldError
+	}{
+		{
+			name:   "duplicate username",
+			call:   func() error { _, err := client.CreateUser(ctx, newUser("taken")); return err },
+			status: http.StatusConflict,
+			code:   "duplicate_username",
+		},
+		{
+			name:   "invalid field",
+			call:   func() error { _, err := client.CreateUser(ctx, newUser("x")); return err },
+			status: http.StatusUnprocessableEntity,
+			code:   "validation_failed",
+			fields: []api.FieldError{{Field: "username", Code: "username_too_short", Detail: "username too short"}},
+		},
+		{
+			name:   "every invalid field",
+			call:   func() error { _, err := client.CreateUser(ctx, newUser("y")); return err },
+			status: http.StatusUnprocessableEntity,
+			code:   "validation_failed",
+			fields: []api.FieldError{
+				{Field: "username", Code: "username_too_short", Detail: "username too short"},
+				{Field: "password", Code: "password_no_digit", Detail: "password must contain a digit"},
+			},
+		},
+		{
+			name: "not found",
+			call: func() error {
+				_, err := authed.GetUserByUsername(ctx, api.GetUserByUsernameParams{Username: "nobody"})
+				return err
+			},
+			status: http.StatusNotFound,
+			code:   "user_not_found",
+		},
+		{
+			name:   "unexpected error",
+			call:   func() error { _, err := authed.GetUserByID(ctx); return err },
+			status: http.StatusInternalServerError,
+			code:   "internal_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := problemOf(t, tt.call())
+			assert.Equal(t, tt.status, problem.StatusCode)
+			assert.Equal(t, tt.status, problem.Response.Status)
+			assert.Equal(t, tt.code, problem.Response.Code)
+			assert.Equal(t, http.StatusText(tt.status), problem.Response.Title)
+			assert.Equal(t, tt.fields, problem.Response.Errors)
+		})
+	}
+
+	// Internal details never reach the client
+	problem := problemOf(t, tests[len(tests)-1].call())
+	assert.NotContains(t, problem.Response.Detail.Or(""), "connection refused")
+}
+
+func TestContract_LoginSetsSessionCookies(t *testing.T) {
+	srv := newTestServer(t, nil)
+	client, jar := srv.client(t, credentials{})
+	ctx := context.Background()
+
+	userID := uuid.New()
+	srv.auth.On("Login", mock.Anything, mock.MatchedBy(func(req auth.LoginReq) bool {
+		return req.Username == "janedoe" && req.Password == "SecurePass123!" && req.IP != ""
+	})).Return(auth.LoginResp{UserID: userID, Roles: []string{"user"}, RefreshToken: "refresh"}, nil).Once()
+
+	require.NoError(t, client.Login(ctx, &api.LoginReq{Username: "janedoe", Password: "SecurePass123!"}))
+
+	refreshURL, err := url.Parse(srv.URL + v1.Prefix + "/auth/refresh")
+	require.NoError(t, err)
+
+	cookies := map[string]string{}
+	for _, c := range jar.Cookies(refreshURL) {
+		cookies[c.Name] = c.Value
+	}
+	assert.Equal(t, "refresh", cookies["refresh_token"])
+
+	authUser, err := srv.jwtManager.ValidateJWT(cookies["session"])
+	require.NoError(t, err)
+	assert.Equal(t, userID, authUser.UserID)
+
+	// The refresh token cookie is sent back to the refresh operation
+	srv.auth.On("Refresh", mock.Anything, auth.RefreshReq{Token: "refresh"}).
+		Return(auth.RefreshResp{UserID: userID, Roles: []string{"user"}, Token: "rotated"}, nil).Once()
+
+	require.NoError(t, client.Refresh(ctx, api.RefreshParams{RefreshToken: "refresh"}))
+	srv.auth.AssertExpectations(t)
+}
+
+func TestContract_LoginErrors(t *testing.T) {
+	srv := newTestServer(t, nil)
+	client, _ := srv.client(t, credentials{})
+	ctx := context.Background()
+
+	srv.auth.On("Login", mock.Anything, mock.MatchedBy(func(req auth.LoginReq) bool { return req.Username == "wrong" })).
+		Return(auth.LoginResp{}, auth.ErrInvalidCredentials)
+	srv.auth.On("Login", mock.Anything, mock.MatchedBy(func(req auth.LoginReq) bool { return req.Username == "locked" })).
+		Return(auth.LoginResp{}, &auth.ThrottledError{RetryAfter: time.Minute})
+
+	err := client.Login(ctx, &api.LoginReq{Username: "wrong", Password: "x"})
+	assert.Equal(t, http.StatusUnauthorized, statusOf(t, err))
+
+	err = client.Login(ctx, &api.LoginReq{Username: "locked", Password: "x"})
+	assert.Equal(t, http.StatusTooManyRequests, statusOf(t, err))
+}
+
+func TestContract_LoginRestoresPendingDeletion(t *testing.T) {
+	srv := newTestServer(t, nil)
+	client, _ := srv.client(t, credentials{})
+	ctx := context.Background()
+
+	userID := uuid.New()
+	srv.auth.On("Login", mock.Anything, mock.MatchedBy(func(req auth.LoginReq) bool { return !req.Restore })).
+		Return(auth.LoginResp{}, auth.ErrAccountPendingDeletion).Once()
+	srv.auth.On("Login", mock.Anything, mock.MatchedBy(func(req auth.LoginReq) bool { return req.Restore })).
+		Return(auth.LoginResp{UserID: userID, Roles: []string{"user"}, RefreshToken: "refresh"}, nil).Once()
+
+	err := client.Login(ctx, &api.LoginReq{Username: "janedoe", Password: "SecurePass123!"})
+	problem := problemOf(t, err)
+	assert.Equal(t, http.StatusForbidden, problem.StatusCode)
+	assert.Equal(t, "account_pending_deletion", problem.Response.Code)
+
+	require.NoError(t, client.Login(ctx, &api.LoginReq{Username: "janedoe", Password: "SecurePass123!", Restore: api.NewOptBool(true)}))
+	srv.auth.AssertExpectations(t)
+}
+
+func TestContract_DeleteUserEndsSession(t *testing.T) {
+	srv := newTestServer(t, nil)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	token, err := srv.jwtManager.MakeJWT(userID, []string{"user"})
+	require.NoError(t, err)
+	client, jar := srv.client(t, credentials{bearer: token})
+
+	srv.auth.On("Login", mock.Anything, mock.Anything).
+		Return(auth.LoginResp{UserID: userID, Roles: []string{"user"}, RefreshToken: "refresh"}, nil).Once()
+	srv.users.On("Delete", mock.Anything, users.DeleteAccountReq{ID: userID.String()}).Return(nil).Once()
+
+	require.NoError(t, client.Login(ctx, &api.LoginReq{Username: "janedoe", Password: "SecurePass123!"}))
+	require.NoError(t, client.DeleteUser(ctx))
+	srv.users.AssertExpectations(t)
+
+	refreshURL, err := url.Parse(srv.URL + v1.Prefix + "/auth/refresh")
+	require.NoError(t, err)
+	for _, c := range jar.Cookies(refreshURL) {
+		assert.NotContains(t, []string{"session", "refresh_token"}, c.Name, "expected the session cookies cleared")
+	}
+}
+
+func TestContract_Security(t *testing.T) {
+	srv := newTestServer(t, nil)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	token, err := srv.jwtManager.MakeJWT(userID, []string{"user"})
+	require.NoError(t, err)
+
+	srv.users.On("GetByID", mock.Anything, users.GetUserByIDReq{ID: userID.String()}).Return(testUser(userID), nil)
+	srv.users.On("GetStats", mock.Anything, users.GetStatsReq{ID: userID.String()}).
+		Return(&users.GetStatsResp{Stats: user.Stats{}}, nil)
+	srv.auth.On("AuthenticateAPIKey", mock.Anything, auth.AuthenticateAPIKeyReq{Key: "fk_valid"}).
+		Return(auth.AuthenticateAPIKeyResp{UserID: userID, Roles: []string{"user"}, Scopes: domain.Scopes{domain.ScopeStatsRead}}, nil)
+	srv.auth.On("AuthenticateAPIKey", mock.Anything, mock.Anything).
+		Return(auth.AuthenticateAPIKeyResp{}, auth.ErrInvalidAPIKey)
+
+	t.Run("session cookie", func(t *testing.T) {
+		client, _ := srv.client(t, credentials{session: token})
+		resp, err := client.GetUserByID(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, userID, resp.ID)
+		assert.Equal(t, "Jane Doe", resp.FullName)
+	})
+
+	t.Run("bearer token", func(t *testing.T) {
+		client, _ := srv.client(t, credentials{bearer: token})
+		_, err := client.GetUserByID(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		client, _ := srv.client(t, credentials{bearer: "not-a-jwt"})
+		_, err := client.GetUserByID(ctx)
+		assert.Equal(t, http.StatusUnauthorized, statusOf(t, err))
+	})
+
+	t.Run("api key with the scope of the operation", func(t *testing.T) {
+		client, _ := srv.client(t, credentials{apiKey: "fk_valid"})
+		_, err := client.GetUserStats(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("api key without the scope of the operation", func(t *testing.T) {
+		client, _ := srv.client(t, credentials{apiKey: "fk_valid"})
+		_, err := client.GetUserByID(ctx)
+		assert.Equal(t, http.StatusForbidden, statusOf(t, err))
+	})
+
+	t.Run("invalid api key", func(t *testing.T) {
+		client, _ := srv.client(t, credentials{apiKey: "fk_wrong"})
+		_, err := client.GetUserStats(ctx)
+		assert.Equal(t, http.StatusUnauthorized, statusOf(t, err))
+	})
+
+	raw := func(t *testing.T, path, authorization string) int {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, srv.URL+v1.Prefix+path, nil)
+		require.NoError(t, err)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	t.Run("api key on a session only operation", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, raw(t, "/user/subscription", "ApiKey fk_valid"))
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, raw(t, "/user", ""))
+	})
+}
+
+func TestContract_UpdateSettingsReturnsSettings(t *testing.T) {
+	srv := newTestServer(t, nil)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	token, err := srv.jwtManager.MakeJWT(userID, []string{"user"})
+	require.NoError(t, err)
+
+	theme := "light"
+	srv.users.On("UpdateSettings", mock.Anything, users.UpdateSettingsReq{UserID: userID.String(), Theme: &theme}).
+		Return(nil).Once()
+	srv.users.On("GetSettings", mock.Anything, users.GetSettingsReq{ID: userID.String()}).
+		Return(&users.GetSettingsResp{Settings: user.Settings{WeightUnit: user.Kg, HeightUnit: user.Cm, Theme: user.Light, Visibility: user.Public}}, nil).Once()
+
+	client, _ := srv.client(t, credentials{session: token})
+	resp, err := client.UpdateUserSettings(ctx, &api.UpdateUserSettingsReq{Theme: api.NewOptString(theme)})
+	require.NoError(t, err)
+	assert.Equal(t, "light", resp.Theme)
+	srv.users.AssertExpectations(t)
+}
+
+func TestContract_UsernameCooldown(t *testing.T) {
+	srv := newTestServer(t, nil)
+
+	userID := uuid.New()
+	token, err := srv.jwtManager.MakeJWT(userID, []string{"user"})
+	require.NoError(t, err)
+
+	until := time.Now().Add(48 * time.Hour)
+	srv.users.On("Update", mock.Anything, users.UpdateUserReq{ID: userID.String(), Username: "renamed"}).
+		Return(fmt.Errorf("failed to update user: %w", &user.UsernameCooldownError{Until: until}))
+
+	req, err := http.NewRequest(http.MethodPut, srv.URL+v1.Prefix+"/user", strings.NewReader(`{"username":"renamed"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	require.NoError(t, err)
+	assert.InDelta(t, (48 * time.Hour).Seconds(), retryAfter, 5)
+
+	var problem api.Problem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+	assert.Equal(t, "username_cooldown", problem.Code)
+}
+
+func TestContract_StartOAuthRedirects(t *testing.T) {
+	srv := newTestServer(t, nil)
+	client, _ := srv.client(t, credentials{})
+	ctx := context.Background()
+
+	srv.auth.On("StartExternalLogin", mock.Anything, auth.StartExternalLoginReq{Provider: "google"}).
+		Return(auth.StartExternalLoginResp{AuthURL: "https://accounts.example.com/auth?state=s", State: "s"}, nil)
+	srv.auth.On("StartExternalLogin", mock.Anything, mock.Anything).
+		Return(auth.StartExternalLoginResp{}, auth.ErrUnknownProvider)
+
+	resp, err := client.StartOAuth(ctx, api.StartOAuthParams{Provider: "google"})
+	require.NoError(t, err)
+	assert.Equal(t, "accounts.example.com", resp.Location.Host)
+
+	_, err = client.StartOAuth(ctx, api.StartOAuthParams{Provider: "myspace"})
+	assert.Equal(t, http.StatusNotFound, statusOf(t, err))
+}
+
+func TestContract_OAuthFlowCookieIsSigned(t *testing.T) {
+	srv := newTestServer(t, nil)
+
+	srv.auth.On("StartExternalLogin", mock.Anything, auth.StartExternalLoginReq{Provider: "google"}).
+		Return(auth.StartExternalLoginResp{AuthURL: "https://accounts.example.com/auth?state=s", State: "s"}, nil)
+
+	noRedirect := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := noRedirect.Get(srv.URL + v1.Prefix + "/auth/oauth/google")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	var flow *http.Cookie
+	for _, c := range resp.Cookies() {
+		if c.Name == "oauth_flow" {
+			flow = c
+		}
+	}
+	require.NotNil(t, flow)
+	assert.Equal(t, v1.Prefix+"/auth/oauth", flow.Path)
+	assert.True(t, flow.HttpOnly)
+
+	// Flip Restore on while keeping the original MAC
+	payload, mac, ok := strings.Cut(flow.Value, ".")
+	require.True(t, ok)
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	require.NoError(t, err)
+	tampered := strings.Replace(string(raw), `"restore":false`, `"restore":true`, 1)
+	require.NotEqual(t, string(raw), tampered)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+v1.Prefix+"/auth/oauth/google/callback?code=c&state=s", nil)
+	require.NoError(t, err)
+	req.AddCookie(&http.Cookie{Name: "oauth_flow", Value: base64.RawURLEncoding.EncodeToString([]byte(tampered)) + "." + mac})
+
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	var problem api.Problem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+	assert.Equal(t, "invalid_oauth_state", problem.Code)
+	srv.auth.AssertNotCalled(t, "ExternalLogin", mock.Anything, mock.Anything)
+}
+
+func TestContract_RateLimit(t *testing.T) {
+	srv := newTestServer(t, middleware.RateLimits{
+		"auth": {Default: middleware.Limit{Burst: 1, Per: time.Minute}},
+	})
+
+	srv.auth.On("UnlockAccount", mock.Anything, mock.Anything).Return(nil)
+
+	unlock := func() *http.Response {
+		resp, err := http.Get(srv.URL + v1.Prefix + "/auth/unlock?token=t")
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := unlock()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "0", resp.Header.Get("RateLimit-Remaining"))
+
+	resp = unlock()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "60", resp.Header.Get("Retry-After"))
+}
+
+func TestContract_RateLimitsRejectedCredentials(t *testing.T) {
+	srv := newTestServer(t, middleware.RateLimits{
+		"auth": {Default: middleware.Limit{Burst: 1, Per: time.Minute}},
+	})
+
+	srv.auth.On("AuthenticateAPIKey", mock.Anything, mock.Anything).
+		Return(auth.AuthenticateAPIKeyResp{}, auth.ErrInvalidAPIKey)
+
+	guess := func(set func(*http.Request)) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+v1.Prefix+"/user", nil)
+		require.NoError(t, err)
+		set(req)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	t.Run("expired session tokens are not charged", func(t *testing.T) {
+		for range 5 {
+			resp := guess(func(req *http.Request) { req.Header.Set("Authorization", "Bearer not-a-jwt") })
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+			resp = guess(func(req *http.Request) {
+				req.AddCookie(&http.Cookie{Name: string(middleware.Session), Value: "not-a-jwt"})
+			})
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		}
+	})
+
+	t.Run("guessed api keys are", func(t *testing.T) {
+		apiKey := func(req *http.Request) { req.Header.Set("Authorization", "ApiKey fk_wrong") }
+
+		resp := guess(apiKey)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(t, "0", resp.Header.Get("RateLimit-Remaining"))
+
+		resp = guess(apiKey)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "60", resp.Header.Get("Retry-After"))
+	})
+}
+
+func TestContract_GetMe(t *testing.T) {
+	srv := newTestServer(t, nil)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	token, err := srv.jwtManager.MakeJWT(userID, []string{"user"})
+	require.NoError(t, err)
+
+	born := time.Date(1990, 4, 21, 0, 0, 0, 0, time.UTC)
+	weight := user.WeightValue(220.5)
+	me := testUser(userID)
+	me.Profile = user.Profile{BirthDate: &born, Sex: user.Female, ExperienceLevel: user.Intermediate}
+	me.HasAvatar = true
+
+	srv.users.On("GetFullProfile", mock.Anything, users.GetFullProfileReq{ID: userID.String()}).
+		Return(&users.GetFullProfileResp{
+			User:         *me,
+			Stats:        user.Stats{Weight: &weight},
+			Subscription: user.Subscription{Plan: user.Basic},
+			Settings:     user.Settings{WeightUnit: user.Lb, HeightUnit: user.Ft, Theme: user.Dark, Visibility: user.Private},
+		}, nil).Once()
+
+	client, _ := srv.client(t, credentials{session: token})
+	resp, err := client.GetMe(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, "janedoe", resp.User.Username)
+	assert.Equal(t, api.NewOptNilDate(born), resp.User.BirthDate)
+	assert.Equal(t, api.NewOptNilUserSex(api.UserSexFemale), resp.User.Sex)
+	assert.True(t, resp.User.Bio.IsNull())
+	assert.Equal(t, api.NewOptNilString(fmt.Sprintf("%s/user/%s/avatar", v1.Prefix, userID)), resp.User.AvatarURL)
+	assert.Equal(t, api.NewOptNilFloat64(220.5), resp.Stats.Weight)
+	assert.Equal(t, "lb", resp.Settings.WeightUnit)
+	srv.users.AssertExpectations(t)
+}
+
+func TestContract_PublicProfileLeavesPrivateFieldsOut(t *testing.T) {
+	srv := newTestServer(t, nil)
+
+	viewerID, ownerID := uuid.New(), uuid.New()
+	token, err := srv.jwtManager.MakeJWT(viewerID, []string{"user"})
+	require.NoError(t, err)
+
+	srv.users.On("GetByUsername", mock.Anything, users.GetUserByUsernameReq{Username: "janedoe"}).
+		Return(&users.GetPublicUserResp{
+			ID:       ownerID,
+			Username: "janedoe",
+			FullName: "Jane Doe",
+			Profile:  user.PublicProfile{Bio: "Powerlifter", ExperienceLevel: user.Advanced},
+		}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+v1.Prefix+"/user/username/janedoe", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "Powerlifter", body["bio"])
+	assert.Equal(t, "advanced", body["experience_level"])
+	for _, field := range []string{"email", "roles", "birth_date", "sex"} {
+		assert.NotContains(t, body, field)
+	}
+}
+
+func TestContract_UpdateProfileClearsWithNull(t *testing.T) {
+	srv := newTestServer(t, nil)
+
+	userID := uuid.New()
+	token, err := srv.jwtManager.MakeJWT(userID, []string{"user"})
+	require.NoError(t, err)
+
+	empty, female := "", "female"
+	srv.users.On("Update", mock.Anything, users.UpdateUserReq{ID: userID.String(), Bio: &empty, Sex: &female}).
+		Return(nil).Once()
+	srv.users.On("GetByID", mock.Anything, users.GetUserByIDReq{ID: userID.String()}).
+		Return(testUser(userID), nil).Once()
+
+	req, err := http.NewRequest(http.MethodPut, srv.URL+v1.Prefix+"/user", strings.NewReader(`{"bio":null,"sex":"female"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	srv.users.AssertExpectations(t)
+}
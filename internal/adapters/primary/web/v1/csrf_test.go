@@ -0,0 +1,184 @@
+package v1_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+	v1 "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1"
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+// deleteAccount sends DELETE /user the way a browser would, with whatever
+// cookies and headers the page manages to attach.
+func deleteAccount(t *testing.T, srv *testServer, cookies []*http.Cookie, header http.Header) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodDelete, srv.URL+v1.Prefix+"/user", nil)
+	require.NoError(t, err)
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var problem api.Problem
+	if resp.StatusCode >= http.StatusBadRequest {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+	}
+	return resp.StatusCode, problem.Code
+}
+
+func TestCSRF_CookieAuthenticatedWrites(t *testing.T) {
+	srv := newTestServer(t, nil)
+
+	userID := uuid.New()
+	session, err := srv.jwtManager.MakeJWT(userID, []string{"user"})
+	require.NoError(t, err)
+
+	csrfToken, err := middleware.NewCSRFToken()
+	require.NoError(t, err)
+	otherToken, err := middleware.NewCSRFToken()
+	require.NoError(t, err)
+
+	sessionCookie := &http.Cookie{Name: "session", Value: session}
+	csrfCookie := &http.Cookie{Name: "csrf_token", Value: csrfToken}
+
+	tests := []struct {
+		name    string
+		cookies []*http.Cookie
+		header  http.Header
+		allowed bool
+	}{
+		{
+			// A form on another site posting with the user's cookies
+			name:    "cross site without token",
+			cookies: []*http.Cookie{sessionCookie, csrfCookie},
+			header:  http.Header{"Origin": {"https://evil.example"}},
+		},
+		{
+			name:    "no csrf cookie",
+			cookies: []*http.Cookie{sessionCookie},
+			header:  http.Header{middleware.CSRFHeader: {csrfToken}},
+		},
+		{
+			name:    "token does not match the cookie",
+			cookies: []*http.Cookie{sessionCookie, csrfCookie},
+			header:  http.Header{middleware.CSRFHeader: {otherToken}},
+		},
+		{
+			name:    "malformed token in both",
+			cookies: []*http.Cookie{sessionCookie, {Name: "csrf_token", Value: "x"}},
+			header:  http.Header{middleware.CSRFHeader: {"x"}},
+		},
+		{
+			name:    "matching token",
+			cookies: []*http.Cookie{sessionCookie, csrfCookie},
+			header:  http.Header{middleware.CSRFHeader: {csrfToken}},
+			allowed: true,
+		},
+		{
+			name:    "bearer token is exempt",
+			header:  http.Header{"Authorization": {"Bearer " + session}},
+			allowed: true,
+		},
+		{
+			name:    "bearer token alongside the cookies is exempt",
+			cookies: []*http.Cookie{sessionCookie, csrfCookie},
+			header:  http.Header{"Authorization": {"Bearer " + session}},
+			allowed: true,
+		},
+		{
+			// No handler takes Basic, the session cookie authenticates
+			name:    "unused authorization header with the cookies",
+			cookies: []*http.Cookie{sessionCookie, csrfCookie},
+			header:  http.Header{"Authorization": {"Basic x"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.allowed {
+				srv.users.On("Delete", mock.Anything, users.DeleteAccountReq{ID: userID.String()}).Return(nil).Once()
+			}
+
+			status, code := deleteAccount(t, srv, tt.cookies, tt.header)
+			if tt.allowed {
+				assert.Equal(t, http.StatusNoContent, status)
+			} else {
+				assert.Equal(t, http.StatusForbidden, status)
+				assert.Equal(t, "csrf_token_invalid", code)
+			}
+		})
+	}
+
+	srv.users.AssertExpectations(t)
+}
+
+func TestCSRF_RefreshCookieNeedsToken(t *testing.T) {
+	srv := newTestServer(t, nil)
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+v1.Prefix+"/auth/refresh", nil)
+	require.NoError(t, err)
+	req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "refresh"})
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	srv.auth.AssertNotCalled(t, "Refresh", mock.Anything, mock.Anything)
+}
+
+func TestCSRF_LoginWithoutCookiesIsAllowed(t *testing.T) {
+	srv := newTestServer(t, nil)
+
+	// No session rides along, there is nothing to forge
+	srv.auth.On("Login", mock.Anything, mock.Anything).Return(auth.LoginResp{}, auth.ErrInvalidCredentials).Once()
+
+	client, _ := srv.client(t, credentials{})
+	err := client.Login(context.Background(), &api.LoginReq{Username: "janedoe", Password: "wrong"})
+	assert.Equal(t, http.StatusUnauthorized, statusOf(t, err))
+}
+
+func TestGetCSRFToken(t *testing.T) {
+	srv := newTestServer(t, nil)
+	client, jar := srv.client(t, credentials{})
+	ctx := context.Background()
+
+	first, err := client.GetCSRFToken(ctx, api.GetCSRFTokenParams{})
+	require.NoError(t, err)
+	assert.True(t, middleware.ValidCSRFToken(first.CsrfToken))
+
+	// The cookie is kept, so other tabs holding the token stay valid
+	second, err := client.GetCSRFToken(ctx, api.GetCSRFTokenParams{CsrfToken: api.NewOptString(first.CsrfToken)})
+	require.NoError(t, err)
+	assert.Equal(t, first.CsrfToken, second.CsrfToken)
+
+	apiURL, err := url.Parse(srv.URL + v1.Prefix)
+	require.NoError(t, err)
+
+	var cookie *http.Cookie
+	for _, c := range jar.Cookies(apiURL) {
+		if c.Name == "csrf_token" {
+			cookie = c
+		}
+	}
+	require.NotNil(t, cookie, "expected the csrf_token cookie")
+	assert.Equal(t, first.CsrfToken, cookie.Value)
+}
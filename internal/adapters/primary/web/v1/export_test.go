@@ -0,0 +1,115 @@
+package v1_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/exports"
+)
+
+func TestExport_RequestAndPoll(t *testing.T) {
+	srv := newTestServer(t, nil)
+	ctx := context.Background()
+
+	client, userID := srv.clientWithRoles(t, "user")
+
+	pending := export.New(userID, time.Now())
+	srv.exports.On("RequestExport", mock.Anything, exports.RequestExportReq{UserID: userID}).
+		Return(&exports.ExportResp{Export: pending}, nil).Once()
+	srv.exports.On("RequestExport", mock.Anything, exports.RequestExportReq{UserID: userID}).
+		Return(nil, exports.ErrExportInProgress).Once()
+
+	completed := pending.Complete("key", 2048, time.Hour, time.Now())
+	srv.exports.On("GetExport", mock.Anything, exports.GetExportReq{UserID: userID, ExportID: pending.ID}).
+		Return(&exports.ExportResp{Export: completed}, nil)
+
+	created, err := client.RequestDataExport(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, api.DataExportStatusPending, created.Status)
+	assert.False(t, created.DownloadURL.IsSet())
+
+	_, err = client.RequestDataExport(ctx)
+	problem := problemOf(t, err)
+	assert.Equal(t, http.StatusConflict, problem.StatusCode)
+	assert.Equal(t, "export_in_progress", problem.Response.Code)
+
+	polled, err := client.GetDataExport(ctx, api.GetDataExportParams{ID: pending.ID})
+	require.NoError(t, err)
+	assert.Equal(t, api.DataExportStatusCompleted, polled.Status)
+	assert.Equal(t, "/api/v1/user/exports/"+pending.ID.String()+"/archive", polled.DownloadURL.Or(""))
+	assert.Equal(t, int64(2048), polled.SizeBytes.Or(0))
+
+	srv.exports.AssertExpectations(t)
+}
+
+func TestExport_Download(t *testing.T) {
+	srv := newTestServer(t, nil)
+	ctx := context.Background()
+
+	client, userID := srv.clientWithRoles(t, "user")
+
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	completed := export.New(userID, createdAt).Complete("key", 6, time.Hour, time.Now())
+	body := &closeRecorder{Reader: strings.NewReader("zipped")}
+	srv.exports.On("OpenArchive", mock.Anything, exports.GetExportReq{UserID: userID, ExportID: completed.ID}).
+		Return(&exports.OpenArchiveResp{Export: completed, Body: body}, nil)
+
+	resp, err := client.DownloadDataExport(ctx, api.DownloadDataExportParams{ID: completed.ID})
+	require.NoError(t, err)
+	assert.Equal(t, `attachment; filename="fitrkr-export-2026-01-02.zip"`, resp.ContentDisposition.Or(""))
+
+	data, err := io.ReadAll(resp.Response.Data)
+	require.NoError(t, err)
+	assert.Equal(t, "zipped", string(data))
+	assert.True(t, body.closed.Load(), "expected the archive closed once streamed")
+}
+
+// closeRecorder is an object store body that records being closed.
+type closeRecorder struct {
+	io.Reader
+	closed atomic.Bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed.Store(true)
+	return nil
+}
+
+func TestExport_DownloadProblems(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{name: "not found", err: exports.ErrExportNotFound, status: http.StatusNotFound, code: "export_not_found"},
+		{name: "not ready", err: export.ErrNotReady, status: http.StatusConflict, code: "export_not_ready"},
+		{name: "expired", err: export.ErrExpired, status: http.StatusGone, code: "export_expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(t, nil)
+			client, _ := srv.clientWithRoles(t, "user")
+
+			srv.exports.On("OpenArchive", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			_, err := client.DownloadDataExport(context.Background(), api.DownloadDataExportParams{ID: uuid.New()})
+			problem := problemOf(t, err)
+			assert.Equal(t, tt.status, problem.StatusCode)
+			assert.Equal(t, tt.code, problem.Response.Code)
+		})
+	}
+}
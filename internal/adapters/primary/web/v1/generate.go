@@ -1,3 +1,3 @@
 package v1
 
-//go:generate go run github.com/ogen-go/ogen/cmd/ogen@latest --target ogen --clean ../docs/fitrkr.yml
+//go:generate go tool ogen --config ogen.yml --target ogen --clean ../docs/fitrkr.yml
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
+)
+
+// The admin operations are limited to the permissions in
+// v1.operationPermissions by the authorize middleware.
+
+// SearchUsers needs users:read_any.
+func (h *Handler) SearchUsers(ctx context.Context, params api.SearchUsersParams) (*api.AdminUserPage, error) {
+	resp, err := h.admin.SearchUsers(ctx, admin.SearchUsersReq{
+		Username:      params.Username.Or(""),
+		Email:         params.Email.Or(""),
+		CreatedAfter:  optDateTime(params.CreatedAfter),
+		CreatedBefore: optDateTime(params.CreatedBefore),
+		Limit:         params.Limit.Or(0),
+		Offset:        params.Offset.Or(0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &api.AdminUserPage{
+		Users:  make([]api.AdminUser, 0, len(resp.Users)),
+		Total:  resp.Total,
+		Limit:  resp.Limit,
+		Offset: resp.Offset,
+	}
+	for _, u := range resp.Users {
+		page.Users = append(page.Users, toAdminUserSummary(u))
+	}
+
+	return page, nil
+}
+
+// GetAdminUser needs users:read_any.
+func (h *Handler) GetAdminUser(ctx context.Context, params api.GetAdminUserParams) (*api.AdminUserDetail, error) {
+	return h.adminUserDetail(ctx, params.ID)
+}
+
+// UpdateAdminUser needs users:manage.
+func (h *Handler) UpdateAdminUser(ctx context.Context, req *api.UpdateAdminUserReq, params api.UpdateAdminUserParams) (*api.AdminUserDetail, error) {
+	action, err := adminAction(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.admin.UpdateUser(ctx, admin.UpdateUserReq{
+		UserActionReq: action,
+		Username:      req.Username.Or(""),
+		Email:         req.Email.Or(""),
+		FirstName:     req.FirstName.Or(""),
+		LastName:      req.LastName.Or(""),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return h.adminUserDetail(ctx, params.ID)
+}
+
+// ResetUserPassword needs users:manage.
+func (h *Handler) ResetUserPassword(ctx context.Context, params api.ResetUserPasswordParams) (*api.TemporaryPassword, error) {
+	action, err := adminAction(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.admin.ResetPassword(ctx, action)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.TemporaryPassword{TemporaryPassword: resp.TemporaryPassword}, nil
+}
+
+// VerifyUserEmail needs users:manage.
+func (h *Handler) VerifyUserEmail(ctx context.Context, params api.VerifyUserEmailParams) (*api.AdminUserDetail, error) {
+	action, err := adminAction(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.admin.VerifyEmail(ctx, action); err != nil {
+		return nil, err
+	}
+
+	return h.adminUserDetail(ctx, params.ID)
+}
+
+// SuspendUser needs users:manage.
+func (h *Handler) SuspendUser(ctx context.Context, req *api.SuspendUserReq, params api.SuspendUserParams) (*api.AdminUserDetail, error) {
+	action, err := adminAction(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.admin.SuspendUser(ctx, admin.SuspendUserReq{UserActionReq: action, Reason: req.Reason}); err != nil {
+		return nil, err
+	}
+
+	return h.adminUserDetail(ctx, params.ID)
+}
+
+// UnsuspendUser needs users:manage.
+func (h *Handler) UnsuspendUser(ctx context.Context, params api.UnsuspendUserParams) (*api.AdminUserDetail, error) {
+	action, err := adminAction(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.admin.UnsuspendUser(ctx, action); err != nil {
+		return nil, err
+	}
+
+	return h.adminUserDetail(ctx, params.ID)
+}
+
+// ForceLogoutUser needs users:manage.
+func (h *Handler) ForceLogoutUser(ctx context.Context, params api.ForceLogoutUserParams) error {
+	action, err := adminAction(ctx, params.ID)
+	if err != nil {
+		return err
+	}
+
+	return h.admin.ForceLogout(ctx, action)
+}
+
+// GrantUserRole needs users:manage_roles.
+func (h *Handler) GrantUserRole(ctx context.Context, params api.GrantUserRoleParams) (*api.UserRoles, error) {
+	action, err := adminAction(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.admin.GrantRole(ctx, admin.ManageRoleReq{UserActionReq: action, Role: params.Role})
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.UserRoles{Roles: resp.Roles.ToStrings()}, nil
+}
+
+// RevokeUserRole needs users:manage_roles.
+func (h *Handler) RevokeUserRole(ctx context.Context, params api.RevokeUserRoleParams) (*api.UserRoles, error) {
+	action, err := adminAction(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.admin.RevokeRole(ctx, admin.ManageRoleReq{UserActionReq: action, Role: params.Role})
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.UserRoles{Roles: resp.Roles.ToStrings()}, nil
+}
+
+// SearchAuditEvents needs audit:read.
+func (h *Handler) SearchAuditEvents(ctx context.Context, params api.SearchAuditEventsParams) (*api.AuditEventPage, error) {
+	resp, err := h.admin.SearchAuditEvents(ctx, admin.SearchAuditEventsReq{
+		ActorID:  optUUID(params.ActorID),
+		TargetID: optUUID(params.TargetID),
+		Action:   params.Action.Or(""),
+		Since:    optDateTime(params.Since),
+		Until:    optDateTime(params.Until),
+		Limit:    params.Limit.Or(0),
+		Offset:   params.Offset.Or(0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &api.AuditEventPage{
+		Events: make([]api.AuditEvent, 0, len(resp.Events)),
+		Total:  resp.Total,
+		Limit:  resp.Limit,
+		Offset: resp.Offset,
+	}
+	for _, event := range resp.Events {
+		page.Events = append(page.Events, toAuditEvent(event))
+	}
+
+	return page, nil
+}
+
+// adminAction names the authenticated admin as the actor on userID.
+func adminAction(ctx context.Context, userID uuid.UUID) (admin.UserActionReq, error) {
+	actor, err := getUser(ctx)
+	if err != nil {
+		return admin.UserActionReq{}, err
+	}
+
+	return admin.UserActionReq{ActorID: actor.UserID, UserID: userID}, nil
+}
+
+func (h *Handler) adminUserDetail(ctx context.Context, userID uuid.UUID) (*api.AdminUserDetail, error) {
+	resp, err := h.admin.GetUser(ctx, admin.GetUserReq{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	return toAdminUserDetail(resp), nil
+}
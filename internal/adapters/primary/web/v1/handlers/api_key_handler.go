@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+)
+
+// CreateAPIKey returns the plaintext key once, only its hash is kept.
+func (h *Handler) CreateAPIKey(ctx context.Context, req *api.CreateAPIKeyReq) (*api.CreateAPIKeyCreated, error) {
+	user, err := getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt *time.Time
+	if v, ok := req.ExpiresAt.Get(); ok {
+		expiresAt = &v
+	}
+
+	resp, err := h.auth.CreateAPIKey(ctx, auth.CreateAPIKeyReq{
+		UserID:    user.UserID.String(),
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CreateAPIKeyCreated{Key: toAPIKey(resp.Key), APIKey: resp.Plaintext}, nil
+}
+
+func (h *Handler) ListAPIKeys(ctx context.Context) ([]api.APIKey, error) {
+	user, err := getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.auth.ListAPIKeys(ctx, auth.ListAPIKeysReq{UserID: user.UserID.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]api.APIKey, 0, len(resp.Keys))
+	for _, key := range resp.Keys {
+		keys = append(keys, toAPIKey(key))
+	}
+
+	return keys, nil
+}
+
+func (h *Handler) RevokeAPIKey(ctx context.Context, params api.RevokeAPIKeyParams) error {
+	user, err := getUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	return h.auth.RevokeAPIKey(ctx, auth.RevokeAPIKeyReq{
+		UserID: user.UserID.String(),
+		KeyID:  params.ID.String(),
+	})
+}
@@ -1,148 +1,123 @@
-// Package handlers package handlers
 package handlers
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"net/http"
-	"time"
+	"strconv"
 
 	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
 
 	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
-	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/jwt"
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
 	"github.com/cheezecakee/fitrkr-athena/pkg/web"
 )
 
-type AuthHandler struct {
-	Service    auth.AuthService
-	jwtManager jwt.JWT
-}
+func (h *Handler) Login(ctx context.Context, req *api.LoginReq) error {
+	resp, err := h.auth.Login(ctx, auth.LoginReq{
+		Username: req.Username,
+		Password: req.Password,
+		Restore:  req.Restore.Or(false),
+		IP:       clientIP(ctx),
+	})
+	if err != nil {
+		var throttled *auth.ThrottledError
+		if errors.As(err, &throttled) {
+			setHeader(ctx, "Retry-After", strconv.Itoa(ceilSeconds(throttled.RetryAfter)))
+		}
+		return err
+	}
 
-func NewAuthHandler(service auth.AuthService, jwtManager jwt.JWT) *AuthHandler {
-	return &AuthHandler{Service: service, jwtManager: jwtManager}
+	return h.setSessionCookies(ctx, resp.UserID, resp.Roles, resp.RefreshToken)
 }
 
-func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
-	var req auth.LoginReq
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		web.ClientError(w, http.StatusBadRequest)
-		return
-	}
+// UnlockAccount is the target of the link in the lockout email.
+func (h *Handler) UnlockAccount(ctx context.Context, params api.UnlockAccountParams) error {
+	return h.auth.UnlockAccount(ctx, auth.UnlockAccountReq{Token: params.Token})
+}
 
-	resp, err := h.Service.Login(r.Context(), req)
+func (h *Handler) Refresh(ctx context.Context, params api.RefreshParams) error {
+	resp, err := h.auth.Refresh(ctx, auth.RefreshReq{Token: params.RefreshToken})
 	if err != nil {
-		web.ServerError(w, err)
-		return
+		return err
 	}
 
-	token, err := h.jwtManager.MakeJWT(resp.UserID, resp.Roles)
-	if err != nil {
-		web.ServerError(w, err)
-	}
+	return h.setSessionCookies(ctx, resp.UserID, resp.Roles, resp.Token)
+}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     "session",
-		Value:    token,
-		Path:     "/",
-		HttpOnly: false, // Set to true in production with HTTPS
-		Secure:   false, // Set to true in production with HTTPS
-		SameSite: http.SameSiteLaxMode,
-		Expires:  time.Now().Add(15 * time.Minute), // Adjust as needed
-	})
+func (h *Handler) Logout(ctx context.Context, params api.LogoutParams) error {
+	if token, ok := params.RefreshToken.Get(); ok {
+		if err := h.auth.Revoke(ctx, auth.RevokeTokenReq{Token: token}); err != nil {
+			return err
+		}
+	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     "refresh_token",
-		Value:    resp.RefreshToken, // access token
-		Path:     "/auth/refresh",
-		HttpOnly: false, // Set to true in production with HTTPS
-		Secure:   false, // Set to true in production with HTTPS
-		SameSite: http.SameSiteLaxMode,
-		Expires:  time.Now().Add(7 * 24 * time.Hour), // Adjust as needed
-	})
+	setCookie(ctx, h.cookies.Expired(middleware.Session))
+	setCookie(ctx, h.cookies.Expired(middleware.RefreshToken))
+	setCookie(ctx, h.cookies.Expired(middleware.CSRFToken))
 
-	w.Header().Set("Content-Type", "application/json")
+	logr.Get().Info("User logged out successfully!")
 
-	web.Response(w, http.StatusOK, "Login successful")
+	return nil
 }
 
-func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
-	token, err := middleware.ExtractToken(r, middleware.RefreshToken)
-	if err != nil {
-		web.ServerError(w, err)
+// GetCSRFToken hands out the token for the double submit check, keeping the
+// current one so other open tabs stay valid.
+func (h *Handler) GetCSRFToken(ctx context.Context, params api.GetCSRFTokenParams) (*api.CSRFToken, error) {
+	token, ok := params.CsrfToken.Get()
+	if !ok || !middleware.ValidCSRFToken(token) {
+		var err error
+		if token, err = middleware.NewCSRFToken(); err != nil {
+			return nil, err
+		}
 	}
 
-	refresh := auth.RefreshReq{Token: token}
+	setCookie(ctx, h.cookies.Cookie(middleware.CSRFToken, token, h.refreshTokenTTL))
 
-	resp, err := h.Service.Refresh(r.Context(), refresh)
-	if err != nil {
-		web.ServerError(w, err)
-	}
+	return &api.CSRFToken{CsrfToken: token}, nil
+}
 
-	accessToken, err := h.jwtManager.MakeJWT(resp.UserID, resp.Roles)
+func (h *Handler) GetSecurityActivity(ctx context.Context, params api.GetSecurityActivityParams) ([]api.SecurityEvent, error) {
+	user, err := getUser(ctx)
 	if err != nil {
-		web.ServerError(w, err)
+		return nil, err
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     "session",
-		Value:    accessToken,
-		Path:     "/",
-		HttpOnly: false, // Set to true in production with HTTPS
-		Secure:   false, // Set to true in production with HTTPS
-		SameSite: http.SameSiteLaxMode,
-		Expires:  time.Now().Add(15 * time.Minute), // Adjust as needed
-	})
-
-	http.SetCookie(w, &http.Cookie{
-		Name:     "refresh_token",
-		Value:    resp.Token, // access token
-		Path:     "/auth/refresh",
-		HttpOnly: false, // Set to true in production with HTTPS
-		Secure:   false, // Set to true in production with HTTPS
-		SameSite: http.SameSiteLaxMode,
-		Expires:  time.Now().Add(7 * 24 * time.Hour), // Adjust as needed
+	resp, err := h.auth.SecurityActivity(ctx, auth.SecurityActivityReq{
+		UserID: user.UserID,
+		Limit:  params.Limit.Or(0),
 	})
-
-	w.Header().Set("Content-Type", "application/json")
-
-	web.Response(w, http.StatusOK, "Login successful")
-}
-
-func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
-	var req auth.RevokeTokenReq
-
-	token, err := middleware.ExtractToken(r, middleware.RefreshToken)
 	if err != nil {
-		web.ServerError(w, err)
+		return nil, err
 	}
 
-	req.Token = token
+	events := make([]api.SecurityEvent, 0, len(resp.Events))
+	for _, event := range resp.Events {
+		events = append(events, toSecurityEvent(event))
+	}
+
+	return events, nil
+}
 
-	err = h.Service.Revoke(r.Context(), req)
+// setSessionCookies issues the access token and stores it with the refresh
+// token in cookies.
+func (h *Handler) setSessionCookies(ctx context.Context, userID uuid.UUID, roles []string, refreshToken string) error {
+	token, err := h.jwtManager.MakeJWT(userID, roles)
 	if err != nil {
-		web.ServerError(w, err)
-		return
+		return err
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     "session",
-		Value:    "",
-		Path:     "/",
-		MaxAge:   -1, // Deletes the cookie
-		HttpOnly: true,
-	})
-
-	http.SetCookie(w, &http.Cookie{
-		Name:     "refresh_token",
-		Value:    "",
-		Path:     "/auth/refresh",
-		MaxAge:   -1,
-		HttpOnly: true,
-	})
+	setCookie(ctx, h.cookies.Cookie(middleware.Session, token, h.sessionTTL))
+	setCookie(ctx, h.cookies.Cookie(middleware.RefreshToken, refreshToken, h.refreshTokenTTL))
 
-	logr.Get().Info("User logged out successfully!")
+	return nil
+}
 
-	w.WriteHeader(http.StatusNoContent)
+// JWKS is served outside the API at /.well-known/jwks.json.
+func (h *Handler) JWKS(w http.ResponseWriter, r *http.Request) {
+	// Verifiers cache the set, rotation keeps retired keys published long enough
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	web.Response(w, http.StatusOK, h.jwtManager.JWKS())
 }
@@ -0,0 +1,370 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	domain "github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+// Conversions from the domain to the generated API types
+
+func toUser(u *users.GetUserResp) *api.User {
+	out := &api.User{
+		ID:           u.ID,
+		Username:     string(u.Username),
+		Email:        string(u.Email),
+		FullName:     u.FullName,
+		Roles:        u.Roles.ToStrings(),
+		Bio:          optNilString(nonEmpty(u.Profile.Bio)),
+		BirthDate:    optNilDate(u.Profile.BirthDate),
+		PreferredGym: optNilString(nonEmpty(u.Profile.PreferredGym)),
+		CreatedAt:    u.CreatedAt,
+		UpdatedAt:    u.UpdatedAt,
+	}
+
+	out.Sex.SetToNull()
+	if u.Profile.Sex != "" {
+		out.Sex.SetTo(api.UserSex(u.Profile.Sex))
+	}
+
+	out.ExperienceLevel.SetToNull()
+	if u.Profile.ExperienceLevel != "" {
+		out.ExperienceLevel.SetTo(api.UserExperienceLevel(u.Profile.ExperienceLevel))
+	}
+
+	out.AvatarURL.SetToNull()
+	if u.HasAvatar {
+		out.AvatarURL.SetTo(fmt.Sprintf(avatarPath, u.ID))
+	}
+
+	return out
+}
+
+func toPublicUser(u *users.GetPublicUserResp) *api.PublicUser {
+	out := &api.PublicUser{
+		ID:           u.ID,
+		Username:     string(u.Username),
+		FullName:     u.FullName,
+		Bio:          optNilString(nonEmpty(u.Profile.Bio)),
+		PreferredGym: optNilString(nonEmpty(u.Profile.PreferredGym)),
+		CreatedAt:    u.CreatedAt,
+	}
+
+	out.ExperienceLevel.SetToNull()
+	if u.Profile.ExperienceLevel != "" {
+		out.ExperienceLevel.SetTo(api.PublicUserExperienceLevel(u.Profile.ExperienceLevel))
+	}
+
+	out.AvatarURL.SetToNull()
+	if u.HasAvatar {
+		out.AvatarURL.SetTo(fmt.Sprintf(avatarPath, u.ID))
+	}
+
+	return out
+}
+
+func toFullProfile(resp *users.GetFullProfileResp) *api.FullProfile {
+	return &api.FullProfile{
+		User:         *toUser(&resp.User),
+		Stats:        *toStats(resp.Stats),
+		Subscription: *toSubscription(resp.Subscription),
+		Settings:     *toSettings(resp.Settings),
+	}
+}
+
+func toAdminUserSummary(u admin.UserSummary) api.AdminUser {
+	return toAdminUser(user.User{
+		ID:        u.ID,
+		Username:  u.Username,
+		Email:     u.Email,
+		FullName:  u.FullName,
+		Roles:     u.Roles,
+		Status:    u.Status,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	})
+}
+
+func toAdminUser(u user.User) api.AdminUser {
+	return api.AdminUser{
+		ID:                  u.ID,
+		Username:            string(u.Username),
+		Email:               string(u.Email),
+		FullName:            u.FullName,
+		Roles:               u.Roles.ToStrings(),
+		EmailVerifiedAt:     optNilDateTime(u.Status.EmailVerifiedAt),
+		SuspendedAt:         optNilDateTime(u.Status.SuspendedAt),
+		SuspensionReason:    optNilString(nonEmpty(u.Status.SuspensionReason)),
+		DeletionRequestedAt: optNilDateTime(u.Status.DeletionRequestedAt),
+		CreatedAt:           u.CreatedAt,
+		UpdatedAt:           u.UpdatedAt,
+	}
+}
+
+func toAdminUserDetail(resp *admin.GetUserResp) *api.AdminUserDetail {
+	return &api.AdminUserDetail{
+		User:         toAdminUser(resp.User),
+		Stats:        *toStats(resp.User.Stats),
+		Subscription: *toSubscription(resp.User.Subscription),
+		Settings:     *toSettings(resp.User.Settings),
+	}
+}
+
+func toAuditEvent(e audit.Event) api.AuditEvent {
+	return api.AuditEvent{
+		ID:        e.ID,
+		Action:    string(e.Action),
+		ActorID:   e.ActorID,
+		TargetID:  e.TargetID,
+		IP:        optNilString(nonEmpty(e.IP)),
+		UserAgent: optNilString(nonEmpty(e.UserAgent)),
+		Metadata:  toAuditMetadata(e.Metadata),
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func toSecurityEvent(e audit.Event) api.SecurityEvent {
+	return api.SecurityEvent{
+		ID:        e.ID,
+		Action:    string(e.Action),
+		ByAdmin:   !e.BySelf(),
+		IP:        optNilString(nonEmpty(e.IP)),
+		UserAgent: optNilString(nonEmpty(e.UserAgent)),
+		Metadata:  toAuditMetadata(e.Metadata),
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func toAuditMetadata(m map[string]string) api.AuditMetadata {
+	if m == nil {
+		return api.AuditMetadata{}
+	}
+	return api.AuditMetadata(m)
+}
+
+func toSubscription(s user.Subscription) *api.UserSubscription {
+	return &api.UserSubscription{
+		Plan:          string(s.Plan),
+		BillingPeriod: optNilString(s.BillingPeriod),
+		StartedAt:     s.StartedAt,
+		ExpiresAt:     optNilDateTime(s.ExpiresAt),
+		AutoRenew:     s.AutoRenew,
+		CancelledAt:   optNilDateTime(s.CancelledAt),
+		LastPaymentAt: optNilDateTime(s.LastPaymentAt),
+
+		LastPaymentAmount:   optNilFloat64(s.LastPaymentAmount),
+		LastPaymentCurrency: optNilString(s.LastPaymentCurrency),
+
+		TrialEndsAt: optNilDateTime(s.TrialEndsAt),
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+func toSettings(s user.Settings) *api.UserSettings {
+	return &api.UserSettings{
+		WeightUnit:      string(s.WeightUnit),
+		HeightUnit:      string(s.HeightUnit),
+		Theme:           string(s.Theme),
+		Visibility:      string(s.Visibility),
+		EmailNotif:      s.EmailNotif,
+		PushNotif:       s.PushNotif,
+		WorkoutReminder: s.WorkoutReminder,
+		StreakReminder:  s.StreakReminder,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+}
+
+func toStats(s user.Stats) *api.UserStats {
+	return &api.UserStats{
+		Weight: optNilFloat64(s.Weight),
+		Height: optNilFloat64(s.Height),
+		Bfp:    optNilFloat64(s.BFP),
+		Streak: api.Streak{
+			RestDays:    s.Streak.RestDays,
+			Current:     s.Streak.Current,
+			Longest:     s.Streak.Longest,
+			LastWorkout: optNilDateTime(s.Streak.LastWorkout),
+		},
+		Totals: api.Totals{
+			Workouts: s.Totals.Workouts,
+			Lifted:   s.Totals.Lifted,
+			Time:     s.Totals.Time,
+		},
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func toAPIKey(k *domain.APIKey) api.APIKey {
+	return api.APIKey{
+		ID:         k.ID,
+		UserID:     k.UserID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.Scopes.ToStrings(),
+		ExpiresAt:  optNilDateTime(k.ExpiresAt),
+		LastUsedAt: optNilDateTime(k.LastUsedAt),
+		RevokedAt:  optNilDateTime(k.RevokedAt),
+		CreatedAt:  k.CreatedAt,
+		UpdatedAt:  k.UpdatedAt,
+	}
+}
+
+func toIdentity(i *domain.Identity) api.Identity {
+	return api.Identity{
+		Provider:  i.Provider,
+		UserID:    i.UserID,
+		Email:     i.Email,
+		CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt,
+	}
+}
+
+// exportPath is where the archives of exports are downloaded.
+const exportPath = "/api/v1/user/exports/%s/archive"
+
+func toDataExport(e export.Export) *api.DataExport {
+	out := &api.DataExport{
+		ID:          e.ID,
+		Status:      api.DataExportStatus(e.Status),
+		CompletedAt: optNilDateTime(e.CompletedAt),
+		ExpiresAt:   optNilDateTime(e.ExpiresAt),
+		CreatedAt:   e.CreatedAt,
+	}
+
+	if e.Downloadable(time.Now()) == nil {
+		out.DownloadURL = api.NewOptString(fmt.Sprintf(exportPath, e.ID))
+		out.SizeBytes = api.NewOptNilInt64(e.Size)
+	} else {
+		out.SizeBytes.SetToNull()
+	}
+
+	return out
+}
+
+// Where the images of avatars and progress photos are served.
+const (
+	avatarPath        = "/api/v1/user/%s/avatar"
+	progressPhotoPath = "/api/v1/user/progress-photos/%s/image"
+)
+
+func toPhoto(p photo.Photo) *api.Photo {
+	out := &api.Photo{
+		ID:          p.ID,
+		ContentType: p.ContentType,
+		Width:       p.Width,
+		Height:      p.Height,
+		SizeBytes:   p.Size,
+		TakenOn:     optNilDate(p.TakenOn),
+		ImageURL:    fmt.Sprintf(progressPhotoPath, p.ID),
+		CreatedAt:   p.CreatedAt,
+	}
+	if p.Kind == photo.KindAvatar {
+		out.ImageURL = fmt.Sprintf(avatarPath, p.UserID)
+	}
+	return out
+}
+
+// Nullable fields are always sent, as null when unset
+
+func optNilString[T ~string](v *T) api.OptNilString {
+	if v == nil {
+		var o api.OptNilString
+		o.SetToNull()
+		return o
+	}
+	return api.NewOptNilString(string(*v))
+}
+
+func optNilFloat64[T ~float64](v *T) api.OptNilFloat64 {
+	if v == nil {
+		var o api.OptNilFloat64
+		o.SetToNull()
+		return o
+	}
+	return api.NewOptNilFloat64(float64(*v))
+}
+
+func optNilDateTime(v *time.Time) api.OptNilDateTime {
+	if v == nil {
+		var o api.OptNilDateTime
+		o.SetToNull()
+		return o
+	}
+	return api.NewOptNilDateTime(*v)
+}
+
+func optNilDate(v *time.Time) api.OptNilDate {
+	if v == nil {
+		var o api.OptNilDate
+		o.SetToNull()
+		return o
+	}
+	return api.NewOptNilDate(*v)
+}
+
+// nonEmpty is nil for the empty string, which the core uses for unset.
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// Optional request fields map to the nil pointers the services treat as unset
+
+func optString(o api.OptString) *string {
+	if v, ok := o.Get(); ok {
+		return &v
+	}
+	return nil
+}
+
+// optNilStringValue maps a field that null or an empty string clears to ""
+// and a missing one to nil.
+func optNilStringValue(o api.OptNilString) *string {
+	if !o.Set {
+		return nil
+	}
+	return &o.Value
+}
+
+func optDateTime(o api.OptDateTime) *time.Time {
+	if v, ok := o.Get(); ok {
+		return &v
+	}
+	return nil
+}
+
+func optUUID(o api.OptUUID) *uuid.UUID {
+	if v, ok := o.Get(); ok {
+		return &v
+	}
+	return nil
+}
+
+func optBool(o api.OptBool) *bool {
+	if v, ok := o.Get(); ok {
+		return &v
+	}
+	return nil
+}
+
+func optFloat64(o api.OptFloat64) *float64 {
+	if v, ok := o.Get(); ok {
+		return &v
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/ogen-go/ogen/ogenerrors"
+
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+)
+
+const problemContentType = "application/problem+json"
+
+// NewError translates every error returned by a handler, a security handler
+// or a middleware into a problem.
+func (h *Handler) NewError(ctx context.Context, err error) *api.ProblemStatusCode {
+	return translate(err)
+}
+
+// ErrorHandler answers requests the generated server rejects before they
+// reach a handler, such as unknown operations.
+func ErrorHandler(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	writeProblem(w, translate(err))
+}
+
+// NotFound answers requests for paths the API does not have.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, newProblem(http.StatusNotFound, "not_found", ""))
+}
+
+// MethodNotAllowed answers requests for a path with a method it does not
+// support.
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request, allowed string) {
+	w.Header().Set("Allow", allowed)
+	writeProblem(w, newProblem(http.StatusMethodNotAllowed, "method_not_allowed", ""))
+}
+
+func translate(err error) *api.ProblemStatusCode {
+	var validation *user.ValidationError
+	if errors.As(err, &validation) {
+		fields := make([]api.FieldError, 0, len(validation.Fields))
+		for _, f := range validation.Fields {
+			fields = append(fields, fieldError(f.Field, f.Err))
+		}
+		return validationProblem(fields)
+	}
+
+	if p, ok := problemFor(err); ok {
+		if p.field != "" {
+			return validationProblem([]api.FieldError{fieldError(p.field, err)})
+		}
+
+		// Only the sentinel is shown, the wrapping may carry internal details
+		return newProblem(p.status, p.code, p.err.Error())
+	}
+
+	switch status := ogenerrors.ErrorCode(err); {
+	case status == http.StatusUnauthorized:
+		return newProblem(status, "unauthenticated", "")
+	case status < http.StatusInternalServerError:
+		// Decoding and validation errors of the generated server
+		return newProblem(status, "invalid_request", err.Error())
+	}
+
+	logr.Get().Errorf("server error: %v", err)
+
+	return newProblem(http.StatusInternalServerError, "internal_error", "")
+}
+
+func validationProblem(fields []api.FieldError) *api.ProblemStatusCode {
+	resp := newProblem(http.StatusUnprocessableEntity, "validation_failed", "one or more fields are invalid")
+	resp.Response.Errors = fields
+	return resp
+}
+
+func fieldError(field string, err error) api.FieldError {
+	p, ok := problemFor(err)
+	if !ok {
+		return api.FieldError{Field: field, Code: "invalid", Detail: "invalid value"}
+	}
+	return api.FieldError{Field: field, Code: p.code, Detail: p.err.Error()}
+}
+
+func newProblem(status int, code, detail string) *api.ProblemStatusCode {
+	resp := &api.ProblemStatusCode{
+		StatusCode: status,
+		Response: api.Problem{
+			Type:   "about:blank",
+			Title:  http.StatusText(status),
+			Status: status,
+			Code:   code,
+		},
+	}
+	if detail != "" {
+		resp.Response.Detail = api.NewOptString(detail)
+	}
+	return resp
+}
+
+func writeProblem(w http.ResponseWriter, p *api.ProblemStatusCode) {
+	b, err := json.Marshal(&p.Response)
+	if err != nil {
+		logr.Get().Errorf("failed to encode problem: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(p.StatusCode)
+	w.Write(b)
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
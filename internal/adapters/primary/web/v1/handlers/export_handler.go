@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/exports"
+)
+
+func (h *Handler) RequestDataExport(ctx context.Context) (*api.DataExport, error) {
+	authUser, err := getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.exports.RequestExport(ctx, exports.RequestExportReq{UserID: authUser.UserID})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDataExport(resp.Export), nil
+}
+
+func (h *Handler) GetDataExport(ctx context.Context, params api.GetDataExportParams) (*api.DataExport, error) {
+	authUser, err := getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.exports.GetExport(ctx, exports.GetExportReq{UserID: authUser.UserID, ExportID: params.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDataExport(resp.Export), nil
+}
+
+// DownloadDataExport streams the archive from the store, the generated server
+// closes the body once it is written.
+func (h *Handler) DownloadDataExport(ctx context.Context, params api.DownloadDataExportParams) (*api.DownloadDataExportOKHeaders, error) {
+	authUser, err := getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.exports.OpenArchive(ctx, exports.GetExportReq{UserID: authUser.UserID, ExportID: params.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	filename := fmt.Sprintf("fitrkr-export-%s.zip", resp.Export.CreatedAt.UTC().Format("2006-01-02"))
+	return &api.DownloadDataExportOKHeaders{
+		ContentDisposition: api.NewOptString(fmt.Sprintf("attachment; filename=%q", filename)),
+		Response:           api.DownloadDataExportOK{Data: resp.Body},
+	}, nil
+}
@@ -1,24 +1,130 @@
+// Package handlers implements the generated API server on top of the core
+// services.
 package handlers
 
 import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	webctx "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/context"
 	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
 	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/jwt"
+	domain "github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/exports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/photos"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
 )
 
-type HandlerResgistry struct {
-	UserHandler *UserHandler
-	AuthHandler *AuthHandler
-	JwtManager  jwt.JWT
-	*middleware.Middleware
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+const defaultSessionTTL = 15 * time.Minute
+
+type Handler struct {
+	users      users.UserService
+	auth       auth.AuthService
+	admin      admin.AdminService
+	exports    exports.ExportService
+	photos     photos.PhotoService
+	jwtManager jwt.JWT
+
+	cookies         middleware.CookiePolicy
+	sessionTTL      time.Duration
+	refreshTokenTTL time.Duration
+	flowKey         []byte
+}
+
+var _ api.Handler = (*Handler)(nil)
+
+type Option func(h *Handler)
+
+// WithCookiePolicy sets the attributes of the session and refresh token
+// cookies.
+func WithCookiePolicy(policy middleware.CookiePolicy) Option {
+	return func(h *Handler) { h.cookies = policy }
+}
+
+// WithTokenTTLs sets how long the session and refresh cookies live, they
+// should match the lifetimes of the tokens they hold.
+func WithTokenTTLs(session, refreshToken time.Duration) Option {
+	return func(h *Handler) {
+		if session > 0 {
+			h.sessionTTL = session
+		}
+		if refreshToken > 0 {
+			h.refreshTokenTTL = refreshToken
+		}
+	}
+}
+
+// WithOAuthFlowKey MACs the external login flow cookie. Replicas must share
+// it, the callback may reach another instance than the redirect. Without one
+// a key is generated, which suits a single instance.
+func WithOAuthFlowKey(key []byte) Option {
+	return func(h *Handler) {
+		if len(key) > 0 {
+			h.flowKey = key
+		}
+	}
+}
+
+func NewHandler(userService users.UserService, authService auth.AuthService, adminService admin.AdminService, exportService exports.ExportService, photoService photos.PhotoService, jwtManager jwt.JWT, opts ...Option) *Handler {
+	h := &Handler{
+		users:           userService,
+		auth:            authService,
+		admin:           adminService,
+		exports:         exportService,
+		photos:          photoService,
+		jwtManager:      jwtManager,
+		cookies:         middleware.DefaultCookiePolicy(),
+		sessionTTL:      defaultSessionTTL,
+		refreshTokenTTL: domain.DefaultRefreshTokenTTL,
+	}
+
+	for _, applyOption := range opts {
+		applyOption(h)
+	}
+
+	if h.flowKey == nil {
+		h.flowKey = make([]byte, 32)
+		rand.Read(h.flowKey)
+	}
+
+	return h
+}
+
+// getUser returns the user the security handler authenticated.
+func getUser(ctx context.Context) (*jwt.AuthenticatedUser, error) {
+	user, ok := ctx.Value(webctx.AuthenticatedUserKey).(*jwt.AuthenticatedUser)
+	if !ok || user == nil || user.UserID == uuid.Nil {
+		return nil, ErrUnauthenticated
+	}
+
+	return user, nil
+}
+
+// setCookie writes to the response through the writer stored by
+// middleware.RequestContext, the generated handlers only pass on the context.
+func setCookie(ctx context.Context, cookie *http.Cookie) {
+	if w, ok := ctx.Value(webctx.ResponseWriterKey).(http.ResponseWriter); ok {
+		http.SetCookie(w, cookie)
+	}
 }
 
-func NewHandlerRegistry(userService users.UserService, authService auth.AuthService, jwtManager jwt.JWT, middleware middleware.Middleware) *HandlerResgistry {
-	return &HandlerResgistry{
-		UserHandler: NewUserHandler(userService),
-		AuthHandler: NewAuthHandler(authService, jwtManager),
-		JwtManager:  jwtManager,
-		Middleware:  &middleware,
+func setHeader(ctx context.Context, key, value string) {
+	if w, ok := ctx.Value(webctx.ResponseWriterKey).(http.ResponseWriter); ok {
+		w.Header().Set(key, value)
 	}
 }
+
+func clientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(webctx.ClientIPKey).(string)
+	return ip
+}
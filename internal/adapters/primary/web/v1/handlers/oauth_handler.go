@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+)
+
+const oauthFlowTTL = 10 * time.Minute
+
+var errInvalidFlow = errors.New("invalid oauth flow")
+
+// oauthFlow is kept in a short lived cookie between the redirect to the
+// provider and the callback. The cookie is MACed, Link and Restore change
+// what the callback does.
+type oauthFlow struct {
+	Provider     string `json:"provider"`
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	Link         bool   `json:"link"`
+	Restore      bool   `json:"restore"`
+	ExpiresAt    int64  `json:"expires_at"`
+}
+
+// oauthCallback holds the callback parameters, from the query or the form.
+type oauthCallback struct {
+	Provider string
+	Code     string
+	State    string
+	Error    string
+	Flow     string
+	Session  api.OptString
+}
+
+// StartOAuth redirects to the provider. With ?link=true the signed in user
+// links the provider identity to their account instead of logging in, with
+// ?restore=true an account pending deletion is restored.
+func (h *Handler) StartOAuth(ctx context.Context, params api.StartOAuthParams) (*api.StartOAuthFound, error) {
+	link := params.Link.Or(false)
+
+	if link {
+		if _, err := h.sessionUserID(params.Session); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := h.auth.StartExternalLogin(ctx, auth.StartExternalLoginReq{Provider: params.Provider})
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := url.Parse(resp.AuthURL)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := h.encodeFlow(oauthFlow{
+		Provider:     params.Provider,
+		State:        resp.State,
+		Nonce:        resp.Nonce,
+		CodeVerifier: resp.CodeVerifier,
+		Link:         link,
+		Restore:      params.Restore.Or(false),
+		ExpiresAt:    time.Now().Add(oauthFlowTTL).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	setCookie(ctx, h.cookies.Cookie(middleware.OAuthFlow, value, oauthFlowTTL))
+
+	return &api.StartOAuthFound{Location: *location}, nil
+}
+
+func (h *Handler) OauthCallback(ctx context.Context, params api.OauthCallbackParams) error {
+	return h.completeOAuth(ctx, oauthCallback{
+		Provider: params.Provider,
+		Code:     params.Code.Or(""),
+		State:    params.State.Or(""),
+		Error:    params.Error.Or(""),
+		Flow:     params.OAuthFlow,
+		Session:  params.Session,
+	})
+}
+
+// OauthCallbackForm is the form_post variant of the callback.
+func (h *Handler) OauthCallbackForm(ctx context.Context, req *api.OauthCallbackFormReq, params api.OauthCallbackFormParams) error {
+	return h.completeOAuth(ctx, oauthCallback{
+		Provider: params.Provider,
+		Code:     req.Code.Or(""),
+		State:    req.State.Or(""),
+		Error:    req.Error.Or(""),
+		Flow:     params.OAuthFlow,
+		Session:  params.Session,
+	})
+}
+
+func (h *Handler) completeOAuth(ctx context.Context, cb oauthCallback) error {
+	if cb.Error != "" {
+		return newProblem(http.StatusBadRequest, "oauth_denied", "the provider denied the authorization")
+	}
+
+	// The flow cookie is single use
+	setCookie(ctx, h.cookies.Expired(middleware.OAuthFlow))
+
+	flow, err := h.decodeFlow(cb.Flow)
+	if err != nil || flow.Provider != cb.Provider {
+		return auth.ErrInvalidOAuthState
+	}
+
+	req := auth.ExternalLoginReq{
+		Provider:      cb.Provider,
+		Code:          cb.Code,
+		State:         cb.State,
+		ExpectedState: flow.State,
+		Nonce:         flow.Nonce,
+		CodeVerifier:  flow.CodeVerifier,
+		Restore:       flow.Restore,
+	}
+
+	if flow.Link {
+		// The user to link to comes from the session, never from the cookie
+		userID, err := h.sessionUserID(cb.Session)
+		if err != nil {
+			return err
+		}
+		req.LinkUserID = &userID
+	}
+
+	resp, err := h.auth.ExternalLogin(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return h.setSessionCookies(ctx, resp.UserID, resp.Roles, resp.RefreshToken)
+}
+
+func (h *Handler) ListIdentities(ctx context.Context) ([]api.Identity, error) {
+	user, err := getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.auth.ListIdentities(ctx, auth.ListIdentitiesReq{UserID: user.UserID.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	identities := make([]api.Identity, 0, len(resp.Identities))
+	for _, identity := range resp.Identities {
+		identities = append(identities, toIdentity(identity))
+	}
+
+	return identities, nil
+}
+
+func (h *Handler) sessionUserID(session api.OptString) (uuid.UUID, error) {
+	token, ok := session.Get()
+	if !ok || token == "" {
+		return uuid.Nil, ErrUnauthenticated
+	}
+
+	authUser, err := h.jwtManager.ValidateJWT(token)
+	if err != nil {
+		return uuid.Nil, ErrUnauthenticated
+	}
+
+	return authUser.UserID, nil
+}
+
+// Helper functions
+
+func (h *Handler) encodeFlow(flow oauthFlow) (string, error) {
+	b, err := json.Marshal(flow)
+	if err != nil {
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	return payload + "." + h.flowMAC(payload), nil
+}
+
+func (h *Handler) decodeFlow(value string) (oauthFlow, error) {
+	var flow oauthFlow
+
+	payload, mac, ok := strings.Cut(value, ".")
+	if !ok || !hmac.Equal([]byte(mac), []byte(h.flowMAC(payload))) {
+		return flow, errInvalidFlow
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return flow, err
+	}
+
+	if err := json.Unmarshal(b, &flow); err != nil {
+		return flow, err
+	}
+
+	if time.Now().Unix() > flow.ExpiresAt {
+		return flow, errInvalidFlow
+	}
+	return flow, nil
+}
+
+func (h *Handler) flowMAC(payload string) string {
+	mac := hmac.New(sha256.New, h.flowKey)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
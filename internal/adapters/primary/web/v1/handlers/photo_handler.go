@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/photos"
+)
+
+// Avatars change in place, progress photos never do.
+const (
+	avatarCacheControl        = "private, max-age=300"
+	progressPhotoCacheControl = "private, max-age=86400"
+)
+
+// UploadAvatar ignores the content type of the request, the service sniffs
+// the image itself.
+func (h *Handler) UploadAvatar(ctx context.Context, req *api.UploadAvatarReqWithContentType) (*api.Photo, error) {
+	authUser, err := getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.photos.UploadAvatar(ctx, photos.UploadAvatarReq{UserID: authUser.UserID, Body: req.Content.Data})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPhoto(resp.Photo), nil
+}
+
+func (h *Handler) DeleteAvatar(ctx context.Context) error {
+	authUser, err := getUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	return h.photos.DeleteAvatar(ctx, photos.DeleteAvatarReq{UserID: authUser.UserID})
+}
+
+func (h *Handler) GetAvatar(ctx context.Context, params api.GetAvatarParams) (*api.ImageHeaders, error) {
+	if _, err := getUser(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := h.photos.OpenAvatar(ctx, photos.OpenAvatarReq{
+		UserID:    params.ID,
+		Thumbnail: params.Size.Or(api.ImageSizeFull) == api.ImageSizeThumbnail,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toImage(resp, avatarCacheControl), nil
+}
+
+func (h *Handler) UploadProgressPhoto(ctx context.Context, req *api.UploadProgressPhotoReqWithContentType, params api.UploadProgressPhotoParams) (*api.Photo, error) {
+	authUser, err := getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.photos.UploadProgressPhoto(ctx, photos.UploadProgressPhotoReq{
+		UserID:  authUser.UserID,
+		TakenOn: params.TakenOn.Or(time.Time{}),
+		Body:    req.Content.Data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPhoto(resp.Photo), nil
+}
+
+func (h *Handler) ListProgressPhotos(ctx context.Context) ([]api.Photo, error) {
+	authUser, err := getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.photos.ListProgressPhotos(ctx, photos.ListProgressPhotosReq{UserID: authUser.UserID})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]api.Photo, 0, len(resp.Photos))
+	for _, p := range resp.Photos {
+		out = append(out, *toPhoto(p))
+	}
+	return out, nil
+}
+
+func (h *Handler) GetProgressPhotoImage(ctx context.Context, params api.GetProgressPhotoImageParams) (*api.ImageHeaders, error) {
+	authUser, err := getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.photos.OpenProgressPhoto(ctx, photos.OpenProgressPhotoReq{
+		ProgressPhotoReq: photos.ProgressPhotoReq{UserID: authUser.UserID, PhotoID: params.ID},
+		Thumbnail:        params.Size.Or(api.ImageSizeFull) == api.ImageSizeThumbnail,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toImage(resp, progressPhotoCacheControl), nil
+}
+
+func (h *Handler) DeleteProgressPhoto(ctx context.Context, params api.DeleteProgressPhotoParams) error {
+	authUser, err := getUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	return h.photos.DeleteProgressPhoto(ctx, photos.ProgressPhotoReq{UserID: authUser.UserID, PhotoID: params.ID})
+}
+
+// toImage streams the image from the store, the generated server closes the
+// body once it is written.
+func toImage(resp *photos.OpenPhotoResp, cacheControl string) *api.ImageHeaders {
+	return &api.ImageHeaders{
+		CacheControl: api.NewOptString(cacheControl),
+		ContentType:  resp.Photo.ContentType,
+		Response:     api.Image{Data: resp.Body},
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+	domain "github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/exports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/photos"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+// problem is how a sentinel error is presented to clients. Errors with a
+// field are validation errors and are reported under the errors of a
+// validation_failed problem.
+type problem struct {
+	err    error
+	status int
+	code   string
+	field  string
+}
+
+// problemFor returns the problem of the first sentinel err matches.
+func problemFor(err error) (problem, bool) {
+	for _, p := range problems {
+		if errors.Is(err, p.err) {
+			return p, true
+		}
+	}
+	return problem{}, false
+}
+
+// problems maps the sentinel errors of the core to problems. Codes are part
+// of the API contract, changing one is a breaking change.
+var problems = []problem{
+	// Validation
+	{err: user.ErrEmptyUsername, field: "username", code: "empty_username"},
+	{err: user.ErrUsernameTooShort, field: "username", code: "username_too_short"},
+	{err: user.ErrUsernameTooLong, field: "username", code: "username_too_long"},
+	{err: user.ErrUsernameInvalidChars, field: "username", code: "username_invalid_chars"},
+	{err: user.ErrUsernameReserved, field: "username", code: "username_reserved"},
+	{err: user.ErrEmptyEmail, field: "email", code: "empty_email"},
+	{err: user.ErrInvalidEmail, field: "email", code: "invalid_email"},
+	{err: user.ErrEmptyName, field: "name", code: "empty_name"},
+	{err: user.ErrNameTooShort, field: "name", code: "name_too_short"},
+	{err: user.ErrNameHasDigit, field: "name", code: "name_has_digit"},
+	{err: user.ErrNameHasSpecial, field: "name", code: "name_has_special"},
+	{err: user.ErrIncompleteName, field: "name", code: "incomplete_name"},
+	{err: user.ErrEmptyPassword, field: "password", code: "empty_password"},
+	{err: user.ErrPasswordTooShort, field: "password", code: "password_too_short"},
+	{err: user.ErrPasswordTooLong, field: "password", code: "password_too_long"},
+	{err: user.ErrPasswordNoChar, field: "password", code: "password_no_char"},
+	{err: user.ErrPasswordNoDigit, field: "password", code: "password_no_digit"},
+	{err: user.ErrPasswordNoSpecial, field: "password", code: "password_no_special"},
+	{err: user.ErrPasswordNoUpper, field: "password", code: "password_no_upper"},
+	{err: user.ErrInvalidRole, field: "roles", code: "invalid_role"},
+	{err: user.ErrBioTooLong, field: "bio", code: "bio_too_long"},
+	{err: user.ErrInvalidBirthDate, field: "birth_date", code: "invalid_birth_date"},
+	{err: user.ErrBirthDateOutOfRange, field: "birth_date", code: "birth_date_out_of_range"},
+	{err: user.ErrInvalidSex, field: "sex", code: "invalid_sex"},
+	{err: user.ErrInvalidExperienceLevel, field: "experience_level", code: "invalid_experience_level"},
+	{err: user.ErrPreferredGymTooLong, field: "preferred_gym", code: "preferred_gym_too_long"},
+	{err: user.ErrNegativeWeight, field: "weight_value", code: "negative_weight"},
+	{err: user.ErrWeightZero, field: "weight_value", code: "weight_zero"},
+	{err: user.ErrInvalidWeightUnit, field: "weight_unit", code: "invalid_weight_unit"},
+	{err: user.ErrNegativeHeight, field: "height_value", code: "negative_height"},
+	{err: user.ErrHeightZero, field: "height_value", code: "height_zero"},
+	{err: user.ErrInvalidHeightUnit, field: "height_unit", code: "invalid_height_unit"},
+	{err: user.ErrInvalidBFP, field: "bfp", code: "invalid_bfp"},
+	{err: user.ErrInvalidTheme, field: "theme", code: "invalid_theme"},
+	{err: user.ErrInvalidVisibility, field: "visibility", code: "invalid_visibility"},
+	{err: user.ErrInvalidPlan, field: "plan", code: "invalid_plan"},
+	{err: user.ErrInvalidUpgradeTarget, field: "plan", code: "invalid_upgrade_target"},
+	{err: user.ErrInvalidBillingPeriod, field: "billing_period", code: "invalid_billing_period"},
+	{err: user.ErrInvalidCurrency, field: "currency", code: "invalid_currency"},
+	{err: domain.ErrEmptyAPIKeyName, field: "name", code: "empty_api_key_name"},
+	{err: domain.ErrAPIKeyNameTooLong, field: "name", code: "api_key_name_too_long"},
+	{err: domain.ErrNoScopes, field: "scopes", code: "no_scopes"},
+	{err: domain.ErrInvalidScope, field: "scopes", code: "invalid_scope"},
+	{err: domain.ErrExpiryInPast, field: "expires_at", code: "expiry_in_past"},
+	{err: user.ErrEmptySuspensionReason, field: "reason", code: "empty_suspension_reason"},
+	{err: admin.ErrInvalidDateRange, field: "created_before", code: "invalid_date_range"},
+	{err: admin.ErrInvalidAuditRange, field: "until", code: "invalid_date_range"},
+	{err: photo.ErrInvalidImage, field: "image", code: "invalid_image"},
+	{err: photo.ErrTakenInFuture, field: "taken_on", code: "taken_on_in_future"},
+
+	// Authentication
+	{err: auth.ErrInvalidCredentials, status: http.StatusUnauthorized, code: "invalid_credentials"},
+	{err: auth.ErrRefreshTokenExpired, status: http.StatusUnauthorized, code: "refresh_token_expired"},
+	{err: auth.ErrRefreshTokenRevoked, status: http.StatusUnauthorized, code: "refresh_token_revoked"},
+	{err: auth.ErrInvalidAPIKey, status: http.StatusUnauthorized, code: "unauthenticated"},
+	{err: middleware.ErrInvalidToken, status: http.StatusUnauthorized, code: "unauthenticated"},
+	{err: middleware.ErrInvalidAPIKey, status: http.StatusUnauthorized, code: "unauthenticated"},
+	{err: ErrUnauthenticated, status: http.StatusUnauthorized, code: "unauthenticated"},
+	{err: middleware.ErrForbidden, status: http.StatusForbidden, code: "forbidden"},
+	{err: middleware.ErrInsufficientScope, status: http.StatusForbidden, code: "insufficient_scope"},
+	{err: middleware.ErrInvalidCSRFToken, status: http.StatusForbidden, code: "csrf_token_invalid"},
+	{err: auth.ErrInvalidUnlockToken, status: http.StatusBadRequest, code: "invalid_unlock_token"},
+	{err: auth.ErrInvalidOAuthState, status: http.StatusBadRequest, code: "invalid_oauth_state"},
+	{err: auth.ErrEmailNotVerified, status: http.StatusBadRequest, code: "email_not_verified"},
+	{err: auth.ErrUnknownProvider, status: http.StatusNotFound, code: "unknown_provider"},
+	{err: auth.ErrAccountSuspended, status: http.StatusForbidden, code: "account_suspended"},
+	{err: auth.ErrAccountPendingDeletion, status: http.StatusForbidden, code: "account_pending_deletion"},
+	{err: auth.ErrTooManyAttempts, status: http.StatusTooManyRequests, code: "too_many_attempts"},
+	{err: middleware.ErrRateLimited, status: http.StatusTooManyRequests, code: "rate_limited"},
+	{err: user.ErrUsernameCooldown, status: http.StatusTooManyRequests, code: "username_cooldown"},
+
+	// Resources
+	{err: users.ErrUserNotFound, status: http.StatusNotFound, code: "user_not_found"},
+	{err: ports.ErrUserNotFound, status: http.StatusNotFound, code: "user_not_found"},
+	{err: ports.ErrAPIKeyNotFound, status: http.StatusNotFound, code: "api_key_not_found"},
+	{err: ports.ErrIdentityNotFound, status: http.StatusNotFound, code: "identity_not_found"},
+	{err: users.ErrDuplicateUsername, status: http.StatusConflict, code: "duplicate_username"},
+	{err: ports.ErrDuplicateUsername, status: http.StatusConflict, code: "duplicate_username"},
+	{err: users.ErrDuplicateEmail, status: http.StatusConflict, code: "duplicate_email"},
+	{err: ports.ErrDuplicateEmail, status: http.StatusConflict, code: "duplicate_email"},
+	{err: ports.ErrIdentityLinked, status: http.StatusConflict, code: "identity_linked"},
+	{err: auth.ErrLinkRequired, status: http.StatusConflict, code: "link_required"},
+	{err: user.ErrUpgradeNotAvailable, status: http.StatusConflict, code: "upgrade_not_available"},
+	{err: user.ErrDowngradeNotAvailable, status: http.StatusConflict, code: "downgrade_not_available"},
+	{err: user.ErrInvalidDowngradeTarget, status: http.StatusConflict, code: "downgrade_not_available"},
+	{err: user.ErrAlreadyOnBasic, status: http.StatusConflict, code: "already_on_basic"},
+	{err: user.ErrBaseRole, status: http.StatusConflict, code: "base_role_required"},
+	{err: admin.ErrSelfDemotion, status: http.StatusConflict, code: "self_demotion"},
+	{err: admin.ErrSelfSuspension, status: http.StatusConflict, code: "self_suspension"},
+	{err: user.ErrDeletionScheduled, status: http.StatusConflict, code: "deletion_scheduled"},
+	{err: exports.ErrExportNotFound, status: http.StatusNotFound, code: "export_not_found"},
+	{err: exports.ErrExportInProgress, status: http.StatusConflict, code: "export_in_progress"},
+	{err: export.ErrNotReady, status: http.StatusConflict, code: "export_not_ready"},
+	{err: export.ErrExpired, status: http.StatusGone, code: "export_expired"},
+	{err: photos.ErrPhotoNotFound, status: http.StatusNotFound, code: "photo_not_found"},
+	{err: ports.ErrPhotoNotFound, status: http.StatusNotFound, code: "photo_not_found"},
+	{err: photo.ErrTooLarge, status: http.StatusRequestEntityTooLarge, code: "photo_too_large"},
+	{err: photo.ErrUnsupportedType, status: http.StatusUnsupportedMediaType, code: "unsupported_image_type"},
+}
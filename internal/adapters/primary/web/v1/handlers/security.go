@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ogen-go/ogen/ogenerrors"
+
+	webctx "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/context"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	domain "github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+)
+
+// SecurityHandler authenticates the security schemes of the spec. The scopes
+// an operation grants to API keys come from the spec as the scheme roles.
+type SecurityHandler struct {
+	middleware *middleware.Middleware
+}
+
+var _ api.SecurityHandler = (*SecurityHandler)(nil)
+
+func NewSecurityHandler(m *middleware.Middleware) *SecurityHandler {
+	return &SecurityHandler{middleware: m}
+}
+
+func (s *SecurityHandler) HandleCookieAuth(ctx context.Context, operationName api.OperationName, t api.CookieAuth) (context.Context, error) {
+	return s.authenticate(ctx, middleware.Credential{Scheme: middleware.SchemeSession, Value: t.APIKey}, nil)
+}
+
+func (s *SecurityHandler) HandleBearerAuth(ctx context.Context, operationName api.OperationName, t api.BearerAuth) (context.Context, error) {
+	return s.authenticate(ctx, middleware.Credential{Scheme: middleware.SchemeBearer, Value: t.Token}, nil)
+}
+
+// HandleApiKeyAuth receives the whole Authorization header, Bearer tokens
+// sharing the header are left to HandleBearerAuth.
+func (s *SecurityHandler) HandleApiKeyAuth(ctx context.Context, operationName api.OperationName, t api.ApiKeyAuth) (context.Context, error) {
+	scheme, key, ok := strings.Cut(t.APIKey, " ")
+	if !ok || !strings.EqualFold(scheme, string(middleware.SchemeAPIKey)) {
+		return nil, ogenerrors.ErrSkipServerSecurity
+	}
+
+	scopes := make([]domain.Scope, 0, len(t.Roles))
+	for _, role := range t.Roles {
+		scopes = append(scopes, domain.Scope(role))
+	}
+
+	cred := middleware.Credential{Scheme: middleware.SchemeAPIKey, Value: strings.TrimSpace(key)}
+	return s.authenticate(ctx, cred, scopes)
+}
+
+func (s *SecurityHandler) authenticate(ctx context.Context, cred middleware.Credential, scopes []domain.Scope) (context.Context, error) {
+	authUser, err := s.middleware.Authenticate(ctx, cred, scopes...)
+	if err != nil {
+		// The generated server stops before its middlewares, so guessed API
+		// keys are counted here, against the auth bucket of the IP. Expired
+		// session tokens are not guesses, the client only has to refresh
+		if errors.Is(err, middleware.ErrInvalidAPIKey) {
+			if limitErr := s.middleware.ThrottleRequest(ctx, "auth"); limitErr != nil {
+				return nil, limitErr
+			}
+		}
+		return nil, err
+	}
+
+	// The CSRF check needs to know whether the cookies or a header did it
+	ctx = context.WithValue(ctx, webctx.AuthSchemeKey, cred.Scheme)
+	return context.WithValue(ctx, webctx.AuthenticatedUserKey, authUser), nil
+}
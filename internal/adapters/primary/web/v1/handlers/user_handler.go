@@ -1,310 +1,290 @@
-// Package handlers package handlers
 package handlers
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
+	"errors"
+	"strconv"
+	"time"
 
-	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
-	webctx "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/context"
-	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/jwt"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
-	"github.com/cheezecakee/fitrkr-athena/pkg/web"
 )
 
-type UserHandler struct {
-	Service users.UserService
-}
-
-func NewUserHandler(service users.UserService) *UserHandler {
-	return &UserHandler{Service: service}
-}
-
-func (h *UserHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
-	var req users.CreateAccountReq
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		web.ClientError(w, http.StatusBadRequest)
-		return
+// CreateUser never takes roles from the request, sign ups must not pick
+// their own permissions.
+func (h *Handler) CreateUser(ctx context.Context, req *api.CreateUserReq) (*api.CreateUserCreated, error) {
+	resp, err := h.users.CreateAccount(ctx, users.CreateAccountReq{
+		Username:  req.Username,
+		Email:     req.Email,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Password:  req.Password,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	resp, err := h.Service.CreateAccount(r.Context(), req)
+	userID, err := uuid.Parse(resp.UserID)
 	if err != nil {
-		web.ServerError(w, err)
-		return
+		return nil, err
 	}
 
-	web.Response(w, http.StatusCreated, resp)
+	return &api.CreateUserCreated{UserID: userID}, nil
 }
 
-func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
-	user, err := getUser(r.Context())
+func (h *Handler) GetUserByID(ctx context.Context) (*api.User, error) {
+	user, err := getUser(ctx)
 	if err != nil {
-		web.ClientError(w, http.StatusUnauthorized)
-		return
+		return nil, err
 	}
 
-	resp, err := h.Service.GetByID(r.Context(), users.GetUserByIDReq{ID: user.UserID.String()})
+	resp, err := h.users.GetByID(ctx, users.GetUserByIDReq{ID: user.UserID.String()})
 	if err != nil {
-		web.ServerError(w, err)
-		return
+		return nil, err
 	}
 
-	web.Response(w, http.StatusOK, resp)
+	return toUser(resp), nil
 }
 
-func (h *UserHandler) GetUserByUsername(w http.ResponseWriter, r *http.Request) {
-	username := chi.URLParam(r, "username")
-
-	resp, err := h.Service.GetByUsername(r.Context(), users.GetUserByUsernameReq{Username: username})
+// GetMe returns the user with their stats, subscription and settings, saving
+// clients four round trips on start up.
+func (h *Handler) GetMe(ctx context.Context) (*api.FullProfile, error) {
+	authUser, err := getUser(ctx)
 	if err != nil {
-		web.ServerError(w, err)
-		return
+		return nil, err
 	}
 
-	web.Response(w, http.StatusOK, resp)
-}
-
-// GetUserByEmail Make this admin only later
-func (h *UserHandler) GetUserByEmail(w http.ResponseWriter, r *http.Request) {
-	email := chi.URLParam(r, "email")
-
-	resp, err := h.Service.GetByEmail(r.Context(), users.GetUserByEmailReq{Email: email})
+	resp, err := h.users.GetFullProfile(ctx, users.GetFullProfileReq{ID: authUser.UserID.String()})
 	if err != nil {
-		web.ServerError(w, err)
-		return
+		return nil, err
 	}
 
-	web.Response(w, http.StatusOK, resp)
+	return toFullProfile(resp), nil
 }
 
-func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
-	user, err := getUser(r.Context())
+// GetUserByUsername returns the public profile (view profile). A username
+// given up in the last few months still resolves to its owner, clients
+// compare the username in the response to follow the rename.
+func (h *Handler) GetUserByUsername(ctx context.Context, params api.GetUserByUsernameParams) (*api.PublicUser, error) {
+	resp, err := h.users.GetByUsername(ctx, users.GetUserByUsernameReq{Username: params.Username})
 	if err != nil {
-		web.ClientError(w, http.StatusUnauthorized)
-		return
+		return nil, err
 	}
 
-	var req users.UpdateUserReq
+	return toPublicUser(resp), nil
+}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		web.ClientError(w, http.StatusBadRequest)
-		return
+// GetUserByEmail is limited to users:read_any by the authorize middleware.
+func (h *Handler) GetUserByEmail(ctx context.Context, params api.GetUserByEmailParams) (*api.User, error) {
+	resp, err := h.users.GetByEmail(ctx, users.GetUserByEmailReq{Email: params.Email})
+	if err != nil {
+		return nil, err
 	}
 
-	req.ID = user.UserID.String()
+	return toUser(resp), nil
+}
 
-	err = h.Service.Update(r.Context(), req)
+func (h *Handler) UpdateUser(ctx context.Context, req *api.UpdateUserReq) (*api.User, error) {
+	authUser, err := getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.users.Update(ctx, users.UpdateUserReq{
+		ID:        authUser.UserID.String(),
+		Username:  req.Username.Or(""),
+		Email:     req.Email.Or(""),
+		FirstName: req.FirstName.Or(""),
+		LastName:  req.LastName.Or(""),
+
+		Bio:             optNilStringValue(req.Bio),
+		BirthDate:       optNilStringValue(req.BirthDate),
+		Sex:             optNilStringValue(req.Sex),
+		ExperienceLevel: optNilStringValue(req.ExperienceLevel),
+		PreferredGym:    optNilStringValue(req.PreferredGym),
+	})
 	if err != nil {
-		web.ServerError(w, err)
-		return
+		var cooldown *user.UsernameCooldownError
+		if errors.As(err, &cooldown) {
+			setHeader(ctx, "Retry-After", strconv.Itoa(ceilSeconds(time.Until(cooldown.Until))))
+		}
+		return nil, err
 	}
 
-	web.Response(w, http.StatusOK, "User updated")
+	return h.GetUserByID(ctx)
 }
 
-func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
-	user, err := getUser(r.Context())
+func (h *Handler) DeleteUser(ctx context.Context) error {
+	user, err := getUser(ctx)
 	if err != nil {
-		web.ClientError(w, http.StatusUnauthorized)
-		return
+		return err
 	}
 
-	err = h.Service.Delete(r.Context(), users.DeleteAccountReq{ID: user.UserID.String()})
-	if err != nil {
-		web.ServerError(w, err)
-		return
+	if err := h.users.Delete(ctx, users.DeleteAccountReq{ID: user.UserID.String()}); err != nil {
+		return err
 	}
 
-	web.Response(w, http.StatusOK, "User deleted!")
-}
+	// Refresh is refused from now on, the session ends with the cookies
+	setCookie(ctx, h.cookies.Expired(middleware.Session))
+	setCookie(ctx, h.cookies.Expired(middleware.RefreshToken))
 
-func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
-	// TODO
+	return nil
 }
 
-func (h *UserHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
-	user, err := getUser(r.Context())
+func (h *Handler) GetUserSubscription(ctx context.Context) (*api.UserSubscription, error) {
+	user, err := getUser(ctx)
 	if err != nil {
-		web.ClientError(w, http.StatusUnauthorized)
-		return
+		return nil, err
 	}
 
-	resp, err := h.Service.GetSubscription(r.Context(), users.GetSubscriptionReq{ID: user.UserID.String()})
+	resp, err := h.users.GetSubscription(ctx, users.GetSubscriptionReq{ID: user.UserID.String()})
 	if err != nil {
-		web.ServerError(w, err)
-		return
+		return nil, err
 	}
 
-	web.Response(w, http.StatusOK, resp.Subscription)
+	return toSubscription(resp.Subscription), nil
 }
 
-func (h *UserHandler) UpgradePlan(w http.ResponseWriter, r *http.Request) {
-	user, err := getUser(r.Context())
+func (h *Handler) UpgradeUserPlan(ctx context.Context, req *api.UpgradeUserPlanReq) (*api.UserSubscription, error) {
+	user, err := getUser(ctx)
 	if err != nil {
-		web.ClientError(w, http.StatusUnauthorized)
-		return
-	}
-
-	var req users.UpgradePlanReq
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		web.ClientError(w, http.StatusBadRequest)
-		return
+		return nil, err
 	}
 
-	req.UserID = user.UserID.String()
-	err = h.Service.UpgradePlan(r.Context(), req)
+	err = h.users.UpgradePlan(ctx, users.UpgradePlanReq{
+		UserID:        user.UserID.String(),
+		Plan:          req.Plan,
+		BillingPeriod: req.BillingPeriod,
+	})
 	if err != nil {
-		web.ServerError(w, err)
-		return
+		return nil, err
 	}
-	web.Response(w, http.StatusOK, "User plan upgraded")
+
+	return h.GetUserSubscription(ctx)
 }
 
-func (h *UserHandler) RecordPayment(w http.ResponseWriter, r *http.Request) {
-	user, err := getUser(r.Context())
+func (h *Handler) UpdateUserRecordPayment(ctx context.Context, req *api.UpdateUserRecordPaymentReq) (*api.UserSubscription, error) {
+	user, err := getUser(ctx)
 	if err != nil {
-		web.ClientError(w, http.StatusUnauthorized)
-		return
+		return nil, err
 	}
-	var req users.RecordPaymentReq
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		web.ClientError(w, http.StatusBadRequest)
-		return
-	}
-
-	req.UserID = user.UserID.String()
-	err = h.Service.RecordPayment(r.Context(), req)
+	err = h.users.RecordPayment(ctx, users.RecordPaymentReq{
+		UserID:   user.UserID.String(),
+		Amount:   req.Amount,
+		Currency: req.Currency,
+	})
 	if err != nil {
-		web.ServerError(w, err)
-		return
+		return nil, err
 	}
-	web.Response(w, http.StatusOK, "User payment recorded")
+
+	return h.GetUserSubscription(ctx)
 }
 
-func (h *UserHandler) CancelSubscription(w http.ResponseWriter, r *http.Request) {
-	user, err := getUser(r.Context())
+func (h *Handler) CancelUserSubscription(ctx context.Context) (*api.UserSubscription, error) {
+	user, err := getUser(ctx)
 	if err != nil {
-		web.ClientError(w, http.StatusUnauthorized)
-		return
+		return nil, err
 	}
-	var req users.CancelSubscriptionReq
 
-	req.UserID = user.UserID.String()
-	err = h.Service.CancelSubscription(r.Context(), req)
+	err = h.users.CancelSubscription(ctx, users.CancelSubscriptionReq{UserID: user.UserID.String()})
 	if err != nil {
-		web.ServerError(w, err)
-		return
+		return nil, err
 	}
-	web.Response(w, http.StatusOK, "User subscription cancelled")
+
+	return h.GetUserSubscription(ctx)
 }
 
-func (h *UserHandler) StartTrial(w http.ResponseWriter, r *http.Request) {
-	user, err := getUser(r.Context())
+func (h *Handler) StartUserTrial(ctx context.Context) (*api.UserSubscription, error) {
+	user, err := getUser(ctx)
 	if err != nil {
-		web.ClientError(w, http.StatusUnauthorized)
-		return
+		return nil, err
 	}
-	var req users.StartTrialReq
 
-	req.UserID = user.UserID.String()
-	err = h.Service.StartTrial(r.Context(), req)
+	err = h.users.StartTrial(ctx, users.StartTrialReq{UserID: user.UserID.String()})
 	if err != nil {
-		web.ServerError(w, err)
-		return
+		return nil, err
 	}
-	web.Response(w, http.StatusOK, "User trail started")
+
+	return h.GetUserSubscription(ctx)
 }
 
-func (h *UserHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
-	user, err := getUser(r.Context())
+func (h *Handler) GetUserSettings(ctx context.Context) (*api.UserSettings, error) {
+	user, err := getUser(ctx)
 	if err != nil {
-		web.ClientError(w, http.StatusUnauthorized)
-		return
+		return nil, err
 	}
 
-	resp, err := h.Service.GetSettings(r.Context(), users.GetSettingsReq{ID: user.UserID.String()})
+	resp, err := h.users.GetSettings(ctx, users.GetSettingsReq{ID: user.UserID.String()})
 	if err != nil {
-		web.ServerError(w, err)
-		return
+		return nil, err
 	}
 
-	web.Response(w, http.StatusOK, resp.Settings)
+	return toSettings(resp.Settings), nil
 }
 
-func (h *UserHandler) GetStats(w http.ResponseWriter, r *http.Request) {
-	user, err := getUser(r.Context())
+func (h *Handler) UpdateUserSettings(ctx context.Context, req *api.UpdateUserSettingsReq) (*api.UserSettings, error) {
+	user, err := getUser(ctx)
 	if err != nil {
-		web.ClientError(w, http.StatusUnauthorized)
-		return
-	}
-
-	resp, err := h.Service.GetStats(r.Context(), users.GetStatsReq{ID: user.UserID.String()})
+		return nil, err
+	}
+
+	err = h.users.UpdateSettings(ctx, users.UpdateSettingsReq{
+		UserID:          user.UserID.String(),
+		WeightUnit:      optString(req.WeightUnit),
+		HeightUnit:      optString(req.HeightUnit),
+		Theme:           optString(req.Theme),
+		Visibility:      optString(req.Visibility),
+		EmailNotif:      optBool(req.EmailNotif),
+		PushNotif:       optBool(req.PushNotif),
+		WorkoutReminder: optBool(req.WorkoutReminder),
+		StreakReminder:  optBool(req.StreakReminder),
+	})
 	if err != nil {
-		web.ServerError(w, err)
-		return
+		return nil, err
 	}
 
-	web.Response(w, http.StatusOK, resp.Stats)
+	return h.GetUserSettings(ctx)
 }
 
-func (h *UserHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
-	user, err := getUser(r.Context())
+func (h *Handler) GetUserStats(ctx context.Context) (*api.UserStats, error) {
+	user, err := getUser(ctx)
 	if err != nil {
-		web.ClientError(w, http.StatusUnauthorized)
-		return
+		return nil, err
 	}
 
-	var req users.UpdateSettingsReq
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		web.ClientError(w, http.StatusBadRequest)
-		return
-	}
-
-	req.UserID = user.UserID.String()
-	err = h.Service.UpdateSettings(r.Context(), req)
+	resp, err := h.users.GetStats(ctx, users.GetStatsReq{ID: user.UserID.String()})
 	if err != nil {
-		web.ServerError(w, err)
-		return
+		return nil, err
 	}
-	web.Response(w, http.StatusOK, "User settings updated")
+
+	return toStats(resp.Stats), nil
 }
 
-func (h *UserHandler) UpdateBodyMetrics(w http.ResponseWriter, r *http.Request) {
-	user, err := getUser(r.Context())
+func (h *Handler) UpdateUserBodyMetrics(ctx context.Context, req *api.UpdateUserBodyMetricsReq) (*api.UserBodyMetrics, error) {
+	user, err := getUser(ctx)
 	if err != nil {
-		web.ClientError(w, http.StatusUnauthorized)
-		return
+		return nil, err
 	}
 
-	var req users.UpdateBodyMetricsReq
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		web.ClientError(w, http.StatusBadRequest)
-		return
-	}
-
-	req.UserID = user.UserID.String()
-	err = h.Service.UpdateBodyMetrics(r.Context(), req)
+	err = h.users.UpdateBodyMetrics(ctx, users.UpdateBodyMetricsReq{
+		UserID:      user.UserID.String(),
+		WeightValue: optFloat64(req.WeightValue),
+		HeightValue: optFloat64(req.HeightValue),
+		BFP:         optFloat64(req.Bfp),
+	})
 	if err != nil {
-		web.ServerError(w, err)
-		return
+		return nil, err
 	}
-	web.Response(w, http.StatusOK, "User body metrics updated")
-}
 
-func getUser(ctx context.Context) (*jwt.AuthenticatedUser, error) {
-	user := ctx.Value(webctx.AuthenticatedUserKey).(*jwt.AuthenticatedUser)
-	if user.UserID == uuid.Nil {
-		return nil, fmt.Errorf("unauthorized user")
+	stats, err := h.GetUserStats(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return user, nil
+	return &api.UserBodyMetrics{Weight: stats.Weight, Height: stats.Height, Bfp: stats.Bfp}, nil
 }
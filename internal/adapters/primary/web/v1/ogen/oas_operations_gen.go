@@ -7,19 +7,53 @@ type OperationName = string
 
 const (
 	CancelUserSubscriptionOperation  OperationName = "CancelUserSubscription"
+	CreateAPIKeyOperation            OperationName = "CreateAPIKey"
 	CreateUserOperation              OperationName = "CreateUser"
+	DeleteAvatarOperation            OperationName = "DeleteAvatar"
+	DeleteProgressPhotoOperation     OperationName = "DeleteProgressPhoto"
 	DeleteUserOperation              OperationName = "DeleteUser"
+	DownloadDataExportOperation      OperationName = "DownloadDataExport"
+	ForceLogoutUserOperation         OperationName = "ForceLogoutUser"
+	GetAdminUserOperation            OperationName = "GetAdminUser"
+	GetAvatarOperation               OperationName = "GetAvatar"
+	GetCSRFTokenOperation            OperationName = "GetCSRFToken"
+	GetDataExportOperation           OperationName = "GetDataExport"
+	GetMeOperation                   OperationName = "GetMe"
+	GetProgressPhotoImageOperation   OperationName = "GetProgressPhotoImage"
+	GetSecurityActivityOperation     OperationName = "GetSecurityActivity"
 	GetUserByEmailOperation          OperationName = "GetUserByEmail"
 	GetUserByIDOperation             OperationName = "GetUserByID"
 	GetUserByUsernameOperation       OperationName = "GetUserByUsername"
 	GetUserSettingsOperation         OperationName = "GetUserSettings"
 	GetUserStatsOperation            OperationName = "GetUserStats"
 	GetUserSubscriptionOperation     OperationName = "GetUserSubscription"
+	GrantUserRoleOperation           OperationName = "GrantUserRole"
+	ListAPIKeysOperation             OperationName = "ListAPIKeys"
+	ListIdentitiesOperation          OperationName = "ListIdentities"
+	ListProgressPhotosOperation      OperationName = "ListProgressPhotos"
 	LoginOperation                   OperationName = "Login"
+	LogoutOperation                  OperationName = "Logout"
+	OauthCallbackOperation           OperationName = "OauthCallback"
+	OauthCallbackFormOperation       OperationName = "OauthCallbackForm"
+	RefreshOperation                 OperationName = "Refresh"
+	RequestDataExportOperation       OperationName = "RequestDataExport"
+	ResetUserPasswordOperation       OperationName = "ResetUserPassword"
+	RevokeAPIKeyOperation            OperationName = "RevokeAPIKey"
+	RevokeUserRoleOperation          OperationName = "RevokeUserRole"
+	SearchAuditEventsOperation       OperationName = "SearchAuditEvents"
+	SearchUsersOperation             OperationName = "SearchUsers"
+	StartOAuthOperation              OperationName = "StartOAuth"
 	StartUserTrialOperation          OperationName = "StartUserTrial"
+	SuspendUserOperation             OperationName = "SuspendUser"
+	UnlockAccountOperation           OperationName = "UnlockAccount"
+	UnsuspendUserOperation           OperationName = "UnsuspendUser"
+	UpdateAdminUserOperation         OperationName = "UpdateAdminUser"
 	UpdateUserOperation              OperationName = "UpdateUser"
 	UpdateUserBodyMetricsOperation   OperationName = "UpdateUserBodyMetrics"
 	UpdateUserRecordPaymentOperation OperationName = "UpdateUserRecordPayment"
 	UpdateUserSettingsOperation      OperationName = "UpdateUserSettings"
 	UpgradeUserPlanOperation         OperationName = "UpgradeUserPlan"
+	UploadAvatarOperation            OperationName = "UploadAvatar"
+	UploadProgressPhotoOperation     OperationName = "UploadProgressPhoto"
+	VerifyUserEmailOperation         OperationName = "VerifyUserEmail"
 )
@@ -40,7 +40,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		s.notFound(w, r)
 		return
 	}
-	args := [1]string{}
+	args := [2]string{}
 
 	// Static code generated router with unwrapped path search.
 	switch {
@@ -61,54 +61,21 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				break
 			}
 			switch elem[0] {
-			case 'a': // Prefix: "auth/login"
+			case 'a': // Prefix: "a"
 
-				if l := len("auth/login"); len(elem) >= l && elem[0:l] == "auth/login" {
+				if l := len("a"); len(elem) >= l && elem[0:l] == "a" {
 					elem = elem[l:]
 				} else {
 					break
 				}
 
 				if len(elem) == 0 {
-					// Leaf node.
-					switch r.Method {
-					case "POST":
-						s.handleLoginRequest([0]string{}, elemIsEscaped, w, r)
-					default:
-						s.notAllowed(w, r, "POST")
-					}
-
-					return
-				}
-
-			case 'u': // Prefix: "user"
-
-				if l := len("user"); len(elem) >= l && elem[0:l] == "user" {
-					elem = elem[l:]
-				} else {
 					break
 				}
-
-				if len(elem) == 0 {
-					switch r.Method {
-					case "DELETE":
-						s.handleDeleteUserRequest([0]string{}, elemIsEscaped, w, r)
-					case "GET":
-						s.handleGetUserByIDRequest([0]string{}, elemIsEscaped, w, r)
-					case "POST":
-						s.handleCreateUserRequest([0]string{}, elemIsEscaped, w, r)
-					case "PUT":
-						s.handleUpdateUserRequest([0]string{}, elemIsEscaped, w, r)
-					default:
-						s.notAllowed(w, r, "DELETE,GET,POST,PUT")
-					}
-
-					return
-				}
 				switch elem[0] {
-				case '/': // Prefix: "/"
+				case 'd': // Prefix: "dmin/"
 
-					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+					if l := len("dmin/"); len(elem) >= l && elem[0:l] == "dmin/" {
 						elem = elem[l:]
 					} else {
 						break
@@ -118,30 +85,19 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 						break
 					}
 					switch elem[0] {
-					case 'e': // Prefix: "email/"
+					case 'a': // Prefix: "audit-events"
 
-						if l := len("email/"); len(elem) >= l && elem[0:l] == "email/" {
+						if l := len("audit-events"); len(elem) >= l && elem[0:l] == "audit-events" {
 							elem = elem[l:]
 						} else {
 							break
 						}
 
-						// Param: "email"
-						// Leaf parameter, slashes are prohibited
-						idx := strings.IndexByte(elem, '/')
-						if idx >= 0 {
-							break
-						}
-						args[0] = elem
-						elem = ""
-
 						if len(elem) == 0 {
 							// Leaf node.
 							switch r.Method {
 							case "GET":
-								s.handleGetUserByEmailRequest([1]string{
-									args[0],
-								}, elemIsEscaped, w, r)
+								s.handleSearchAuditEventsRequest([0]string{}, elemIsEscaped, w, r)
 							default:
 								s.notAllowed(w, r, "GET")
 							}
@@ -149,135 +105,155 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 							return
 						}
 
-					case 's': // Prefix: "s"
+					case 'u': // Prefix: "users"
 
-						if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+						if l := len("users"); len(elem) >= l && elem[0:l] == "users" {
 							elem = elem[l:]
 						} else {
 							break
 						}
 
 						if len(elem) == 0 {
-							break
+							switch r.Method {
+							case "GET":
+								s.handleSearchUsersRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
 						}
 						switch elem[0] {
-						case 'e': // Prefix: "ettings"
+						case '/': // Prefix: "/"
 
-							if l := len("ettings"); len(elem) >= l && elem[0:l] == "ettings" {
+							if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
 								elem = elem[l:]
 							} else {
 								break
 							}
 
-							if len(elem) == 0 {
-								// Leaf node.
-								switch r.Method {
-								case "GET":
-									s.handleGetUserSettingsRequest([0]string{}, elemIsEscaped, w, r)
-								case "PUT":
-									s.handleUpdateUserSettingsRequest([0]string{}, elemIsEscaped, w, r)
-								default:
-									s.notAllowed(w, r, "GET,PUT")
-								}
-
-								return
-							}
-
-						case 't': // Prefix: "tats"
-
-							if l := len("tats"); len(elem) >= l && elem[0:l] == "tats" {
-								elem = elem[l:]
-							} else {
-								break
+							// Param: "id"
+							// Match until "/"
+							idx := strings.IndexByte(elem, '/')
+							if idx < 0 {
+								idx = len(elem)
 							}
+							args[0] = elem[:idx]
+							elem = elem[idx:]
 
 							if len(elem) == 0 {
 								switch r.Method {
 								case "GET":
-									s.handleGetUserStatsRequest([0]string{}, elemIsEscaped, w, r)
+									s.handleGetAdminUserRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								case "PATCH":
+									s.handleUpdateAdminUserRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
 								default:
-									s.notAllowed(w, r, "GET")
+									s.notAllowed(w, r, "GET,PATCH")
 								}
 
 								return
 							}
 							switch elem[0] {
-							case '/': // Prefix: "/body"
+							case '/': // Prefix: "/"
 
-								if l := len("/body"); len(elem) >= l && elem[0:l] == "/body" {
+								if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
 									elem = elem[l:]
 								} else {
 									break
 								}
 
 								if len(elem) == 0 {
-									// Leaf node.
-									switch r.Method {
-									case "PUT":
-										s.handleUpdateUserBodyMetricsRequest([0]string{}, elemIsEscaped, w, r)
-									default:
-										s.notAllowed(w, r, "PUT")
-									}
-
-									return
+									break
 								}
+								switch elem[0] {
+								case 'e': // Prefix: "email-verification"
 
-							}
+									if l := len("email-verification"); len(elem) >= l && elem[0:l] == "email-verification" {
+										elem = elem[l:]
+									} else {
+										break
+									}
 
-						case 'u': // Prefix: "ubscription"
+									if len(elem) == 0 {
+										// Leaf node.
+										switch r.Method {
+										case "POST":
+											s.handleVerifyUserEmailRequest([1]string{
+												args[0],
+											}, elemIsEscaped, w, r)
+										default:
+											s.notAllowed(w, r, "POST")
+										}
 
-							if l := len("ubscription"); len(elem) >= l && elem[0:l] == "ubscription" {
-								elem = elem[l:]
-							} else {
-								break
-							}
+										return
+									}
 
-							if len(elem) == 0 {
-								switch r.Method {
-								case "GET":
-									s.handleGetUserSubscriptionRequest([0]string{}, elemIsEscaped, w, r)
-								default:
-									s.notAllowed(w, r, "GET")
-								}
+								case 'p': // Prefix: "password-reset"
 
-								return
-							}
-							switch elem[0] {
-							case '/': // Prefix: "/"
+									if l := len("password-reset"); len(elem) >= l && elem[0:l] == "password-reset" {
+										elem = elem[l:]
+									} else {
+										break
+									}
 
-								if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
-									elem = elem[l:]
-								} else {
-									break
-								}
+									if len(elem) == 0 {
+										// Leaf node.
+										switch r.Method {
+										case "POST":
+											s.handleResetUserPasswordRequest([1]string{
+												args[0],
+											}, elemIsEscaped, w, r)
+										default:
+											s.notAllowed(w, r, "POST")
+										}
 
-								if len(elem) == 0 {
-									break
-								}
-								switch elem[0] {
-								case 'c': // Prefix: "cancel"
+										return
+									}
 
-									if l := len("cancel"); len(elem) >= l && elem[0:l] == "cancel" {
+								case 'r': // Prefix: "roles/"
+
+									if l := len("roles/"); len(elem) >= l && elem[0:l] == "roles/" {
 										elem = elem[l:]
 									} else {
 										break
 									}
 
+									// Param: "role"
+									// Leaf parameter, slashes are prohibited
+									idx := strings.IndexByte(elem, '/')
+									if idx >= 0 {
+										break
+									}
+									args[1] = elem
+									elem = ""
+
 									if len(elem) == 0 {
 										// Leaf node.
 										switch r.Method {
+										case "DELETE":
+											s.handleRevokeUserRoleRequest([2]string{
+												args[0],
+												args[1],
+											}, elemIsEscaped, w, r)
 										case "PUT":
-											s.handleCancelUserSubscriptionRequest([0]string{}, elemIsEscaped, w, r)
+											s.handleGrantUserRoleRequest([2]string{
+												args[0],
+												args[1],
+											}, elemIsEscaped, w, r)
 										default:
-											s.notAllowed(w, r, "PUT")
+											s.notAllowed(w, r, "DELETE,PUT")
 										}
 
 										return
 									}
 
-								case 'p': // Prefix: "p"
+								case 's': // Prefix: "s"
 
-									if l := len("p"); len(elem) >= l && elem[0:l] == "p" {
+									if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
 										elem = elem[l:]
 									} else {
 										break
@@ -287,9 +263,9 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 										break
 									}
 									switch elem[0] {
-									case 'a': // Prefix: "ayment"
+									case 'e': // Prefix: "essions"
 
-										if l := len("ayment"); len(elem) >= l && elem[0:l] == "ayment" {
+										if l := len("essions"); len(elem) >= l && elem[0:l] == "essions" {
 											elem = elem[l:]
 										} else {
 											break
@@ -298,18 +274,20 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 										if len(elem) == 0 {
 											// Leaf node.
 											switch r.Method {
-											case "PUT":
-												s.handleUpdateUserRecordPaymentRequest([0]string{}, elemIsEscaped, w, r)
+											case "DELETE":
+												s.handleForceLogoutUserRequest([1]string{
+													args[0],
+												}, elemIsEscaped, w, r)
 											default:
-												s.notAllowed(w, r, "PUT")
+												s.notAllowed(w, r, "DELETE")
 											}
 
 											return
 										}
 
-									case 'l': // Prefix: "lan"
+									case 'u': // Prefix: "uspension"
 
-										if l := len("lan"); len(elem) >= l && elem[0:l] == "lan" {
+										if l := len("uspension"); len(elem) >= l && elem[0:l] == "uspension" {
 											elem = elem[l:]
 										} else {
 											break
@@ -318,10 +296,16 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 										if len(elem) == 0 {
 											// Leaf node.
 											switch r.Method {
+											case "DELETE":
+												s.handleUnsuspendUserRequest([1]string{
+													args[0],
+												}, elemIsEscaped, w, r)
 											case "PUT":
-												s.handleUpgradeUserPlanRequest([0]string{}, elemIsEscaped, w, r)
+												s.handleSuspendUserRequest([1]string{
+													args[0],
+												}, elemIsEscaped, w, r)
 											default:
-												s.notAllowed(w, r, "PUT")
+												s.notAllowed(w, r, "DELETE,PUT")
 											}
 
 											return
@@ -329,54 +313,121 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 									}
 
-								case 't': // Prefix: "trial"
+								}
 
-									if l := len("trial"); len(elem) >= l && elem[0:l] == "trial" {
-										elem = elem[l:]
-									} else {
-										break
-									}
+							}
 
-									if len(elem) == 0 {
-										// Leaf node.
-										switch r.Method {
-										case "PUT":
-											s.handleStartUserTrialRequest([0]string{}, elemIsEscaped, w, r)
-										default:
-											s.notAllowed(w, r, "PUT")
-										}
+						}
 
-										return
-									}
+					}
+
+				case 'u': // Prefix: "uth/"
+
+					if l := len("uth/"); len(elem) >= l && elem[0:l] == "uth/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'c': // Prefix: "csrf"
+
+						if l := len("csrf"); len(elem) >= l && elem[0:l] == "csrf" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetCSRFTokenRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+
+					case 'l': // Prefix: "log"
+
+						if l := len("log"); len(elem) >= l && elem[0:l] == "log" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'i': // Prefix: "in"
+
+							if l := len("in"); len(elem) >= l && elem[0:l] == "in" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "POST":
+									s.handleLoginRequest([0]string{}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "POST")
+								}
+
+								return
+							}
+
+						case 'o': // Prefix: "out"
+
+							if l := len("out"); len(elem) >= l && elem[0:l] == "out" {
+								elem = elem[l:]
+							} else {
+								break
+							}
 
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "POST":
+									s.handleLogoutRequest([0]string{}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "POST")
 								}
 
+								return
 							}
 
 						}
 
-					case 'u': // Prefix: "username/"
+					case 'o': // Prefix: "oauth/"
 
-						if l := len("username/"); len(elem) >= l && elem[0:l] == "username/" {
+						if l := len("oauth/"); len(elem) >= l && elem[0:l] == "oauth/" {
 							elem = elem[l:]
 						} else {
 							break
 						}
 
-						// Param: "username"
-						// Leaf parameter, slashes are prohibited
+						// Param: "provider"
+						// Match until "/"
 						idx := strings.IndexByte(elem, '/')
-						if idx >= 0 {
-							break
+						if idx < 0 {
+							idx = len(elem)
 						}
-						args[0] = elem
-						elem = ""
+						args[0] = elem[:idx]
+						elem = elem[idx:]
 
 						if len(elem) == 0 {
-							// Leaf node.
 							switch r.Method {
 							case "GET":
-								s.handleGetUserByUsernameRequest([1]string{
+								s.handleStartOAuthRequest([1]string{
 									args[0],
 								}, elemIsEscaped, w, r)
 							default:
@@ -385,264 +436,1887 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 							return
 						}
+						switch elem[0] {
+						case '/': // Prefix: "/callback"
 
-					}
-
-				}
+							if l := len("/callback"); len(elem) >= l && elem[0:l] == "/callback" {
+								elem = elem[l:]
+							} else {
+								break
+							}
 
-			}
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleOauthCallbackRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								case "POST":
+									s.handleOauthCallbackFormRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET,POST")
+								}
 
-		}
-	}
-	s.notFound(w, r)
-}
+								return
+							}
 
-// Route is route object.
-type Route struct {
-	name        string
-	summary     string
-	operationID string
-	pathPattern string
-	count       int
-	args        [1]string
-}
+						}
 
-// Name returns ogen operation name.
-//
-// It is guaranteed to be unique and not empty.
-func (r Route) Name() string {
-	return r.name
-}
+					case 'r': // Prefix: "refresh"
 
-// Summary returns OpenAPI summary.
-func (r Route) Summary() string {
-	return r.summary
-}
+						if l := len("refresh"); len(elem) >= l && elem[0:l] == "refresh" {
+							elem = elem[l:]
+						} else {
+							break
+						}
 
-// OperationID returns OpenAPI operationId.
-func (r Route) OperationID() string {
-	return r.operationID
-}
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleRefreshRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
 
-// PathPattern returns OpenAPI path.
-func (r Route) PathPattern() string {
-	return r.pathPattern
-}
+							return
+						}
 
-// Args returns parsed arguments.
-func (r Route) Args() []string {
-	return r.args[:r.count]
-}
+					case 'u': // Prefix: "unlock"
 
-// FindRoute finds Route for given method and path.
-//
-// Note: this method does not unescape path or handle reserved characters in path properly. Use FindPath instead.
-func (s *Server) FindRoute(method, path string) (Route, bool) {
-	return s.FindPath(method, &url.URL{Path: path})
-}
+						if l := len("unlock"); len(elem) >= l && elem[0:l] == "unlock" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleUnlockAccountRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+
+					}
 
-// FindPath finds Route for given method and URL.
-func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
-	var (
-		elem = u.Path
-		args = r.args
-	)
-	if rawPath := u.RawPath; rawPath != "" {
-		if normalized, ok := uri.NormalizeEscapedPath(rawPath); ok {
-			elem = normalized
-		}
-		defer func() {
-			for i, arg := range r.args[:r.count] {
-				if unescaped, err := url.PathUnescape(arg); err == nil {
-					r.args[i] = unescaped
 				}
-			}
-		}()
-	}
 
-	elem, ok := s.cutPrefix(elem)
-	if !ok {
-		return r, false
-	}
+			case 'u': // Prefix: "user"
 
-	// Static code generated router with unwrapped path search.
-	switch {
-	default:
-		if len(elem) == 0 {
-			break
-		}
-		switch elem[0] {
-		case '/': // Prefix: "/"
+				if l := len("user"); len(elem) >= l && elem[0:l] == "user" {
+					elem = elem[l:]
+				} else {
+					break
+				}
 
-			if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
-				elem = elem[l:]
-			} else {
-				break
-			}
+				if len(elem) == 0 {
+					switch r.Method {
+					case "DELETE":
+						s.handleDeleteUserRequest([0]string{}, elemIsEscaped, w, r)
+					case "GET":
+						s.handleGetUserByIDRequest([0]string{}, elemIsEscaped, w, r)
+					case "POST":
+						s.handleCreateUserRequest([0]string{}, elemIsEscaped, w, r)
+					case "PUT":
+						s.handleUpdateUserRequest([0]string{}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "DELETE,GET,POST,PUT")
+					}
+
+					return
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'a': // Prefix: "a"
+						origElem := elem
+						if l := len("a"); len(elem) >= l && elem[0:l] == "a" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'p': // Prefix: "pi-keys"
+
+							if l := len("pi-keys"); len(elem) >= l && elem[0:l] == "pi-keys" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								switch r.Method {
+								case "GET":
+									s.handleListAPIKeysRequest([0]string{}, elemIsEscaped, w, r)
+								case "POST":
+									s.handleCreateAPIKeyRequest([0]string{}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET,POST")
+								}
+
+								return
+							}
+							switch elem[0] {
+							case '/': // Prefix: "/"
+
+								if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								// Param: "id"
+								// Leaf parameter, slashes are prohibited
+								idx := strings.IndexByte(elem, '/')
+								if idx >= 0 {
+									break
+								}
+								args[0] = elem
+								elem = ""
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch r.Method {
+									case "DELETE":
+										s.handleRevokeAPIKeyRequest([1]string{
+											args[0],
+										}, elemIsEscaped, w, r)
+									default:
+										s.notAllowed(w, r, "DELETE")
+									}
+
+									return
+								}
+
+							}
+
+						case 'v': // Prefix: "vatar"
+
+							if l := len("vatar"); len(elem) >= l && elem[0:l] == "vatar" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "DELETE":
+									s.handleDeleteAvatarRequest([0]string{}, elemIsEscaped, w, r)
+								case "PUT":
+									s.handleUploadAvatarRequest([0]string{}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "DELETE,PUT")
+								}
+
+								return
+							}
+
+						}
+
+						elem = origElem
+					case 'e': // Prefix: "e"
+						origElem := elem
+						if l := len("e"); len(elem) >= l && elem[0:l] == "e" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'm': // Prefix: "mail/"
+
+							if l := len("mail/"); len(elem) >= l && elem[0:l] == "mail/" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							// Param: "email"
+							// Leaf parameter, slashes are prohibited
+							idx := strings.IndexByte(elem, '/')
+							if idx >= 0 {
+								break
+							}
+							args[0] = elem
+							elem = ""
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleGetUserByEmailRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
+
+								return
+							}
+
+						case 'x': // Prefix: "xports"
+
+							if l := len("xports"); len(elem) >= l && elem[0:l] == "xports" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								switch r.Method {
+								case "POST":
+									s.handleRequestDataExportRequest([0]string{}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "POST")
+								}
+
+								return
+							}
+							switch elem[0] {
+							case '/': // Prefix: "/"
+
+								if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								// Param: "id"
+								// Match until "/"
+								idx := strings.IndexByte(elem, '/')
+								if idx < 0 {
+									idx = len(elem)
+								}
+								args[0] = elem[:idx]
+								elem = elem[idx:]
+
+								if len(elem) == 0 {
+									switch r.Method {
+									case "GET":
+										s.handleGetDataExportRequest([1]string{
+											args[0],
+										}, elemIsEscaped, w, r)
+									default:
+										s.notAllowed(w, r, "GET")
+									}
+
+									return
+								}
+								switch elem[0] {
+								case '/': // Prefix: "/archive"
+
+									if l := len("/archive"); len(elem) >= l && elem[0:l] == "/archive" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch r.Method {
+										case "GET":
+											s.handleDownloadDataExportRequest([1]string{
+												args[0],
+											}, elemIsEscaped, w, r)
+										default:
+											s.notAllowed(w, r, "GET")
+										}
+
+										return
+									}
+
+								}
+
+							}
+
+						}
+
+						elem = origElem
+					case 'i': // Prefix: "identities"
+						origElem := elem
+						if l := len("identities"); len(elem) >= l && elem[0:l] == "identities" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleListIdentitiesRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+
+						elem = origElem
+					case 'm': // Prefix: "me"
+						origElem := elem
+						if l := len("me"); len(elem) >= l && elem[0:l] == "me" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetMeRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+
+						elem = origElem
+					case 'p': // Prefix: "progress-photos"
+						origElem := elem
+						if l := len("progress-photos"); len(elem) >= l && elem[0:l] == "progress-photos" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch r.Method {
+							case "GET":
+								s.handleListProgressPhotosRequest([0]string{}, elemIsEscaped, w, r)
+							case "POST":
+								s.handleUploadProgressPhotoRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET,POST")
+							}
+
+							return
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/"
+
+							if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							// Param: "id"
+							// Match until "/"
+							idx := strings.IndexByte(elem, '/')
+							if idx < 0 {
+								idx = len(elem)
+							}
+							args[0] = elem[:idx]
+							elem = elem[idx:]
+
+							if len(elem) == 0 {
+								switch r.Method {
+								case "DELETE":
+									s.handleDeleteProgressPhotoRequest([1]string{
+										args[0],
+									}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "DELETE")
+								}
+
+								return
+							}
+							switch elem[0] {
+							case '/': // Prefix: "/image"
+
+								if l := len("/image"); len(elem) >= l && elem[0:l] == "/image" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch r.Method {
+									case "GET":
+										s.handleGetProgressPhotoImageRequest([1]string{
+											args[0],
+										}, elemIsEscaped, w, r)
+									default:
+										s.notAllowed(w, r, "GET")
+									}
+
+									return
+								}
+
+							}
+
+						}
+
+						elem = origElem
+					case 's': // Prefix: "s"
+						origElem := elem
+						if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'e': // Prefix: "e"
+
+							if l := len("e"); len(elem) >= l && elem[0:l] == "e" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								break
+							}
+							switch elem[0] {
+							case 'c': // Prefix: "curity-activity"
+
+								if l := len("curity-activity"); len(elem) >= l && elem[0:l] == "curity-activity" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch r.Method {
+									case "GET":
+										s.handleGetSecurityActivityRequest([0]string{}, elemIsEscaped, w, r)
+									default:
+										s.notAllowed(w, r, "GET")
+									}
+
+									return
+								}
+
+							case 't': // Prefix: "ttings"
+
+								if l := len("ttings"); len(elem) >= l && elem[0:l] == "ttings" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch r.Method {
+									case "GET":
+										s.handleGetUserSettingsRequest([0]string{}, elemIsEscaped, w, r)
+									case "PUT":
+										s.handleUpdateUserSettingsRequest([0]string{}, elemIsEscaped, w, r)
+									default:
+										s.notAllowed(w, r, "GET,PUT")
+									}
+
+									return
+								}
+
+							}
+
+						case 't': // Prefix: "tats"
+
+							if l := len("tats"); len(elem) >= l && elem[0:l] == "tats" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								switch r.Method {
+								case "GET":
+									s.handleGetUserStatsRequest([0]string{}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
+
+								return
+							}
+							switch elem[0] {
+							case '/': // Prefix: "/body"
+
+								if l := len("/body"); len(elem) >= l && elem[0:l] == "/body" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch r.Method {
+									case "PUT":
+										s.handleUpdateUserBodyMetricsRequest([0]string{}, elemIsEscaped, w, r)
+									default:
+										s.notAllowed(w, r, "PUT")
+									}
+
+									return
+								}
+
+							}
+
+						case 'u': // Prefix: "ubscription"
+
+							if l := len("ubscription"); len(elem) >= l && elem[0:l] == "ubscription" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								switch r.Method {
+								case "GET":
+									s.handleGetUserSubscriptionRequest([0]string{}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
+
+								return
+							}
+							switch elem[0] {
+							case '/': // Prefix: "/"
+
+								if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									break
+								}
+								switch elem[0] {
+								case 'c': // Prefix: "cancel"
+
+									if l := len("cancel"); len(elem) >= l && elem[0:l] == "cancel" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch r.Method {
+										case "PUT":
+											s.handleCancelUserSubscriptionRequest([0]string{}, elemIsEscaped, w, r)
+										default:
+											s.notAllowed(w, r, "PUT")
+										}
+
+										return
+									}
+
+								case 'p': // Prefix: "p"
+
+									if l := len("p"); len(elem) >= l && elem[0:l] == "p" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										break
+									}
+									switch elem[0] {
+									case 'a': // Prefix: "ayment"
+
+										if l := len("ayment"); len(elem) >= l && elem[0:l] == "ayment" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch r.Method {
+											case "PUT":
+												s.handleUpdateUserRecordPaymentRequest([0]string{}, elemIsEscaped, w, r)
+											default:
+												s.notAllowed(w, r, "PUT")
+											}
+
+											return
+										}
+
+									case 'l': // Prefix: "lan"
+
+										if l := len("lan"); len(elem) >= l && elem[0:l] == "lan" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch r.Method {
+											case "PUT":
+												s.handleUpgradeUserPlanRequest([0]string{}, elemIsEscaped, w, r)
+											default:
+												s.notAllowed(w, r, "PUT")
+											}
+
+											return
+										}
+
+									}
+
+								case 't': // Prefix: "trial"
+
+									if l := len("trial"); len(elem) >= l && elem[0:l] == "trial" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch r.Method {
+										case "PUT":
+											s.handleStartUserTrialRequest([0]string{}, elemIsEscaped, w, r)
+										default:
+											s.notAllowed(w, r, "PUT")
+										}
+
+										return
+									}
+
+								}
+
+							}
+
+						}
+
+						elem = origElem
+					case 'u': // Prefix: "username/"
+						origElem := elem
+						if l := len("username/"); len(elem) >= l && elem[0:l] == "username/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "username"
+						// Leaf parameter, slashes are prohibited
+						idx := strings.IndexByte(elem, '/')
+						if idx >= 0 {
+							break
+						}
+						args[0] = elem
+						elem = ""
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetUserByUsernameRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+
+						elem = origElem
+					}
+					// Param: "id"
+					// Match until "/"
+					idx := strings.IndexByte(elem, '/')
+					if idx < 0 {
+						idx = len(elem)
+					}
+					args[0] = elem[:idx]
+					elem = elem[idx:]
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/avatar"
+
+						if l := len("/avatar"); len(elem) >= l && elem[0:l] == "/avatar" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetAvatarRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+
+					}
+
+				}
+
+			}
+
+		}
+	}
+	s.notFound(w, r)
+}
+
+// Route is route object.
+type Route struct {
+	name        string
+	summary     string
+	operationID string
+	pathPattern string
+	count       int
+	args        [2]string
+}
+
+// Name returns ogen operation name.
+//
+// It is guaranteed to be unique and not empty.
+func (r Route) Name() string {
+	return r.name
+}
+
+// Summary returns OpenAPI summary.
+func (r Route) Summary() string {
+	return r.summary
+}
+
+// OperationID returns OpenAPI operationId.
+func (r Route) OperationID() string {
+	return r.operationID
+}
+
+// PathPattern returns OpenAPI path.
+func (r Route) PathPattern() string {
+	return r.pathPattern
+}
+
+// Args returns parsed arguments.
+func (r Route) Args() []string {
+	return r.args[:r.count]
+}
+
+// FindRoute finds Route for given method and path.
+//
+// Note: this method does not unescape path or handle reserved characters in path properly. Use FindPath instead.
+func (s *Server) FindRoute(method, path string) (Route, bool) {
+	return s.FindPath(method, &url.URL{Path: path})
+}
+
+// FindPath finds Route for given method and URL.
+func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
+	var (
+		elem = u.Path
+		args = r.args
+	)
+	if rawPath := u.RawPath; rawPath != "" {
+		if normalized, ok := uri.NormalizeEscapedPath(rawPath); ok {
+			elem = normalized
+		}
+		defer func() {
+			for i, arg := range r.args[:r.count] {
+				if unescaped, err := url.PathUnescape(arg); err == nil {
+					r.args[i] = unescaped
+				}
+			}
+		}()
+	}
+
+	elem, ok := s.cutPrefix(elem)
+	if !ok {
+		return r, false
+	}
+
+	// Static code generated router with unwrapped path search.
+	switch {
+	default:
+		if len(elem) == 0 {
+			break
+		}
+		switch elem[0] {
+		case '/': // Prefix: "/"
+
+			if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+				elem = elem[l:]
+			} else {
+				break
+			}
+
+			if len(elem) == 0 {
+				break
+			}
+			switch elem[0] {
+			case 'a': // Prefix: "a"
+
+				if l := len("a"); len(elem) >= l && elem[0:l] == "a" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					break
+				}
+				switch elem[0] {
+				case 'd': // Prefix: "dmin/"
+
+					if l := len("dmin/"); len(elem) >= l && elem[0:l] == "dmin/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'a': // Prefix: "audit-events"
+
+						if l := len("audit-events"); len(elem) >= l && elem[0:l] == "audit-events" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = SearchAuditEventsOperation
+								r.summary = "Search the audit log"
+								r.operationID = "searchAuditEvents"
+								r.pathPattern = "/admin/audit-events"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+					case 'u': // Prefix: "users"
+
+						if l := len("users"); len(elem) >= l && elem[0:l] == "users" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch method {
+							case "GET":
+								r.name = SearchUsersOperation
+								r.summary = "Search users"
+								r.operationID = "searchUsers"
+								r.pathPattern = "/admin/users"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/"
+
+							if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							// Param: "id"
+							// Match until "/"
+							idx := strings.IndexByte(elem, '/')
+							if idx < 0 {
+								idx = len(elem)
+							}
+							args[0] = elem[:idx]
+							elem = elem[idx:]
+
+							if len(elem) == 0 {
+								switch method {
+								case "GET":
+									r.name = GetAdminUserOperation
+									r.summary = "Get a user with their stats, subscription and settings"
+									r.operationID = "getAdminUser"
+									r.pathPattern = "/admin/users/{id}"
+									r.args = args
+									r.count = 1
+									return r, true
+								case "PATCH":
+									r.name = UpdateAdminUserOperation
+									r.summary = "Edit a user"
+									r.operationID = "updateAdminUser"
+									r.pathPattern = "/admin/users/{id}"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+							switch elem[0] {
+							case '/': // Prefix: "/"
+
+								if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									break
+								}
+								switch elem[0] {
+								case 'e': // Prefix: "email-verification"
+
+									if l := len("email-verification"); len(elem) >= l && elem[0:l] == "email-verification" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch method {
+										case "POST":
+											r.name = VerifyUserEmailOperation
+											r.summary = "Mark a user's email verified"
+											r.operationID = "verifyUserEmail"
+											r.pathPattern = "/admin/users/{id}/email-verification"
+											r.args = args
+											r.count = 1
+											return r, true
+										default:
+											return
+										}
+									}
+
+								case 'p': // Prefix: "password-reset"
+
+									if l := len("password-reset"); len(elem) >= l && elem[0:l] == "password-reset" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch method {
+										case "POST":
+											r.name = ResetUserPasswordOperation
+											r.summary = "Reset a user's password"
+											r.operationID = "resetUserPassword"
+											r.pathPattern = "/admin/users/{id}/password-reset"
+											r.args = args
+											r.count = 1
+											return r, true
+										default:
+											return
+										}
+									}
+
+								case 'r': // Prefix: "roles/"
+
+									if l := len("roles/"); len(elem) >= l && elem[0:l] == "roles/" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									// Param: "role"
+									// Leaf parameter, slashes are prohibited
+									idx := strings.IndexByte(elem, '/')
+									if idx >= 0 {
+										break
+									}
+									args[1] = elem
+									elem = ""
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch method {
+										case "DELETE":
+											r.name = RevokeUserRoleOperation
+											r.summary = "Revoke a role"
+											r.operationID = "revokeUserRole"
+											r.pathPattern = "/admin/users/{id}/roles/{role}"
+											r.args = args
+											r.count = 2
+											return r, true
+										case "PUT":
+											r.name = GrantUserRoleOperation
+											r.summary = "Grant a role"
+											r.operationID = "grantUserRole"
+											r.pathPattern = "/admin/users/{id}/roles/{role}"
+											r.args = args
+											r.count = 2
+											return r, true
+										default:
+											return
+										}
+									}
+
+								case 's': // Prefix: "s"
+
+									if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										break
+									}
+									switch elem[0] {
+									case 'e': // Prefix: "essions"
+
+										if l := len("essions"); len(elem) >= l && elem[0:l] == "essions" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch method {
+											case "DELETE":
+												r.name = ForceLogoutUserOperation
+												r.summary = "Log a user out everywhere"
+												r.operationID = "forceLogoutUser"
+												r.pathPattern = "/admin/users/{id}/sessions"
+												r.args = args
+												r.count = 1
+												return r, true
+											default:
+												return
+											}
+										}
+
+									case 'u': // Prefix: "uspension"
+
+										if l := len("uspension"); len(elem) >= l && elem[0:l] == "uspension" {
+											elem = elem[l:]
+										} else {
+											break
+										}
+
+										if len(elem) == 0 {
+											// Leaf node.
+											switch method {
+											case "DELETE":
+												r.name = UnsuspendUserOperation
+												r.summary = "Lift a suspension"
+												r.operationID = "unsuspendUser"
+												r.pathPattern = "/admin/users/{id}/suspension"
+												r.args = args
+												r.count = 1
+												return r, true
+											case "PUT":
+												r.name = SuspendUserOperation
+												r.summary = "Suspend a user"
+												r.operationID = "suspendUser"
+												r.pathPattern = "/admin/users/{id}/suspension"
+												r.args = args
+												r.count = 1
+												return r, true
+											default:
+												return
+											}
+										}
+
+									}
+
+								}
+
+							}
+
+						}
+
+					}
+
+				case 'u': // Prefix: "uth/"
+
+					if l := len("uth/"); len(elem) >= l && elem[0:l] == "uth/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'c': // Prefix: "csrf"
+
+						if l := len("csrf"); len(elem) >= l && elem[0:l] == "csrf" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = GetCSRFTokenOperation
+								r.summary = "Get a CSRF token"
+								r.operationID = "getCSRFToken"
+								r.pathPattern = "/auth/csrf"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+					case 'l': // Prefix: "log"
+
+						if l := len("log"); len(elem) >= l && elem[0:l] == "log" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'i': // Prefix: "in"
+
+							if l := len("in"); len(elem) >= l && elem[0:l] == "in" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "POST":
+									r.name = LoginOperation
+									r.summary = "Login"
+									r.operationID = "login"
+									r.pathPattern = "/auth/login"
+									r.args = args
+									r.count = 0
+									return r, true
+								default:
+									return
+								}
+							}
+
+						case 'o': // Prefix: "out"
+
+							if l := len("out"); len(elem) >= l && elem[0:l] == "out" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "POST":
+									r.name = LogoutOperation
+									r.summary = "Logout"
+									r.operationID = "logout"
+									r.pathPattern = "/auth/logout"
+									r.args = args
+									r.count = 0
+									return r, true
+								default:
+									return
+								}
+							}
+
+						}
+
+					case 'o': // Prefix: "oauth/"
+
+						if l := len("oauth/"); len(elem) >= l && elem[0:l] == "oauth/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "provider"
+						// Match until "/"
+						idx := strings.IndexByte(elem, '/')
+						if idx < 0 {
+							idx = len(elem)
+						}
+						args[0] = elem[:idx]
+						elem = elem[idx:]
+
+						if len(elem) == 0 {
+							switch method {
+							case "GET":
+								r.name = StartOAuthOperation
+								r.summary = "Start an external login"
+								r.operationID = "startOAuth"
+								r.pathPattern = "/auth/oauth/{provider}"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/callback"
+
+							if l := len("/callback"); len(elem) >= l && elem[0:l] == "/callback" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "GET":
+									r.name = OauthCallbackOperation
+									r.summary = "External login callback"
+									r.operationID = "oauthCallback"
+									r.pathPattern = "/auth/oauth/{provider}/callback"
+									r.args = args
+									r.count = 1
+									return r, true
+								case "POST":
+									r.name = OauthCallbackFormOperation
+									r.summary = "External login callback (form_post)"
+									r.operationID = "oauthCallbackForm"
+									r.pathPattern = "/auth/oauth/{provider}/callback"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+
+						}
+
+					case 'r': // Prefix: "refresh"
+
+						if l := len("refresh"); len(elem) >= l && elem[0:l] == "refresh" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "POST":
+								r.name = RefreshOperation
+								r.summary = "Refresh the session"
+								r.operationID = "refresh"
+								r.pathPattern = "/auth/refresh"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+					case 'u': // Prefix: "unlock"
+
+						if l := len("unlock"); len(elem) >= l && elem[0:l] == "unlock" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = UnlockAccountOperation
+								r.summary = "Unlock a locked account"
+								r.operationID = "unlockAccount"
+								r.pathPattern = "/auth/unlock"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+					}
+
+				}
+
+			case 'u': // Prefix: "user"
+
+				if l := len("user"); len(elem) >= l && elem[0:l] == "user" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch method {
+					case "DELETE":
+						r.name = DeleteUserOperation
+						r.summary = "Delete the signed in user"
+						r.operationID = "deleteUser"
+						r.pathPattern = "/user"
+						r.args = args
+						r.count = 0
+						return r, true
+					case "GET":
+						r.name = GetUserByIDOperation
+						r.summary = "Get the signed in user"
+						r.operationID = "getUserByID"
+						r.pathPattern = "/user"
+						r.args = args
+						r.count = 0
+						return r, true
+					case "POST":
+						r.name = CreateUserOperation
+						r.summary = "Create a new user account"
+						r.operationID = "createUser"
+						r.pathPattern = "/user"
+						r.args = args
+						r.count = 0
+						return r, true
+					case "PUT":
+						r.name = UpdateUserOperation
+						r.summary = "Update the signed in user"
+						r.operationID = "updateUser"
+						r.pathPattern = "/user"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'a': // Prefix: "a"
+						origElem := elem
+						if l := len("a"); len(elem) >= l && elem[0:l] == "a" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'p': // Prefix: "pi-keys"
+
+							if l := len("pi-keys"); len(elem) >= l && elem[0:l] == "pi-keys" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								switch method {
+								case "GET":
+									r.name = ListAPIKeysOperation
+									r.summary = "List API keys"
+									r.operationID = "listAPIKeys"
+									r.pathPattern = "/user/api-keys"
+									r.args = args
+									r.count = 0
+									return r, true
+								case "POST":
+									r.name = CreateAPIKeyOperation
+									r.summary = "Create an API key"
+									r.operationID = "createAPIKey"
+									r.pathPattern = "/user/api-keys"
+									r.args = args
+									r.count = 0
+									return r, true
+								default:
+									return
+								}
+							}
+							switch elem[0] {
+							case '/': // Prefix: "/"
+
+								if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								// Param: "id"
+								// Leaf parameter, slashes are prohibited
+								idx := strings.IndexByte(elem, '/')
+								if idx >= 0 {
+									break
+								}
+								args[0] = elem
+								elem = ""
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch method {
+									case "DELETE":
+										r.name = RevokeAPIKeyOperation
+										r.summary = "Revoke an API key"
+										r.operationID = "revokeAPIKey"
+										r.pathPattern = "/user/api-keys/{id}"
+										r.args = args
+										r.count = 1
+										return r, true
+									default:
+										return
+									}
+								}
+
+							}
+
+						case 'v': // Prefix: "vatar"
+
+							if l := len("vatar"); len(elem) >= l && elem[0:l] == "vatar" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "DELETE":
+									r.name = DeleteAvatarOperation
+									r.summary = "Delete your avatar"
+									r.operationID = "deleteAvatar"
+									r.pathPattern = "/user/avatar"
+									r.args = args
+									r.count = 0
+									return r, true
+								case "PUT":
+									r.name = UploadAvatarOperation
+									r.summary = "Upload your avatar"
+									r.operationID = "uploadAvatar"
+									r.pathPattern = "/user/avatar"
+									r.args = args
+									r.count = 0
+									return r, true
+								default:
+									return
+								}
+							}
+
+						}
+
+						elem = origElem
+					case 'e': // Prefix: "e"
+						origElem := elem
+						if l := len("e"); len(elem) >= l && elem[0:l] == "e" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'm': // Prefix: "mail/"
+
+							if l := len("mail/"); len(elem) >= l && elem[0:l] == "mail/" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							// Param: "email"
+							// Leaf parameter, slashes are prohibited
+							idx := strings.IndexByte(elem, '/')
+							if idx >= 0 {
+								break
+							}
+							args[0] = elem
+							elem = ""
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "GET":
+									r.name = GetUserByEmailOperation
+									r.summary = "Get user by email"
+									r.operationID = "getUserByEmail"
+									r.pathPattern = "/user/email/{email}"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+
+						case 'x': // Prefix: "xports"
+
+							if l := len("xports"); len(elem) >= l && elem[0:l] == "xports" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								switch method {
+								case "POST":
+									r.name = RequestDataExportOperation
+									r.summary = "Request an export of your data"
+									r.operationID = "requestDataExport"
+									r.pathPattern = "/user/exports"
+									r.args = args
+									r.count = 0
+									return r, true
+								default:
+									return
+								}
+							}
+							switch elem[0] {
+							case '/': // Prefix: "/"
 
-			if len(elem) == 0 {
-				break
-			}
-			switch elem[0] {
-			case 'a': // Prefix: "auth/login"
+								if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+									elem = elem[l:]
+								} else {
+									break
+								}
 
-				if l := len("auth/login"); len(elem) >= l && elem[0:l] == "auth/login" {
-					elem = elem[l:]
-				} else {
-					break
-				}
+								// Param: "id"
+								// Match until "/"
+								idx := strings.IndexByte(elem, '/')
+								if idx < 0 {
+									idx = len(elem)
+								}
+								args[0] = elem[:idx]
+								elem = elem[idx:]
 
-				if len(elem) == 0 {
-					// Leaf node.
-					switch method {
-					case "POST":
-						r.name = LoginOperation
-						r.summary = "Login"
-						r.operationID = "login"
-						r.pathPattern = "/auth/login"
-						r.args = args
-						r.count = 0
-						return r, true
-					default:
-						return
-					}
-				}
+								if len(elem) == 0 {
+									switch method {
+									case "GET":
+										r.name = GetDataExportOperation
+										r.summary = "Get an export of your data"
+										r.operationID = "getDataExport"
+										r.pathPattern = "/user/exports/{id}"
+										r.args = args
+										r.count = 1
+										return r, true
+									default:
+										return
+									}
+								}
+								switch elem[0] {
+								case '/': // Prefix: "/archive"
 
-			case 'u': // Prefix: "user"
+									if l := len("/archive"); len(elem) >= l && elem[0:l] == "/archive" {
+										elem = elem[l:]
+									} else {
+										break
+									}
 
-				if l := len("user"); len(elem) >= l && elem[0:l] == "user" {
-					elem = elem[l:]
-				} else {
-					break
-				}
+									if len(elem) == 0 {
+										// Leaf node.
+										switch method {
+										case "GET":
+											r.name = DownloadDataExportOperation
+											r.summary = "Download an export of your data"
+											r.operationID = "downloadDataExport"
+											r.pathPattern = "/user/exports/{id}/archive"
+											r.args = args
+											r.count = 1
+											return r, true
+										default:
+											return
+										}
+									}
 
-				if len(elem) == 0 {
-					switch method {
-					case "DELETE":
-						r.name = DeleteUserOperation
-						r.summary = "Delete user"
-						r.operationID = "deleteUser"
-						r.pathPattern = "/user"
-						r.args = args
-						r.count = 0
-						return r, true
-					case "GET":
-						r.name = GetUserByIDOperation
-						r.summary = "Get user by ID"
-						r.operationID = "getUserByID"
-						r.pathPattern = "/user"
-						r.args = args
-						r.count = 0
-						return r, true
-					case "POST":
-						r.name = CreateUserOperation
-						r.summary = "Create a new user account"
-						r.operationID = "createUser"
-						r.pathPattern = "/user"
-						r.args = args
-						r.count = 0
-						return r, true
-					case "PUT":
-						r.name = UpdateUserOperation
-						r.summary = "Update user"
-						r.operationID = "updateUser"
-						r.pathPattern = "/user"
-						r.args = args
-						r.count = 0
-						return r, true
-					default:
-						return
-					}
-				}
-				switch elem[0] {
-				case '/': // Prefix: "/"
+								}
 
-					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
-						elem = elem[l:]
-					} else {
-						break
-					}
+							}
 
-					if len(elem) == 0 {
-						break
-					}
-					switch elem[0] {
-					case 'e': // Prefix: "email/"
+						}
 
-						if l := len("email/"); len(elem) >= l && elem[0:l] == "email/" {
+						elem = origElem
+					case 'i': // Prefix: "identities"
+						origElem := elem
+						if l := len("identities"); len(elem) >= l && elem[0:l] == "identities" {
 							elem = elem[l:]
 						} else {
 							break
 						}
 
-						// Param: "email"
-						// Leaf parameter, slashes are prohibited
-						idx := strings.IndexByte(elem, '/')
-						if idx >= 0 {
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = ListIdentitiesOperation
+								r.summary = "List linked identities"
+								r.operationID = "listIdentities"
+								r.pathPattern = "/user/identities"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+						elem = origElem
+					case 'm': // Prefix: "me"
+						origElem := elem
+						if l := len("me"); len(elem) >= l && elem[0:l] == "me" {
+							elem = elem[l:]
+						} else {
 							break
 						}
-						args[0] = elem
-						elem = ""
 
 						if len(elem) == 0 {
 							// Leaf node.
 							switch method {
 							case "GET":
-								r.name = GetUserByEmailOperation
-								r.summary = "Get user by email"
-								r.operationID = "getUserByEmail"
-								r.pathPattern = "/user/email/{email}"
+								r.name = GetMeOperation
+								r.summary = "Get everything about the signed in user"
+								r.operationID = "getMe"
+								r.pathPattern = "/user/me"
 								r.args = args
-								r.count = 1
+								r.count = 0
 								return r, true
 							default:
 								return
 							}
 						}
 
-					case 's': // Prefix: "s"
-
-						if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+						elem = origElem
+					case 'p': // Prefix: "progress-photos"
+						origElem := elem
+						if l := len("progress-photos"); len(elem) >= l && elem[0:l] == "progress-photos" {
 							elem = elem[l:]
 						} else {
 							break
 						}
 
 						if len(elem) == 0 {
-							break
+							switch method {
+							case "GET":
+								r.name = ListProgressPhotosOperation
+								r.summary = "List your progress photos"
+								r.operationID = "listProgressPhotos"
+								r.pathPattern = "/user/progress-photos"
+								r.args = args
+								r.count = 0
+								return r, true
+							case "POST":
+								r.name = UploadProgressPhotoOperation
+								r.summary = "Upload a progress photo"
+								r.operationID = "uploadProgressPhoto"
+								r.pathPattern = "/user/progress-photos"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
 						}
 						switch elem[0] {
-						case 'e': // Prefix: "ettings"
+						case '/': // Prefix: "/"
 
-							if l := len("ettings"); len(elem) >= l && elem[0:l] == "ettings" {
+							if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
 								elem = elem[l:]
 							} else {
 								break
 							}
 
+							// Param: "id"
+							// Match until "/"
+							idx := strings.IndexByte(elem, '/')
+							if idx < 0 {
+								idx = len(elem)
+							}
+							args[0] = elem[:idx]
+							elem = elem[idx:]
+
 							if len(elem) == 0 {
-								// Leaf node.
 								switch method {
-								case "GET":
-									r.name = GetUserSettingsOperation
-									r.summary = "Get user settings"
-									r.operationID = "getUserSettings"
-									r.pathPattern = "/user/settings"
+								case "DELETE":
+									r.name = DeleteProgressPhotoOperation
+									r.summary = "Delete a progress photo"
+									r.operationID = "deleteProgressPhoto"
+									r.pathPattern = "/user/progress-photos/{id}"
 									r.args = args
-									r.count = 0
-									return r, true
-								case "PUT":
-									r.name = UpdateUserSettingsOperation
-									r.summary = "Update user settings"
-									r.operationID = "updateUserSettings"
-									r.pathPattern = "/user/settings"
-									r.args = args
-									r.count = 0
+									r.count = 1
 									return r, true
 								default:
 									return
 								}
 							}
+							switch elem[0] {
+							case '/': // Prefix: "/image"
+
+								if l := len("/image"); len(elem) >= l && elem[0:l] == "/image" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch method {
+									case "GET":
+										r.name = GetProgressPhotoImageOperation
+										r.summary = "Get a progress photo"
+										r.operationID = "getProgressPhotoImage"
+										r.pathPattern = "/user/progress-photos/{id}/image"
+										r.args = args
+										r.count = 1
+										return r, true
+									default:
+										return
+									}
+								}
+
+							}
+
+						}
+
+						elem = origElem
+					case 's': // Prefix: "s"
+						origElem := elem
+						if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'e': // Prefix: "e"
+
+							if l := len("e"); len(elem) >= l && elem[0:l] == "e" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								break
+							}
+							switch elem[0] {
+							case 'c': // Prefix: "curity-activity"
+
+								if l := len("curity-activity"); len(elem) >= l && elem[0:l] == "curity-activity" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch method {
+									case "GET":
+										r.name = GetSecurityActivityOperation
+										r.summary = "List recent security activity"
+										r.operationID = "getSecurityActivity"
+										r.pathPattern = "/user/security-activity"
+										r.args = args
+										r.count = 0
+										return r, true
+									default:
+										return
+									}
+								}
+
+							case 't': // Prefix: "ttings"
+
+								if l := len("ttings"); len(elem) >= l && elem[0:l] == "ttings" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch method {
+									case "GET":
+										r.name = GetUserSettingsOperation
+										r.summary = "Get user settings"
+										r.operationID = "getUserSettings"
+										r.pathPattern = "/user/settings"
+										r.args = args
+										r.count = 0
+										return r, true
+									case "PUT":
+										r.name = UpdateUserSettingsOperation
+										r.summary = "Update user settings"
+										r.operationID = "updateUserSettings"
+										r.pathPattern = "/user/settings"
+										r.args = args
+										r.count = 0
+										return r, true
+									default:
+										return
+									}
+								}
+
+							}
 
 						case 't': // Prefix: "tats"
 
@@ -777,7 +2451,7 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 											switch method {
 											case "PUT":
 												r.name = UpdateUserRecordPaymentOperation
-												r.summary = "Update user record payment"
+												r.summary = "Record a subscription payment"
 												r.operationID = "updateUserRecordPayment"
 												r.pathPattern = "/user/subscription/payment"
 												r.args = args
@@ -844,8 +2518,9 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 
 						}
 
+						elem = origElem
 					case 'u': // Prefix: "username/"
-
+						origElem := elem
 						if l := len("username/"); len(elem) >= l && elem[0:l] == "username/" {
 							elem = elem[l:]
 						} else {
@@ -877,6 +2552,45 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 							}
 						}
 
+						elem = origElem
+					}
+					// Param: "id"
+					// Match until "/"
+					idx := strings.IndexByte(elem, '/')
+					if idx < 0 {
+						idx = len(elem)
+					}
+					args[0] = elem[:idx]
+					elem = elem[idx:]
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/avatar"
+
+						if l := len("/avatar"); len(elem) >= l && elem[0:l] == "/avatar" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = GetAvatarOperation
+								r.summary = "Get the avatar of a user"
+								r.operationID = "getAvatar"
+								r.pathPattern = "/user/{id}/avatar"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
+
 					}
 
 				}
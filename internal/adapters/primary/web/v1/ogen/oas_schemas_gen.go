@@ -3,374 +3,1375 @@
 package api
 
 import (
+	"fmt"
+	"io"
+	"net/url"
 	"time"
 
+	"github.com/go-faster/errors"
 	"github.com/google/uuid"
 )
 
-type CancelUserSubscriptionBadRequest Error
-
-func (*CancelUserSubscriptionBadRequest) cancelUserSubscriptionRes() {}
-
-type CancelUserSubscriptionInternalServerError Error
+func (s *ProblemStatusCode) Error() string {
+	return fmt.Sprintf("code %d: %+v", s.StatusCode, s.Response)
+}
 
-func (*CancelUserSubscriptionInternalServerError) cancelUserSubscriptionRes() {}
+// Ref: #/components/schemas/APIKey
+type APIKey struct {
+	ID         uuid.UUID      `json:"id"`
+	UserID     uuid.UUID      `json:"user_id"`
+	Name       string         `json:"name"`
+	Prefix     string         `json:"prefix"`
+	Scopes     []string       `json:"scopes"`
+	ExpiresAt  OptNilDateTime `json:"expires_at"`
+	LastUsedAt OptNilDateTime `json:"last_used_at"`
+	RevokedAt  OptNilDateTime `json:"revoked_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
 
-type CancelUserSubscriptionNotFound Error
+// GetID returns the value of ID.
+func (s *APIKey) GetID() uuid.UUID {
+	return s.ID
+}
 
-func (*CancelUserSubscriptionNotFound) cancelUserSubscriptionRes() {}
+// GetUserID returns the value of UserID.
+func (s *APIKey) GetUserID() uuid.UUID {
+	return s.UserID
+}
 
-// CancelUserSubscriptionOK is response for CancelUserSubscription operation.
-type CancelUserSubscriptionOK struct{}
+// GetName returns the value of Name.
+func (s *APIKey) GetName() string {
+	return s.Name
+}
 
-func (*CancelUserSubscriptionOK) cancelUserSubscriptionRes() {}
+// GetPrefix returns the value of Prefix.
+func (s *APIKey) GetPrefix() string {
+	return s.Prefix
+}
 
-type CookieAuth struct {
-	APIKey string
-	Roles  []string
+// GetScopes returns the value of Scopes.
+func (s *APIKey) GetScopes() []string {
+	return s.Scopes
 }
 
-// GetAPIKey returns the value of APIKey.
-func (s *CookieAuth) GetAPIKey() string {
-	return s.APIKey
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *APIKey) GetExpiresAt() OptNilDateTime {
+	return s.ExpiresAt
 }
 
-// GetRoles returns the value of Roles.
-func (s *CookieAuth) GetRoles() []string {
-	return s.Roles
+// GetLastUsedAt returns the value of LastUsedAt.
+func (s *APIKey) GetLastUsedAt() OptNilDateTime {
+	return s.LastUsedAt
 }
 
-// SetAPIKey sets the value of APIKey.
-func (s *CookieAuth) SetAPIKey(val string) {
-	s.APIKey = val
+// GetRevokedAt returns the value of RevokedAt.
+func (s *APIKey) GetRevokedAt() OptNilDateTime {
+	return s.RevokedAt
 }
 
-// SetRoles sets the value of Roles.
-func (s *CookieAuth) SetRoles(val []string) {
-	s.Roles = val
+// GetCreatedAt returns the value of CreatedAt.
+func (s *APIKey) GetCreatedAt() time.Time {
+	return s.CreatedAt
 }
 
-type CreateUserBadRequest Error
+// GetUpdatedAt returns the value of UpdatedAt.
+func (s *APIKey) GetUpdatedAt() time.Time {
+	return s.UpdatedAt
+}
 
-func (*CreateUserBadRequest) createUserRes() {}
+// SetID sets the value of ID.
+func (s *APIKey) SetID(val uuid.UUID) {
+	s.ID = val
+}
 
-type CreateUserConflict Error
+// SetUserID sets the value of UserID.
+func (s *APIKey) SetUserID(val uuid.UUID) {
+	s.UserID = val
+}
 
-func (*CreateUserConflict) createUserRes() {}
+// SetName sets the value of Name.
+func (s *APIKey) SetName(val string) {
+	s.Name = val
+}
 
-type CreateUserCreated struct {
-	UserID OptUUID `json:"UserID"`
+// SetPrefix sets the value of Prefix.
+func (s *APIKey) SetPrefix(val string) {
+	s.Prefix = val
 }
 
-// GetUserID returns the value of UserID.
-func (s *CreateUserCreated) GetUserID() OptUUID {
-	return s.UserID
+// SetScopes sets the value of Scopes.
+func (s *APIKey) SetScopes(val []string) {
+	s.Scopes = val
 }
 
-// SetUserID sets the value of UserID.
-func (s *CreateUserCreated) SetUserID(val OptUUID) {
-	s.UserID = val
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *APIKey) SetExpiresAt(val OptNilDateTime) {
+	s.ExpiresAt = val
 }
 
-func (*CreateUserCreated) createUserRes() {}
+// SetLastUsedAt sets the value of LastUsedAt.
+func (s *APIKey) SetLastUsedAt(val OptNilDateTime) {
+	s.LastUsedAt = val
+}
 
-type CreateUserInternalServerError Error
+// SetRevokedAt sets the value of RevokedAt.
+func (s *APIKey) SetRevokedAt(val OptNilDateTime) {
+	s.RevokedAt = val
+}
 
-func (*CreateUserInternalServerError) createUserRes() {}
+// SetCreatedAt sets the value of CreatedAt.
+func (s *APIKey) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
 
-type CreateUserReq struct {
-	Username  string   `json:"username"`
-	FirstName string   `json:"first_name"`
-	LastName  string   `json:"last_name"`
-	Email     string   `json:"email"`
-	Password  string   `json:"password"`
-	Roles     []string `json:"roles"`
+// SetUpdatedAt sets the value of UpdatedAt.
+func (s *APIKey) SetUpdatedAt(val time.Time) {
+	s.UpdatedAt = val
 }
 
-// GetUsername returns the value of Username.
-func (s *CreateUserReq) GetUsername() string {
-	return s.Username
+// Ref: #/components/schemas/AdminUser
+type AdminUser struct {
+	ID               uuid.UUID      `json:"id"`
+	Username         string         `json:"username"`
+	Email            string         `json:"email"`
+	FullName         string         `json:"full_name"`
+	Roles            []string       `json:"roles"`
+	EmailVerifiedAt  OptNilDateTime `json:"email_verified_at"`
+	SuspendedAt      OptNilDateTime `json:"suspended_at"`
+	SuspensionReason OptNilString   `json:"suspension_reason"`
+	// Set while the account is pending deletion, it is purged 30 days later.
+	DeletionRequestedAt OptNilDateTime `json:"deletion_requested_at"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
 }
 
-// GetFirstName returns the value of FirstName.
-func (s *CreateUserReq) GetFirstName() string {
-	return s.FirstName
+// GetID returns the value of ID.
+func (s *AdminUser) GetID() uuid.UUID {
+	return s.ID
 }
 
-// GetLastName returns the value of LastName.
-func (s *CreateUserReq) GetLastName() string {
-	return s.LastName
+// GetUsername returns the value of Username.
+func (s *AdminUser) GetUsername() string {
+	return s.Username
 }
 
 // GetEmail returns the value of Email.
-func (s *CreateUserReq) GetEmail() string {
+func (s *AdminUser) GetEmail() string {
 	return s.Email
 }
 
-// GetPassword returns the value of Password.
-func (s *CreateUserReq) GetPassword() string {
-	return s.Password
+// GetFullName returns the value of FullName.
+func (s *AdminUser) GetFullName() string {
+	return s.FullName
 }
 
 // GetRoles returns the value of Roles.
-func (s *CreateUserReq) GetRoles() []string {
+func (s *AdminUser) GetRoles() []string {
 	return s.Roles
 }
 
-// SetUsername sets the value of Username.
-func (s *CreateUserReq) SetUsername(val string) {
-	s.Username = val
+// GetEmailVerifiedAt returns the value of EmailVerifiedAt.
+func (s *AdminUser) GetEmailVerifiedAt() OptNilDateTime {
+	return s.EmailVerifiedAt
 }
 
-// SetFirstName sets the value of FirstName.
-func (s *CreateUserReq) SetFirstName(val string) {
-	s.FirstName = val
+// GetSuspendedAt returns the value of SuspendedAt.
+func (s *AdminUser) GetSuspendedAt() OptNilDateTime {
+	return s.SuspendedAt
 }
 
-// SetLastName sets the value of LastName.
-func (s *CreateUserReq) SetLastName(val string) {
-	s.LastName = val
+// GetSuspensionReason returns the value of SuspensionReason.
+func (s *AdminUser) GetSuspensionReason() OptNilString {
+	return s.SuspensionReason
+}
+
+// GetDeletionRequestedAt returns the value of DeletionRequestedAt.
+func (s *AdminUser) GetDeletionRequestedAt() OptNilDateTime {
+	return s.DeletionRequestedAt
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *AdminUser) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetUpdatedAt returns the value of UpdatedAt.
+func (s *AdminUser) GetUpdatedAt() time.Time {
+	return s.UpdatedAt
+}
+
+// SetID sets the value of ID.
+func (s *AdminUser) SetID(val uuid.UUID) {
+	s.ID = val
+}
+
+// SetUsername sets the value of Username.
+func (s *AdminUser) SetUsername(val string) {
+	s.Username = val
 }
 
 // SetEmail sets the value of Email.
-func (s *CreateUserReq) SetEmail(val string) {
+func (s *AdminUser) SetEmail(val string) {
 	s.Email = val
 }
 
-// SetPassword sets the value of Password.
-func (s *CreateUserReq) SetPassword(val string) {
-	s.Password = val
+// SetFullName sets the value of FullName.
+func (s *AdminUser) SetFullName(val string) {
+	s.FullName = val
 }
 
 // SetRoles sets the value of Roles.
-func (s *CreateUserReq) SetRoles(val []string) {
+func (s *AdminUser) SetRoles(val []string) {
 	s.Roles = val
 }
 
-type DeleteUserInternalServerError Error
+// SetEmailVerifiedAt sets the value of EmailVerifiedAt.
+func (s *AdminUser) SetEmailVerifiedAt(val OptNilDateTime) {
+	s.EmailVerifiedAt = val
+}
 
-func (*DeleteUserInternalServerError) deleteUserRes() {}
+// SetSuspendedAt sets the value of SuspendedAt.
+func (s *AdminUser) SetSuspendedAt(val OptNilDateTime) {
+	s.SuspendedAt = val
+}
 
-// DeleteUserNoContent is response for DeleteUser operation.
-type DeleteUserNoContent struct{}
+// SetSuspensionReason sets the value of SuspensionReason.
+func (s *AdminUser) SetSuspensionReason(val OptNilString) {
+	s.SuspensionReason = val
+}
+
+// SetDeletionRequestedAt sets the value of DeletionRequestedAt.
+func (s *AdminUser) SetDeletionRequestedAt(val OptNilDateTime) {
+	s.DeletionRequestedAt = val
+}
 
-func (*DeleteUserNoContent) deleteUserRes() {}
+// SetCreatedAt sets the value of CreatedAt.
+func (s *AdminUser) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
 
-type DeleteUserNotFound Error
+// SetUpdatedAt sets the value of UpdatedAt.
+func (s *AdminUser) SetUpdatedAt(val time.Time) {
+	s.UpdatedAt = val
+}
 
-func (*DeleteUserNotFound) deleteUserRes() {}
+// Ref: #/components/schemas/AdminUserDetail
+type AdminUserDetail struct {
+	User         AdminUser        `json:"user"`
+	Stats        UserStats        `json:"stats"`
+	Subscription UserSubscription `json:"subscription"`
+	Settings     UserSettings     `json:"settings"`
+}
 
-// Ref: #/components/schemas/Error
-type Error struct {
-	Error string    `json:"error"`
-	Code  OptString `json:"code"`
+// GetUser returns the value of User.
+func (s *AdminUserDetail) GetUser() AdminUser {
+	return s.User
 }
 
-// GetError returns the value of Error.
-func (s *Error) GetError() string {
-	return s.Error
+// GetStats returns the value of Stats.
+func (s *AdminUserDetail) GetStats() UserStats {
+	return s.Stats
 }
 
-// GetCode returns the value of Code.
-func (s *Error) GetCode() OptString {
-	return s.Code
+// GetSubscription returns the value of Subscription.
+func (s *AdminUserDetail) GetSubscription() UserSubscription {
+	return s.Subscription
 }
 
-// SetError sets the value of Error.
-func (s *Error) SetError(val string) {
-	s.Error = val
+// GetSettings returns the value of Settings.
+func (s *AdminUserDetail) GetSettings() UserSettings {
+	return s.Settings
 }
 
-// SetCode sets the value of Code.
-func (s *Error) SetCode(val OptString) {
-	s.Code = val
+// SetUser sets the value of User.
+func (s *AdminUserDetail) SetUser(val AdminUser) {
+	s.User = val
 }
 
-type GetUserByEmailInternalServerError Error
+// SetStats sets the value of Stats.
+func (s *AdminUserDetail) SetStats(val UserStats) {
+	s.Stats = val
+}
 
-func (*GetUserByEmailInternalServerError) getUserByEmailRes() {}
+// SetSubscription sets the value of Subscription.
+func (s *AdminUserDetail) SetSubscription(val UserSubscription) {
+	s.Subscription = val
+}
 
-type GetUserByEmailNotFound Error
+// SetSettings sets the value of Settings.
+func (s *AdminUserDetail) SetSettings(val UserSettings) {
+	s.Settings = val
+}
 
-func (*GetUserByEmailNotFound) getUserByEmailRes() {}
+// Ref: #/components/schemas/AdminUserPage
+type AdminUserPage struct {
+	Users []AdminUser `json:"users"`
+	// Users matching the search across all pages.
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
 
-type GetUserByIDInternalServerError Error
+// GetUsers returns the value of Users.
+func (s *AdminUserPage) GetUsers() []AdminUser {
+	return s.Users
+}
 
-func (*GetUserByIDInternalServerError) getUserByIDRes() {}
+// GetTotal returns the value of Total.
+func (s *AdminUserPage) GetTotal() int {
+	return s.Total
+}
 
-type GetUserByIDNotFound Error
+// GetLimit returns the value of Limit.
+func (s *AdminUserPage) GetLimit() int {
+	return s.Limit
+}
 
-func (*GetUserByIDNotFound) getUserByIDRes() {}
+// GetOffset returns the value of Offset.
+func (s *AdminUserPage) GetOffset() int {
+	return s.Offset
+}
 
-type GetUserByIDUnauthorized Error
+// SetUsers sets the value of Users.
+func (s *AdminUserPage) SetUsers(val []AdminUser) {
+	s.Users = val
+}
 
-func (*GetUserByIDUnauthorized) getUserByIDRes() {}
+// SetTotal sets the value of Total.
+func (s *AdminUserPage) SetTotal(val int) {
+	s.Total = val
+}
 
-type GetUserByUsernameInternalServerError Error
+// SetLimit sets the value of Limit.
+func (s *AdminUserPage) SetLimit(val int) {
+	s.Limit = val
+}
 
-func (*GetUserByUsernameInternalServerError) getUserByUsernameRes() {}
+// SetOffset sets the value of Offset.
+func (s *AdminUserPage) SetOffset(val int) {
+	s.Offset = val
+}
 
-type GetUserByUsernameNotFound Error
+type ApiKeyAuth struct {
+	APIKey string
+	Roles  []string
+}
 
-func (*GetUserByUsernameNotFound) getUserByUsernameRes() {}
+// GetAPIKey returns the value of APIKey.
+func (s *ApiKeyAuth) GetAPIKey() string {
+	return s.APIKey
+}
 
-type GetUserSettingsBadRequest Error
+// GetRoles returns the value of Roles.
+func (s *ApiKeyAuth) GetRoles() []string {
+	return s.Roles
+}
 
-func (*GetUserSettingsBadRequest) getUserSettingsRes() {}
+// SetAPIKey sets the value of APIKey.
+func (s *ApiKeyAuth) SetAPIKey(val string) {
+	s.APIKey = val
+}
 
-type GetUserSettingsInternalServerError Error
+// SetRoles sets the value of Roles.
+func (s *ApiKeyAuth) SetRoles(val []string) {
+	s.Roles = val
+}
 
-func (*GetUserSettingsInternalServerError) getUserSettingsRes() {}
+// Ref: #/components/schemas/AuditEvent
+type AuditEvent struct {
+	ID        uuid.UUID     `json:"id"`
+	Action    string        `json:"action"`
+	ActorID   uuid.UUID     `json:"actor_id"`
+	TargetID  uuid.UUID     `json:"target_id"`
+	IP        OptNilString  `json:"ip"`
+	UserAgent OptNilString  `json:"user_agent"`
+	Metadata  AuditMetadata `json:"metadata"`
+	CreatedAt time.Time     `json:"created_at"`
+}
 
-type GetUserStatsBadRequest Error
+// GetID returns the value of ID.
+func (s *AuditEvent) GetID() uuid.UUID {
+	return s.ID
+}
 
-func (*GetUserStatsBadRequest) getUserStatsRes() {}
+// GetAction returns the value of Action.
+func (s *AuditEvent) GetAction() string {
+	return s.Action
+}
 
-type GetUserStatsInternalServerError Error
+// GetActorID returns the value of ActorID.
+func (s *AuditEvent) GetActorID() uuid.UUID {
+	return s.ActorID
+}
 
-func (*GetUserStatsInternalServerError) getUserStatsRes() {}
+// GetTargetID returns the value of TargetID.
+func (s *AuditEvent) GetTargetID() uuid.UUID {
+	return s.TargetID
+}
 
-type GetUserSubscriptionBadRequest Error
+// GetIP returns the value of IP.
+func (s *AuditEvent) GetIP() OptNilString {
+	return s.IP
+}
 
-func (*GetUserSubscriptionBadRequest) getUserSubscriptionRes() {}
+// GetUserAgent returns the value of UserAgent.
+func (s *AuditEvent) GetUserAgent() OptNilString {
+	return s.UserAgent
+}
 
-type GetUserSubscriptionInternalServerError Error
+// GetMetadata returns the value of Metadata.
+func (s *AuditEvent) GetMetadata() AuditMetadata {
+	return s.Metadata
+}
 
-func (*GetUserSubscriptionInternalServerError) getUserSubscriptionRes() {}
+// GetCreatedAt returns the value of CreatedAt.
+func (s *AuditEvent) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
 
-type LoginBadRequest Error
+// SetID sets the value of ID.
+func (s *AuditEvent) SetID(val uuid.UUID) {
+	s.ID = val
+}
 
-func (*LoginBadRequest) loginRes() {}
+// SetAction sets the value of Action.
+func (s *AuditEvent) SetAction(val string) {
+	s.Action = val
+}
 
-type LoginInternalServerError Error
+// SetActorID sets the value of ActorID.
+func (s *AuditEvent) SetActorID(val uuid.UUID) {
+	s.ActorID = val
+}
 
-func (*LoginInternalServerError) loginRes() {}
+// SetTargetID sets the value of TargetID.
+func (s *AuditEvent) SetTargetID(val uuid.UUID) {
+	s.TargetID = val
+}
 
-// LoginOK is response for Login operation.
-type LoginOK struct {
-	SetCookie OptString
+// SetIP sets the value of IP.
+func (s *AuditEvent) SetIP(val OptNilString) {
+	s.IP = val
 }
 
-// GetSetCookie returns the value of SetCookie.
-func (s *LoginOK) GetSetCookie() OptString {
-	return s.SetCookie
+// SetUserAgent sets the value of UserAgent.
+func (s *AuditEvent) SetUserAgent(val OptNilString) {
+	s.UserAgent = val
 }
 
-// SetSetCookie sets the value of SetCookie.
-func (s *LoginOK) SetSetCookie(val OptString) {
-	s.SetCookie = val
+// SetMetadata sets the value of Metadata.
+func (s *AuditEvent) SetMetadata(val AuditMetadata) {
+	s.Metadata = val
 }
 
-func (*LoginOK) loginRes() {}
+// SetCreatedAt sets the value of CreatedAt.
+func (s *AuditEvent) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
 
-type LoginReq struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+// Ref: #/components/schemas/AuditEventPage
+type AuditEventPage struct {
+	Events []AuditEvent `json:"events"`
+	// Events matching the search across all pages.
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
 }
 
-// GetUsername returns the value of Username.
-func (s *LoginReq) GetUsername() string {
-	return s.Username
+// GetEvents returns the value of Events.
+func (s *AuditEventPage) GetEvents() []AuditEvent {
+	return s.Events
 }
 
-// GetPassword returns the value of Password.
-func (s *LoginReq) GetPassword() string {
-	return s.Password
+// GetTotal returns the value of Total.
+func (s *AuditEventPage) GetTotal() int {
+	return s.Total
 }
 
-// SetUsername sets the value of Username.
-func (s *LoginReq) SetUsername(val string) {
-	s.Username = val
+// GetLimit returns the value of Limit.
+func (s *AuditEventPage) GetLimit() int {
+	return s.Limit
 }
 
-// SetPassword sets the value of Password.
-func (s *LoginReq) SetPassword(val string) {
-	s.Password = val
+// GetOffset returns the value of Offset.
+func (s *AuditEventPage) GetOffset() int {
+	return s.Offset
 }
 
-type LoginUnauthorized Error
+// SetEvents sets the value of Events.
+func (s *AuditEventPage) SetEvents(val []AuditEvent) {
+	s.Events = val
+}
 
-func (*LoginUnauthorized) loginRes() {}
+// SetTotal sets the value of Total.
+func (s *AuditEventPage) SetTotal(val int) {
+	s.Total = val
+}
 
-// NewOptBool returns new OptBool with value set to v.
-func NewOptBool(v bool) OptBool {
-	return OptBool{
-		Value: v,
-		Set:   true,
-	}
+// SetLimit sets the value of Limit.
+func (s *AuditEventPage) SetLimit(val int) {
+	s.Limit = val
 }
 
-// OptBool is optional bool.
-type OptBool struct {
-	Value bool
-	Set   bool
+// SetOffset sets the value of Offset.
+func (s *AuditEventPage) SetOffset(val int) {
+	s.Offset = val
 }
 
-// IsSet returns true if OptBool was set.
-func (o OptBool) IsSet() bool { return o.Set }
+// Details of the action, such as the role granted.
+// Ref: #/components/schemas/AuditMetadata
+type AuditMetadata map[string]string
 
-// Reset unsets value.
-func (o *OptBool) Reset() {
-	var v bool
-	o.Value = v
-	o.Set = false
+func (s *AuditMetadata) init() AuditMetadata {
+	m := *s
+	if m == nil {
+		m = map[string]string{}
+		*s = m
+	}
+	return m
 }
 
-// SetTo sets value to v.
-func (o *OptBool) SetTo(v bool) {
-	o.Set = true
-	o.Value = v
+type BearerAuth struct {
+	Token string
+	Roles []string
 }
 
-// Get returns value and boolean that denotes whether value was set.
-func (o OptBool) Get() (v bool, ok bool) {
-	if !o.Set {
-		return v, false
-	}
-	return o.Value, true
+// GetToken returns the value of Token.
+func (s *BearerAuth) GetToken() string {
+	return s.Token
 }
 
-// Or returns value if set, or given parameter if does not.
-func (o OptBool) Or(d bool) bool {
-	if v, ok := o.Get(); ok {
-		return v
-	}
-	return d
+// GetRoles returns the value of Roles.
+func (s *BearerAuth) GetRoles() []string {
+	return s.Roles
 }
 
-// NewOptDateTime returns new OptDateTime with value set to v.
-func NewOptDateTime(v time.Time) OptDateTime {
-	return OptDateTime{
-		Value: v,
-		Set:   true,
-	}
+// SetToken sets the value of Token.
+func (s *BearerAuth) SetToken(val string) {
+	s.Token = val
 }
 
-// OptDateTime is optional time.Time.
-type OptDateTime struct {
-	Value time.Time
-	Set   bool
+// SetRoles sets the value of Roles.
+func (s *BearerAuth) SetRoles(val []string) {
+	s.Roles = val
 }
 
-// IsSet returns true if OptDateTime was set.
-func (o OptDateTime) IsSet() bool { return o.Set }
+// Ref: #/components/schemas/CSRFToken
+type CSRFToken struct {
+	CsrfToken string `json:"csrf_token"`
+}
 
-// Reset unsets value.
-func (o *OptDateTime) Reset() {
-	var v time.Time
-	o.Value = v
-	o.Set = false
+// GetCsrfToken returns the value of CsrfToken.
+func (s *CSRFToken) GetCsrfToken() string {
+	return s.CsrfToken
 }
 
-// SetTo sets value to v.
-func (o *OptDateTime) SetTo(v time.Time) {
-	o.Set = true
-	o.Value = v
+// SetCsrfToken sets the value of CsrfToken.
+func (s *CSRFToken) SetCsrfToken(val string) {
+	s.CsrfToken = val
 }
 
-// Get returns value and boolean that denotes whether value was set.
-func (o OptDateTime) Get() (v time.Time, ok bool) {
-	if !o.Set {
+type CookieAuth struct {
+	APIKey string
+	Roles  []string
+}
+
+// GetAPIKey returns the value of APIKey.
+func (s *CookieAuth) GetAPIKey() string {
+	return s.APIKey
+}
+
+// GetRoles returns the value of Roles.
+func (s *CookieAuth) GetRoles() []string {
+	return s.Roles
+}
+
+// SetAPIKey sets the value of APIKey.
+func (s *CookieAuth) SetAPIKey(val string) {
+	s.APIKey = val
+}
+
+// SetRoles sets the value of Roles.
+func (s *CookieAuth) SetRoles(val []string) {
+	s.Roles = val
+}
+
+type CreateAPIKeyCreated struct {
+	Key    APIKey `json:"key"`
+	APIKey string `json:"api_key"`
+}
+
+// GetKey returns the value of Key.
+func (s *CreateAPIKeyCreated) GetKey() APIKey {
+	return s.Key
+}
+
+// GetAPIKey returns the value of APIKey.
+func (s *CreateAPIKeyCreated) GetAPIKey() string {
+	return s.APIKey
+}
+
+// SetKey sets the value of Key.
+func (s *CreateAPIKeyCreated) SetKey(val APIKey) {
+	s.Key = val
+}
+
+// SetAPIKey sets the value of APIKey.
+func (s *CreateAPIKeyCreated) SetAPIKey(val string) {
+	s.APIKey = val
+}
+
+type CreateAPIKeyReq struct {
+	Name      string      `json:"name"`
+	Scopes    []string    `json:"scopes"`
+	ExpiresAt OptDateTime `json:"expires_at"`
+}
+
+// GetName returns the value of Name.
+func (s *CreateAPIKeyReq) GetName() string {
+	return s.Name
+}
+
+// GetScopes returns the value of Scopes.
+func (s *CreateAPIKeyReq) GetScopes() []string {
+	return s.Scopes
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *CreateAPIKeyReq) GetExpiresAt() OptDateTime {
+	return s.ExpiresAt
+}
+
+// SetName sets the value of Name.
+func (s *CreateAPIKeyReq) SetName(val string) {
+	s.Name = val
+}
+
+// SetScopes sets the value of Scopes.
+func (s *CreateAPIKeyReq) SetScopes(val []string) {
+	s.Scopes = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *CreateAPIKeyReq) SetExpiresAt(val OptDateTime) {
+	s.ExpiresAt = val
+}
+
+type CreateUserCreated struct {
+	UserID uuid.UUID `json:"UserID"`
+}
+
+// GetUserID returns the value of UserID.
+func (s *CreateUserCreated) GetUserID() uuid.UUID {
+	return s.UserID
+}
+
+// SetUserID sets the value of UserID.
+func (s *CreateUserCreated) SetUserID(val uuid.UUID) {
+	s.UserID = val
+}
+
+type CreateUserReq struct {
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Password  string `json:"password"`
+}
+
+// GetUsername returns the value of Username.
+func (s *CreateUserReq) GetUsername() string {
+	return s.Username
+}
+
+// GetFirstName returns the value of FirstName.
+func (s *CreateUserReq) GetFirstName() string {
+	return s.FirstName
+}
+
+// GetLastName returns the value of LastName.
+func (s *CreateUserReq) GetLastName() string {
+	return s.LastName
+}
+
+// GetEmail returns the value of Email.
+func (s *CreateUserReq) GetEmail() string {
+	return s.Email
+}
+
+// GetPassword returns the value of Password.
+func (s *CreateUserReq) GetPassword() string {
+	return s.Password
+}
+
+// SetUsername sets the value of Username.
+func (s *CreateUserReq) SetUsername(val string) {
+	s.Username = val
+}
+
+// SetFirstName sets the value of FirstName.
+func (s *CreateUserReq) SetFirstName(val string) {
+	s.FirstName = val
+}
+
+// SetLastName sets the value of LastName.
+func (s *CreateUserReq) SetLastName(val string) {
+	s.LastName = val
+}
+
+// SetEmail sets the value of Email.
+func (s *CreateUserReq) SetEmail(val string) {
+	s.Email = val
+}
+
+// SetPassword sets the value of Password.
+func (s *CreateUserReq) SetPassword(val string) {
+	s.Password = val
+}
+
+// Ref: #/components/schemas/DataExport
+type DataExport struct {
+	ID     uuid.UUID        `json:"id"`
+	Status DataExportStatus `json:"status"`
+	// Where to download the archive, set while it is available.
+	DownloadURL OptString      `json:"download_url"`
+	SizeBytes   OptNilInt64    `json:"size_bytes"`
+	CompletedAt OptNilDateTime `json:"completed_at"`
+	ExpiresAt   OptNilDateTime `json:"expires_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// GetID returns the value of ID.
+func (s *DataExport) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetStatus returns the value of Status.
+func (s *DataExport) GetStatus() DataExportStatus {
+	return s.Status
+}
+
+// GetDownloadURL returns the value of DownloadURL.
+func (s *DataExport) GetDownloadURL() OptString {
+	return s.DownloadURL
+}
+
+// GetSizeBytes returns the value of SizeBytes.
+func (s *DataExport) GetSizeBytes() OptNilInt64 {
+	return s.SizeBytes
+}
+
+// GetCompletedAt returns the value of CompletedAt.
+func (s *DataExport) GetCompletedAt() OptNilDateTime {
+	return s.CompletedAt
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *DataExport) GetExpiresAt() OptNilDateTime {
+	return s.ExpiresAt
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *DataExport) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *DataExport) SetID(val uuid.UUID) {
+	s.ID = val
+}
+
+// SetStatus sets the value of Status.
+func (s *DataExport) SetStatus(val DataExportStatus) {
+	s.Status = val
+}
+
+// SetDownloadURL sets the value of DownloadURL.
+func (s *DataExport) SetDownloadURL(val OptString) {
+	s.DownloadURL = val
+}
+
+// SetSizeBytes sets the value of SizeBytes.
+func (s *DataExport) SetSizeBytes(val OptNilInt64) {
+	s.SizeBytes = val
+}
+
+// SetCompletedAt sets the value of CompletedAt.
+func (s *DataExport) SetCompletedAt(val OptNilDateTime) {
+	s.CompletedAt = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *DataExport) SetExpiresAt(val OptNilDateTime) {
+	s.ExpiresAt = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *DataExport) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+type DataExportStatus string
+
+const (
+	DataExportStatusPending   DataExportStatus = "pending"
+	DataExportStatusRunning   DataExportStatus = "running"
+	DataExportStatusCompleted DataExportStatus = "completed"
+	DataExportStatusFailed    DataExportStatus = "failed"
+	DataExportStatusExpired   DataExportStatus = "expired"
+)
+
+// AllValues returns all DataExportStatus values.
+func (DataExportStatus) AllValues() []DataExportStatus {
+	return []DataExportStatus{
+		DataExportStatusPending,
+		DataExportStatusRunning,
+		DataExportStatusCompleted,
+		DataExportStatusFailed,
+		DataExportStatusExpired,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s DataExportStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case DataExportStatusPending:
+		return []byte(s), nil
+	case DataExportStatusRunning:
+		return []byte(s), nil
+	case DataExportStatusCompleted:
+		return []byte(s), nil
+	case DataExportStatusFailed:
+		return []byte(s), nil
+	case DataExportStatusExpired:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *DataExportStatus) UnmarshalText(data []byte) error {
+	switch DataExportStatus(data) {
+	case DataExportStatusPending:
+		*s = DataExportStatusPending
+		return nil
+	case DataExportStatusRunning:
+		*s = DataExportStatusRunning
+		return nil
+	case DataExportStatusCompleted:
+		*s = DataExportStatusCompleted
+		return nil
+	case DataExportStatusFailed:
+		*s = DataExportStatusFailed
+		return nil
+	case DataExportStatusExpired:
+		*s = DataExportStatusExpired
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// DeleteAvatarNoContent is response for DeleteAvatar operation.
+type DeleteAvatarNoContent struct{}
+
+// DeleteProgressPhotoNoContent is response for DeleteProgressPhoto operation.
+type DeleteProgressPhotoNoContent struct{}
+
+// DeleteUserNoContent is response for DeleteUser operation.
+type DeleteUserNoContent struct{}
+
+type DownloadDataExportOK struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s DownloadDataExportOK) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+// DownloadDataExportOKHeaders wraps DownloadDataExportOK with response headers.
+type DownloadDataExportOKHeaders struct {
+	ContentDisposition OptString
+	Response           DownloadDataExportOK
+}
+
+// GetContentDisposition returns the value of ContentDisposition.
+func (s *DownloadDataExportOKHeaders) GetContentDisposition() OptString {
+	return s.ContentDisposition
+}
+
+// GetResponse returns the value of Response.
+func (s *DownloadDataExportOKHeaders) GetResponse() DownloadDataExportOK {
+	return s.Response
+}
+
+// SetContentDisposition sets the value of ContentDisposition.
+func (s *DownloadDataExportOKHeaders) SetContentDisposition(val OptString) {
+	s.ContentDisposition = val
+}
+
+// SetResponse sets the value of Response.
+func (s *DownloadDataExportOKHeaders) SetResponse(val DownloadDataExportOK) {
+	s.Response = val
+}
+
+// Ref: #/components/schemas/FieldError
+type FieldError struct {
+	Field  string `json:"field"`
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+}
+
+// GetField returns the value of Field.
+func (s *FieldError) GetField() string {
+	return s.Field
+}
+
+// GetCode returns the value of Code.
+func (s *FieldError) GetCode() string {
+	return s.Code
+}
+
+// GetDetail returns the value of Detail.
+func (s *FieldError) GetDetail() string {
+	return s.Detail
+}
+
+// SetField sets the value of Field.
+func (s *FieldError) SetField(val string) {
+	s.Field = val
+}
+
+// SetCode sets the value of Code.
+func (s *FieldError) SetCode(val string) {
+	s.Code = val
+}
+
+// SetDetail sets the value of Detail.
+func (s *FieldError) SetDetail(val string) {
+	s.Detail = val
+}
+
+// ForceLogoutUserNoContent is response for ForceLogoutUser operation.
+type ForceLogoutUserNoContent struct{}
+
+// Ref: #/components/schemas/FullProfile
+type FullProfile struct {
+	User         User             `json:"user"`
+	Stats        UserStats        `json:"stats"`
+	Subscription UserSubscription `json:"subscription"`
+	Settings     UserSettings     `json:"settings"`
+}
+
+// GetUser returns the value of User.
+func (s *FullProfile) GetUser() User {
+	return s.User
+}
+
+// GetStats returns the value of Stats.
+func (s *FullProfile) GetStats() UserStats {
+	return s.Stats
+}
+
+// GetSubscription returns the value of Subscription.
+func (s *FullProfile) GetSubscription() UserSubscription {
+	return s.Subscription
+}
+
+// GetSettings returns the value of Settings.
+func (s *FullProfile) GetSettings() UserSettings {
+	return s.Settings
+}
+
+// SetUser sets the value of User.
+func (s *FullProfile) SetUser(val User) {
+	s.User = val
+}
+
+// SetStats sets the value of Stats.
+func (s *FullProfile) SetStats(val UserStats) {
+	s.Stats = val
+}
+
+// SetSubscription sets the value of Subscription.
+func (s *FullProfile) SetSubscription(val UserSubscription) {
+	s.Subscription = val
+}
+
+// SetSettings sets the value of Settings.
+func (s *FullProfile) SetSettings(val UserSettings) {
+	s.Settings = val
+}
+
+// Ref: #/components/schemas/Identity
+type Identity struct {
+	Provider  string    `json:"provider"`
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetProvider returns the value of Provider.
+func (s *Identity) GetProvider() string {
+	return s.Provider
+}
+
+// GetUserID returns the value of UserID.
+func (s *Identity) GetUserID() uuid.UUID {
+	return s.UserID
+}
+
+// GetEmail returns the value of Email.
+func (s *Identity) GetEmail() string {
+	return s.Email
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Identity) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetUpdatedAt returns the value of UpdatedAt.
+func (s *Identity) GetUpdatedAt() time.Time {
+	return s.UpdatedAt
+}
+
+// SetProvider sets the value of Provider.
+func (s *Identity) SetProvider(val string) {
+	s.Provider = val
+}
+
+// SetUserID sets the value of UserID.
+func (s *Identity) SetUserID(val uuid.UUID) {
+	s.UserID = val
+}
+
+// SetEmail sets the value of Email.
+func (s *Identity) SetEmail(val string) {
+	s.Email = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Identity) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetUpdatedAt sets the value of UpdatedAt.
+func (s *Identity) SetUpdatedAt(val time.Time) {
+	s.UpdatedAt = val
+}
+
+type Image struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s Image) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+// ImageHeaders wraps Image with response headers.
+type ImageHeaders struct {
+	CacheControl OptString
+	ContentType  string
+	Response     Image
+}
+
+// GetCacheControl returns the value of CacheControl.
+func (s *ImageHeaders) GetCacheControl() OptString {
+	return s.CacheControl
+}
+
+// GetContentType returns the value of ContentType.
+func (s *ImageHeaders) GetContentType() string {
+	return s.ContentType
+}
+
+// GetResponse returns the value of Response.
+func (s *ImageHeaders) GetResponse() Image {
+	return s.Response
+}
+
+// SetCacheControl sets the value of CacheControl.
+func (s *ImageHeaders) SetCacheControl(val OptString) {
+	s.CacheControl = val
+}
+
+// SetContentType sets the value of ContentType.
+func (s *ImageHeaders) SetContentType(val string) {
+	s.ContentType = val
+}
+
+// SetResponse sets the value of Response.
+func (s *ImageHeaders) SetResponse(val Image) {
+	s.Response = val
+}
+
+type ImageSize string
+
+const (
+	ImageSizeFull      ImageSize = "full"
+	ImageSizeThumbnail ImageSize = "thumbnail"
+)
+
+// AllValues returns all ImageSize values.
+func (ImageSize) AllValues() []ImageSize {
+	return []ImageSize{
+		ImageSizeFull,
+		ImageSizeThumbnail,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ImageSize) MarshalText() ([]byte, error) {
+	switch s {
+	case ImageSizeFull:
+		return []byte(s), nil
+	case ImageSizeThumbnail:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ImageSize) UnmarshalText(data []byte) error {
+	switch ImageSize(data) {
+	case ImageSizeFull:
+		*s = ImageSizeFull
+		return nil
+	case ImageSizeThumbnail:
+		*s = ImageSizeThumbnail
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// LoginNoContent is response for Login operation.
+type LoginNoContent struct{}
+
+type LoginReq struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// Restore the account if it is pending deletion.
+	Restore OptBool `json:"restore"`
+}
+
+// GetUsername returns the value of Username.
+func (s *LoginReq) GetUsername() string {
+	return s.Username
+}
+
+// GetPassword returns the value of Password.
+func (s *LoginReq) GetPassword() string {
+	return s.Password
+}
+
+// GetRestore returns the value of Restore.
+func (s *LoginReq) GetRestore() OptBool {
+	return s.Restore
+}
+
+// SetUsername sets the value of Username.
+func (s *LoginReq) SetUsername(val string) {
+	s.Username = val
+}
+
+// SetPassword sets the value of Password.
+func (s *LoginReq) SetPassword(val string) {
+	s.Password = val
+}
+
+// SetRestore sets the value of Restore.
+func (s *LoginReq) SetRestore(val OptBool) {
+	s.Restore = val
+}
+
+// LogoutNoContent is response for Logout operation.
+type LogoutNoContent struct{}
+
+// OauthCallbackFormNoContent is response for OauthCallbackForm operation.
+type OauthCallbackFormNoContent struct{}
+
+type OauthCallbackFormReq struct {
+	Code  OptString `json:"code"`
+	State OptString `json:"state"`
+	Error OptString `json:"error"`
+}
+
+// GetCode returns the value of Code.
+func (s *OauthCallbackFormReq) GetCode() OptString {
+	return s.Code
+}
+
+// GetState returns the value of State.
+func (s *OauthCallbackFormReq) GetState() OptString {
+	return s.State
+}
+
+// GetError returns the value of Error.
+func (s *OauthCallbackFormReq) GetError() OptString {
+	return s.Error
+}
+
+// SetCode sets the value of Code.
+func (s *OauthCallbackFormReq) SetCode(val OptString) {
+	s.Code = val
+}
+
+// SetState sets the value of State.
+func (s *OauthCallbackFormReq) SetState(val OptString) {
+	s.State = val
+}
+
+// SetError sets the value of Error.
+func (s *OauthCallbackFormReq) SetError(val OptString) {
+	s.Error = val
+}
+
+// OauthCallbackNoContent is response for OauthCallback operation.
+type OauthCallbackNoContent struct{}
+
+// NewOptBool returns new OptBool with value set to v.
+func NewOptBool(v bool) OptBool {
+	return OptBool{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptBool is optional bool.
+type OptBool struct {
+	Value bool
+	Set   bool
+}
+
+// IsSet returns true if OptBool was set.
+func (o OptBool) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptBool) Reset() {
+	var v bool
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptBool) SetTo(v bool) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptBool) Get() (v bool, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptBool) Or(d bool) bool {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptDate returns new OptDate with value set to v.
+func NewOptDate(v time.Time) OptDate {
+	return OptDate{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDate is optional time.Time.
+type OptDate struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDate was set.
+func (o OptDate) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDate) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDate) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDate) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDate) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDateTime) Get() (v time.Time, ok bool) {
+	if !o.Set {
 		return v, false
 	}
 	return o.Value, true
@@ -430,52 +1431,333 @@ func (o OptFloat64) Or(d float64) float64 {
 	return d
 }
 
-// NewOptNilDateTime returns new OptNilDateTime with value set to v.
-func NewOptNilDateTime(v time.Time) OptNilDateTime {
-	return OptNilDateTime{
+// NewOptImageSize returns new OptImageSize with value set to v.
+func NewOptImageSize(v ImageSize) OptImageSize {
+	return OptImageSize{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptImageSize is optional ImageSize.
+type OptImageSize struct {
+	Value ImageSize
+	Set   bool
+}
+
+// IsSet returns true if OptImageSize was set.
+func (o OptImageSize) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptImageSize) Reset() {
+	var v ImageSize
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptImageSize) SetTo(v ImageSize) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptImageSize) Get() (v ImageSize, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptImageSize) Or(d ImageSize) ImageSize {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt returns new OptInt with value set to v.
+func NewOptInt(v int) OptInt {
+	return OptInt{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt is optional int.
+type OptInt struct {
+	Value int
+	Set   bool
+}
+
+// IsSet returns true if OptInt was set.
+func (o OptInt) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt) Reset() {
+	var v int
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt) SetTo(v int) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt) Get() (v int, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt) Or(d int) int {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptNilDate returns new OptNilDate with value set to v.
+func NewOptNilDate(v time.Time) OptNilDate {
+	return OptNilDate{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptNilDate is optional nullable time.Time.
+type OptNilDate struct {
+	Value time.Time
+	Set   bool
+	Null  bool
+}
+
+// IsSet returns true if OptNilDate was set.
+func (o OptNilDate) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptNilDate) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+	o.Null = false
+}
+
+// SetTo sets value to v.
+func (o *OptNilDate) SetTo(v time.Time) {
+	o.Set = true
+	o.Null = false
+	o.Value = v
+}
+
+// IsNull returns true if value is Null.
+func (o OptNilDate) IsNull() bool { return o.Null }
+
+// SetToNull sets value to null.
+func (o *OptNilDate) SetToNull() {
+	o.Set = true
+	o.Null = true
+	var v time.Time
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptNilDate) Get() (v time.Time, ok bool) {
+	if o.Null {
+		return v, false
+	}
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptNilDate) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptNilDateTime returns new OptNilDateTime with value set to v.
+func NewOptNilDateTime(v time.Time) OptNilDateTime {
+	return OptNilDateTime{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptNilDateTime is optional nullable time.Time.
+type OptNilDateTime struct {
+	Value time.Time
+	Set   bool
+	Null  bool
+}
+
+// IsSet returns true if OptNilDateTime was set.
+func (o OptNilDateTime) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptNilDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+	o.Null = false
+}
+
+// SetTo sets value to v.
+func (o *OptNilDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Null = false
+	o.Value = v
+}
+
+// IsNull returns true if value is Null.
+func (o OptNilDateTime) IsNull() bool { return o.Null }
+
+// SetToNull sets value to null.
+func (o *OptNilDateTime) SetToNull() {
+	o.Set = true
+	o.Null = true
+	var v time.Time
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptNilDateTime) Get() (v time.Time, ok bool) {
+	if o.Null {
+		return v, false
+	}
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptNilDateTime) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptNilFloat64 returns new OptNilFloat64 with value set to v.
+func NewOptNilFloat64(v float64) OptNilFloat64 {
+	return OptNilFloat64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptNilFloat64 is optional nullable float64.
+type OptNilFloat64 struct {
+	Value float64
+	Set   bool
+	Null  bool
+}
+
+// IsSet returns true if OptNilFloat64 was set.
+func (o OptNilFloat64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptNilFloat64) Reset() {
+	var v float64
+	o.Value = v
+	o.Set = false
+	o.Null = false
+}
+
+// SetTo sets value to v.
+func (o *OptNilFloat64) SetTo(v float64) {
+	o.Set = true
+	o.Null = false
+	o.Value = v
+}
+
+// IsNull returns true if value is Null.
+func (o OptNilFloat64) IsNull() bool { return o.Null }
+
+// SetToNull sets value to null.
+func (o *OptNilFloat64) SetToNull() {
+	o.Set = true
+	o.Null = true
+	var v float64
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptNilFloat64) Get() (v float64, ok bool) {
+	if o.Null {
+		return v, false
+	}
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptNilFloat64) Or(d float64) float64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptNilInt64 returns new OptNilInt64 with value set to v.
+func NewOptNilInt64(v int64) OptNilInt64 {
+	return OptNilInt64{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptNilDateTime is optional nullable time.Time.
-type OptNilDateTime struct {
-	Value time.Time
+// OptNilInt64 is optional nullable int64.
+type OptNilInt64 struct {
+	Value int64
 	Set   bool
 	Null  bool
 }
 
-// IsSet returns true if OptNilDateTime was set.
-func (o OptNilDateTime) IsSet() bool { return o.Set }
+// IsSet returns true if OptNilInt64 was set.
+func (o OptNilInt64) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptNilDateTime) Reset() {
-	var v time.Time
+func (o *OptNilInt64) Reset() {
+	var v int64
 	o.Value = v
 	o.Set = false
 	o.Null = false
 }
 
 // SetTo sets value to v.
-func (o *OptNilDateTime) SetTo(v time.Time) {
+func (o *OptNilInt64) SetTo(v int64) {
 	o.Set = true
 	o.Null = false
 	o.Value = v
 }
 
 // IsNull returns true if value is Null.
-func (o OptNilDateTime) IsNull() bool { return o.Null }
+func (o OptNilInt64) IsNull() bool { return o.Null }
 
 // SetToNull sets value to null.
-func (o *OptNilDateTime) SetToNull() {
+func (o *OptNilInt64) SetToNull() {
 	o.Set = true
 	o.Null = true
-	var v time.Time
+	var v int64
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptNilDateTime) Get() (v time.Time, ok bool) {
+func (o OptNilInt64) Get() (v int64, ok bool) {
 	if o.Null {
 		return v, false
 	}
@@ -486,59 +1768,59 @@ func (o OptNilDateTime) Get() (v time.Time, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptNilDateTime) Or(d time.Time) time.Time {
+func (o OptNilInt64) Or(d int64) int64 {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// NewOptNilFloat64 returns new OptNilFloat64 with value set to v.
-func NewOptNilFloat64(v float64) OptNilFloat64 {
-	return OptNilFloat64{
+// NewOptNilPublicUserExperienceLevel returns new OptNilPublicUserExperienceLevel with value set to v.
+func NewOptNilPublicUserExperienceLevel(v PublicUserExperienceLevel) OptNilPublicUserExperienceLevel {
+	return OptNilPublicUserExperienceLevel{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptNilFloat64 is optional nullable float64.
-type OptNilFloat64 struct {
-	Value float64
+// OptNilPublicUserExperienceLevel is optional nullable PublicUserExperienceLevel.
+type OptNilPublicUserExperienceLevel struct {
+	Value PublicUserExperienceLevel
 	Set   bool
 	Null  bool
 }
 
-// IsSet returns true if OptNilFloat64 was set.
-func (o OptNilFloat64) IsSet() bool { return o.Set }
+// IsSet returns true if OptNilPublicUserExperienceLevel was set.
+func (o OptNilPublicUserExperienceLevel) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptNilFloat64) Reset() {
-	var v float64
+func (o *OptNilPublicUserExperienceLevel) Reset() {
+	var v PublicUserExperienceLevel
 	o.Value = v
 	o.Set = false
 	o.Null = false
 }
 
 // SetTo sets value to v.
-func (o *OptNilFloat64) SetTo(v float64) {
+func (o *OptNilPublicUserExperienceLevel) SetTo(v PublicUserExperienceLevel) {
 	o.Set = true
 	o.Null = false
 	o.Value = v
 }
 
 // IsNull returns true if value is Null.
-func (o OptNilFloat64) IsNull() bool { return o.Null }
+func (o OptNilPublicUserExperienceLevel) IsNull() bool { return o.Null }
 
 // SetToNull sets value to null.
-func (o *OptNilFloat64) SetToNull() {
+func (o *OptNilPublicUserExperienceLevel) SetToNull() {
 	o.Set = true
 	o.Null = true
-	var v float64
+	var v PublicUserExperienceLevel
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptNilFloat64) Get() (v float64, ok bool) {
+func (o OptNilPublicUserExperienceLevel) Get() (v PublicUserExperienceLevel, ok bool) {
 	if o.Null {
 		return v, false
 	}
@@ -549,7 +1831,7 @@ func (o OptNilFloat64) Get() (v float64, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptNilFloat64) Or(d float64) float64 {
+func (o OptNilPublicUserExperienceLevel) Or(d PublicUserExperienceLevel) PublicUserExperienceLevel {
 	if v, ok := o.Get(); ok {
 		return v
 	}
@@ -619,38 +1901,118 @@ func (o OptNilString) Or(d string) string {
 	return d
 }
 
-// NewOptStreak returns new OptStreak with value set to v.
-func NewOptStreak(v Streak) OptStreak {
-	return OptStreak{
+// NewOptNilUserExperienceLevel returns new OptNilUserExperienceLevel with value set to v.
+func NewOptNilUserExperienceLevel(v UserExperienceLevel) OptNilUserExperienceLevel {
+	return OptNilUserExperienceLevel{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptNilUserExperienceLevel is optional nullable UserExperienceLevel.
+type OptNilUserExperienceLevel struct {
+	Value UserExperienceLevel
+	Set   bool
+	Null  bool
+}
+
+// IsSet returns true if OptNilUserExperienceLevel was set.
+func (o OptNilUserExperienceLevel) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptNilUserExperienceLevel) Reset() {
+	var v UserExperienceLevel
+	o.Value = v
+	o.Set = false
+	o.Null = false
+}
+
+// SetTo sets value to v.
+func (o *OptNilUserExperienceLevel) SetTo(v UserExperienceLevel) {
+	o.Set = true
+	o.Null = false
+	o.Value = v
+}
+
+// IsNull returns true if value is Null.
+func (o OptNilUserExperienceLevel) IsNull() bool { return o.Null }
+
+// SetToNull sets value to null.
+func (o *OptNilUserExperienceLevel) SetToNull() {
+	o.Set = true
+	o.Null = true
+	var v UserExperienceLevel
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptNilUserExperienceLevel) Get() (v UserExperienceLevel, ok bool) {
+	if o.Null {
+		return v, false
+	}
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptNilUserExperienceLevel) Or(d UserExperienceLevel) UserExperienceLevel {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptNilUserSex returns new OptNilUserSex with value set to v.
+func NewOptNilUserSex(v UserSex) OptNilUserSex {
+	return OptNilUserSex{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptStreak is optional Streak.
-type OptStreak struct {
-	Value Streak
+// OptNilUserSex is optional nullable UserSex.
+type OptNilUserSex struct {
+	Value UserSex
 	Set   bool
+	Null  bool
 }
 
-// IsSet returns true if OptStreak was set.
-func (o OptStreak) IsSet() bool { return o.Set }
+// IsSet returns true if OptNilUserSex was set.
+func (o OptNilUserSex) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptStreak) Reset() {
-	var v Streak
+func (o *OptNilUserSex) Reset() {
+	var v UserSex
 	o.Value = v
 	o.Set = false
+	o.Null = false
 }
 
 // SetTo sets value to v.
-func (o *OptStreak) SetTo(v Streak) {
+func (o *OptNilUserSex) SetTo(v UserSex) {
+	o.Set = true
+	o.Null = false
+	o.Value = v
+}
+
+// IsNull returns true if value is Null.
+func (o OptNilUserSex) IsNull() bool { return o.Null }
+
+// SetToNull sets value to null.
+func (o *OptNilUserSex) SetToNull() {
 	o.Set = true
+	o.Null = true
+	var v UserSex
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptStreak) Get() (v Streak, ok bool) {
+func (o OptNilUserSex) Get() (v UserSex, ok bool) {
+	if o.Null {
+		return v, false
+	}
 	if !o.Set {
 		return v, false
 	}
@@ -658,7 +2020,7 @@ func (o OptStreak) Get() (v Streak, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptStreak) Or(d Streak) Streak {
+func (o OptNilUserSex) Or(d UserSex) UserSex {
 	if v, ok := o.Get(); ok {
 		return v
 	}
@@ -711,38 +2073,38 @@ func (o OptString) Or(d string) string {
 	return d
 }
 
-// NewOptTotals returns new OptTotals with value set to v.
-func NewOptTotals(v Totals) OptTotals {
-	return OptTotals{
+// NewOptUUID returns new OptUUID with value set to v.
+func NewOptUUID(v uuid.UUID) OptUUID {
+	return OptUUID{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptTotals is optional Totals.
-type OptTotals struct {
-	Value Totals
+// OptUUID is optional uuid.UUID.
+type OptUUID struct {
+	Value uuid.UUID
 	Set   bool
 }
 
-// IsSet returns true if OptTotals was set.
-func (o OptTotals) IsSet() bool { return o.Set }
+// IsSet returns true if OptUUID was set.
+func (o OptUUID) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptTotals) Reset() {
-	var v Totals
+func (o *OptUUID) Reset() {
+	var v uuid.UUID
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptTotals) SetTo(v Totals) {
+func (o *OptUUID) SetTo(v uuid.UUID) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptTotals) Get() (v Totals, ok bool) {
+func (o OptUUID) Get() (v uuid.UUID, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -750,82 +2112,471 @@ func (o OptTotals) Get() (v Totals, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptTotals) Or(d Totals) Totals {
+func (o OptUUID) Or(d uuid.UUID) uuid.UUID {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// NewOptUUID returns new OptUUID with value set to v.
-func NewOptUUID(v uuid.UUID) OptUUID {
-	return OptUUID{
-		Value: v,
-		Set:   true,
-	}
+// Ref: #/components/schemas/Photo
+type Photo struct {
+	ID          uuid.UUID `json:"id"`
+	ContentType string    `json:"content_type"`
+	Width       int       `json:"width"`
+	Height      int       `json:"height"`
+	SizeBytes   int64     `json:"size_bytes"`
+	// Set on progress photos.
+	TakenOn   OptNilDate `json:"taken_on"`
+	ImageURL  string     `json:"image_url"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// GetID returns the value of ID.
+func (s *Photo) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetContentType returns the value of ContentType.
+func (s *Photo) GetContentType() string {
+	return s.ContentType
+}
+
+// GetWidth returns the value of Width.
+func (s *Photo) GetWidth() int {
+	return s.Width
+}
+
+// GetHeight returns the value of Height.
+func (s *Photo) GetHeight() int {
+	return s.Height
+}
+
+// GetSizeBytes returns the value of SizeBytes.
+func (s *Photo) GetSizeBytes() int64 {
+	return s.SizeBytes
+}
+
+// GetTakenOn returns the value of TakenOn.
+func (s *Photo) GetTakenOn() OptNilDate {
+	return s.TakenOn
+}
+
+// GetImageURL returns the value of ImageURL.
+func (s *Photo) GetImageURL() string {
+	return s.ImageURL
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Photo) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *Photo) SetID(val uuid.UUID) {
+	s.ID = val
+}
+
+// SetContentType sets the value of ContentType.
+func (s *Photo) SetContentType(val string) {
+	s.ContentType = val
+}
+
+// SetWidth sets the value of Width.
+func (s *Photo) SetWidth(val int) {
+	s.Width = val
+}
+
+// SetHeight sets the value of Height.
+func (s *Photo) SetHeight(val int) {
+	s.Height = val
+}
+
+// SetSizeBytes sets the value of SizeBytes.
+func (s *Photo) SetSizeBytes(val int64) {
+	s.SizeBytes = val
+}
+
+// SetTakenOn sets the value of TakenOn.
+func (s *Photo) SetTakenOn(val OptNilDate) {
+	s.TakenOn = val
+}
+
+// SetImageURL sets the value of ImageURL.
+func (s *Photo) SetImageURL(val string) {
+	s.ImageURL = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Photo) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// Ref: #/components/schemas/Problem
+type Problem struct {
+	Type   string       `json:"type"`
+	Title  string       `json:"title"`
+	Status int          `json:"status"`
+	Detail OptString    `json:"detail"`
+	Code   string       `json:"code"`
+	Errors []FieldError `json:"errors"`
+}
+
+// GetType returns the value of Type.
+func (s *Problem) GetType() string {
+	return s.Type
+}
+
+// GetTitle returns the value of Title.
+func (s *Problem) GetTitle() string {
+	return s.Title
+}
+
+// GetStatus returns the value of Status.
+func (s *Problem) GetStatus() int {
+	return s.Status
+}
+
+// GetDetail returns the value of Detail.
+func (s *Problem) GetDetail() OptString {
+	return s.Detail
+}
+
+// GetCode returns the value of Code.
+func (s *Problem) GetCode() string {
+	return s.Code
+}
+
+// GetErrors returns the value of Errors.
+func (s *Problem) GetErrors() []FieldError {
+	return s.Errors
+}
+
+// SetType sets the value of Type.
+func (s *Problem) SetType(val string) {
+	s.Type = val
+}
+
+// SetTitle sets the value of Title.
+func (s *Problem) SetTitle(val string) {
+	s.Title = val
+}
+
+// SetStatus sets the value of Status.
+func (s *Problem) SetStatus(val int) {
+	s.Status = val
+}
+
+// SetDetail sets the value of Detail.
+func (s *Problem) SetDetail(val OptString) {
+	s.Detail = val
+}
+
+// SetCode sets the value of Code.
+func (s *Problem) SetCode(val string) {
+	s.Code = val
+}
+
+// SetErrors sets the value of Errors.
+func (s *Problem) SetErrors(val []FieldError) {
+	s.Errors = val
+}
+
+// ProblemStatusCode wraps Problem with StatusCode.
+type ProblemStatusCode struct {
+	StatusCode int
+	Response   Problem
+}
+
+// GetStatusCode returns the value of StatusCode.
+func (s *ProblemStatusCode) GetStatusCode() int {
+	return s.StatusCode
+}
+
+// GetResponse returns the value of Response.
+func (s *ProblemStatusCode) GetResponse() Problem {
+	return s.Response
+}
+
+// SetStatusCode sets the value of StatusCode.
+func (s *ProblemStatusCode) SetStatusCode(val int) {
+	s.StatusCode = val
+}
+
+// SetResponse sets the value of Response.
+func (s *ProblemStatusCode) SetResponse(val Problem) {
+	s.Response = val
+}
+
+// What other users see of a profile.
+// Ref: #/components/schemas/PublicUser
+type PublicUser struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+	FullName string    `json:"full_name"`
+	// Null while the profile is private.
+	Bio OptNilString `json:"bio"`
+	// Null while the profile is private.
+	ExperienceLevel OptNilPublicUserExperienceLevel `json:"experience_level"`
+	// Null while the profile is private.
+	PreferredGym OptNilString `json:"preferred_gym"`
+	// Set when the user has uploaded an avatar.
+	AvatarURL OptNilString `json:"avatar_url"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// GetID returns the value of ID.
+func (s *PublicUser) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetUsername returns the value of Username.
+func (s *PublicUser) GetUsername() string {
+	return s.Username
+}
+
+// GetFullName returns the value of FullName.
+func (s *PublicUser) GetFullName() string {
+	return s.FullName
+}
+
+// GetBio returns the value of Bio.
+func (s *PublicUser) GetBio() OptNilString {
+	return s.Bio
+}
+
+// GetExperienceLevel returns the value of ExperienceLevel.
+func (s *PublicUser) GetExperienceLevel() OptNilPublicUserExperienceLevel {
+	return s.ExperienceLevel
+}
+
+// GetPreferredGym returns the value of PreferredGym.
+func (s *PublicUser) GetPreferredGym() OptNilString {
+	return s.PreferredGym
+}
+
+// GetAvatarURL returns the value of AvatarURL.
+func (s *PublicUser) GetAvatarURL() OptNilString {
+	return s.AvatarURL
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *PublicUser) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *PublicUser) SetID(val uuid.UUID) {
+	s.ID = val
+}
+
+// SetUsername sets the value of Username.
+func (s *PublicUser) SetUsername(val string) {
+	s.Username = val
+}
+
+// SetFullName sets the value of FullName.
+func (s *PublicUser) SetFullName(val string) {
+	s.FullName = val
+}
+
+// SetBio sets the value of Bio.
+func (s *PublicUser) SetBio(val OptNilString) {
+	s.Bio = val
+}
+
+// SetExperienceLevel sets the value of ExperienceLevel.
+func (s *PublicUser) SetExperienceLevel(val OptNilPublicUserExperienceLevel) {
+	s.ExperienceLevel = val
+}
+
+// SetPreferredGym sets the value of PreferredGym.
+func (s *PublicUser) SetPreferredGym(val OptNilString) {
+	s.PreferredGym = val
+}
+
+// SetAvatarURL sets the value of AvatarURL.
+func (s *PublicUser) SetAvatarURL(val OptNilString) {
+	s.AvatarURL = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *PublicUser) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// Null while the profile is private.
+type PublicUserExperienceLevel string
+
+const (
+	PublicUserExperienceLevelBeginner     PublicUserExperienceLevel = "beginner"
+	PublicUserExperienceLevelNovice       PublicUserExperienceLevel = "novice"
+	PublicUserExperienceLevelIntermediate PublicUserExperienceLevel = "intermediate"
+	PublicUserExperienceLevelAdvanced     PublicUserExperienceLevel = "advanced"
+	PublicUserExperienceLevelElite        PublicUserExperienceLevel = "elite"
+)
+
+// AllValues returns all PublicUserExperienceLevel values.
+func (PublicUserExperienceLevel) AllValues() []PublicUserExperienceLevel {
+	return []PublicUserExperienceLevel{
+		PublicUserExperienceLevelBeginner,
+		PublicUserExperienceLevelNovice,
+		PublicUserExperienceLevelIntermediate,
+		PublicUserExperienceLevelAdvanced,
+		PublicUserExperienceLevelElite,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s PublicUserExperienceLevel) MarshalText() ([]byte, error) {
+	switch s {
+	case PublicUserExperienceLevelBeginner:
+		return []byte(s), nil
+	case PublicUserExperienceLevelNovice:
+		return []byte(s), nil
+	case PublicUserExperienceLevelIntermediate:
+		return []byte(s), nil
+	case PublicUserExperienceLevelAdvanced:
+		return []byte(s), nil
+	case PublicUserExperienceLevelElite:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *PublicUserExperienceLevel) UnmarshalText(data []byte) error {
+	switch PublicUserExperienceLevel(data) {
+	case PublicUserExperienceLevelBeginner:
+		*s = PublicUserExperienceLevelBeginner
+		return nil
+	case PublicUserExperienceLevelNovice:
+		*s = PublicUserExperienceLevelNovice
+		return nil
+	case PublicUserExperienceLevelIntermediate:
+		*s = PublicUserExperienceLevelIntermediate
+		return nil
+	case PublicUserExperienceLevelAdvanced:
+		*s = PublicUserExperienceLevelAdvanced
+		return nil
+	case PublicUserExperienceLevelElite:
+		*s = PublicUserExperienceLevelElite
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// RefreshNoContent is response for Refresh operation.
+type RefreshNoContent struct{}
+
+// RevokeAPIKeyNoContent is response for RevokeAPIKey operation.
+type RevokeAPIKeyNoContent struct{}
+
+// Ref: #/components/schemas/SecurityEvent
+type SecurityEvent struct {
+	ID     uuid.UUID `json:"id"`
+	Action string    `json:"action"`
+	// Whether an admin acted rather than the user.
+	ByAdmin   bool          `json:"by_admin"`
+	IP        OptNilString  `json:"ip"`
+	UserAgent OptNilString  `json:"user_agent"`
+	Metadata  AuditMetadata `json:"metadata"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// GetID returns the value of ID.
+func (s *SecurityEvent) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetAction returns the value of Action.
+func (s *SecurityEvent) GetAction() string {
+	return s.Action
 }
 
-// OptUUID is optional uuid.UUID.
-type OptUUID struct {
-	Value uuid.UUID
-	Set   bool
+// GetByAdmin returns the value of ByAdmin.
+func (s *SecurityEvent) GetByAdmin() bool {
+	return s.ByAdmin
 }
 
-// IsSet returns true if OptUUID was set.
-func (o OptUUID) IsSet() bool { return o.Set }
+// GetIP returns the value of IP.
+func (s *SecurityEvent) GetIP() OptNilString {
+	return s.IP
+}
 
-// Reset unsets value.
-func (o *OptUUID) Reset() {
-	var v uuid.UUID
-	o.Value = v
-	o.Set = false
+// GetUserAgent returns the value of UserAgent.
+func (s *SecurityEvent) GetUserAgent() OptNilString {
+	return s.UserAgent
 }
 
-// SetTo sets value to v.
-func (o *OptUUID) SetTo(v uuid.UUID) {
-	o.Set = true
-	o.Value = v
+// GetMetadata returns the value of Metadata.
+func (s *SecurityEvent) GetMetadata() AuditMetadata {
+	return s.Metadata
 }
 
-// Get returns value and boolean that denotes whether value was set.
-func (o OptUUID) Get() (v uuid.UUID, ok bool) {
-	if !o.Set {
-		return v, false
-	}
-	return o.Value, true
+// GetCreatedAt returns the value of CreatedAt.
+func (s *SecurityEvent) GetCreatedAt() time.Time {
+	return s.CreatedAt
 }
 
-// Or returns value if set, or given parameter if does not.
-func (o OptUUID) Or(d uuid.UUID) uuid.UUID {
-	if v, ok := o.Get(); ok {
-		return v
-	}
-	return d
+// SetID sets the value of ID.
+func (s *SecurityEvent) SetID(val uuid.UUID) {
+	s.ID = val
 }
 
-type StartUserTrialBadRequest Error
+// SetAction sets the value of Action.
+func (s *SecurityEvent) SetAction(val string) {
+	s.Action = val
+}
+
+// SetByAdmin sets the value of ByAdmin.
+func (s *SecurityEvent) SetByAdmin(val bool) {
+	s.ByAdmin = val
+}
 
-func (*StartUserTrialBadRequest) startUserTrialRes() {}
+// SetIP sets the value of IP.
+func (s *SecurityEvent) SetIP(val OptNilString) {
+	s.IP = val
+}
 
-type StartUserTrialInternalServerError Error
+// SetUserAgent sets the value of UserAgent.
+func (s *SecurityEvent) SetUserAgent(val OptNilString) {
+	s.UserAgent = val
+}
 
-func (*StartUserTrialInternalServerError) startUserTrialRes() {}
+// SetMetadata sets the value of Metadata.
+func (s *SecurityEvent) SetMetadata(val AuditMetadata) {
+	s.Metadata = val
+}
 
-type StartUserTrialNotFound Error
+// SetCreatedAt sets the value of CreatedAt.
+func (s *SecurityEvent) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
 
-func (*StartUserTrialNotFound) startUserTrialRes() {}
+// StartOAuthFound is response for StartOAuth operation.
+type StartOAuthFound struct {
+	Location url.URL
+}
 
-// StartUserTrialOK is response for StartUserTrial operation.
-type StartUserTrialOK struct{}
+// GetLocation returns the value of Location.
+func (s *StartOAuthFound) GetLocation() url.URL {
+	return s.Location
+}
 
-func (*StartUserTrialOK) startUserTrialRes() {}
+// SetLocation sets the value of Location.
+func (s *StartOAuthFound) SetLocation(val url.URL) {
+	s.Location = val
+}
 
 // Ref: #/components/schemas/Streak
 type Streak struct {
-	RestDays    int       `json:"rest_days"`
-	Current     int       `json:"current"`
-	Longest     int       `json:"longest"`
-	LastWorkout time.Time `json:"last_workout"`
+	RestDays    int            `json:"rest_days"`
+	Current     int            `json:"current"`
+	Longest     int            `json:"longest"`
+	LastWorkout OptNilDateTime `json:"last_workout"`
 }
 
 // GetRestDays returns the value of RestDays.
@@ -844,7 +2595,7 @@ func (s *Streak) GetLongest() int {
 }
 
 // GetLastWorkout returns the value of LastWorkout.
-func (s *Streak) GetLastWorkout() time.Time {
+func (s *Streak) GetLastWorkout() OptNilDateTime {
 	return s.LastWorkout
 }
 
@@ -864,14 +2615,43 @@ func (s *Streak) SetLongest(val int) {
 }
 
 // SetLastWorkout sets the value of LastWorkout.
-func (s *Streak) SetLastWorkout(val time.Time) {
+func (s *Streak) SetLastWorkout(val OptNilDateTime) {
 	s.LastWorkout = val
 }
 
+type SuspendUserReq struct {
+	Reason string `json:"reason"`
+}
+
+// GetReason returns the value of Reason.
+func (s *SuspendUserReq) GetReason() string {
+	return s.Reason
+}
+
+// SetReason sets the value of Reason.
+func (s *SuspendUserReq) SetReason(val string) {
+	s.Reason = val
+}
+
+// Ref: #/components/schemas/TemporaryPassword
+type TemporaryPassword struct {
+	TemporaryPassword string `json:"temporary_password"`
+}
+
+// GetTemporaryPassword returns the value of TemporaryPassword.
+func (s *TemporaryPassword) GetTemporaryPassword() string {
+	return s.TemporaryPassword
+}
+
+// SetTemporaryPassword sets the value of TemporaryPassword.
+func (s *TemporaryPassword) SetTemporaryPassword(val string) {
+	s.TemporaryPassword = val
+}
+
 // Ref: #/components/schemas/Totals
 type Totals struct {
 	Workouts int `json:"workouts"`
-	// Total weight lifted in kg.
+	// Total weight lifted in the user's weight unit.
 	Lifted float64 `json:"lifted"`
 	// Total time in minutes.
 	Time int `json:"time"`
@@ -907,21 +2687,55 @@ func (s *Totals) SetTime(val int) {
 	s.Time = val
 }
 
-type UpdateUserBadRequest Error
+// UnlockAccountNoContent is response for UnlockAccount operation.
+type UnlockAccountNoContent struct{}
 
-func (*UpdateUserBadRequest) updateUserRes() {}
+type UpdateAdminUserReq struct {
+	Username  OptString `json:"username"`
+	FirstName OptString `json:"first_name"`
+	LastName  OptString `json:"last_name"`
+	Email     OptString `json:"email"`
+}
+
+// GetUsername returns the value of Username.
+func (s *UpdateAdminUserReq) GetUsername() OptString {
+	return s.Username
+}
+
+// GetFirstName returns the value of FirstName.
+func (s *UpdateAdminUserReq) GetFirstName() OptString {
+	return s.FirstName
+}
 
-type UpdateUserBodyMetricsBadRequest Error
+// GetLastName returns the value of LastName.
+func (s *UpdateAdminUserReq) GetLastName() OptString {
+	return s.LastName
+}
 
-func (*UpdateUserBodyMetricsBadRequest) updateUserBodyMetricsRes() {}
+// GetEmail returns the value of Email.
+func (s *UpdateAdminUserReq) GetEmail() OptString {
+	return s.Email
+}
 
-type UpdateUserBodyMetricsInternalServerError Error
+// SetUsername sets the value of Username.
+func (s *UpdateAdminUserReq) SetUsername(val OptString) {
+	s.Username = val
+}
 
-func (*UpdateUserBodyMetricsInternalServerError) updateUserBodyMetricsRes() {}
+// SetFirstName sets the value of FirstName.
+func (s *UpdateAdminUserReq) SetFirstName(val OptString) {
+	s.FirstName = val
+}
 
-type UpdateUserBodyMetricsNotFound Error
+// SetLastName sets the value of LastName.
+func (s *UpdateAdminUserReq) SetLastName(val OptString) {
+	s.LastName = val
+}
 
-func (*UpdateUserBodyMetricsNotFound) updateUserBodyMetricsRes() {}
+// SetEmail sets the value of Email.
+func (s *UpdateAdminUserReq) SetEmail(val OptString) {
+	s.Email = val
+}
 
 type UpdateUserBodyMetricsReq struct {
 	WeightValue OptFloat64 `json:"weight_value"`
@@ -959,31 +2773,47 @@ func (s *UpdateUserBodyMetricsReq) SetBfp(val OptFloat64) {
 	s.Bfp = val
 }
 
-type UpdateUserInternalServerError Error
-
-func (*UpdateUserInternalServerError) updateUserRes() {}
-
-type UpdateUserNotFound Error
-
-func (*UpdateUserNotFound) updateUserRes() {}
-
-type UpdateUserRecordPaymentBadRequest Error
-
-func (*UpdateUserRecordPaymentBadRequest) updateUserRecordPaymentRes() {}
+type UpdateUserRecordPaymentReq struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
 
-type UpdateUserRecordPaymentInternalServerError Error
+// GetAmount returns the value of Amount.
+func (s *UpdateUserRecordPaymentReq) GetAmount() float64 {
+	return s.Amount
+}
 
-func (*UpdateUserRecordPaymentInternalServerError) updateUserRecordPaymentRes() {}
+// GetCurrency returns the value of Currency.
+func (s *UpdateUserRecordPaymentReq) GetCurrency() string {
+	return s.Currency
+}
 
-type UpdateUserRecordPaymentNotFound Error
+// SetAmount sets the value of Amount.
+func (s *UpdateUserRecordPaymentReq) SetAmount(val float64) {
+	s.Amount = val
+}
 
-func (*UpdateUserRecordPaymentNotFound) updateUserRecordPaymentRes() {}
+// SetCurrency sets the value of Currency.
+func (s *UpdateUserRecordPaymentReq) SetCurrency(val string) {
+	s.Currency = val
+}
 
+// Fields left out are unchanged. The profile fields are cleared with null or an empty string.
 type UpdateUserReq struct {
 	Username  OptString `json:"username"`
 	FirstName OptString `json:"first_name"`
 	LastName  OptString `json:"last_name"`
 	Email     OptString `json:"email"`
+	// At most 500 characters.
+	Bio OptNilString `json:"bio"`
+	// A date such as 1990-04-21, users are between 13 and 120 years old.
+	BirthDate OptNilString `json:"birth_date"`
+	// Male or female, as used by body fat and calorie estimates.
+	Sex OptNilString `json:"sex"`
+	// Beginner, novice, intermediate, advanced or elite.
+	ExperienceLevel OptNilString `json:"experience_level"`
+	// At most 100 characters.
+	PreferredGym OptNilString `json:"preferred_gym"`
 }
 
 // GetUsername returns the value of Username.
@@ -1006,6 +2836,31 @@ func (s *UpdateUserReq) GetEmail() OptString {
 	return s.Email
 }
 
+// GetBio returns the value of Bio.
+func (s *UpdateUserReq) GetBio() OptNilString {
+	return s.Bio
+}
+
+// GetBirthDate returns the value of BirthDate.
+func (s *UpdateUserReq) GetBirthDate() OptNilString {
+	return s.BirthDate
+}
+
+// GetSex returns the value of Sex.
+func (s *UpdateUserReq) GetSex() OptNilString {
+	return s.Sex
+}
+
+// GetExperienceLevel returns the value of ExperienceLevel.
+func (s *UpdateUserReq) GetExperienceLevel() OptNilString {
+	return s.ExperienceLevel
+}
+
+// GetPreferredGym returns the value of PreferredGym.
+func (s *UpdateUserReq) GetPreferredGym() OptNilString {
+	return s.PreferredGym
+}
+
 // SetUsername sets the value of Username.
 func (s *UpdateUserReq) SetUsername(val OptString) {
 	s.Username = val
@@ -1026,17 +2881,30 @@ func (s *UpdateUserReq) SetEmail(val OptString) {
 	s.Email = val
 }
 
-type UpdateUserSettingsBadRequest Error
-
-func (*UpdateUserSettingsBadRequest) updateUserSettingsRes() {}
+// SetBio sets the value of Bio.
+func (s *UpdateUserReq) SetBio(val OptNilString) {
+	s.Bio = val
+}
 
-type UpdateUserSettingsInternalServerError Error
+// SetBirthDate sets the value of BirthDate.
+func (s *UpdateUserReq) SetBirthDate(val OptNilString) {
+	s.BirthDate = val
+}
 
-func (*UpdateUserSettingsInternalServerError) updateUserSettingsRes() {}
+// SetSex sets the value of Sex.
+func (s *UpdateUserReq) SetSex(val OptNilString) {
+	s.Sex = val
+}
 
-type UpdateUserSettingsNotFound Error
+// SetExperienceLevel sets the value of ExperienceLevel.
+func (s *UpdateUserReq) SetExperienceLevel(val OptNilString) {
+	s.ExperienceLevel = val
+}
 
-func (*UpdateUserSettingsNotFound) updateUserSettingsRes() {}
+// SetPreferredGym sets the value of PreferredGym.
+func (s *UpdateUserReq) SetPreferredGym(val OptNilString) {
+	s.PreferredGym = val
+}
 
 type UpdateUserSettingsReq struct {
 	WeightUnit      OptString `json:"weight_unit"`
@@ -1129,140 +2997,259 @@ func (s *UpdateUserSettingsReq) SetStreakReminder(val OptBool) {
 	s.StreakReminder = val
 }
 
-type UpgradeUserPlanBadRequest Error
-
-func (*UpgradeUserPlanBadRequest) upgradeUserPlanRes() {}
-
-type UpgradeUserPlanInternalServerError Error
-
-func (*UpgradeUserPlanInternalServerError) upgradeUserPlanRes() {}
-
-type UpgradeUserPlanNotFound Error
-
-func (*UpgradeUserPlanNotFound) upgradeUserPlanRes() {}
-
 type UpgradeUserPlanReq struct {
-	Plan          OptString `json:"plan"`
-	BillingPeriod OptString `json:"billing_period"`
+	Plan          string `json:"plan"`
+	BillingPeriod string `json:"billing_period"`
 }
 
 // GetPlan returns the value of Plan.
-func (s *UpgradeUserPlanReq) GetPlan() OptString {
+func (s *UpgradeUserPlanReq) GetPlan() string {
 	return s.Plan
 }
 
 // GetBillingPeriod returns the value of BillingPeriod.
-func (s *UpgradeUserPlanReq) GetBillingPeriod() OptString {
+func (s *UpgradeUserPlanReq) GetBillingPeriod() string {
 	return s.BillingPeriod
 }
 
 // SetPlan sets the value of Plan.
-func (s *UpgradeUserPlanReq) SetPlan(val OptString) {
+func (s *UpgradeUserPlanReq) SetPlan(val string) {
 	s.Plan = val
 }
 
 // SetBillingPeriod sets the value of BillingPeriod.
-func (s *UpgradeUserPlanReq) SetBillingPeriod(val OptString) {
+func (s *UpgradeUserPlanReq) SetBillingPeriod(val string) {
 	s.BillingPeriod = val
 }
 
+type UploadAvatarReq struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s UploadAvatarReq) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+// UploadAvatarReqWithContentType wraps UploadAvatarReq with Content-Type.
+type UploadAvatarReqWithContentType struct {
+	ContentType string
+	Content     UploadAvatarReq
+}
+
+// GetContentType returns the value of ContentType.
+func (s *UploadAvatarReqWithContentType) GetContentType() string {
+	return s.ContentType
+}
+
+// GetContent returns the value of Content.
+func (s *UploadAvatarReqWithContentType) GetContent() UploadAvatarReq {
+	return s.Content
+}
+
+// SetContentType sets the value of ContentType.
+func (s *UploadAvatarReqWithContentType) SetContentType(val string) {
+	s.ContentType = val
+}
+
+// SetContent sets the value of Content.
+func (s *UploadAvatarReqWithContentType) SetContent(val UploadAvatarReq) {
+	s.Content = val
+}
+
+type UploadProgressPhotoReq struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s UploadProgressPhotoReq) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+// UploadProgressPhotoReqWithContentType wraps UploadProgressPhotoReq with Content-Type.
+type UploadProgressPhotoReqWithContentType struct {
+	ContentType string
+	Content     UploadProgressPhotoReq
+}
+
+// GetContentType returns the value of ContentType.
+func (s *UploadProgressPhotoReqWithContentType) GetContentType() string {
+	return s.ContentType
+}
+
+// GetContent returns the value of Content.
+func (s *UploadProgressPhotoReqWithContentType) GetContent() UploadProgressPhotoReq {
+	return s.Content
+}
+
+// SetContentType sets the value of ContentType.
+func (s *UploadProgressPhotoReqWithContentType) SetContentType(val string) {
+	s.ContentType = val
+}
+
+// SetContent sets the value of Content.
+func (s *UploadProgressPhotoReqWithContentType) SetContent(val UploadProgressPhotoReq) {
+	s.Content = val
+}
+
 // Ref: #/components/schemas/User
 type User struct {
-	ID        OptUUID     `json:"id"`
-	Username  OptString   `json:"username"`
-	FirstName OptString   `json:"first_name"`
-	LastName  OptString   `json:"last_name"`
-	Email     OptString   `json:"email"`
-	Roles     []string    `json:"roles"`
-	CreatedAt OptDateTime `json:"created_at"`
-	UpdatedAt OptDateTime `json:"updated_at"`
+	ID              uuid.UUID                 `json:"id"`
+	Username        string                    `json:"username"`
+	Email           string                    `json:"email"`
+	FullName        string                    `json:"full_name"`
+	Roles           []string                  `json:"roles"`
+	Bio             OptNilString              `json:"bio"`
+	BirthDate       OptNilDate                `json:"birth_date"`
+	Sex             OptNilUserSex             `json:"sex"`
+	ExperienceLevel OptNilUserExperienceLevel `json:"experience_level"`
+	PreferredGym    OptNilString              `json:"preferred_gym"`
+	// Set when the user has uploaded an avatar.
+	AvatarURL OptNilString `json:"avatar_url"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
 }
 
 // GetID returns the value of ID.
-func (s *User) GetID() OptUUID {
+func (s *User) GetID() uuid.UUID {
 	return s.ID
 }
 
 // GetUsername returns the value of Username.
-func (s *User) GetUsername() OptString {
+func (s *User) GetUsername() string {
 	return s.Username
 }
 
-// GetFirstName returns the value of FirstName.
-func (s *User) GetFirstName() OptString {
-	return s.FirstName
-}
-
-// GetLastName returns the value of LastName.
-func (s *User) GetLastName() OptString {
-	return s.LastName
-}
-
 // GetEmail returns the value of Email.
-func (s *User) GetEmail() OptString {
+func (s *User) GetEmail() string {
 	return s.Email
 }
 
+// GetFullName returns the value of FullName.
+func (s *User) GetFullName() string {
+	return s.FullName
+}
+
 // GetRoles returns the value of Roles.
 func (s *User) GetRoles() []string {
 	return s.Roles
 }
 
+// GetBio returns the value of Bio.
+func (s *User) GetBio() OptNilString {
+	return s.Bio
+}
+
+// GetBirthDate returns the value of BirthDate.
+func (s *User) GetBirthDate() OptNilDate {
+	return s.BirthDate
+}
+
+// GetSex returns the value of Sex.
+func (s *User) GetSex() OptNilUserSex {
+	return s.Sex
+}
+
+// GetExperienceLevel returns the value of ExperienceLevel.
+func (s *User) GetExperienceLevel() OptNilUserExperienceLevel {
+	return s.ExperienceLevel
+}
+
+// GetPreferredGym returns the value of PreferredGym.
+func (s *User) GetPreferredGym() OptNilString {
+	return s.PreferredGym
+}
+
+// GetAvatarURL returns the value of AvatarURL.
+func (s *User) GetAvatarURL() OptNilString {
+	return s.AvatarURL
+}
+
 // GetCreatedAt returns the value of CreatedAt.
-func (s *User) GetCreatedAt() OptDateTime {
+func (s *User) GetCreatedAt() time.Time {
 	return s.CreatedAt
 }
 
 // GetUpdatedAt returns the value of UpdatedAt.
-func (s *User) GetUpdatedAt() OptDateTime {
+func (s *User) GetUpdatedAt() time.Time {
 	return s.UpdatedAt
 }
 
 // SetID sets the value of ID.
-func (s *User) SetID(val OptUUID) {
+func (s *User) SetID(val uuid.UUID) {
 	s.ID = val
 }
 
 // SetUsername sets the value of Username.
-func (s *User) SetUsername(val OptString) {
+func (s *User) SetUsername(val string) {
 	s.Username = val
 }
 
-// SetFirstName sets the value of FirstName.
-func (s *User) SetFirstName(val OptString) {
-	s.FirstName = val
-}
-
-// SetLastName sets the value of LastName.
-func (s *User) SetLastName(val OptString) {
-	s.LastName = val
-}
-
 // SetEmail sets the value of Email.
-func (s *User) SetEmail(val OptString) {
+func (s *User) SetEmail(val string) {
 	s.Email = val
 }
 
+// SetFullName sets the value of FullName.
+func (s *User) SetFullName(val string) {
+	s.FullName = val
+}
+
 // SetRoles sets the value of Roles.
 func (s *User) SetRoles(val []string) {
 	s.Roles = val
 }
 
+// SetBio sets the value of Bio.
+func (s *User) SetBio(val OptNilString) {
+	s.Bio = val
+}
+
+// SetBirthDate sets the value of BirthDate.
+func (s *User) SetBirthDate(val OptNilDate) {
+	s.BirthDate = val
+}
+
+// SetSex sets the value of Sex.
+func (s *User) SetSex(val OptNilUserSex) {
+	s.Sex = val
+}
+
+// SetExperienceLevel sets the value of ExperienceLevel.
+func (s *User) SetExperienceLevel(val OptNilUserExperienceLevel) {
+	s.ExperienceLevel = val
+}
+
+// SetPreferredGym sets the value of PreferredGym.
+func (s *User) SetPreferredGym(val OptNilString) {
+	s.PreferredGym = val
+}
+
+// SetAvatarURL sets the value of AvatarURL.
+func (s *User) SetAvatarURL(val OptNilString) {
+	s.AvatarURL = val
+}
+
 // SetCreatedAt sets the value of CreatedAt.
-func (s *User) SetCreatedAt(val OptDateTime) {
+func (s *User) SetCreatedAt(val time.Time) {
 	s.CreatedAt = val
 }
 
 // SetUpdatedAt sets the value of UpdatedAt.
-func (s *User) SetUpdatedAt(val OptDateTime) {
+func (s *User) SetUpdatedAt(val time.Time) {
 	s.UpdatedAt = val
 }
 
-func (*User) getUserByEmailRes()    {}
-func (*User) getUserByIDRes()       {}
-func (*User) getUserByUsernameRes() {}
-func (*User) updateUserRes()        {}
-
 // Ref: #/components/schemas/UserBodyMetrics
 type UserBodyMetrics struct {
 	Weight OptNilFloat64 `json:"weight"`
@@ -1300,190 +3287,247 @@ func (s *UserBodyMetrics) SetBfp(val OptNilFloat64) {
 	s.Bfp = val
 }
 
-func (*UserBodyMetrics) updateUserBodyMetricsRes() {}
-
-// Ref: #/components/schemas/UserPayment
-type UserPayment struct {
-	Amount   OptFloat64 `json:"amount"`
-	Currency OptString  `json:"currency"`
-}
-
-// GetAmount returns the value of Amount.
-func (s *UserPayment) GetAmount() OptFloat64 {
-	return s.Amount
-}
-
-// GetCurrency returns the value of Currency.
-func (s *UserPayment) GetCurrency() OptString {
-	return s.Currency
-}
+type UserExperienceLevel string
 
-// SetAmount sets the value of Amount.
-func (s *UserPayment) SetAmount(val OptFloat64) {
-	s.Amount = val
-}
+const (
+	UserExperienceLevelBeginner     UserExperienceLevel = "beginner"
+	UserExperienceLevelNovice       UserExperienceLevel = "novice"
+	UserExperienceLevelIntermediate UserExperienceLevel = "intermediate"
+	UserExperienceLevelAdvanced     UserExperienceLevel = "advanced"
+	UserExperienceLevelElite        UserExperienceLevel = "elite"
+)
 
-// SetCurrency sets the value of Currency.
-func (s *UserPayment) SetCurrency(val OptString) {
-	s.Currency = val
+// AllValues returns all UserExperienceLevel values.
+func (UserExperienceLevel) AllValues() []UserExperienceLevel {
+	return []UserExperienceLevel{
+		UserExperienceLevelBeginner,
+		UserExperienceLevelNovice,
+		UserExperienceLevelIntermediate,
+		UserExperienceLevelAdvanced,
+		UserExperienceLevelElite,
+	}
 }
 
-func (*UserPayment) updateUserRecordPaymentRes() {}
-
-// Ref: #/components/schemas/UserPlan
-type UserPlan struct {
-	Plan          OptString `json:"plan"`
-	BillingPeriod OptString `json:"billing_period"`
+// MarshalText implements encoding.TextMarshaler.
+func (s UserExperienceLevel) MarshalText() ([]byte, error) {
+	switch s {
+	case UserExperienceLevelBeginner:
+		return []byte(s), nil
+	case UserExperienceLevelNovice:
+		return []byte(s), nil
+	case UserExperienceLevelIntermediate:
+		return []byte(s), nil
+	case UserExperienceLevelAdvanced:
+		return []byte(s), nil
+	case UserExperienceLevelElite:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
 }
 
-// GetPlan returns the value of Plan.
-func (s *UserPlan) GetPlan() OptString {
-	return s.Plan
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *UserExperienceLevel) UnmarshalText(data []byte) error {
+	switch UserExperienceLevel(data) {
+	case UserExperienceLevelBeginner:
+		*s = UserExperienceLevelBeginner
+		return nil
+	case UserExperienceLevelNovice:
+		*s = UserExperienceLevelNovice
+		return nil
+	case UserExperienceLevelIntermediate:
+		*s = UserExperienceLevelIntermediate
+		return nil
+	case UserExperienceLevelAdvanced:
+		*s = UserExperienceLevelAdvanced
+		return nil
+	case UserExperienceLevelElite:
+		*s = UserExperienceLevelElite
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
 }
 
-// GetBillingPeriod returns the value of BillingPeriod.
-func (s *UserPlan) GetBillingPeriod() OptString {
-	return s.BillingPeriod
+// Ref: #/components/schemas/UserRoles
+type UserRoles struct {
+	Roles []string `json:"roles"`
 }
 
-// SetPlan sets the value of Plan.
-func (s *UserPlan) SetPlan(val OptString) {
-	s.Plan = val
+// GetRoles returns the value of Roles.
+func (s *UserRoles) GetRoles() []string {
+	return s.Roles
 }
 
-// SetBillingPeriod sets the value of BillingPeriod.
-func (s *UserPlan) SetBillingPeriod(val OptString) {
-	s.BillingPeriod = val
+// SetRoles sets the value of Roles.
+func (s *UserRoles) SetRoles(val []string) {
+	s.Roles = val
 }
 
-func (*UserPlan) upgradeUserPlanRes() {}
-
 // Ref: #/components/schemas/UserSettings
 type UserSettings struct {
-	WeightUnit      OptFloat64  `json:"weight_unit"`
-	HeightUnit      OptFloat64  `json:"height_unit"`
-	Theme           OptString   `json:"theme"`
-	Visibility      OptString   `json:"visibility"`
-	EmailNotif      OptBool     `json:"email_notif"`
-	PushNotif       OptBool     `json:"push_notif"`
-	WorkoutReminder OptBool     `json:"workout_reminder"`
-	StreakReminder  OptBool     `json:"streak_reminder"`
-	CreatedAt       OptDateTime `json:"created_at"`
-	UpdatedAt       OptDateTime `json:"updated_at"`
+	WeightUnit      string    `json:"weight_unit"`
+	HeightUnit      string    `json:"height_unit"`
+	Theme           string    `json:"theme"`
+	Visibility      string    `json:"visibility"`
+	EmailNotif      bool      `json:"email_notif"`
+	PushNotif       bool      `json:"push_notif"`
+	WorkoutReminder bool      `json:"workout_reminder"`
+	StreakReminder  bool      `json:"streak_reminder"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
 }
 
 // GetWeightUnit returns the value of WeightUnit.
-func (s *UserSettings) GetWeightUnit() OptFloat64 {
+func (s *UserSettings) GetWeightUnit() string {
 	return s.WeightUnit
 }
 
 // GetHeightUnit returns the value of HeightUnit.
-func (s *UserSettings) GetHeightUnit() OptFloat64 {
+func (s *UserSettings) GetHeightUnit() string {
 	return s.HeightUnit
 }
 
 // GetTheme returns the value of Theme.
-func (s *UserSettings) GetTheme() OptString {
+func (s *UserSettings) GetTheme() string {
 	return s.Theme
 }
 
 // GetVisibility returns the value of Visibility.
-func (s *UserSettings) GetVisibility() OptString {
+func (s *UserSettings) GetVisibility() string {
 	return s.Visibility
 }
 
 // GetEmailNotif returns the value of EmailNotif.
-func (s *UserSettings) GetEmailNotif() OptBool {
+func (s *UserSettings) GetEmailNotif() bool {
 	return s.EmailNotif
 }
 
 // GetPushNotif returns the value of PushNotif.
-func (s *UserSettings) GetPushNotif() OptBool {
+func (s *UserSettings) GetPushNotif() bool {
 	return s.PushNotif
 }
 
 // GetWorkoutReminder returns the value of WorkoutReminder.
-func (s *UserSettings) GetWorkoutReminder() OptBool {
+func (s *UserSettings) GetWorkoutReminder() bool {
 	return s.WorkoutReminder
 }
 
 // GetStreakReminder returns the value of StreakReminder.
-func (s *UserSettings) GetStreakReminder() OptBool {
+func (s *UserSettings) GetStreakReminder() bool {
 	return s.StreakReminder
 }
 
 // GetCreatedAt returns the value of CreatedAt.
-func (s *UserSettings) GetCreatedAt() OptDateTime {
+func (s *UserSettings) GetCreatedAt() time.Time {
 	return s.CreatedAt
 }
 
 // GetUpdatedAt returns the value of UpdatedAt.
-func (s *UserSettings) GetUpdatedAt() OptDateTime {
+func (s *UserSettings) GetUpdatedAt() time.Time {
 	return s.UpdatedAt
 }
 
 // SetWeightUnit sets the value of WeightUnit.
-func (s *UserSettings) SetWeightUnit(val OptFloat64) {
+func (s *UserSettings) SetWeightUnit(val string) {
 	s.WeightUnit = val
 }
 
 // SetHeightUnit sets the value of HeightUnit.
-func (s *UserSettings) SetHeightUnit(val OptFloat64) {
+func (s *UserSettings) SetHeightUnit(val string) {
 	s.HeightUnit = val
 }
 
 // SetTheme sets the value of Theme.
-func (s *UserSettings) SetTheme(val OptString) {
+func (s *UserSettings) SetTheme(val string) {
 	s.Theme = val
 }
 
 // SetVisibility sets the value of Visibility.
-func (s *UserSettings) SetVisibility(val OptString) {
+func (s *UserSettings) SetVisibility(val string) {
 	s.Visibility = val
 }
 
 // SetEmailNotif sets the value of EmailNotif.
-func (s *UserSettings) SetEmailNotif(val OptBool) {
+func (s *UserSettings) SetEmailNotif(val bool) {
 	s.EmailNotif = val
 }
 
 // SetPushNotif sets the value of PushNotif.
-func (s *UserSettings) SetPushNotif(val OptBool) {
+func (s *UserSettings) SetPushNotif(val bool) {
 	s.PushNotif = val
 }
 
 // SetWorkoutReminder sets the value of WorkoutReminder.
-func (s *UserSettings) SetWorkoutReminder(val OptBool) {
+func (s *UserSettings) SetWorkoutReminder(val bool) {
 	s.WorkoutReminder = val
 }
 
 // SetStreakReminder sets the value of StreakReminder.
-func (s *UserSettings) SetStreakReminder(val OptBool) {
+func (s *UserSettings) SetStreakReminder(val bool) {
 	s.StreakReminder = val
 }
 
 // SetCreatedAt sets the value of CreatedAt.
-func (s *UserSettings) SetCreatedAt(val OptDateTime) {
+func (s *UserSettings) SetCreatedAt(val time.Time) {
 	s.CreatedAt = val
 }
 
 // SetUpdatedAt sets the value of UpdatedAt.
-func (s *UserSettings) SetUpdatedAt(val OptDateTime) {
+func (s *UserSettings) SetUpdatedAt(val time.Time) {
 	s.UpdatedAt = val
 }
 
-func (*UserSettings) getUserSettingsRes()    {}
-func (*UserSettings) updateUserSettingsRes() {}
+type UserSex string
+
+const (
+	UserSexMale   UserSex = "male"
+	UserSexFemale UserSex = "female"
+)
+
+// AllValues returns all UserSex values.
+func (UserSex) AllValues() []UserSex {
+	return []UserSex{
+		UserSexMale,
+		UserSexFemale,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s UserSex) MarshalText() ([]byte, error) {
+	switch s {
+	case UserSexMale:
+		return []byte(s), nil
+	case UserSexFemale:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *UserSex) UnmarshalText(data []byte) error {
+	switch UserSex(data) {
+	case UserSexMale:
+		*s = UserSexMale
+		return nil
+	case UserSexFemale:
+		*s = UserSexFemale
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
 
 // Ref: #/components/schemas/UserStats
 type UserStats struct {
 	Weight    OptNilFloat64 `json:"weight"`
 	Height    OptNilFloat64 `json:"height"`
 	Bfp       OptNilFloat64 `json:"bfp"`
-	Streak    OptStreak     `json:"streak"`
-	Totals    OptTotals     `json:"totals"`
-	CreatedAt OptDateTime   `json:"created_at"`
-	UpdatedAt OptDateTime   `json:"updated_at"`
+	Streak    Streak        `json:"streak"`
+	Totals    Totals        `json:"totals"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
 }
 
 // GetWeight returns the value of Weight.
@@ -1502,22 +3546,22 @@ func (s *UserStats) GetBfp() OptNilFloat64 {
 }
 
 // GetStreak returns the value of Streak.
-func (s *UserStats) GetStreak() OptStreak {
+func (s *UserStats) GetStreak() Streak {
 	return s.Streak
 }
 
 // GetTotals returns the value of Totals.
-func (s *UserStats) GetTotals() OptTotals {
+func (s *UserStats) GetTotals() Totals {
 	return s.Totals
 }
 
 // GetCreatedAt returns the value of CreatedAt.
-func (s *UserStats) GetCreatedAt() OptDateTime {
+func (s *UserStats) GetCreatedAt() time.Time {
 	return s.CreatedAt
 }
 
 // GetUpdatedAt returns the value of UpdatedAt.
-func (s *UserStats) GetUpdatedAt() OptDateTime {
+func (s *UserStats) GetUpdatedAt() time.Time {
 	return s.UpdatedAt
 }
 
@@ -1537,45 +3581,43 @@ func (s *UserStats) SetBfp(val OptNilFloat64) {
 }
 
 // SetStreak sets the value of Streak.
-func (s *UserStats) SetStreak(val OptStreak) {
+func (s *UserStats) SetStreak(val Streak) {
 	s.Streak = val
 }
 
 // SetTotals sets the value of Totals.
-func (s *UserStats) SetTotals(val OptTotals) {
+func (s *UserStats) SetTotals(val Totals) {
 	s.Totals = val
 }
 
 // SetCreatedAt sets the value of CreatedAt.
-func (s *UserStats) SetCreatedAt(val OptDateTime) {
+func (s *UserStats) SetCreatedAt(val time.Time) {
 	s.CreatedAt = val
 }
 
 // SetUpdatedAt sets the value of UpdatedAt.
-func (s *UserStats) SetUpdatedAt(val OptDateTime) {
+func (s *UserStats) SetUpdatedAt(val time.Time) {
 	s.UpdatedAt = val
 }
 
-func (*UserStats) getUserStatsRes() {}
-
 // Ref: #/components/schemas/UserSubscription
 type UserSubscription struct {
-	Plan                OptString      `json:"plan"`
+	Plan                string         `json:"plan"`
 	BillingPeriod       OptNilString   `json:"billing_period"`
-	StartedAt           OptDateTime    `json:"started_at"`
+	StartedAt           time.Time      `json:"started_at"`
 	ExpiresAt           OptNilDateTime `json:"expires_at"`
-	AutoRenew           OptBool        `json:"auto_renew"`
+	AutoRenew           bool           `json:"auto_renew"`
 	CancelledAt         OptNilDateTime `json:"cancelled_at"`
 	LastPaymentAt       OptNilDateTime `json:"last_payment_at"`
 	LastPaymentAmount   OptNilFloat64  `json:"last_payment_amount"`
 	LastPaymentCurrency OptNilString   `json:"last_payment_currency"`
 	TrialEndsAt         OptNilDateTime `json:"trial_ends_at"`
-	CreatedAt           OptDateTime    `json:"created_at"`
-	UpdatedAt           OptDateTime    `json:"updated_at"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
 }
 
 // GetPlan returns the value of Plan.
-func (s *UserSubscription) GetPlan() OptString {
+func (s *UserSubscription) GetPlan() string {
 	return s.Plan
 }
 
@@ -1585,7 +3627,7 @@ func (s *UserSubscription) GetBillingPeriod() OptNilString {
 }
 
 // GetStartedAt returns the value of StartedAt.
-func (s *UserSubscription) GetStartedAt() OptDateTime {
+func (s *UserSubscription) GetStartedAt() time.Time {
 	return s.StartedAt
 }
 
@@ -1595,7 +3637,7 @@ func (s *UserSubscription) GetExpiresAt() OptNilDateTime {
 }
 
 // GetAutoRenew returns the value of AutoRenew.
-func (s *UserSubscription) GetAutoRenew() OptBool {
+func (s *UserSubscription) GetAutoRenew() bool {
 	return s.AutoRenew
 }
 
@@ -1625,17 +3667,17 @@ func (s *UserSubscription) GetTrialEndsAt() OptNilDateTime {
 }
 
 // GetCreatedAt returns the value of CreatedAt.
-func (s *UserSubscription) GetCreatedAt() OptDateTime {
+func (s *UserSubscription) GetCreatedAt() time.Time {
 	return s.CreatedAt
 }
 
 // GetUpdatedAt returns the value of UpdatedAt.
-func (s *UserSubscription) GetUpdatedAt() OptDateTime {
+func (s *UserSubscription) GetUpdatedAt() time.Time {
 	return s.UpdatedAt
 }
 
 // SetPlan sets the value of Plan.
-func (s *UserSubscription) SetPlan(val OptString) {
+func (s *UserSubscription) SetPlan(val string) {
 	s.Plan = val
 }
 
@@ -1645,7 +3687,7 @@ func (s *UserSubscription) SetBillingPeriod(val OptNilString) {
 }
 
 // SetStartedAt sets the value of StartedAt.
-func (s *UserSubscription) SetStartedAt(val OptDateTime) {
+func (s *UserSubscription) SetStartedAt(val time.Time) {
 	s.StartedAt = val
 }
 
@@ -1655,7 +3697,7 @@ func (s *UserSubscription) SetExpiresAt(val OptNilDateTime) {
 }
 
 // SetAutoRenew sets the value of AutoRenew.
-func (s *UserSubscription) SetAutoRenew(val OptBool) {
+func (s *UserSubscription) SetAutoRenew(val bool) {
 	s.AutoRenew = val
 }
 
@@ -1685,13 +3727,11 @@ func (s *UserSubscription) SetTrialEndsAt(val OptNilDateTime) {
 }
 
 // SetCreatedAt sets the value of CreatedAt.
-func (s *UserSubscription) SetCreatedAt(val OptDateTime) {
+func (s *UserSubscription) SetCreatedAt(val time.Time) {
 	s.CreatedAt = val
 }
 
 // SetUpdatedAt sets the value of UpdatedAt.
-func (s *UserSubscription) SetUpdatedAt(val OptDateTime) {
+func (s *UserSubscription) SetUpdatedAt(val time.Time) {
 	s.UpdatedAt = val
 }
-
-func (*UserSubscription) getUserSubscriptionRes() {}
@@ -13,6 +13,12 @@ import (
 
 // SecurityHandler is handler for security parameters.
 type SecurityHandler interface {
+	// HandleApiKeyAuth handles apiKeyAuth security.
+	// Personal API key sent as `Authorization: ApiKey <key>`. Keys may only call operations that list
+	// the scopes they need.
+	HandleApiKeyAuth(ctx context.Context, operationName OperationName, t ApiKeyAuth) (context.Context, error)
+	// HandleBearerAuth handles bearerAuth security.
+	HandleBearerAuth(ctx context.Context, operationName OperationName, t BearerAuth) (context.Context, error)
 	// HandleCookieAuth handles cookieAuth security.
 	// Session cookie containing JWT access token.
 	HandleCookieAuth(ctx context.Context, operationName OperationName, t CookieAuth) (context.Context, error)
@@ -33,8 +39,148 @@ func findAuthorization(h http.Header, prefix string) (string, bool) {
 	return "", false
 }
 
+var operationRolesApiKeyAuth = map[string][]string{
+	GetMeOperation: []string{
+		"profile:read",
+		"stats:read",
+	},
+	GetUserByIDOperation: []string{
+		"profile:read",
+	},
+	GetUserSettingsOperation: []string{
+		"profile:read",
+	},
+	GetUserStatsOperation: []string{
+		"stats:read",
+	},
+	UpdateUserBodyMetricsOperation: []string{
+		"stats:write",
+	},
+}
+
+func (s *Server) securityApiKeyAuth(ctx context.Context, operationName OperationName, req *http.Request) (context.Context, bool, error) {
+	var t ApiKeyAuth
+	const parameterName = "Authorization"
+	value := req.Header.Get(parameterName)
+	if value == "" {
+		return ctx, false, nil
+	}
+	t.APIKey = value
+	t.Roles = operationRolesApiKeyAuth[operationName]
+	rctx, err := s.sec.HandleApiKeyAuth(ctx, operationName, t)
+	if errors.Is(err, ogenerrors.ErrSkipServerSecurity) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return rctx, true, err
+}
+
+var operationRolesBearerAuth = map[string][]string{
+	CancelUserSubscriptionOperation:  []string{},
+	CreateAPIKeyOperation:            []string{},
+	DeleteAvatarOperation:            []string{},
+	DeleteProgressPhotoOperation:     []string{},
+	DeleteUserOperation:              []string{},
+	DownloadDataExportOperation:      []string{},
+	ForceLogoutUserOperation:         []string{},
+	GetAdminUserOperation:            []string{},
+	GetAvatarOperation:               []string{},
+	GetDataExportOperation:           []string{},
+	GetMeOperation:                   []string{},
+	GetProgressPhotoImageOperation:   []string{},
+	GetSecurityActivityOperation:     []string{},
+	GetUserByEmailOperation:          []string{},
+	GetUserByIDOperation:             []string{},
+	GetUserByUsernameOperation:       []string{},
+	GetUserSettingsOperation:         []string{},
+	GetUserStatsOperation:            []string{},
+	GetUserSubscriptionOperation:     []string{},
+	GrantUserRoleOperation:           []string{},
+	ListAPIKeysOperation:             []string{},
+	ListIdentitiesOperation:          []string{},
+	ListProgressPhotosOperation:      []string{},
+	LogoutOperation:                  []string{},
+	RequestDataExportOperation:       []string{},
+	ResetUserPasswordOperation:       []string{},
+	RevokeAPIKeyOperation:            []string{},
+	RevokeUserRoleOperation:          []string{},
+	SearchAuditEventsOperation:       []string{},
+	SearchUsersOperation:             []string{},
+	StartUserTrialOperation:          []string{},
+	SuspendUserOperation:             []string{},
+	UnsuspendUserOperation:           []string{},
+	UpdateAdminUserOperation:         []string{},
+	UpdateUserOperation:              []string{},
+	UpdateUserBodyMetricsOperation:   []string{},
+	UpdateUserRecordPaymentOperation: []string{},
+	UpdateUserSettingsOperation:      []string{},
+	UpgradeUserPlanOperation:         []string{},
+	UploadAvatarOperation:            []string{},
+	UploadProgressPhotoOperation:     []string{},
+	VerifyUserEmailOperation:         []string{},
+}
+
+func (s *Server) securityBearerAuth(ctx context.Context, operationName OperationName, req *http.Request) (context.Context, bool, error) {
+	var t BearerAuth
+	token, ok := findAuthorization(req.Header, "Bearer")
+	if !ok {
+		return ctx, false, nil
+	}
+	t.Token = token
+	t.Roles = operationRolesBearerAuth[operationName]
+	rctx, err := s.sec.HandleBearerAuth(ctx, operationName, t)
+	if errors.Is(err, ogenerrors.ErrSkipServerSecurity) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return rctx, true, err
+}
+
 var operationRolesCookieAuth = map[string][]string{
-	GetUserByIDOperation: []string{},
+	CancelUserSubscriptionOperation:  []string{},
+	CreateAPIKeyOperation:            []string{},
+	DeleteAvatarOperation:            []string{},
+	DeleteProgressPhotoOperation:     []string{},
+	DeleteUserOperation:              []string{},
+	DownloadDataExportOperation:      []string{},
+	ForceLogoutUserOperation:         []string{},
+	GetAdminUserOperation:            []string{},
+	GetAvatarOperation:               []string{},
+	GetDataExportOperation:           []string{},
+	GetMeOperation:                   []string{},
+	GetProgressPhotoImageOperation:   []string{},
+	GetSecurityActivityOperation:     []string{},
+	GetUserByEmailOperation:          []string{},
+	GetUserByIDOperation:             []string{},
+	GetUserByUsernameOperation:       []string{},
+	GetUserSettingsOperation:         []string{},
+	GetUserStatsOperation:            []string{},
+	GetUserSubscriptionOperation:     []string{},
+	GrantUserRoleOperation:           []string{},
+	ListAPIKeysOperation:             []string{},
+	ListIdentitiesOperation:          []string{},
+	ListProgressPhotosOperation:      []string{},
+	LogoutOperation:                  []string{},
+	RequestDataExportOperation:       []string{},
+	ResetUserPasswordOperation:       []string{},
+	RevokeAPIKeyOperation:            []string{},
+	RevokeUserRoleOperation:          []string{},
+	SearchAuditEventsOperation:       []string{},
+	SearchUsersOperation:             []string{},
+	StartUserTrialOperation:          []string{},
+	SuspendUserOperation:             []string{},
+	UnsuspendUserOperation:           []string{},
+	UpdateAdminUserOperation:         []string{},
+	UpdateUserOperation:              []string{},
+	UpdateUserBodyMetricsOperation:   []string{},
+	UpdateUserRecordPaymentOperation: []string{},
+	UpdateUserSettingsOperation:      []string{},
+	UpgradeUserPlanOperation:         []string{},
+	UploadAvatarOperation:            []string{},
+	UploadProgressPhotoOperation:     []string{},
+	VerifyUserEmailOperation:         []string{},
 }
 
 func (s *Server) securityCookieAuth(ctx context.Context, operationName OperationName, req *http.Request) (context.Context, bool, error) {
@@ -62,11 +208,33 @@ func (s *Server) securityCookieAuth(ctx context.Context, operationName Operation
 
 // SecuritySource is provider of security values (tokens, passwords, etc.).
 type SecuritySource interface {
+	// ApiKeyAuth provides apiKeyAuth security value.
+	// Personal API key sent as `Authorization: ApiKey <key>`. Keys may only call operations that list
+	// the scopes they need.
+	ApiKeyAuth(ctx context.Context, operationName OperationName) (ApiKeyAuth, error)
+	// BearerAuth provides bearerAuth security value.
+	BearerAuth(ctx context.Context, operationName OperationName) (BearerAuth, error)
 	// CookieAuth provides cookieAuth security value.
 	// Session cookie containing JWT access token.
 	CookieAuth(ctx context.Context, operationName OperationName) (CookieAuth, error)
 }
 
+func (s *Client) securityApiKeyAuth(ctx context.Context, operationName OperationName, req *http.Request) error {
+	t, err := s.sec.ApiKeyAuth(ctx, operationName)
+	if err != nil {
+		return errors.Wrap(err, "security source \"ApiKeyAuth\"")
+	}
+	req.Header.Set("Authorization", t.APIKey)
+	return nil
+}
+func (s *Client) securityBearerAuth(ctx context.Context, operationName OperationName, req *http.Request) error {
+	t, err := s.sec.BearerAuth(ctx, operationName)
+	if err != nil {
+		return errors.Wrap(err, "security source \"BearerAuth\"")
+	}
+	req.Header.Set("Authorization", "Bearer "+t.Token)
+	return nil
+}
 func (s *Client) securityCookieAuth(ctx context.Context, operationName OperationName, req *http.Request) error {
 	t, err := s.sec.CookieAuth(ctx, operationName)
 	if err != nil {
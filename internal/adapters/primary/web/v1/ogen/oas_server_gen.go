@@ -13,97 +13,335 @@ type Handler interface {
 	// Cancel user subscription.
 	//
 	// PUT /user/subscription/cancel
-	CancelUserSubscription(ctx context.Context) (CancelUserSubscriptionRes, error)
+	CancelUserSubscription(ctx context.Context) (*UserSubscription, error)
+	// CreateAPIKey implements createAPIKey operation.
+	//
+	// The plaintext key is only returned once.
+	//
+	// POST /user/api-keys
+	CreateAPIKey(ctx context.Context, req *CreateAPIKeyReq) (*CreateAPIKeyCreated, error)
 	// CreateUser implements createUser operation.
 	//
-	// Create a new user account.
+	// New accounts hold the `user` role, other roles are granted by an admin.
 	//
 	// POST /user
-	CreateUser(ctx context.Context, req *CreateUserReq) (CreateUserRes, error)
+	CreateUser(ctx context.Context, req *CreateUserReq) (*CreateUserCreated, error)
+	// DeleteAvatar implements deleteAvatar operation.
+	//
+	// Delete your avatar.
+	//
+	// DELETE /user/avatar
+	DeleteAvatar(ctx context.Context) error
+	// DeleteProgressPhoto implements deleteProgressPhoto operation.
+	//
+	// Delete a progress photo.
+	//
+	// DELETE /user/progress-photos/{id}
+	DeleteProgressPhoto(ctx context.Context, params DeleteProgressPhotoParams) error
 	// DeleteUser implements deleteUser operation.
 	//
-	// Delete user.
+	// Schedules the account for deletion and signs the user out. The account is purged with all its data
+	// after 30 days, until then logging in with `restore` brings it back.
 	//
 	// DELETE /user
-	DeleteUser(ctx context.Context) (DeleteUserRes, error)
+	DeleteUser(ctx context.Context) error
+	// DownloadDataExport implements downloadDataExport operation.
+	//
+	// A zip of JSON and CSV files. The link only works for the account that requested the export, until
+	// `expires_at`.
+	//
+	// GET /user/exports/{id}/archive
+	DownloadDataExport(ctx context.Context, params DownloadDataExportParams) (*DownloadDataExportOKHeaders, error)
+	// ForceLogoutUser implements forceLogoutUser operation.
+	//
+	// Requires the `users:manage` permission. Revokes every refresh token, access tokens already issued
+	// stay valid until they expire.
+	//
+	// DELETE /admin/users/{id}/sessions
+	ForceLogoutUser(ctx context.Context, params ForceLogoutUserParams) error
+	// GetAdminUser implements getAdminUser operation.
+	//
+	// Requires the `users:read_any` permission.
+	//
+	// GET /admin/users/{id}
+	GetAdminUser(ctx context.Context, params GetAdminUserParams) (*AdminUserDetail, error)
+	// GetAvatar implements getAvatar operation.
+	//
+	// Avatars are visible to every signed in user.
+	//
+	// GET /user/{id}/avatar
+	GetAvatar(ctx context.Context, params GetAvatarParams) (*ImageHeaders, error)
+	// GetCSRFToken implements getCSRFToken operation.
+	//
+	// Returns the CSRF token and sets it in the `csrf_token` cookie, keeping the current token when the
+	// cookie is already set. Browsers must send it back in the `X-CSRF-Token` header on every POST, PUT,
+	// PATCH and DELETE made with the session cookies. Requests authenticated with the `Authorization`
+	// header are exempt.
+	//
+	// GET /auth/csrf
+	GetCSRFToken(ctx context.Context, params GetCSRFTokenParams) (*CSRFToken, error)
+	// GetDataExport implements getDataExport operation.
+	//
+	// Get an export of your data.
+	//
+	// GET /user/exports/{id}
+	GetDataExport(ctx context.Context, params GetDataExportParams) (*DataExport, error)
+	// GetMe implements getMe operation.
+	//
+	// The user, stats, subscription and settings in one response. Weight and height are in the units of
+	// the settings.
+	//
+	// GET /user/me
+	GetMe(ctx context.Context) (*FullProfile, error)
+	// GetProgressPhotoImage implements getProgressPhotoImage operation.
+	//
+	// Get a progress photo.
+	//
+	// GET /user/progress-photos/{id}/image
+	GetProgressPhotoImage(ctx context.Context, params GetProgressPhotoImageParams) (*ImageHeaders, error)
+	// GetSecurityActivity implements getSecurityActivity operation.
+	//
+	// Sign ins, failed logins, ended sessions and admin actions on the account, newest first. Where an
+	// admin acted, their address and user agent are left out.
+	//
+	// GET /user/security-activity
+	GetSecurityActivity(ctx context.Context, params GetSecurityActivityParams) ([]SecurityEvent, error)
 	// GetUserByEmail implements getUserByEmail operation.
 	//
-	// Get user by email.
+	// Requires the `users:read_any` permission.
 	//
 	// GET /user/email/{email}
-	GetUserByEmail(ctx context.Context, params GetUserByEmailParams) (GetUserByEmailRes, error)
+	GetUserByEmail(ctx context.Context, params GetUserByEmailParams) (*User, error)
 	// GetUserByID implements getUserByID operation.
 	//
-	// Get user by ID.
+	// Get the signed in user.
 	//
 	// GET /user
-	GetUserByID(ctx context.Context) (GetUserByIDRes, error)
+	GetUserByID(ctx context.Context) (*User, error)
 	// GetUserByUsername implements getUserByUsername operation.
 	//
-	// Get user by username.
+	// Returns the public profile of a user. Bio, experience level and preferred gym are only included
+	// while the user's profile visibility is public. Email, birth date and sex are never included.
+	// Usernames given up in the last 180 days still resolve to their owner. The response then carries
+	// the current username, clients compare it to follow the rename.
 	//
 	// GET /user/username/{username}
-	GetUserByUsername(ctx context.Context, params GetUserByUsernameParams) (GetUserByUsernameRes, error)
+	GetUserByUsername(ctx context.Context, params GetUserByUsernameParams) (*PublicUser, error)
 	// GetUserSettings implements getUserSettings operation.
 	//
 	// Get user settings.
 	//
 	// GET /user/settings
-	GetUserSettings(ctx context.Context) (GetUserSettingsRes, error)
+	GetUserSettings(ctx context.Context) (*UserSettings, error)
 	// GetUserStats implements getUserStats operation.
 	//
 	// Get user stats.
 	//
 	// GET /user/stats
-	GetUserStats(ctx context.Context) (GetUserStatsRes, error)
+	GetUserStats(ctx context.Context) (*UserStats, error)
 	// GetUserSubscription implements getUserSubscription operation.
 	//
 	// Get user subscription.
 	//
 	// GET /user/subscription
-	GetUserSubscription(ctx context.Context) (GetUserSubscriptionRes, error)
+	GetUserSubscription(ctx context.Context) (*UserSubscription, error)
+	// GrantUserRole implements grantUserRole operation.
+	//
+	// Requires the `users:manage_roles` permission. The user's current access token keeps its roles
+	// until the next refresh.
+	//
+	// PUT /admin/users/{id}/roles/{role}
+	GrantUserRole(ctx context.Context, params GrantUserRoleParams) (*UserRoles, error)
+	// ListAPIKeys implements listAPIKeys operation.
+	//
+	// List API keys.
+	//
+	// GET /user/api-keys
+	ListAPIKeys(ctx context.Context) ([]APIKey, error)
+	// ListIdentities implements listIdentities operation.
+	//
+	// List linked identities.
+	//
+	// GET /user/identities
+	ListIdentities(ctx context.Context) ([]Identity, error)
+	// ListProgressPhotos implements listProgressPhotos operation.
+	//
+	// Newest day first.
+	//
+	// GET /user/progress-photos
+	ListProgressPhotos(ctx context.Context) ([]Photo, error)
 	// Login implements login operation.
 	//
-	// Login.
+	// Sets the `session` and `refresh_token` cookies. Accounts pending deletion are refused with
+	// `account_pending_deletion`; logging in again with `restore` cancels the deletion.
 	//
 	// POST /auth/login
-	Login(ctx context.Context, req *LoginReq) (LoginRes, error)
+	Login(ctx context.Context, req *LoginReq) error
+	// Logout implements logout operation.
+	//
+	// Revokes the refresh token and clears the session cookies.
+	//
+	// POST /auth/logout
+	Logout(ctx context.Context, params LogoutParams) error
+	// OauthCallback implements oauthCallback operation.
+	//
+	// External login callback.
+	//
+	// GET /auth/oauth/{provider}/callback
+	OauthCallback(ctx context.Context, params OauthCallbackParams) error
+	// OauthCallbackForm implements oauthCallbackForm operation.
+	//
+	// Used by providers that post the callback, such as Apple.
+	//
+	// POST /auth/oauth/{provider}/callback
+	OauthCallbackForm(ctx context.Context, req *OauthCallbackFormReq, params OauthCallbackFormParams) error
+	// Refresh implements refresh operation.
+	//
+	// Rotates the refresh token and sets new `session` and `refresh_token` cookies.
+	//
+	// POST /auth/refresh
+	Refresh(ctx context.Context, params RefreshParams) error
+	// RequestDataExport implements requestDataExport operation.
+	//
+	// Queues an archive of everything held about the account: profile, settings, stats, subscription and
+	// payments, sessions, linked identities, API keys and audit events. Poll the export until it is
+	// completed, then download it before it expires. One export can be in progress at a time.
+	//
+	// POST /user/exports
+	RequestDataExport(ctx context.Context) (*DataExport, error)
+	// ResetUserPassword implements resetUserPassword operation.
+	//
+	// Requires the `users:manage` permission. Replaces the password with a generated one, shown only in
+	// this response, and ends every session.
+	//
+	// POST /admin/users/{id}/password-reset
+	ResetUserPassword(ctx context.Context, params ResetUserPasswordParams) (*TemporaryPassword, error)
+	// RevokeAPIKey implements revokeAPIKey operation.
+	//
+	// Revoke an API key.
+	//
+	// DELETE /user/api-keys/{id}
+	RevokeAPIKey(ctx context.Context, params RevokeAPIKeyParams) error
+	// RevokeUserRole implements revokeUserRole operation.
+	//
+	// Requires the `users:manage_roles` permission. The `user` role cannot be revoked and admins cannot
+	// revoke their own admin role.
+	//
+	// DELETE /admin/users/{id}/roles/{role}
+	RevokeUserRole(ctx context.Context, params RevokeUserRoleParams) (*UserRoles, error)
+	// SearchAuditEvents implements searchAuditEvents operation.
+	//
+	// Requires the `audit:read` permission. Results are newest first.
+	//
+	// GET /admin/audit-events
+	SearchAuditEvents(ctx context.Context, params SearchAuditEventsParams) (*AuditEventPage, error)
+	// SearchUsers implements searchUsers operation.
+	//
+	// Requires the `users:read_any` permission. `username` and `email` match any part of the value,
+	// ignoring case. Results are newest first.
+	//
+	// GET /admin/users
+	SearchUsers(ctx context.Context, params SearchUsersParams) (*AdminUserPage, error)
+	// StartOAuth implements startOAuth operation.
+	//
+	// Redirects to the identity provider. With `link=true` the signed in user links the provider
+	// identity to their account instead of logging in. With `restore=true` an account pending deletion
+	// is restored. A new identity is only linked by email to an account that verified the email,
+	// otherwise the callback answers `link_required` and the user signs in to that account and starts
+	// again with `link=true`.
+	//
+	// GET /auth/oauth/{provider}
+	StartOAuth(ctx context.Context, params StartOAuthParams) (*StartOAuthFound, error)
 	// StartUserTrial implements startUserTrial operation.
 	//
 	// Start user trial.
 	//
 	// PUT /user/subscription/trial
-	StartUserTrial(ctx context.Context) (StartUserTrialRes, error)
+	StartUserTrial(ctx context.Context) (*UserSubscription, error)
+	// SuspendUser implements suspendUser operation.
+	//
+	// Requires the `users:manage` permission. Suspended users cannot sign in or refresh their session,
+	// and every session is ended. Access tokens already issued stay valid until they expire.
+	//
+	// PUT /admin/users/{id}/suspension
+	SuspendUser(ctx context.Context, req *SuspendUserReq, params SuspendUserParams) (*AdminUserDetail, error)
+	// UnlockAccount implements unlockAccount operation.
+	//
+	// Target of the link in the lockout email.
+	//
+	// GET /auth/unlock
+	UnlockAccount(ctx context.Context, params UnlockAccountParams) error
+	// UnsuspendUser implements unsuspendUser operation.
+	//
+	// Requires the `users:manage` permission.
+	//
+	// DELETE /admin/users/{id}/suspension
+	UnsuspendUser(ctx context.Context, params UnsuspendUserParams) (*AdminUserDetail, error)
+	// UpdateAdminUser implements updateAdminUser operation.
+	//
+	// Requires the `users:manage` permission. Fields are checked like the user's own edits, omitted
+	// fields are left unchanged.
+	//
+	// PATCH /admin/users/{id}
+	UpdateAdminUser(ctx context.Context, req *UpdateAdminUserReq, params UpdateAdminUserParams) (*AdminUserDetail, error)
 	// UpdateUser implements updateUser operation.
 	//
-	// Update user.
+	// The username can be changed once every 30 days, earlier changes are refused with
+	// `username_cooldown` and a `Retry-After` header. A username given up stays reserved for its
+	// previous owner for 180 days, and a few names such as `admin` or `support` are never available.
 	//
 	// PUT /user
-	UpdateUser(ctx context.Context, req *UpdateUserReq) (UpdateUserRes, error)
+	UpdateUser(ctx context.Context, req *UpdateUserReq) (*User, error)
 	// UpdateUserBodyMetrics implements updateUserBodyMetrics operation.
 	//
 	// Update user body metrics.
 	//
 	// PUT /user/stats/body
-	UpdateUserBodyMetrics(ctx context.Context, req *UpdateUserBodyMetricsReq) (UpdateUserBodyMetricsRes, error)
+	UpdateUserBodyMetrics(ctx context.Context, req *UpdateUserBodyMetricsReq) (*UserBodyMetrics, error)
 	// UpdateUserRecordPayment implements updateUserRecordPayment operation.
 	//
-	// Update user record payment.
+	// Record a subscription payment.
 	//
 	// PUT /user/subscription/payment
-	UpdateUserRecordPayment(ctx context.Context, params UpdateUserRecordPaymentParams) (UpdateUserRecordPaymentRes, error)
+	UpdateUserRecordPayment(ctx context.Context, req *UpdateUserRecordPaymentReq) (*UserSubscription, error)
 	// UpdateUserSettings implements updateUserSettings operation.
 	//
 	// Update user settings.
 	//
 	// PUT /user/settings
-	UpdateUserSettings(ctx context.Context, req *UpdateUserSettingsReq) (UpdateUserSettingsRes, error)
+	UpdateUserSettings(ctx context.Context, req *UpdateUserSettingsReq) (*UserSettings, error)
 	// UpgradeUserPlan implements upgradeUserPlan operation.
 	//
 	// Upgrade user plan.
 	//
 	// PUT /user/subscription/plan
-	UpgradeUserPlan(ctx context.Context, req *UpgradeUserPlanReq) (UpgradeUserPlanRes, error)
+	UpgradeUserPlan(ctx context.Context, req *UpgradeUserPlanReq) (*UserSubscription, error)
+	// UploadAvatar implements uploadAvatar operation.
+	//
+	// Replaces the avatar of the account. JPEG, PNG and GIF images are accepted, the type is read from
+	// the image itself rather than the `Content-Type` header. The image is re-encoded without its
+	// metadata, EXIF orientation applied, and a thumbnail is generated.
+	//
+	// PUT /user/avatar
+	UploadAvatar(ctx context.Context, req *UploadAvatarReqWithContentType) (*Photo, error)
+	// UploadProgressPhoto implements uploadProgressPhoto operation.
+	//
+	// Progress photos are private, they are only ever shown to their owner whatever the profile
+	// visibility. `taken_on` is the day of the body measurements the photo goes with, today when left
+	// out. Images are processed as for avatars.
+	//
+	// POST /user/progress-photos
+	UploadProgressPhoto(ctx context.Context, req *UploadProgressPhotoReqWithContentType, params UploadProgressPhotoParams) (*Photo, error)
+	// VerifyUserEmail implements verifyUserEmail operation.
+	//
+	// Requires the `users:manage` permission.
+	//
+	// POST /admin/users/{id}/email-verification
+	VerifyUserEmail(ctx context.Context, params VerifyUserEmailParams) (*AdminUserDetail, error)
+	// NewError creates *ProblemStatusCode from error returned by handler.
+	//
+	// Used for common default response.
+	NewError(ctx context.Context, err error) *ProblemStatusCode
 }
 
 // Server implements http server based on OpenAPI v3 specification and
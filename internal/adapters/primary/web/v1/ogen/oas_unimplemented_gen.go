@@ -18,52 +18,171 @@ var _ Handler = UnimplementedHandler{}
 // Cancel user subscription.
 //
 // PUT /user/subscription/cancel
-func (UnimplementedHandler) CancelUserSubscription(ctx context.Context) (r CancelUserSubscriptionRes, _ error) {
+func (UnimplementedHandler) CancelUserSubscription(ctx context.Context) (r *UserSubscription, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// CreateAPIKey implements createAPIKey operation.
+//
+// The plaintext key is only returned once.
+//
+// POST /user/api-keys
+func (UnimplementedHandler) CreateAPIKey(ctx context.Context, req *CreateAPIKeyReq) (r *CreateAPIKeyCreated, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
 // CreateUser implements createUser operation.
 //
-// Create a new user account.
+// New accounts hold the `user` role, other roles are granted by an admin.
 //
 // POST /user
-func (UnimplementedHandler) CreateUser(ctx context.Context, req *CreateUserReq) (r CreateUserRes, _ error) {
+func (UnimplementedHandler) CreateUser(ctx context.Context, req *CreateUserReq) (r *CreateUserCreated, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
+// DeleteAvatar implements deleteAvatar operation.
+//
+// Delete your avatar.
+//
+// DELETE /user/avatar
+func (UnimplementedHandler) DeleteAvatar(ctx context.Context) error {
+	return ht.ErrNotImplemented
+}
+
+// DeleteProgressPhoto implements deleteProgressPhoto operation.
+//
+// Delete a progress photo.
+//
+// DELETE /user/progress-photos/{id}
+func (UnimplementedHandler) DeleteProgressPhoto(ctx context.Context, params DeleteProgressPhotoParams) error {
+	return ht.ErrNotImplemented
+}
+
 // DeleteUser implements deleteUser operation.
 //
-// Delete user.
+// Schedules the account for deletion and signs the user out. The account is purged with all its data
+// after 30 days, until then logging in with `restore` brings it back.
 //
 // DELETE /user
-func (UnimplementedHandler) DeleteUser(ctx context.Context) (r DeleteUserRes, _ error) {
+func (UnimplementedHandler) DeleteUser(ctx context.Context) error {
+	return ht.ErrNotImplemented
+}
+
+// DownloadDataExport implements downloadDataExport operation.
+//
+// A zip of JSON and CSV files. The link only works for the account that requested the export, until
+// `expires_at`.
+//
+// GET /user/exports/{id}/archive
+func (UnimplementedHandler) DownloadDataExport(ctx context.Context, params DownloadDataExportParams) (r *DownloadDataExportOKHeaders, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ForceLogoutUser implements forceLogoutUser operation.
+//
+// Requires the `users:manage` permission. Revokes every refresh token, access tokens already issued
+// stay valid until they expire.
+//
+// DELETE /admin/users/{id}/sessions
+func (UnimplementedHandler) ForceLogoutUser(ctx context.Context, params ForceLogoutUserParams) error {
+	return ht.ErrNotImplemented
+}
+
+// GetAdminUser implements getAdminUser operation.
+//
+// Requires the `users:read_any` permission.
+//
+// GET /admin/users/{id}
+func (UnimplementedHandler) GetAdminUser(ctx context.Context, params GetAdminUserParams) (r *AdminUserDetail, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetAvatar implements getAvatar operation.
+//
+// Avatars are visible to every signed in user.
+//
+// GET /user/{id}/avatar
+func (UnimplementedHandler) GetAvatar(ctx context.Context, params GetAvatarParams) (r *ImageHeaders, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetCSRFToken implements getCSRFToken operation.
+//
+// Returns the CSRF token and sets it in the `csrf_token` cookie, keeping the current token when the
+// cookie is already set. Browsers must send it back in the `X-CSRF-Token` header on every POST, PUT,
+// PATCH and DELETE made with the session cookies. Requests authenticated with the `Authorization`
+// header are exempt.
+//
+// GET /auth/csrf
+func (UnimplementedHandler) GetCSRFToken(ctx context.Context, params GetCSRFTokenParams) (r *CSRFToken, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetDataExport implements getDataExport operation.
+//
+// Get an export of your data.
+//
+// GET /user/exports/{id}
+func (UnimplementedHandler) GetDataExport(ctx context.Context, params GetDataExportParams) (r *DataExport, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetMe implements getMe operation.
+//
+// The user, stats, subscription and settings in one response. Weight and height are in the units of
+// the settings.
+//
+// GET /user/me
+func (UnimplementedHandler) GetMe(ctx context.Context) (r *FullProfile, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetProgressPhotoImage implements getProgressPhotoImage operation.
+//
+// Get a progress photo.
+//
+// GET /user/progress-photos/{id}/image
+func (UnimplementedHandler) GetProgressPhotoImage(ctx context.Context, params GetProgressPhotoImageParams) (r *ImageHeaders, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetSecurityActivity implements getSecurityActivity operation.
+//
+// Sign ins, failed logins, ended sessions and admin actions on the account, newest first. Where an
+// admin acted, their address and user agent are left out.
+//
+// GET /user/security-activity
+func (UnimplementedHandler) GetSecurityActivity(ctx context.Context, params GetSecurityActivityParams) (r []SecurityEvent, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
 // GetUserByEmail implements getUserByEmail operation.
 //
-// Get user by email.
+// Requires the `users:read_any` permission.
 //
 // GET /user/email/{email}
-func (UnimplementedHandler) GetUserByEmail(ctx context.Context, params GetUserByEmailParams) (r GetUserByEmailRes, _ error) {
+func (UnimplementedHandler) GetUserByEmail(ctx context.Context, params GetUserByEmailParams) (r *User, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
 // GetUserByID implements getUserByID operation.
 //
-// Get user by ID.
+// Get the signed in user.
 //
 // GET /user
-func (UnimplementedHandler) GetUserByID(ctx context.Context) (r GetUserByIDRes, _ error) {
+func (UnimplementedHandler) GetUserByID(ctx context.Context) (r *User, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
 // GetUserByUsername implements getUserByUsername operation.
 //
-// Get user by username.
+// Returns the public profile of a user. Bio, experience level and preferred gym are only included
+// while the user's profile visibility is public. Email, birth date and sex are never included.
+// Usernames given up in the last 180 days still resolve to their owner. The response then carries
+// the current username, clients compare it to follow the rename.
 //
 // GET /user/username/{username}
-func (UnimplementedHandler) GetUserByUsername(ctx context.Context, params GetUserByUsernameParams) (r GetUserByUsernameRes, _ error) {
+func (UnimplementedHandler) GetUserByUsername(ctx context.Context, params GetUserByUsernameParams) (r *PublicUser, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
@@ -72,7 +191,7 @@ func (UnimplementedHandler) GetUserByUsername(ctx context.Context, params GetUse
 // Get user settings.
 //
 // GET /user/settings
-func (UnimplementedHandler) GetUserSettings(ctx context.Context) (r GetUserSettingsRes, _ error) {
+func (UnimplementedHandler) GetUserSettings(ctx context.Context) (r *UserSettings, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
@@ -81,7 +200,7 @@ func (UnimplementedHandler) GetUserSettings(ctx context.Context) (r GetUserSetti
 // Get user stats.
 //
 // GET /user/stats
-func (UnimplementedHandler) GetUserStats(ctx context.Context) (r GetUserStatsRes, _ error) {
+func (UnimplementedHandler) GetUserStats(ctx context.Context) (r *UserStats, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
@@ -90,16 +209,162 @@ func (UnimplementedHandler) GetUserStats(ctx context.Context) (r GetUserStatsRes
 // Get user subscription.
 //
 // GET /user/subscription
-func (UnimplementedHandler) GetUserSubscription(ctx context.Context) (r GetUserSubscriptionRes, _ error) {
+func (UnimplementedHandler) GetUserSubscription(ctx context.Context) (r *UserSubscription, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GrantUserRole implements grantUserRole operation.
+//
+// Requires the `users:manage_roles` permission. The user's current access token keeps its roles
+// until the next refresh.
+//
+// PUT /admin/users/{id}/roles/{role}
+func (UnimplementedHandler) GrantUserRole(ctx context.Context, params GrantUserRoleParams) (r *UserRoles, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ListAPIKeys implements listAPIKeys operation.
+//
+// List API keys.
+//
+// GET /user/api-keys
+func (UnimplementedHandler) ListAPIKeys(ctx context.Context) (r []APIKey, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ListIdentities implements listIdentities operation.
+//
+// List linked identities.
+//
+// GET /user/identities
+func (UnimplementedHandler) ListIdentities(ctx context.Context) (r []Identity, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ListProgressPhotos implements listProgressPhotos operation.
+//
+// Newest day first.
+//
+// GET /user/progress-photos
+func (UnimplementedHandler) ListProgressPhotos(ctx context.Context) (r []Photo, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
 // Login implements login operation.
 //
-// Login.
+// Sets the `session` and `refresh_token` cookies. Accounts pending deletion are refused with
+// `account_pending_deletion`; logging in again with `restore` cancels the deletion.
 //
 // POST /auth/login
-func (UnimplementedHandler) Login(ctx context.Context, req *LoginReq) (r LoginRes, _ error) {
+func (UnimplementedHandler) Login(ctx context.Context, req *LoginReq) error {
+	return ht.ErrNotImplemented
+}
+
+// Logout implements logout operation.
+//
+// Revokes the refresh token and clears the session cookies.
+//
+// POST /auth/logout
+func (UnimplementedHandler) Logout(ctx context.Context, params LogoutParams) error {
+	return ht.ErrNotImplemented
+}
+
+// OauthCallback implements oauthCallback operation.
+//
+// External login callback.
+//
+// GET /auth/oauth/{provider}/callback
+func (UnimplementedHandler) OauthCallback(ctx context.Context, params OauthCallbackParams) error {
+	return ht.ErrNotImplemented
+}
+
+// OauthCallbackForm implements oauthCallbackForm operation.
+//
+// Used by providers that post the callback, such as Apple.
+//
+// POST /auth/oauth/{provider}/callback
+func (UnimplementedHandler) OauthCallbackForm(ctx context.Context, req *OauthCallbackFormReq, params OauthCallbackFormParams) error {
+	return ht.ErrNotImplemented
+}
+
+// Refresh implements refresh operation.
+//
+// Rotates the refresh token and sets new `session` and `refresh_token` cookies.
+//
+// POST /auth/refresh
+func (UnimplementedHandler) Refresh(ctx context.Context, params RefreshParams) error {
+	return ht.ErrNotImplemented
+}
+
+// RequestDataExport implements requestDataExport operation.
+//
+// Queues an archive of everything held about the account: profile, settings, stats, subscription and
+// payments, sessions, linked identities, API keys and audit events. Poll the export until it is
+// completed, then download it before it expires. One export can be in progress at a time.
+//
+// POST /user/exports
+func (UnimplementedHandler) RequestDataExport(ctx context.Context) (r *DataExport, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ResetUserPassword implements resetUserPassword operation.
+//
+// Requires the `users:manage` permission. Replaces the password with a generated one, shown only in
+// this response, and ends every session.
+//
+// POST /admin/users/{id}/password-reset
+func (UnimplementedHandler) ResetUserPassword(ctx context.Context, params ResetUserPasswordParams) (r *TemporaryPassword, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// RevokeAPIKey implements revokeAPIKey operation.
+//
+// Revoke an API key.
+//
+// DELETE /user/api-keys/{id}
+func (UnimplementedHandler) RevokeAPIKey(ctx context.Context, params RevokeAPIKeyParams) error {
+	return ht.ErrNotImplemented
+}
+
+// RevokeUserRole implements revokeUserRole operation.
+//
+// Requires the `users:manage_roles` permission. The `user` role cannot be revoked and admins cannot
+// revoke their own admin role.
+//
+// DELETE /admin/users/{id}/roles/{role}
+func (UnimplementedHandler) RevokeUserRole(ctx context.Context, params RevokeUserRoleParams) (r *UserRoles, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SearchAuditEvents implements searchAuditEvents operation.
+//
+// Requires the `audit:read` permission. Results are newest first.
+//
+// GET /admin/audit-events
+func (UnimplementedHandler) SearchAuditEvents(ctx context.Context, params SearchAuditEventsParams) (r *AuditEventPage, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SearchUsers implements searchUsers operation.
+//
+// Requires the `users:read_any` permission. `username` and `email` match any part of the value,
+// ignoring case. Results are newest first.
+//
+// GET /admin/users
+func (UnimplementedHandler) SearchUsers(ctx context.Context, params SearchUsersParams) (r *AdminUserPage, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// StartOAuth implements startOAuth operation.
+//
+// Redirects to the identity provider. With `link=true` the signed in user links the provider
+// identity to their account instead of logging in. With `restore=true` an account pending deletion
+// is restored. A new identity is only linked by email to an account that verified the email,
+// otherwise the callback answers `link_required` and the user signs in to that account and starts
+// again with `link=true`.
+//
+// GET /auth/oauth/{provider}
+func (UnimplementedHandler) StartOAuth(ctx context.Context, params StartOAuthParams) (r *StartOAuthFound, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
@@ -108,16 +373,56 @@ func (UnimplementedHandler) Login(ctx context.Context, req *LoginReq) (r LoginRe
 // Start user trial.
 //
 // PUT /user/subscription/trial
-func (UnimplementedHandler) StartUserTrial(ctx context.Context) (r StartUserTrialRes, _ error) {
+func (UnimplementedHandler) StartUserTrial(ctx context.Context) (r *UserSubscription, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SuspendUser implements suspendUser operation.
+//
+// Requires the `users:manage` permission. Suspended users cannot sign in or refresh their session,
+// and every session is ended. Access tokens already issued stay valid until they expire.
+//
+// PUT /admin/users/{id}/suspension
+func (UnimplementedHandler) SuspendUser(ctx context.Context, req *SuspendUserReq, params SuspendUserParams) (r *AdminUserDetail, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// UnlockAccount implements unlockAccount operation.
+//
+// Target of the link in the lockout email.
+//
+// GET /auth/unlock
+func (UnimplementedHandler) UnlockAccount(ctx context.Context, params UnlockAccountParams) error {
+	return ht.ErrNotImplemented
+}
+
+// UnsuspendUser implements unsuspendUser operation.
+//
+// Requires the `users:manage` permission.
+//
+// DELETE /admin/users/{id}/suspension
+func (UnimplementedHandler) UnsuspendUser(ctx context.Context, params UnsuspendUserParams) (r *AdminUserDetail, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// UpdateAdminUser implements updateAdminUser operation.
+//
+// Requires the `users:manage` permission. Fields are checked like the user's own edits, omitted
+// fields are left unchanged.
+//
+// PATCH /admin/users/{id}
+func (UnimplementedHandler) UpdateAdminUser(ctx context.Context, req *UpdateAdminUserReq, params UpdateAdminUserParams) (r *AdminUserDetail, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
 // UpdateUser implements updateUser operation.
 //
-// Update user.
+// The username can be changed once every 30 days, earlier changes are refused with
+// `username_cooldown` and a `Retry-After` header. A username given up stays reserved for its
+// previous owner for 180 days, and a few names such as `admin` or `support` are never available.
 //
 // PUT /user
-func (UnimplementedHandler) UpdateUser(ctx context.Context, req *UpdateUserReq) (r UpdateUserRes, _ error) {
+func (UnimplementedHandler) UpdateUser(ctx context.Context, req *UpdateUserReq) (r *User, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
@@ -126,16 +431,16 @@ func (UnimplementedHandler) UpdateUser(ctx context.Context, req *UpdateUserReq)
 // Update user body metrics.
 //
 // PUT /user/stats/body
-func (UnimplementedHandler) UpdateUserBodyMetrics(ctx context.Context, req *UpdateUserBodyMetricsReq) (r UpdateUserBodyMetricsRes, _ error) {
+func (UnimplementedHandler) UpdateUserBodyMetrics(ctx context.Context, req *UpdateUserBodyMetricsReq) (r *UserBodyMetrics, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
 // UpdateUserRecordPayment implements updateUserRecordPayment operation.
 //
-// Update user record payment.
+// Record a subscription payment.
 //
 // PUT /user/subscription/payment
-func (UnimplementedHandler) UpdateUserRecordPayment(ctx context.Context, params UpdateUserRecordPaymentParams) (r UpdateUserRecordPaymentRes, _ error) {
+func (UnimplementedHandler) UpdateUserRecordPayment(ctx context.Context, req *UpdateUserRecordPaymentReq) (r *UserSubscription, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
@@ -144,7 +449,7 @@ func (UnimplementedHandler) UpdateUserRecordPayment(ctx context.Context, params
 // Update user settings.
 //
 // PUT /user/settings
-func (UnimplementedHandler) UpdateUserSettings(ctx context.Context, req *UpdateUserSettingsReq) (r UpdateUserSettingsRes, _ error) {
+func (UnimplementedHandler) UpdateUserSettings(ctx context.Context, req *UpdateUserSettingsReq) (r *UserSettings, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
@@ -153,6 +458,45 @@ func (UnimplementedHandler) UpdateUserSettings(ctx context.Context, req *UpdateU
 // Upgrade user plan.
 //
 // PUT /user/subscription/plan
-func (UnimplementedHandler) UpgradeUserPlan(ctx context.Context, req *UpgradeUserPlanReq) (r UpgradeUserPlanRes, _ error) {
+func (UnimplementedHandler) UpgradeUserPlan(ctx context.Context, req *UpgradeUserPlanReq) (r *UserSubscription, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// UploadAvatar implements uploadAvatar operation.
+//
+// Replaces the avatar of the account. JPEG, PNG and GIF images are accepted, the type is read from
+// the image itself rather than the `Content-Type` header. The image is re-encoded without its
+// metadata, EXIF orientation applied, and a thumbnail is generated.
+//
+// PUT /user/avatar
+func (UnimplementedHandler) UploadAvatar(ctx context.Context, req *UploadAvatarReqWithContentType) (r *Photo, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// UploadProgressPhoto implements uploadProgressPhoto operation.
+//
+// Progress photos are private, they are only ever shown to their owner whatever the profile
+// visibility. `taken_on` is the day of the body measurements the photo goes with, today when left
+// out. Images are processed as for avatars.
+//
+// POST /user/progress-photos
+func (UnimplementedHandler) UploadProgressPhoto(ctx context.Context, req *UploadProgressPhotoReqWithContentType, params UploadProgressPhotoParams) (r *Photo, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// VerifyUserEmail implements verifyUserEmail operation.
+//
+// Requires the `users:manage` permission.
+//
+// POST /admin/users/{id}/email-verification
+func (UnimplementedHandler) VerifyUserEmail(ctx context.Context, params VerifyUserEmailParams) (r *AdminUserDetail, _ error) {
 	return r, ht.ErrNotImplemented
 }
+
+// NewError creates *ProblemStatusCode from error returned by handler.
+//
+// Used for common default response.
+func (UnimplementedHandler) NewError(ctx context.Context, err error) (r *ProblemStatusCode) {
+	r = new(ProblemStatusCode)
+	return r
+}
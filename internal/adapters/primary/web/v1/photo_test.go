@@ -0,0 +1,156 @@
+package v1_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1"
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/photos"
+)
+
+func imageBody(data string) *api.UploadAvatarReqWithContentType {
+	return &api.UploadAvatarReqWithContentType{
+		ContentType: "image/jpeg",
+		Content:     api.UploadAvatarReq{Data: strings.NewReader(data)},
+	}
+}
+
+func TestPhoto_UploadAvatar(t *testing.T) {
+	srv := newTestServer(t, nil)
+	client, userID := srv.clientWithRoles(t, "user")
+
+	avatar := photo.NewAvatar(userID, photo.Image{ContentType: "image/jpeg", Width: 640, Height: 480, Data: make([]byte, 100)}, time.Now())
+	srv.photos.On("UploadAvatar", mock.Anything, mock.MatchedBy(func(req photos.UploadAvatarReq) bool {
+		data, _ := io.ReadAll(req.Body)
+		return req.UserID == userID && string(data) == "jpeg bytes"
+	})).Return(&photos.PhotoResp{Photo: avatar}, nil)
+
+	resp, err := client.UploadAvatar(context.Background(), imageBody("jpeg bytes"))
+	require.NoError(t, err)
+	assert.Equal(t, avatar.ID, resp.ID)
+	assert.Equal(t, "/api/v1/user/"+userID.String()+"/avatar", resp.ImageURL)
+	assert.Equal(t, int64(100), resp.SizeBytes)
+	assert.True(t, resp.TakenOn.IsNull())
+}
+
+func TestPhoto_GetAvatar(t *testing.T) {
+	srv := newTestServer(t, nil)
+	client, _ := srv.clientWithRoles(t, "user")
+
+	otherID := uuid.New()
+	avatar := photo.NewAvatar(otherID, photo.Image{ContentType: "image/png"}, time.Now())
+	srv.photos.On("OpenAvatar", mock.Anything, photos.OpenAvatarReq{UserID: otherID, Thumbnail: true}).
+		Return(&photos.OpenPhotoResp{Photo: avatar, Body: io.NopCloser(strings.NewReader("png bytes"))}, nil)
+
+	resp, err := client.GetAvatar(context.Background(), api.GetAvatarParams{ID: otherID, Size: api.NewOptImageSize(api.ImageSizeThumbnail)})
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", resp.ContentType)
+	assert.Equal(t, "private, max-age=300", resp.CacheControl.Or(""))
+
+	data, err := io.ReadAll(resp.Response.Data)
+	require.NoError(t, err)
+	assert.Equal(t, "png bytes", string(data))
+}
+
+func TestPhoto_UploadProgressPhoto(t *testing.T) {
+	srv := newTestServer(t, nil)
+	client, userID := srv.clientWithRoles(t, "user")
+
+	takenOn := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	p, err := photo.NewProgress(userID, photo.Image{ContentType: "image/jpeg"}, takenOn, time.Now())
+	require.NoError(t, err)
+	srv.photos.On("UploadProgressPhoto", mock.Anything, mock.MatchedBy(func(req photos.UploadProgressPhotoReq) bool {
+		return req.UserID == userID && req.TakenOn.Equal(takenOn)
+	})).Return(&photos.PhotoResp{Photo: p}, nil)
+
+	resp, err := client.UploadProgressPhoto(context.Background(),
+		&api.UploadProgressPhotoReqWithContentType{ContentType: "image/jpeg", Content: api.UploadProgressPhotoReq{Data: strings.NewReader("jpeg bytes")}},
+		api.UploadProgressPhotoParams{TakenOn: api.NewOptDate(takenOn)})
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/user/progress-photos/"+p.ID.String()+"/image", resp.ImageURL)
+	assert.Equal(t, takenOn, resp.TakenOn.Or(time.Time{}))
+}
+
+// Progress photos of other users are reported missing, not forbidden, so
+// their IDs cannot be probed.
+func TestPhoto_ProgressPhotoOfAnotherUser(t *testing.T) {
+	srv := newTestServer(t, nil)
+	client, userID := srv.clientWithRoles(t, "user")
+
+	photoID := uuid.New()
+	srv.photos.On("OpenProgressPhoto", mock.Anything, photos.OpenProgressPhotoReq{
+		ProgressPhotoReq: photos.ProgressPhotoReq{UserID: userID, PhotoID: photoID},
+	}).Return(nil, photos.ErrPhotoNotFound)
+
+	_, err := client.GetProgressPhotoImage(context.Background(), api.GetProgressPhotoImageParams{ID: photoID})
+	problem := problemOf(t, err)
+	assert.Equal(t, http.StatusNotFound, problem.StatusCode)
+	assert.Equal(t, "photo_not_found", problem.Response.Code)
+}
+
+func TestPhoto_UploadProblems(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+		field  string
+	}{
+		{name: "unsupported type", err: photo.ErrUnsupportedType, status: http.StatusUnsupportedMediaType, code: "unsupported_image_type"},
+		{name: "too large", err: photo.ErrTooLarge, status: http.StatusRequestEntityTooLarge, code: "photo_too_large"},
+		{name: "unreadable", err: photo.ErrInvalidImage, status: http.StatusUnprocessableEntity, code: "validation_failed", field: "image"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(t, nil)
+			client, _ := srv.clientWithRoles(t, "user")
+
+			srv.photos.On("UploadAvatar", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			_, err := client.UploadAvatar(context.Background(), imageBody("bytes"))
+			problem := problemOf(t, err)
+			assert.Equal(t, tt.status, problem.StatusCode)
+			assert.Equal(t, tt.code, problem.Response.Code)
+			if tt.field != "" {
+				require.Len(t, problem.Response.Errors, 1)
+				assert.Equal(t, tt.field, problem.Response.Errors[0].Field)
+			}
+		})
+	}
+}
+
+func TestContract_UploadNotAnImage(t *testing.T) {
+	srv := newTestServer(t, nil)
+
+	token, err := srv.jwtManager.MakeJWT(uuid.New(), []string{"user"})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPut, srv.URL+v1.Prefix+"/user/avatar", strings.NewReader("<svg/>"))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "text/html")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+
+	var problem api.Problem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+	assert.Equal(t, "invalid_request", problem.Code)
+	srv.photos.AssertNotCalled(t, "UploadAvatar", mock.Anything, mock.Anything)
+}
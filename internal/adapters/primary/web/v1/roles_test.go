@@ -0,0 +1,103 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+// clientWithRoles logs in as a fresh user holding roles.
+func (s *testServer) clientWithRoles(t *testing.T, roles ...string) (*api.Client, uuid.UUID) {
+	t.Helper()
+
+	userID := uuid.New()
+	token, err := s.jwtManager.MakeJWT(userID, roles)
+	require.NoError(t, err)
+
+	client, _ := s.client(t, credentials{bearer: token})
+	return client, userID
+}
+
+func TestRoles_GetUserByEmail(t *testing.T) {
+	srv := newTestServer(t, nil)
+	ctx := context.Background()
+
+	srv.users.On("GetByEmail", mock.Anything, users.GetUserByEmailReq{Email: "jane.doe@example.com"}).
+		Return(testUser(uuid.New()), nil)
+
+	tests := []struct {
+		name   string
+		roles  []string
+		status int
+		code   string
+	}{
+		{name: "admin", roles: []string{"user", "admin"}, status: http.StatusOK},
+		{name: "moderator", roles: []string{"user", "moderator"}, status: http.StatusOK},
+		{name: "user", roles: []string{"user"}, status: http.StatusForbidden, code: "forbidden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := srv.clientWithRoles(t, tt.roles...)
+
+			resp, err := client.GetUserByEmail(ctx, api.GetUserByEmailParams{Email: "jane.doe@example.com"})
+
+			if tt.status == http.StatusOK {
+				require.NoError(t, err)
+				assert.Equal(t, "janedoe", resp.Username)
+				return
+			}
+			problem := problemOf(t, err)
+			assert.Equal(t, tt.status, problem.StatusCode)
+			assert.Equal(t, tt.code, problem.Response.Code)
+		})
+	}
+
+	srv.users.AssertNumberOfCalls(t, "GetByEmail", 2)
+}
+
+func TestRoles_ManageRoles(t *testing.T) {
+	srv := newTestServer(t, nil)
+	ctx := context.Background()
+
+	adminClient, adminID := srv.clientWithRoles(t, "user", "admin")
+	moderator, _ := srv.clientWithRoles(t, "user", "moderator")
+	targetID := uuid.New()
+
+	onTarget := admin.UserActionReq{ActorID: adminID, UserID: targetID}
+	srv.admin.On("GrantRole", mock.Anything, admin.ManageRoleReq{UserActionReq: onTarget, Role: "moderator"}).
+		Return(&admin.ManageRoleResp{Roles: user.Roles{user.RoleUser, user.RoleModerator}}, nil)
+	srv.admin.On("RevokeRole", mock.Anything, admin.ManageRoleReq{UserActionReq: onTarget, Role: "moderator"}).
+		Return(&admin.ManageRoleResp{Roles: user.Roles{user.RoleUser}}, nil)
+	srv.admin.On("RevokeRole", mock.Anything, admin.ManageRoleReq{UserActionReq: admin.UserActionReq{ActorID: adminID, UserID: adminID}, Role: "admin"}).
+		Return(nil, admin.ErrSelfDemotion)
+
+	granted, err := adminClient.GrantUserRole(ctx, api.GrantUserRoleParams{ID: targetID, Role: "moderator"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user", "moderator"}, granted.Roles)
+
+	revoked, err := adminClient.RevokeUserRole(ctx, api.RevokeUserRoleParams{ID: targetID, Role: "moderator"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user"}, revoked.Roles)
+
+	_, err = adminClient.RevokeUserRole(ctx, api.RevokeUserRoleParams{ID: adminID, Role: "admin"})
+	problem := problemOf(t, err)
+	assert.Equal(t, http.StatusConflict, problem.StatusCode)
+	assert.Equal(t, "self_demotion", problem.Response.Code)
+
+	// Moderators read users but cannot hand out roles
+	_, err = moderator.GrantUserRole(ctx, api.GrantUserRoleParams{ID: targetID, Role: "admin"})
+	assert.Equal(t, http.StatusForbidden, statusOf(t, err))
+
+	srv.admin.AssertExpectations(t)
+}
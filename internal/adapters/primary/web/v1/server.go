@@ -0,0 +1,102 @@
+// Package v1
+package v1
+
+import (
+	"net/http"
+	"strings"
+
+	ogenmw "github.com/ogen-go/ogen/middleware"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/handlers"
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+)
+
+const Prefix = "/api/v1"
+
+// NewServer serves the operations of docs/fitrkr.yml under Prefix. Requests
+// are validated against the spec before they reach a handler. opts come after
+// the defaults, for instance to set the telemetry providers.
+func NewServer(handler *handlers.Handler, m *middleware.Middleware, opts ...api.ServerOption) (http.Handler, error) {
+	srv, err := api.NewServer(handler, handlers.NewSecurityHandler(m), append([]api.ServerOption{
+		api.WithPathPrefix(Prefix),
+		api.WithErrorHandler(handlers.ErrorHandler),
+		api.WithNotFound(handlers.NotFound),
+		api.WithMethodNotAllowed(handlers.MethodNotAllowed),
+		api.WithMiddleware(rateLimit(m), csrf, authorize),
+	}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.RequestContext(routeName(srv)), nil
+}
+
+// routeName reports the spec path of the operation to the telemetry
+// middleware, chi only knows the API is mounted under Prefix.
+func routeName(srv *api.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route, ok := srv.FindPath(r.Method, r.URL); ok {
+			middleware.SetRoute(r.Context(), Prefix+route.PathPattern())
+		}
+		srv.ServeHTTP(w, r)
+	})
+}
+
+// operationPermissions lists the operations that need more than a login.
+var operationPermissions = map[api.OperationName]user.Permission{
+	api.GetUserByEmailOperation: user.PermUsersReadAny,
+	api.SearchUsersOperation:    user.PermUsersReadAny,
+	api.GetAdminUserOperation:   user.PermUsersReadAny,
+
+	api.UpdateAdminUserOperation:   user.PermUsersManage,
+	api.ResetUserPasswordOperation: user.PermUsersManage,
+	api.VerifyUserEmailOperation:   user.PermUsersManage,
+	api.SuspendUserOperation:       user.PermUsersManage,
+	api.UnsuspendUserOperation:     user.PermUsersManage,
+	api.ForceLogoutUserOperation:   user.PermUsersManage,
+
+	api.GrantUserRoleOperation:  user.PermUsersManageRoles,
+	api.RevokeUserRoleOperation: user.PermUsersManageRoles,
+
+	api.SearchAuditEventsOperation: user.PermAuditRead,
+}
+
+// authorize checks operationPermissions against the roles of the
+// authenticated user.
+func authorize(req ogenmw.Request, next ogenmw.Next) (ogenmw.Response, error) {
+	if perm, ok := operationPermissions[req.OperationName]; ok {
+		if err := middleware.Authorize(req.Context, perm); err != nil {
+			return ogenmw.Response{}, err
+		}
+	}
+	return next(req)
+}
+
+// csrf rejects cookie authenticated writes without the CSRF token, see
+// middleware.CheckCSRF.
+func csrf(req ogenmw.Request, next ogenmw.Next) (ogenmw.Response, error) {
+	if err := middleware.CheckCSRF(req.Context, req.Raw); err != nil {
+		return ogenmw.Response{}, err
+	}
+	return next(req)
+}
+
+// rateLimit runs after the security handlers, so users are counted by ID
+// rather than by their shared address. Requests whose credentials are
+// rejected never get here, the security handler limits those.
+func rateLimit(m *middleware.Middleware) ogenmw.Middleware {
+	return func(req ogenmw.Request, next ogenmw.Next) (ogenmw.Response, error) {
+		group := "user"
+		if strings.HasPrefix(req.Raw.URL.Path, Prefix+"/auth/") {
+			group = "auth"
+		}
+
+		if err := m.ThrottleRequest(req.Context, group); err != nil {
+			return ogenmw.Response{}, err
+		}
+
+		return next(req)
+	}
+}
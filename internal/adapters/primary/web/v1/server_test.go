@@ -0,0 +1,530 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/ogen-go/ogen/ogenerrors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/middleware"
+	v1 "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/handlers"
+	api "github.com/cheezecakee/fitrkr-athena/internal/adapters/primary/web/v1/ogen"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/jwt"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/exports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/photos"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+type MockUserService struct {
+	mock.Mock
+}
+
+func (m *MockUserService) CreateAccount(ctx context.Context, req users.CreateAccountReq) (*users.CreateAccountResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*users.CreateAccountResp), args.Error(1)
+}
+
+func (m *MockUserService) ProvisionAccount(ctx context.Context, req users.ProvisionAccountReq) (*users.CreateAccountResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*users.CreateAccountResp), args.Error(1)
+}
+
+func (m *MockUserService) GetByID(ctx context.Context, req users.GetUserByIDReq) (*users.GetUserResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*users.GetUserResp), args.Error(1)
+}
+
+func (m *MockUserService) GetByUsername(ctx context.Context, req users.GetUserByUsernameReq) (*users.GetPublicUserResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*users.GetPublicUserResp), args.Error(1)
+}
+
+func (m *MockUserService) GetByEmail(ctx context.Context, req users.GetUserByEmailReq) (*users.GetUserResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*users.GetUserResp), args.Error(1)
+}
+
+func (m *MockUserService) GetFullProfile(ctx context.Context, req users.GetFullProfileReq) (*users.GetFullProfileResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*users.GetFullProfileResp), args.Error(1)
+}
+
+func (m *MockUserService) Update(ctx context.Context, req users.UpdateUserReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserService) Delete(ctx context.Context, req users.DeleteAccountReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserService) PurgeDeleted(ctx context.Context) (*users.PurgeDeletedResp, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*users.PurgeDeletedResp), args.Error(1)
+}
+
+func (m *MockUserService) GetStats(ctx context.Context, req users.GetStatsReq) (*users.GetStatsResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*users.GetStatsResp), args.Error(1)
+}
+
+func (m *MockUserService) GetSubscription(ctx context.Context, req users.GetSubscriptionReq) (*users.GetSubscriptionResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*users.GetSubscriptionResp), args.Error(1)
+}
+
+func (m *MockUserService) GetSettings(ctx context.Context, req users.GetSettingsReq) (*users.GetSettingsResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*users.GetSettingsResp), args.Error(1)
+}
+
+func (m *MockUserService) UpgradePlan(ctx context.Context, req users.UpgradePlanReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserService) RecordPayment(ctx context.Context, req users.RecordPaymentReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserService) CancelSubscription(ctx context.Context, req users.CancelSubscriptionReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserService) StartTrial(ctx context.Context, req users.StartTrialReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserService) UpdateSettings(ctx context.Context, req users.UpdateSettingsReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserService) UpdateBodyMetrics(ctx context.Context, req users.UpdateBodyMetricsReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+type MockAdminService struct {
+	mock.Mock
+}
+
+func (m *MockAdminService) SearchUsers(ctx context.Context, req admin.SearchUsersReq) (*admin.SearchUsersResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.SearchUsersResp), args.Error(1)
+}
+
+func (m *MockAdminService) GetUser(ctx context.Context, req admin.GetUserReq) (*admin.GetUserResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.GetUserResp), args.Error(1)
+}
+
+func (m *MockAdminService) UpdateUser(ctx context.Context, req admin.UpdateUserReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAdminService) ResetPassword(ctx context.Context, req admin.UserActionReq) (*admin.ResetPasswordResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.ResetPasswordResp), args.Error(1)
+}
+
+func (m *MockAdminService) VerifyEmail(ctx context.Context, req admin.UserActionReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAdminService) SuspendUser(ctx context.Context, req admin.SuspendUserReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAdminService) UnsuspendUser(ctx context.Context, req admin.UserActionReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAdminService) ForceLogout(ctx context.Context, req admin.UserActionReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAdminService) GrantRole(ctx context.Context, req admin.ManageRoleReq) (*admin.ManageRoleResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.ManageRoleResp), args.Error(1)
+}
+
+func (m *MockAdminService) RevokeRole(ctx context.Context, req admin.ManageRoleReq) (*admin.ManageRoleResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.ManageRoleResp), args.Error(1)
+}
+
+func (m *MockAdminService) SearchAuditEvents(ctx context.Context, req admin.SearchAuditEventsReq) (*admin.SearchAuditEventsResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.SearchAuditEventsResp), args.Error(1)
+}
+
+type MockExportService struct {
+	mock.Mock
+}
+
+func (m *MockExportService) RequestExport(ctx context.Context, req exports.RequestExportReq) (*exports.ExportResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*exports.ExportResp), args.Error(1)
+}
+
+func (m *MockExportService) GetExport(ctx context.Context, req exports.GetExportReq) (*exports.ExportResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*exports.ExportResp), args.Error(1)
+}
+
+func (m *MockExportService) OpenArchive(ctx context.Context, req exports.GetExportReq) (*exports.OpenArchiveResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*exports.OpenArchiveResp), args.Error(1)
+}
+
+func (m *MockExportService) BuildExport(ctx context.Context, req exports.BuildExportReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockExportService) PurgeExpired(ctx context.Context) (*exports.PurgeExpiredResp, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*exports.PurgeExpiredResp), args.Error(1)
+}
+
+func (m *MockExportService) UserFiles(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockExportService) DeleteFiles(ctx context.Context, keys []string) error {
+	return m.Called(ctx, keys).Error(0)
+}
+
+type MockPhotoService struct {
+	mock.Mock
+}
+
+func (m *MockPhotoService) UploadAvatar(ctx context.Context, req photos.UploadAvatarReq) (*photos.PhotoResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*photos.PhotoResp), args.Error(1)
+}
+
+func (m *MockPhotoService) OpenAvatar(ctx context.Context, req photos.OpenAvatarReq) (*photos.OpenPhotoResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*photos.OpenPhotoResp), args.Error(1)
+}
+
+func (m *MockPhotoService) DeleteAvatar(ctx context.Context, req photos.DeleteAvatarReq) error {
+	return m.Called(ctx, req).Error(0)
+}
+
+func (m *MockPhotoService) HasAvatar(ctx context.Context, userID string) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPhotoService) UploadProgressPhoto(ctx context.Context, req photos.UploadProgressPhotoReq) (*photos.PhotoResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*photos.PhotoResp), args.Error(1)
+}
+
+func (m *MockPhotoService) ListProgressPhotos(ctx context.Context, req photos.ListProgressPhotosReq) (*photos.ListProgressPhotosResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*photos.ListProgressPhotosResp), args.Error(1)
+}
+
+func (m *MockPhotoService) OpenProgressPhoto(ctx context.Context, req photos.OpenProgressPhotoReq) (*photos.OpenPhotoResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*photos.OpenPhotoResp), args.Error(1)
+}
+
+func (m *MockPhotoService) DeleteProgressPhoto(ctx context.Context, req photos.ProgressPhotoReq) error {
+	return m.Called(ctx, req).Error(0)
+}
+
+func (m *MockPhotoService) UserFiles(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockPhotoService) DeleteFiles(ctx context.Context, keys []string) error {
+	return m.Called(ctx, keys).Error(0)
+}
+
+type MockAuthService struct {
+	mock.Mock
+}
+
+func (m *MockAuthService) Login(ctx context.Context, req auth.LoginReq) (auth.LoginResp, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(auth.LoginResp), args.Error(1)
+}
+
+func (m *MockAuthService) Logout(ctx context.Context, req auth.LogoutReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) Revoke(ctx context.Context, req auth.RevokeTokenReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) Refresh(ctx context.Context, req auth.RefreshReq) (auth.RefreshResp, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(auth.RefreshResp), args.Error(1)
+}
+
+func (m *MockAuthService) UnlockAccount(ctx context.Context, req auth.UnlockAccountReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) StartExternalLogin(ctx context.Context, req auth.StartExternalLoginReq) (auth.StartExternalLoginResp, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(auth.StartExternalLoginResp), args.Error(1)
+}
+
+func (m *MockAuthService) ExternalLogin(ctx context.Context, req auth.ExternalLoginReq) (auth.LoginResp, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(auth.LoginResp), args.Error(1)
+}
+
+func (m *MockAuthService) ListIdentities(ctx context.Context, req auth.ListIdentitiesReq) (auth.ListIdentitiesResp, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(auth.ListIdentitiesResp), args.Error(1)
+}
+
+func (m *MockAuthService) CreateAPIKey(ctx context.Context, req auth.CreateAPIKeyReq) (auth.CreateAPIKeyResp, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(auth.CreateAPIKeyResp), args.Error(1)
+}
+
+func (m *MockAuthService) ListAPIKeys(ctx context.Context, req auth.ListAPIKeysReq) (auth.ListAPIKeysResp, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(auth.ListAPIKeysResp), args.Error(1)
+}
+
+func (m *MockAuthService) RevokeAPIKey(ctx context.Context, req auth.RevokeAPIKeyReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) AuthenticateAPIKey(ctx context.Context, req auth.AuthenticateAPIKeyReq) (auth.AuthenticateAPIKeyResp, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(auth.AuthenticateAPIKeyResp), args.Error(1)
+}
+
+func (m *MockAuthService) SecurityActivity(ctx context.Context, req auth.SecurityActivityReq) (auth.SecurityActivityResp, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(auth.SecurityActivityResp), args.Error(1)
+}
+
+// credentials is the security source of the generated client, schemes left
+// empty are skipped.
+type credentials struct {
+	session string
+	bearer  string
+	apiKey  string
+}
+
+func (c credentials) CookieAuth(ctx context.Context, operationName api.OperationName) (api.CookieAuth, error) {
+	if c.session == "" {
+		return api.CookieAuth{}, ogenerrors.ErrSkipClientSecurity
+	}
+	return api.CookieAuth{APIKey: c.session}, nil
+}
+
+func (c credentials) BearerAuth(ctx context.Context, operationName api.OperationName) (api.BearerAuth, error) {
+	if c.bearer == "" {
+		return api.BearerAuth{}, ogenerrors.ErrSkipClientSecurity
+	}
+	return api.BearerAuth{Token: c.bearer}, nil
+}
+
+func (c credentials) ApiKeyAuth(ctx context.Context, operationName api.OperationName) (api.ApiKeyAuth, error) {
+	if c.apiKey == "" {
+		return api.ApiKeyAuth{}, ogenerrors.ErrSkipClientSecurity
+	}
+	return api.ApiKeyAuth{APIKey: "ApiKey " + c.apiKey}, nil
+}
+
+type testServer struct {
+	*httptest.Server
+	users      *MockUserService
+	auth       *MockAuthService
+	admin      *MockAdminService
+	exports    *MockExportService
+	photos     *MockPhotoService
+	jwtManager *jwt.JWTManager
+}
+
+func newTestServer(t *testing.T, limits middleware.RateLimits) *testServer {
+	t.Helper()
+
+	jwtManager, err := jwt.NewHS256Manager("test-secret")
+	require.NoError(t, err)
+
+	userService, authService, adminService := new(MockUserService), new(MockAuthService), new(MockAdminService)
+	exportService, photoService := new(MockExportService), new(MockPhotoService)
+
+	limiter := middleware.NewRateLimiter(middleware.NewMemoryRateLimitStore(), nil, limits)
+	m := middleware.NewMiddleware(jwtManager, authService, middleware.WithRateLimiter(limiter))
+
+	handler, err := v1.NewServer(handlers.NewHandler(userService, authService, adminService, exportService, photoService, jwtManager), m)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return &testServer{Server: srv, users: userService, auth: authService, admin: adminService,
+		exports: exportService, photos: photoService, jwtManager: jwtManager}
+}
+
+// client talks to the server through the generated client, keeping cookies
+// between calls like a browser would.
+func (s *testServer) client(t *testing.T, creds credentials) (*api.Client, http.CookieJar) {
+	t.Helper()
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+
+	transport := &csrfTransport{next: http.DefaultTransport}
+	client, err := api.NewClient(s.URL+v1.Prefix, creds, api.WithClient(&http.Client{
+		Jar:       jar,
+		Timeout:   5 * time.Second,
+		Transport: transport,
+		// Redirects are part of the contract, not something to follow
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}))
+	require.NoError(t, err)
+
+	// Like the web app, fetch the CSRF token first and echo it on every call
+	token, err := client.GetCSRFToken(context.Background(), api.GetCSRFTokenParams{})
+	require.NoError(t, err)
+	transport.token = token.CsrfToken
+
+	return client, jar
+}
+
+// csrfTransport sends the CSRF token header the way the web app does.
+type csrfTransport struct {
+	next  http.RoundTripper
+	token string
+}
+
+func (t *csrfTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if t.token != "" {
+		r = r.Clone(r.Context())
+		r.Header.Set(middleware.CSRFHeader, t.token)
+	}
+	return t.next.RoundTrip(r)
+}
+
+func TestMain(m *testing.M) {
+	logr.Init(&logr.PlainTextFormatter{}, logr.LevelInfo, nil)
+
+	exitCode := m.Run()
+
+	os.Exit(exitCode)
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+// AuditLog keeps events in the order they were recorded.
+type AuditLog struct {
+	mu     sync.Mutex
+	events []audit.Event
+}
+
+func NewAuditLog() *AuditLog {
+	return &AuditLog{}
+}
+
+func (l *AuditLog) Record(ctx context.Context, event audit.Event) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events = append(l.events, event)
+	return nil
+}
+
+// Events returns a copy of the recorded events.
+func (l *AuditLog) Events() []audit.Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return slices.Clone(l.events)
+}
+
+func (l *AuditLog) Search(ctx context.Context, filter ports.AuditFilter) ([]audit.Event, int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var found []audit.Event
+	// Newest first, events are appended in the order they happened
+	for _, event := range slices.Backward(l.events) {
+		if matches(filter, event) {
+			found = append(found, event)
+		}
+	}
+
+	total := len(found)
+	start := min(filter.Offset, total)
+	end := min(start+filter.Limit, total)
+	return found[start:end], total, nil
+}
+
+func (l *AuditLog) Erase(ctx context.Context, userID, tombstone uuid.UUID) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, event := range l.events {
+		if event.ActorID != userID && event.TargetID != userID {
+			continue
+		}
+		if event.ActorID == userID {
+			event.ActorID = tombstone
+		}
+		if event.TargetID == userID {
+			event.TargetID = tombstone
+		}
+		event.Metadata, event.IP, event.UserAgent = nil, "", ""
+		l.events[i] = event
+	}
+	return nil
+}
+
+func matches(filter ports.AuditFilter, event audit.Event) bool {
+	switch {
+	case filter.ActorID != nil && *filter.ActorID != event.ActorID:
+		return false
+	case filter.TargetID != nil && *filter.TargetID != event.TargetID:
+		return false
+	case len(filter.Actions) > 0 && !slices.Contains(filter.Actions, event.Action):
+		return false
+	case filter.Since != nil && event.CreatedAt.Before(*filter.Since):
+		return false
+	case filter.Until != nil && !event.CreatedAt.Before(*filter.Until):
+		return false
+	}
+	return true
+}
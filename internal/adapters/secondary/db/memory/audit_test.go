@@ -0,0 +1,169 @@
+package memory_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+func TestAuditLog(t *testing.T) {
+	ctx := context.Background()
+	log := memory.NewAuditLog()
+
+	for _, action := range []audit.Action{audit.ActionAdminUserSuspended, audit.ActionAdminUserUnsuspended} {
+		event, err := audit.NewEvent(action, uuid.New(), uuid.New(), nil)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if err := log.Record(ctx, event); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+
+	events := log.Events()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Action != audit.ActionAdminUserSuspended || events[1].Action != audit.ActionAdminUserUnsuspended {
+		t.Errorf("expected events in recording order, got %v and %v", events[0].Action, events[1].Action)
+	}
+
+	events[0].Action = "tampered"
+	if log.Events()[0].Action == "tampered" {
+		t.Error("expected Events to return a copy")
+	}
+}
+
+func TestAuditLog_Search(t *testing.T) {
+	ctx := context.Background()
+	log := memory.NewAuditLog()
+
+	jane, john := uuid.New(), uuid.New()
+	for _, e := range []struct {
+		action audit.Action
+		target uuid.UUID
+	}{
+		{audit.ActionLoginSucceeded, jane},
+		{audit.ActionLoginFailed, john},
+		{audit.ActionTokenRefreshed, jane},
+		{audit.ActionLoginFailed, jane},
+	} {
+		event, err := audit.NewEvent(e.action, e.target, e.target, nil)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if err := log.Record(ctx, event); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter ports.AuditFilter
+		want   []audit.Action
+		total  int
+	}{
+		{
+			name:   "by target newest first",
+			filter: ports.AuditFilter{TargetID: &jane, Limit: 10},
+			want:   []audit.Action{audit.ActionLoginFailed, audit.ActionTokenRefreshed, audit.ActionLoginSucceeded},
+			total:  3,
+		},
+		{
+			name:   "by action",
+			filter: ports.AuditFilter{Actions: []audit.Action{audit.ActionLoginFailed}, Limit: 10},
+			want:   []audit.Action{audit.ActionLoginFailed, audit.ActionLoginFailed},
+			total:  2,
+		},
+		{
+			name:   "paged",
+			filter: ports.AuditFilter{TargetID: &jane, Limit: 1, Offset: 1},
+			want:   []audit.Action{audit.ActionTokenRefreshed},
+			total:  3,
+		},
+		{
+			name:   "past the end",
+			filter: ports.AuditFilter{Limit: 10, Offset: 10},
+			total:  4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, total, err := log.Search(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if total != tt.total {
+				t.Errorf("expected total %d, got %d", tt.total, total)
+			}
+
+			var got []audit.Action
+			for _, event := range events {
+				got = append(got, event.Action)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAuditLog_Erase(t *testing.T) {
+	ctx := context.Background()
+	log := memory.NewAuditLog()
+
+	jane, admin, tombstone := uuid.New(), uuid.New(), uuid.New()
+	client := audit.Client{IP: "203.0.113.7", UserAgent: "curl/8.0"}
+
+	for _, e := range []struct {
+		action        audit.Action
+		actor, target uuid.UUID
+	}{
+		{audit.ActionLoginFailed, jane, jane},
+		{audit.ActionAdminUserSuspended, admin, jane},
+		{audit.ActionAdminUserSuspended, admin, admin},
+	} {
+		event, err := audit.NewEvent(e.action, e.actor, e.target, map[string]string{"reason": "spam"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if err := log.Record(ctx, event.WithClient(client)); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+
+	if err := log.Erase(ctx, jane, tombstone); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	events := log.Events()
+	if len(events) != 3 {
+		t.Fatalf("expected the events to be kept, got %d", len(events))
+	}
+
+	for _, event := range events[:2] {
+		if event.ActorID == jane || event.TargetID == jane {
+			t.Errorf("expected %s to be replaced by the tombstone in %+v", jane, event)
+		}
+		if event.TargetID != tombstone {
+			t.Errorf("expected target %s, got %s", tombstone, event.TargetID)
+		}
+		if event.IP != "" || event.UserAgent != "" || len(event.Metadata) != 0 {
+			t.Errorf("expected the personal data to be stripped, got %+v", event)
+		}
+	}
+	if events[1].ActorID != admin {
+		t.Errorf("expected the admin to stay the actor, got %s", events[1].ActorID)
+	}
+
+	if events[2].IP != client.IP || events[2].Metadata["reason"] != "spam" {
+		t.Errorf("expected events of other users to be untouched, got %+v", events[2])
+	}
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+// ExportRepo holds exports by ID and, like the unique index in Postgres,
+// refuses a second export in progress for the same user.
+type ExportRepo struct {
+	mu      sync.Mutex
+	exports map[uuid.UUID]export.Export
+}
+
+func NewExportRepo() *ExportRepo {
+	return &ExportRepo{exports: make(map[uuid.UUID]export.Export)}
+}
+
+func (r *ExportRepo) Add(ctx context.Context, e export.Export) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if e.IsActive() {
+		for _, existing := range r.exports {
+			if existing.UserID == e.UserID && existing.IsActive() {
+				return ports.ErrExportInProgress
+			}
+		}
+	}
+
+	r.exports[e.ID] = e
+	return nil
+}
+
+func (r *ExportRepo) GetByID(ctx context.Context, id string) (*export.Export, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	exportID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ports.ErrExportNotFound
+	}
+
+	e, ok := r.exports[exportID]
+	if !ok {
+		return nil, ports.ErrExportNotFound
+	}
+	return &e, nil
+}
+
+func (r *ExportRepo) GetByUserID(ctx context.Context, userID string) ([]*export.Export, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	exports := r.filter(func(e export.Export) bool { return e.UserID.String() == userID })
+	slices.SortFunc(exports, func(a, b *export.Export) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return exports, nil
+}
+
+func (r *ExportRepo) Update(ctx context.Context, e export.Export) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.exports[e.ID]; !ok {
+		return ports.ErrExportNotFound
+	}
+
+	r.exports[e.ID] = e
+	return nil
+}
+
+func (r *ExportRepo) GetExpired(ctx context.Context, now time.Time) ([]*export.Export, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	exports := r.filter(func(e export.Export) bool {
+		return e.Status == export.StatusCompleted && e.ExpiresAt != nil && !e.ExpiresAt.After(now)
+	})
+	slices.SortFunc(exports, func(a, b *export.Export) int { return a.ExpiresAt.Compare(*b.ExpiresAt) })
+	return exports, nil
+}
+
+func (r *ExportRepo) filter(keep func(export.Export) bool) []*export.Export {
+	var exports []*export.Export
+	for _, e := range r.exports {
+		if keep(e) {
+			exports = append(exports, &e)
+		}
+	}
+	return exports
+}
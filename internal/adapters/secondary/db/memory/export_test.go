@@ -0,0 +1,47 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+func TestExportRepo_OneInProgress(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewExportRepo()
+	userID := uuid.New()
+
+	first := export.New(userID, time.Now())
+	if err := repo.Add(ctx, first); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if err := repo.Add(ctx, export.New(userID, time.Now())); !errors.Is(err, ports.ErrExportInProgress) {
+		t.Fatalf("expected ErrExportInProgress, got: %v", err)
+	}
+	if err := repo.Add(ctx, export.New(uuid.New(), time.Now())); err != nil {
+		t.Fatalf("expected another user's export to be added, got: %v", err)
+	}
+
+	if err := repo.Update(ctx, first.Fail(time.Now())); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := repo.Add(ctx, export.New(userID, time.Now())); err != nil {
+		t.Fatalf("expected a new export once the first failed, got: %v", err)
+	}
+
+	exports, err := repo.GetByUserID(ctx, userID.String())
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(exports) != 2 {
+		t.Fatalf("expected 2 exports, got %d", len(exports))
+	}
+}
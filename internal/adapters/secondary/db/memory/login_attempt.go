@@ -0,0 +1,91 @@
+// Package memory holds in-process implementations of the ports for local
+// development and tests.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+type LoginAttemptRepo struct {
+	mu       sync.Mutex
+	attempts map[string]auth.LoginAttempts
+	tokens   map[string]auth.UnlockToken
+}
+
+func NewLoginAttemptRepo() *LoginAttemptRepo {
+	return &LoginAttemptRepo{
+		attempts: make(map[string]auth.LoginAttempts),
+		tokens:   make(map[string]auth.UnlockToken),
+	}
+}
+
+func (r *LoginAttemptRepo) Get(ctx context.Context, key string) (auth.LoginAttempts, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempts, ok := r.attempts[key]
+	if !ok {
+		return auth.LoginAttempts{Key: key}, nil
+	}
+	return attempts, nil
+}
+
+func (r *LoginAttemptRepo) Reserve(ctx context.Context, key string, policy auth.LockoutPolicy, now time.Time) (auth.LoginAttempts, time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempts := r.attempts[key]
+	attempts.Key = key
+
+	attempts, blockedUntil := policy.Reserve(attempts, now)
+	if blockedUntil.IsZero() {
+		r.attempts[key] = attempts
+	}
+
+	return attempts, blockedUntil, nil
+}
+
+func (r *LoginAttemptRepo) Release(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if attempts, ok := r.attempts[key]; ok && attempts.Failures > 0 {
+		attempts.Failures--
+		r.attempts[key] = attempts
+	}
+	return nil
+}
+
+func (r *LoginAttemptRepo) Reset(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
+
+func (r *LoginAttemptRepo) AddUnlockToken(ctx context.Context, token auth.UnlockToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[token.Hash] = token
+	return nil
+}
+
+func (r *LoginAttemptRepo) ConsumeUnlockToken(ctx context.Context, hash string) (*auth.UnlockToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.tokens[hash]
+	if !ok {
+		return nil, ports.ErrUnlockTokenNotFound
+	}
+	delete(r.tokens, hash)
+
+	return &token, nil
+}
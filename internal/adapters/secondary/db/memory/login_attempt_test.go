@@ -0,0 +1,113 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+func TestLoginAttemptRepo_Counters(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewLoginAttemptRepo()
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, _ = repo.Reserve(ctx, "user:jane", auth.LockoutPolicy{}, time.Now())
+		}()
+	}
+	wg.Wait()
+
+	attempts, err := repo.Get(ctx, "user:jane")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if attempts.Failures != 50 {
+		t.Errorf("expected 50 failures, got %d", attempts.Failures)
+	}
+
+	if err := repo.Reset(ctx, "user:jane"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	attempts, _ = repo.Get(ctx, "user:jane")
+	if attempts.Failures != 0 {
+		t.Errorf("expected reset counter, got %d", attempts.Failures)
+	}
+}
+
+func TestLoginAttemptRepo_ReserveIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewLoginAttemptRepo()
+	policy := auth.LockoutPolicy{FreeAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour, LockoutThreshold: 10, LockoutDuration: time.Hour}
+	now := time.Now()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		allowed int
+	)
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, blockedUntil, err := repo.Reserve(ctx, "user:jane", policy, now)
+			if err == nil && blockedUntil.IsZero() {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != policy.FreeAttempts {
+		t.Errorf("expected %d attempts to be allowed, got %d", policy.FreeAttempts, allowed)
+	}
+
+	attempts, _ := repo.Get(ctx, "user:jane")
+	if attempts.Failures != policy.FreeAttempts {
+		t.Errorf("expected blocked attempts not to be counted, got %d failures", attempts.Failures)
+	}
+
+	if err := repo.Release(ctx, "user:jane"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	attempts, _ = repo.Get(ctx, "user:jane")
+	if attempts.Failures != policy.FreeAttempts-1 {
+		t.Errorf("expected a released attempt, got %d failures", attempts.Failures)
+	}
+}
+
+func TestLoginAttemptRepo_UnlockTokensAreSingleUse(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewLoginAttemptRepo()
+
+	token, _, err := auth.NewUnlockToken(uuid.New(), time.Hour)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if err := repo.AddUnlockToken(ctx, token); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	got, err := repo.ConsumeUnlockToken(ctx, token.Hash)
+	if err != nil || got.UserID != token.UserID {
+		t.Fatalf("ConsumeUnlockToken() = %v, %v", got, err)
+	}
+
+	if _, err := repo.ConsumeUnlockToken(ctx, token.Hash); !errors.Is(err, ports.ErrUnlockTokenNotFound) {
+		t.Errorf("expected second use to fail with %v, got %v", ports.ErrUnlockTokenNotFound, err)
+	}
+}
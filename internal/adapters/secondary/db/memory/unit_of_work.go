@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// UnitOfWork runs fn directly and does not roll anything back, writes fn made
+// before it failed stay in the fakes and mocks it wrote to. Tests check the
+// unit instead: Running tells a repository call made inside fn from one made
+// outside, and Failures holds what fn returned when it failed, so a test can
+// tell the error of the failing write reached the unit and nothing ran after
+// it. Undoing the writes is up to the database adapter.
+type UnitOfWork struct {
+	mu       sync.Mutex
+	running  int
+	commits  int
+	failures []error
+}
+
+func NewUnitOfWork() *UnitOfWork {
+	return &UnitOfWork{}
+}
+
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.mu.Lock()
+	u.running++
+	u.mu.Unlock()
+
+	err := fn(ctx)
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.running--
+	if err != nil {
+		u.failures = append(u.failures, err)
+		return err
+	}
+
+	u.commits++
+	return nil
+}
+
+// Running reports whether fn of a unit of work is running, repository calls
+// made now are part of it.
+func (u *UnitOfWork) Running() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.running > 0
+}
+
+// Commits returns how many units of work succeeded.
+func (u *UnitOfWork) Commits() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.commits
+}
+
+// Rollbacks returns how many units of work failed, their writes were kept.
+func (u *UnitOfWork) Rollbacks() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return len(u.failures)
+}
+
+// Failures returns the errors the failed units of work returned, in order.
+func (u *UnitOfWork) Failures() []error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return slices.Clone(u.failures)
+}
@@ -0,0 +1,42 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+)
+
+func TestUnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	uow := memory.NewUnitOfWork()
+	errFailed := errors.New("failed")
+
+	err := uow.Do(ctx, func(ctx context.Context) error {
+		if !uow.Running() {
+			t.Error("expected the unit of work to be running inside fn")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if err := uow.Do(ctx, func(ctx context.Context) error { return errFailed }); !errors.Is(err, errFailed) {
+		t.Fatalf("expected %v, got: %v", errFailed, err)
+	}
+
+	if uow.Running() {
+		t.Error("expected no unit of work to be running")
+	}
+	if uow.Commits() != 1 {
+		t.Errorf("expected 1 commit, got %d", uow.Commits())
+	}
+	if uow.Rollbacks() != 1 {
+		t.Errorf("expected 1 rollback, got %d", uow.Rollbacks())
+	}
+	if failures := uow.Failures(); len(failures) != 1 || !errors.Is(failures[0], errFailed) {
+		t.Errorf("expected the failure %v, got %v", errFailed, failures)
+	}
+}
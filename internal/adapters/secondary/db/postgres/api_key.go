@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+type APIKeyRepo struct {
+	db      *sql.DB
+	typeMap *pgtype.Map
+}
+
+func NewAPIKeyRepo(db *sql.DB) (*APIKeyRepo, error) {
+	return &APIKeyRepo{
+		db:      db,
+		typeMap: pgtype.NewMap(),
+	}, nil
+}
+
+const CreateAPIKey = `INSERT INTO api_keys (id, user_id, name, prefix, key_hash, scopes, expires_at, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+
+func (r *APIKeyRepo) Add(ctx context.Context, key auth.APIKey) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
+		_, err := tx.ExecContext(ctx, CreateAPIKey, key.ID, key.UserID, key.Name, key.Prefix, key.Hash, key.Scopes.ToStrings(), key.ExpiresAt, key.CreatedAt, key.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		logr.Get().Info("New api key created!")
+
+		return nil
+	})
+}
+
+const apiKeyColumns = `id, user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at, updated_at`
+
+const GetAPIKeyByPrefix = `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE prefix = $1`
+
+func (r *APIKeyRepo) GetByPrefix(ctx context.Context, prefix string) (*auth.APIKey, error) {
+	return r.getOne(ctx, GetAPIKeyByPrefix, prefix)
+}
+
+const GetAPIKeyByID = `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1`
+
+func (r *APIKeyRepo) GetByID(ctx context.Context, id string) (*auth.APIKey, error) {
+	return r.getOne(ctx, GetAPIKeyByID, id)
+}
+
+const GetAPIKeysByUserID = `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE user_id = $1 ORDER BY created_at DESC`
+
+func (r *APIKeyRepo) GetByUserID(ctx context.Context, userID string) ([]*auth.APIKey, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, GetAPIKeysByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*auth.APIKey
+	for rows.Next() {
+		key, err := r.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// TouchAPIKey never writes revoked_at, so a revocation committed after the
+// key was read cannot be undone by recording its use.
+const TouchAPIKey = `UPDATE api_keys SET last_used_at = $1, updated_at = $1 WHERE id = $2 AND revoked_at IS NULL`
+
+func (r *APIKeyRepo) Touch(ctx context.Context, id string, lastUsedAt time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, TouchAPIKey, lastUsedAt, id)
+	return err
+}
+
+const RevokeAPIKey = `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, $1), updated_at = $1 WHERE id = $2`
+
+func (r *APIKeyRepo) Revoke(ctx context.Context, id string, at time.Time) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, RevokeAPIKey, at, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ports.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepo) getOne(ctx context.Context, query string, arg string) (*auth.APIKey, error) {
+	key, err := r.scan(conn(ctx, r.db).QueryRowContext(ctx, query, arg))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ports.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return key, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func (r *APIKeyRepo) scan(row scanner) (*auth.APIKey, error) {
+	var key auth.APIKey
+	var scopesArray pgtype.Array[string]
+
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.Name,
+		&key.Prefix,
+		&key.Hash,
+		r.typeMap.SQLScanner(&scopesArray),
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+		&key.CreatedAt,
+		&key.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, scope := range scopesArray.Elements {
+		key.Scopes = append(key.Scopes, auth.Scope(scope))
+	}
+
+	return &key, nil
+}
@@ -0,0 +1,94 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/postgres"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+)
+
+func TestAPIKeyRepo_TouchKeepsLaterRevocation(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	owner := addTestUser(t, db)
+
+	repo, err := postgres.NewAPIKeyRepo(db)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	key, _, err := auth.NewAPIKey(owner.ID, "script", auth.Scopes{auth.ScopeStatsRead}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := repo.Add(ctx, key); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	// The key is read while active and revoked before its use is recorded
+	read, err := repo.GetByPrefix(ctx, key.Prefix)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !read.IsActive() {
+		t.Fatal("expected the key to be active when read")
+	}
+
+	revokedAt := time.Now()
+	if err := repo.Revoke(ctx, key.ID.String(), revokedAt); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	read.MarkUsed(revokedAt.Add(time.Second))
+	if err := repo.Touch(ctx, read.ID.String(), *read.LastUsedAt); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, key.ID.String())
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !got.IsRevoked() {
+		t.Fatal("expected the key to stay revoked")
+	}
+	if got.LastUsedAt != nil {
+		t.Errorf("expected a revoked key not to record uses, got %v", got.LastUsedAt)
+	}
+}
+
+func TestAPIKeyRepo_RevokeKeepsFirstRevocation(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	owner := addTestUser(t, db)
+
+	repo, err := postgres.NewAPIKeyRepo(db)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	key, _, err := auth.NewAPIKey(owner.ID, "script", auth.Scopes{auth.ScopeStatsRead}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := repo.Add(ctx, key); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	first := time.Now().Add(-time.Hour).UTC().Truncate(time.Microsecond)
+	if err := repo.Revoke(ctx, key.ID.String(), first); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := repo.Revoke(ctx, key.ID.String(), time.Now()); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, key.ID.String())
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if got.RevokedAt == nil || !got.RevokedAt.Equal(first) {
+		t.Errorf("expected revoked_at %v, got %v", first, got.RevokedAt)
+	}
+}
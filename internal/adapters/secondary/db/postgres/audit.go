@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+type AuditRepo struct {
+	db *sql.DB
+}
+
+func NewAuditRepo(db *sql.DB) (*AuditRepo, error) {
+	return &AuditRepo{db: db}, nil
+}
+
+const CreateAuditEvent = `INSERT INTO audit_events (id, action, actor_id, target_id, metadata, ip, user_agent, created_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+
+func (r *AuditRepo) Record(ctx context.Context, event audit.Event) error {
+	metadata := event.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit metadata: %w", err)
+	}
+
+	return WithTransaction(ctx, r.db, func(tx querier) error {
+		_, err := tx.ExecContext(ctx, CreateAuditEvent, event.ID, event.Action, event.ActorID, event.TargetID, string(encoded), event.IP, event.UserAgent, event.CreatedAt)
+		return err
+	})
+}
+
+// The append-only trigger lets EraseAuditEvents through while
+// athena.audit_erasure is on, it is switched off again right after.
+const (
+	SetAuditErasure  = `SELECT set_config('athena.audit_erasure', $1, true)`
+	EraseAuditEvents = `UPDATE audit_events SET
+		actor_id = CASE WHEN actor_id = $1 THEN $2 ELSE actor_id END,
+		target_id = CASE WHEN target_id = $1 THEN $2 ELSE target_id END,
+		metadata = '{}', ip = '', user_agent = ''
+		WHERE actor_id = $1 OR target_id = $1`
+)
+
+func (r *AuditRepo) Erase(ctx context.Context, userID, tombstone uuid.UUID) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
+		if _, err := tx.ExecContext(ctx, SetAuditErasure, "on"); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, EraseAuditEvents, userID, tombstone); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, SetAuditErasure, "off")
+		return err
+	})
+}
+
+const auditEventColumns = `id, action, actor_id, target_id, metadata, ip, user_agent, created_at`
+
+func (r *AuditRepo) Search(ctx context.Context, filter ports.AuditFilter) ([]audit.Event, int, error) {
+	var (
+		where []string
+		args  []any
+	)
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+
+	if filter.ActorID != nil {
+		add(`actor_id = $%d`, *filter.ActorID)
+	}
+	if filter.TargetID != nil {
+		add(`target_id = $%d`, *filter.TargetID)
+	}
+	if len(filter.Actions) > 0 {
+		actions := make([]string, 0, len(filter.Actions))
+		for _, action := range filter.Actions {
+			actions = append(actions, string(action))
+		}
+		add(`action = ANY($%d)`, actions)
+	}
+	if filter.Since != nil {
+		add(`created_at >= $%d`, *filter.Since)
+	}
+	if filter.Until != nil {
+		add(`created_at < $%d`, *filter.Until)
+	}
+
+	conditions := ""
+	if len(where) > 0 {
+		conditions = " WHERE " + strings.Join(where, " AND ")
+	}
+
+	var total int
+	if err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT count(*) FROM audit_events`+conditions, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`SELECT `+auditEventColumns+` FROM audit_events%s ORDER BY created_at DESC, id LIMIT $%d OFFSET $%d`,
+		conditions, len(args)+1, len(args)+2)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var events []audit.Event
+	for rows.Next() {
+		event, err := r.scan(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		events = append(events, event)
+	}
+
+	return events, total, rows.Err()
+}
+
+func (r *AuditRepo) scan(row scanner) (audit.Event, error) {
+	var event audit.Event
+	var metadata []byte
+
+	err := row.Scan(
+		&event.ID,
+		&event.Action,
+		&event.ActorID,
+		&event.TargetID,
+		&metadata,
+		&event.IP,
+		&event.UserAgent,
+		&event.CreatedAt,
+	)
+	if err != nil {
+		return audit.Event{}, err
+	}
+
+	if err := json.Unmarshal(metadata, &event.Metadata); err != nil {
+		return audit.Event{}, fmt.Errorf("failed to decode audit metadata: %w", err)
+	}
+
+	return event, nil
+}
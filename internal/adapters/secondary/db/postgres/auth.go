@@ -4,6 +4,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/cheezecakee/logr"
 	"github.com/google/uuid"
@@ -28,7 +29,7 @@ func NewAuthRepo(db *sql.DB) (*AuthRepo, error) {
 const CreateRefreshToken = `INSERT INTO refresh_tokens (token, user_id, is_revoked, expires_at, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6)`
 
 func (r *AuthRepo) Add(ctx context.Context, refreshToken auth.RefreshToken) error {
-	return WithTransaction(ctx, r.db, func(tx *sql.Tx) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
 		_, err := tx.ExecContext(ctx, CreateRefreshToken, refreshToken.Token, refreshToken.UserID, refreshToken.IsRevoked, refreshToken.ExpiresAt, refreshToken.CreatedAt, refreshToken.UpdatedAt)
 		if err != nil {
 			return err
@@ -45,7 +46,7 @@ const GetRefreshTokenByToken = `SELECT user_id, is_revoked, expires_at, revoked_
 func (r *AuthRepo) GetByToken(ctx context.Context, token string) (*auth.RefreshToken, error) {
 	var row auth.RefreshToken
 
-	err := r.db.QueryRowContext(ctx, GetRefreshTokenByToken, token).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, GetRefreshTokenByToken, token).Scan(
 		&row.UserID,
 		&row.IsRevoked,
 		&row.ExpiresAt,
@@ -67,7 +68,7 @@ func (r *AuthRepo) GetByToken(ctx context.Context, token string) (*auth.RefreshT
 const GetRefreshTokenByID = `SELECT token, is_revoked, expires_at, revoked_at, created_at, updated_at FROM refresh_tokens WHERE user_id = $1`
 
 func (r *AuthRepo) GetByID(ctx context.Context, userID string) ([]*auth.RefreshToken, error) {
-	rows, err := r.db.QueryContext(ctx, GetRefreshTokenByID, userID)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, GetRefreshTokenByID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -102,7 +103,7 @@ const UpdateRefreshToken = `UPDATE refresh_tokens
 	`
 
 func (r *AuthRepo) Update(ctx context.Context, refreshToken auth.RefreshToken) error {
-	return WithTransaction(ctx, r.db, func(tx *sql.Tx) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
 		result, err := tx.ExecContext(ctx, UpdateRefreshToken, refreshToken.Token, refreshToken.IsRevoked, refreshToken.ExpiresAt, refreshToken.RevokedAt, refreshToken.UpdatedAt)
 		if err != nil {
 			return err
@@ -122,10 +123,34 @@ func (r *AuthRepo) Update(ctx context.Context, refreshToken auth.RefreshToken) e
 	})
 }
 
+const RevokeAllRefreshTokens = `UPDATE refresh_tokens
+	SET is_revoked = true,
+		revoked_at = $2,
+		updated_at = $2
+	WHERE user_id = $1 AND NOT is_revoked
+	`
+
+func (r *AuthRepo) RevokeAll(ctx context.Context, userID string, revokedAt time.Time) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
+		result, err := tx.ExecContext(ctx, RevokeAllRefreshTokens, userID, revokedAt)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		logr.Get().Infof("%d refresh tokens revoked!", rowsAffected)
+		return nil
+	})
+}
+
 const DeleteRefreshToken = `DELETE from refresh_tokens WHERE token = $1`
 
 func (r *AuthRepo) Delete(ctx context.Context, token string) error {
-	return WithTransaction(ctx, r.db, func(tx *sql.Tx) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
 		result, err := tx.ExecContext(ctx, DeleteRefreshToken, token)
 		if err != nil {
 			return err
@@ -1,34 +1,65 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
-	"os"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/cheezecakee/logr"
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
-func NewPostgresConn() *sql.DB {
-	conn := os.Getenv("DB_CONN_STRING")
-	if conn == "" {
-		logr.Get().Errorf("DB_CONN_STRING environment variable is required")
-		return nil
-	}
+var ErrMissingConnString = errors.New("database connection string is required")
+
+const pingTimeout = 5 * time.Second
+
+// Config sizes the connection pool, zero values keep the database/sql
+// defaults.
+type Config struct {
+	ConnString      string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
 
-	logr.Get().Infof("dbConn: %s", conn)
+// NewPostgresConn opens the pool and pings the database, so a bad connection
+// string fails at startup instead of on the first query.
+func NewPostgresConn(cfg Config) (*sql.DB, error) {
+	if cfg.ConnString == "" {
+		return nil, ErrMissingConnString
+	}
 
-	db, err := sql.Open("pgx", conn)
+	db, err := sql.Open("pgx", cfg.ConnString)
 	if err != nil {
 		logr.Get().Errorf("Failed to connect to database: %v", err)
-		return nil
+		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	if err := db.Ping(); err != nil {
-		logr.Get().Errorf("Failed to ping database: %v", err)
-		return nil
+	if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
 
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		logr.Get().Errorf("Failed to ping database: %v", err)
+		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
 	logr.Get().Info("Connected successfully!")
-	return db
+	return db, nil
 }
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+type ExportRepo struct {
+	db *sql.DB
+}
+
+func NewExportRepo(db *sql.DB) (*ExportRepo, error) {
+	return &ExportRepo{db: db}, nil
+}
+
+const CreateExport = `INSERT INTO data_exports (id, user_id, status, file_key, size, completed_at, expires_at, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+
+func (r *ExportRepo) Add(ctx context.Context, e export.Export) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
+		_, err := tx.ExecContext(ctx, CreateExport, e.ID, e.UserID, e.Status, e.FileKey, e.Size, e.CompletedAt, e.ExpiresAt, e.CreatedAt, e.UpdatedAt)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == "data_exports_user_id_active_idx" {
+				return ports.ErrExportInProgress
+			}
+			return err
+		}
+
+		logr.Get().Info("New data export requested!")
+
+		return nil
+	})
+}
+
+const exportColumns = `id, user_id, status, file_key, size, completed_at, expires_at, created_at, updated_at`
+
+const GetExportByID = `SELECT ` + exportColumns + ` FROM data_exports WHERE id = $1`
+
+func (r *ExportRepo) GetByID(ctx context.Context, id string) (*export.Export, error) {
+	e, err := r.scan(conn(ctx, r.db).QueryRowContext(ctx, GetExportByID, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ports.ErrExportNotFound
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+const GetExportsByUserID = `SELECT ` + exportColumns + ` FROM data_exports WHERE user_id = $1 ORDER BY created_at DESC`
+
+func (r *ExportRepo) GetByUserID(ctx context.Context, userID string) ([]*export.Export, error) {
+	return r.list(ctx, GetExportsByUserID, userID)
+}
+
+const GetExpiredExports = `SELECT ` + exportColumns + ` FROM data_exports WHERE status = 'completed' AND expires_at <= $1 ORDER BY expires_at`
+
+func (r *ExportRepo) GetExpired(ctx context.Context, now time.Time) ([]*export.Export, error) {
+	return r.list(ctx, GetExpiredExports, now)
+}
+
+const UpdateExport = `UPDATE data_exports SET status = $1, file_key = $2, size = $3, completed_at = $4, expires_at = $5, updated_at = $6 WHERE id = $7`
+
+func (r *ExportRepo) Update(ctx context.Context, e export.Export) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
+		result, err := tx.ExecContext(ctx, UpdateExport, e.Status, e.FileKey, e.Size, e.CompletedAt, e.ExpiresAt, e.UpdatedAt, e.ID)
+		if err != nil {
+			return err
+		}
+
+		n, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ports.ErrExportNotFound
+		}
+
+		return nil
+	})
+}
+
+func (r *ExportRepo) list(ctx context.Context, query string, arg any) ([]*export.Export, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var exports []*export.Export
+	for rows.Next() {
+		e, err := r.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		exports = append(exports, e)
+	}
+	return exports, rows.Err()
+}
+
+func (r *ExportRepo) scan(row scanner) (*export.Export, error) {
+	var e export.Export
+
+	err := row.Scan(
+		&e.ID,
+		&e.UserID,
+		&e.Status,
+		&e.FileKey,
+		&e.Size,
+		&e.CompletedAt,
+		&e.ExpiresAt,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
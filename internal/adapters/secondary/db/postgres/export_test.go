@@ -0,0 +1,40 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/postgres"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+func TestExportRepo_OneInProgress(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	owner := addTestUser(t, db)
+
+	repo, err := postgres.NewExportRepo(db)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	first := export.New(owner.ID, time.Now())
+	if err := repo.Add(ctx, first); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	// Both requests passed the check in the service, the index stops the second
+	if err := repo.Add(ctx, export.New(owner.ID, time.Now())); !errors.Is(err, ports.ErrExportInProgress) {
+		t.Fatalf("expected ErrExportInProgress, got: %v", err)
+	}
+
+	if err := repo.Update(ctx, first.Fail(time.Now())); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := repo.Add(ctx, export.New(owner.ID, time.Now())); err != nil {
+		t.Fatalf("expected a new export once the first failed, got: %v", err)
+	}
+}
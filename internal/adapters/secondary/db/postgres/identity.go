@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/cheezecakee/logr"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+const uniqueViolation = "23505"
+
+type IdentityRepo struct {
+	db *sql.DB
+}
+
+func NewIdentityRepo(db *sql.DB) (*IdentityRepo, error) {
+	return &IdentityRepo{db: db}, nil
+}
+
+const CreateIdentity = `INSERT INTO user_identities (provider, subject, user_id, email, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6)`
+
+func (r *IdentityRepo) Add(ctx context.Context, identity auth.Identity) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
+		_, err := tx.ExecContext(ctx, CreateIdentity, identity.Provider, identity.Subject, identity.UserID, identity.Email, identity.CreatedAt, identity.UpdatedAt)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+				return ports.ErrIdentityLinked
+			}
+			return err
+		}
+
+		logr.Get().Info("New identity linked!")
+
+		return nil
+	})
+}
+
+const GetIdentityByProviderSubject = `SELECT user_id, email, created_at, updated_at FROM user_identities WHERE provider = $1 AND subject = $2`
+
+func (r *IdentityRepo) GetByProviderSubject(ctx context.Context, provider, subject string) (*auth.Identity, error) {
+	row := auth.Identity{Provider: provider, Subject: subject}
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, GetIdentityByProviderSubject, provider, subject).Scan(
+		&row.UserID,
+		&row.Email,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ports.ErrIdentityNotFound
+		}
+		return nil, err
+	}
+
+	return &row, nil
+}
+
+const GetIdentitiesByUserID = `SELECT provider, subject, user_id, email, created_at, updated_at FROM user_identities WHERE user_id = $1 ORDER BY created_at`
+
+func (r *IdentityRepo) GetByUserID(ctx context.Context, userID string) ([]*auth.Identity, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, GetIdentitiesByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var identities []*auth.Identity
+	for rows.Next() {
+		var identity auth.Identity
+		err := rows.Scan(
+			&identity.Provider,
+			&identity.Subject,
+			&identity.UserID,
+			&identity.Email,
+			&identity.CreatedAt,
+			&identity.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, &identity)
+	}
+	return identities, rows.Err()
+}
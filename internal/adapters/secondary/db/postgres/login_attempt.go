@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+type LoginAttemptRepo struct {
+	db *sql.DB
+}
+
+func NewLoginAttemptRepo(db *sql.DB) (*LoginAttemptRepo, error) {
+	return &LoginAttemptRepo{db: db}, nil
+}
+
+const GetLoginAttempts = `SELECT failures, last_failure FROM login_attempts WHERE key = $1`
+
+func (r *LoginAttemptRepo) Get(ctx context.Context, key string) (auth.LoginAttempts, error) {
+	attempts := auth.LoginAttempts{Key: key}
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, GetLoginAttempts, key).Scan(&attempts.Failures, &attempts.LastFailure)
+	if err != nil && err != sql.ErrNoRows {
+		return auth.LoginAttempts{}, err
+	}
+
+	return attempts, nil
+}
+
+// LockLoginAttempts creates the counter if needed and returns it, the no-op
+// update locks the row until the transaction ends so concurrent attempts
+// reserve one after the other.
+const (
+	LockLoginAttempts = `INSERT INTO login_attempts (key, failures, last_failure) VALUES ($1, 0, $2)
+		ON CONFLICT (key) DO UPDATE SET key = EXCLUDED.key
+		RETURNING failures, last_failure`
+	UpdateLoginAttempts  = `UPDATE login_attempts SET failures = $1, last_failure = $2 WHERE key = $3`
+	ReleaseLoginAttempts = `UPDATE login_attempts SET failures = GREATEST(failures - 1, 0) WHERE key = $1`
+)
+
+func (r *LoginAttemptRepo) Reserve(ctx context.Context, key string, policy auth.LockoutPolicy, now time.Time) (auth.LoginAttempts, time.Time, error) {
+	attempts := auth.LoginAttempts{Key: key}
+	var blockedUntil time.Time
+
+	err := WithTransaction(ctx, r.db, func(tx querier) error {
+		if err := tx.QueryRowContext(ctx, LockLoginAttempts, key, now).Scan(&attempts.Failures, &attempts.LastFailure); err != nil {
+			return err
+		}
+
+		attempts, blockedUntil = policy.Reserve(attempts, now)
+		if !blockedUntil.IsZero() {
+			return nil
+		}
+
+		_, err := tx.ExecContext(ctx, UpdateLoginAttempts, attempts.Failures, attempts.LastFailure, key)
+		return err
+	})
+	if err != nil {
+		return auth.LoginAttempts{}, time.Time{}, err
+	}
+
+	return attempts, blockedUntil, nil
+}
+
+func (r *LoginAttemptRepo) Release(ctx context.Context, key string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, ReleaseLoginAttempts, key)
+	return err
+}
+
+const ResetLoginAttempts = `DELETE FROM login_attempts WHERE key = $1`
+
+func (r *LoginAttemptRepo) Reset(ctx context.Context, key string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, ResetLoginAttempts, key)
+	return err
+}
+
+const CreateUnlockToken = `INSERT INTO account_unlock_tokens (token_hash, user_id, expires_at, created_at) VALUES ($1,$2,$3,$4)`
+
+func (r *LoginAttemptRepo) AddUnlockToken(ctx context.Context, token auth.UnlockToken) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
+		_, err := tx.ExecContext(ctx, CreateUnlockToken, token.Hash, token.UserID, token.ExpiresAt, token.CreatedAt)
+		return err
+	})
+}
+
+const ConsumeUnlockToken = `DELETE FROM account_unlock_tokens WHERE token_hash = $1 RETURNING user_id, expires_at, created_at`
+
+func (r *LoginAttemptRepo) ConsumeUnlockToken(ctx context.Context, hash string) (*auth.UnlockToken, error) {
+	token := auth.UnlockToken{Hash: hash}
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, ConsumeUnlockToken, hash).Scan(&token.UserID, &token.ExpiresAt, &token.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ports.ErrUnlockTokenNotFound
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
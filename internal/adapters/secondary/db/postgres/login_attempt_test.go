@@ -0,0 +1,62 @@
+package postgres_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/postgres"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+)
+
+func TestLoginAttemptRepo_ReserveIsAtomic(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	repo, err := postgres.NewLoginAttemptRepo(db)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	key := auth.AccountAttemptKey("test_" + uuid.NewString()[:8])
+	policy := auth.LockoutPolicy{FreeAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour, LockoutThreshold: 10, LockoutDuration: time.Hour}
+	now := time.Now()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		allowed int
+	)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, blockedUntil, err := repo.Reserve(ctx, key, policy, now)
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+			if blockedUntil.IsZero() {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != policy.FreeAttempts {
+		t.Errorf("expected %d attempts to be allowed, got %d", policy.FreeAttempts, allowed)
+	}
+
+	attempts, err := repo.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if attempts.Failures != policy.FreeAttempts {
+		t.Errorf("expected blocked attempts not to be counted, got %d failures", attempts.Failures)
+	}
+}
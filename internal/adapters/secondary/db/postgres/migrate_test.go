@@ -0,0 +1,126 @@
+package postgres_test
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/postgres"
+)
+
+func TestMigrations_Embedded(t *testing.T) {
+	migrations, err := postgres.Migrations()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(migrations) == 0 {
+		t.Fatal("expected embedded migrations")
+	}
+
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Errorf("expected version %d, got %d (%s)", i+1, m.Version, m.Name)
+		}
+		if m.Checksum == "" {
+			t.Errorf("expected a checksum for %s", m.Name)
+		}
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	file := func(body string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(body)} }
+
+	tests := []struct {
+		name     string
+		fsys     fstest.MapFS
+		wantErr  error
+		versions []int
+	}{
+		{
+			name: "valid - ordered by version",
+			fsys: fstest.MapFS{
+				"0002_b.up.sql":   file("CREATE TABLE b ();"),
+				"0002_b.down.sql": file("DROP TABLE b;"),
+				"0001_a.up.sql":   file("CREATE TABLE a ();"),
+				"0001_a.down.sql": file("DROP TABLE a;"),
+				"README.md":       file("ignored"),
+			},
+			versions: []int{1, 2},
+		},
+		{
+			name: "invalid - missing down",
+			fsys: fstest.MapFS{
+				"0001_a.up.sql": file("CREATE TABLE a ();"),
+			},
+			wantErr: postgres.ErrInvalidMigration,
+		},
+		{
+			name: "invalid - version used twice",
+			fsys: fstest.MapFS{
+				"0001_a.up.sql":   file("CREATE TABLE a ();"),
+				"0001_a.down.sql": file("DROP TABLE a;"),
+				"0001_b.up.sql":   file("CREATE TABLE b ();"),
+				"0001_b.down.sql": file("DROP TABLE b;"),
+			},
+			wantErr: postgres.ErrInvalidMigration,
+		},
+		{
+			name: "invalid - file name",
+			fsys: fstest.MapFS{
+				"create_a.sql": file("CREATE TABLE a ();"),
+			},
+			wantErr: postgres.ErrInvalidMigration,
+		},
+		{
+			name: "invalid - version zero",
+			fsys: fstest.MapFS{
+				"0000_a.up.sql":   file("CREATE TABLE a ();"),
+				"0000_a.down.sql": file("DROP TABLE a;"),
+			},
+			wantErr: postgres.ErrInvalidMigration,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := postgres.LoadMigrations(tt.fsys)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			if len(migrations) != len(tt.versions) {
+				t.Fatalf("expected %d migrations, got %d", len(tt.versions), len(migrations))
+			}
+			for i, m := range migrations {
+				if m.Version != tt.versions[i] {
+					t.Errorf("expected version %d, got %d", tt.versions[i], m.Version)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadMigrations_ChecksumFollowsUpScript(t *testing.T) {
+	load := func(up string) string {
+		t.Helper()
+		migrations, err := postgres.LoadMigrations(fstest.MapFS{
+			"0001_a.up.sql":   &fstest.MapFile{Data: []byte(up)},
+			"0001_a.down.sql": &fstest.MapFile{Data: []byte("DROP TABLE a;")},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		return migrations[0].Checksum
+	}
+
+	if load("CREATE TABLE a ();") == load("CREATE TABLE a (id INT);") {
+		t.Error("expected an edited migration to change its checksum")
+	}
+}
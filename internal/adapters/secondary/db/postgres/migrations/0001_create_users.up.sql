@@ -0,0 +1,10 @@
+CREATE TABLE users (
+    id            UUID PRIMARY KEY,
+    username      TEXT NOT NULL UNIQUE,
+    full_name     TEXT NOT NULL,
+    email         TEXT NOT NULL UNIQUE,
+    roles         TEXT[] NOT NULL DEFAULT '{}',
+    password_hash TEXT NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL
+);
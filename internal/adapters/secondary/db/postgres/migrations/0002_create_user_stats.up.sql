@@ -0,0 +1,15 @@
+CREATE TABLE user_stats (
+    user_id            UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    weight             DOUBLE PRECISION,
+    height             DOUBLE PRECISION,
+    body_fat_percent   DOUBLE PRECISION,
+    rest_days          INTEGER NOT NULL,
+    current_streak     INTEGER NOT NULL DEFAULT 0,
+    longest_streak     INTEGER NOT NULL DEFAULT 0,
+    last_workout_date  TIMESTAMPTZ,
+    total_workouts     INTEGER NOT NULL DEFAULT 0,
+    total_lifted       DOUBLE PRECISION NOT NULL DEFAULT 0,
+    total_time_minutes INTEGER NOT NULL DEFAULT 0,
+    created_at         TIMESTAMPTZ NOT NULL,
+    updated_at         TIMESTAMPTZ NOT NULL
+);
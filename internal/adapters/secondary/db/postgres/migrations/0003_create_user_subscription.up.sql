@@ -0,0 +1,15 @@
+CREATE TABLE user_subscription (
+    user_id               UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    plan                  TEXT NOT NULL,
+    billing_period        TEXT,
+    started_at            TIMESTAMPTZ NOT NULL,
+    expires_at            TIMESTAMPTZ,
+    auto_renew            BOOLEAN NOT NULL DEFAULT FALSE,
+    cancelled_at          TIMESTAMPTZ,
+    last_payment_at       TIMESTAMPTZ,
+    last_payment_amount   DOUBLE PRECISION,
+    last_payment_currency TEXT,
+    trial_ends_at         TIMESTAMPTZ,
+    created_at            TIMESTAMPTZ NOT NULL,
+    updated_at            TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,13 @@
+CREATE TABLE user_settings (
+    user_id               UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    preferred_weight_unit TEXT NOT NULL,
+    preferred_height_unit TEXT NOT NULL,
+    theme                 TEXT NOT NULL,
+    profile_visibility    TEXT NOT NULL,
+    email_notifications   BOOLEAN NOT NULL DEFAULT TRUE,
+    push_notifications    BOOLEAN NOT NULL DEFAULT TRUE,
+    workout_reminders     BOOLEAN NOT NULL DEFAULT TRUE,
+    streak_reminders      BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at            TIMESTAMPTZ NOT NULL,
+    updated_at            TIMESTAMPTZ NOT NULL
+);
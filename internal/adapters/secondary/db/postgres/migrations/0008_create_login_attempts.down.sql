@@ -0,0 +1,2 @@
+DROP TABLE account_unlock_tokens;
+DROP TABLE login_attempts;
@@ -0,0 +1,12 @@
+CREATE TABLE login_attempts (
+    key          TEXT PRIMARY KEY,
+    failures     INTEGER NOT NULL,
+    last_failure TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE account_unlock_tokens (
+    token_hash TEXT PRIMARY KEY,
+    user_id    UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
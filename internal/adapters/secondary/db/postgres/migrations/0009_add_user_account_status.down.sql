@@ -0,0 +1,6 @@
+DROP INDEX users_created_at_idx;
+
+ALTER TABLE users
+    DROP COLUMN suspension_reason,
+    DROP COLUMN suspended_at,
+    DROP COLUMN email_verified_at;
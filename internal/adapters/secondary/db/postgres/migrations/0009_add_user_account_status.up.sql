@@ -0,0 +1,7 @@
+ALTER TABLE users
+    ADD COLUMN email_verified_at TIMESTAMPTZ,
+    ADD COLUMN suspended_at      TIMESTAMPTZ,
+    ADD COLUMN suspension_reason TEXT NOT NULL DEFAULT '';
+
+-- Admin search sorts and filters by creation date
+CREATE INDEX users_created_at_idx ON users (created_at DESC, id);
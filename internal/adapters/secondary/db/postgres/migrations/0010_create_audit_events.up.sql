@@ -0,0 +1,12 @@
+-- No foreign keys, the log outlives the accounts it mentions
+CREATE TABLE audit_events (
+    id         UUID PRIMARY KEY,
+    action     TEXT NOT NULL,
+    actor_id   UUID NOT NULL,
+    target_id  UUID NOT NULL,
+    metadata   JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX audit_events_actor_id_idx ON audit_events (actor_id, created_at DESC);
+CREATE INDEX audit_events_target_id_idx ON audit_events (target_id, created_at DESC);
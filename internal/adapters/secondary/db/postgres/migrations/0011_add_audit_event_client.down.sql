@@ -0,0 +1,8 @@
+DROP TRIGGER audit_events_append_only ON audit_events;
+DROP FUNCTION audit_events_append_only();
+
+DROP INDEX audit_events_action_idx;
+
+ALTER TABLE audit_events
+    DROP COLUMN user_agent,
+    DROP COLUMN ip;
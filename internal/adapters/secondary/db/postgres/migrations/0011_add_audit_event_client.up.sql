@@ -0,0 +1,16 @@
+ALTER TABLE audit_events
+    ADD COLUMN ip         TEXT NOT NULL DEFAULT '',
+    ADD COLUMN user_agent TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX audit_events_action_idx ON audit_events (action, created_at DESC);
+
+-- The log is append-only, rows are never changed or removed
+CREATE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,14 @@
+CREATE TABLE data_exports (
+    id           UUID PRIMARY KEY,
+    user_id      UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    status       TEXT NOT NULL,
+    file_key     TEXT NOT NULL DEFAULT '',
+    size         BIGINT NOT NULL DEFAULT 0,
+    completed_at TIMESTAMPTZ,
+    expires_at   TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX data_exports_user_id_idx ON data_exports (user_id, created_at DESC);
+CREATE INDEX data_exports_expires_at_idx ON data_exports (expires_at) WHERE status = 'completed';
@@ -0,0 +1,4 @@
+DROP INDEX users_deletion_requested_at_idx;
+
+ALTER TABLE users
+    DROP COLUMN deletion_requested_at;
@@ -0,0 +1,6 @@
+ALTER TABLE users
+    ADD COLUMN deletion_requested_at TIMESTAMPTZ;
+
+-- The purge job looks for accounts whose grace period has run out
+CREATE INDEX users_deletion_requested_at_idx ON users (deletion_requested_at)
+    WHERE deletion_requested_at IS NOT NULL;
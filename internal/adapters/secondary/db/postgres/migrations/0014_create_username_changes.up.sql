@@ -0,0 +1,10 @@
+CREATE TABLE username_changes (
+    user_id      UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    old_username TEXT NOT NULL,
+    new_username TEXT NOT NULL,
+    changed_at   TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, changed_at)
+);
+
+-- Old usernames are looked up to resolve and reserve them
+CREATE INDEX username_changes_old_username_idx ON username_changes (old_username, changed_at DESC);
@@ -0,0 +1,15 @@
+CREATE TABLE photos (
+    id            UUID PRIMARY KEY,
+    user_id       UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    kind          TEXT NOT NULL,
+    content_type  TEXT NOT NULL,
+    width         INTEGER NOT NULL,
+    height        INTEGER NOT NULL,
+    size          BIGINT NOT NULL,
+    file_key      TEXT NOT NULL,
+    thumbnail_key TEXT NOT NULL,
+    taken_on      DATE,
+    created_at    TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX photos_user_id_idx ON photos (user_id, kind, taken_on DESC, created_at DESC);
@@ -0,0 +1,6 @@
+ALTER TABLE users
+    DROP COLUMN bio,
+    DROP COLUMN birth_date,
+    DROP COLUMN sex,
+    DROP COLUMN experience_level,
+    DROP COLUMN preferred_gym;
@@ -0,0 +1,6 @@
+ALTER TABLE users
+    ADD COLUMN bio TEXT NOT NULL DEFAULT '',
+    ADD COLUMN birth_date DATE,
+    ADD COLUMN sex TEXT NOT NULL DEFAULT '',
+    ADD COLUMN experience_level TEXT NOT NULL DEFAULT '',
+    ADD COLUMN preferred_gym TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,19 @@
+-- Purging an account strips its personal data from the log. Only that update
+-- is let through, and only in a transaction that set athena.audit_erasure.
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND current_setting('athena.audit_erasure', true) = 'on'
+        AND NEW.id = OLD.id
+        AND NEW.action = OLD.action
+        AND NEW.created_at = OLD.created_at
+        AND NEW.ip = ''
+        AND NEW.user_agent = ''
+        AND NEW.metadata = '{}'::jsonb
+    THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1 @@
+DROP INDEX data_exports_user_id_active_idx;
@@ -0,0 +1,3 @@
+-- A user has at most one export in progress, two requests racing past the
+-- check in the service would otherwise both queue an archive build
+CREATE UNIQUE INDEX data_exports_user_id_active_idx ON data_exports (user_id) WHERE status IN ('pending', 'running');
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cheezecakee/logr"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+type PhotoRepo struct {
+	db *sql.DB
+}
+
+func NewPhotoRepo(db *sql.DB) (*PhotoRepo, error) {
+	return &PhotoRepo{db: db}, nil
+}
+
+const CreatePhoto = `INSERT INTO photos (id, user_id, kind, content_type, width, height, size, file_key, thumbnail_key, taken_on, created_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`
+
+func (r *PhotoRepo) Add(ctx context.Context, p photo.Photo) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
+		_, err := tx.ExecContext(ctx, CreatePhoto, p.ID, p.UserID, p.Kind, p.ContentType, p.Width, p.Height, p.Size, p.FileKey, p.ThumbnailKey, p.TakenOn, p.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		logr.Get().Info("New photo uploaded!")
+
+		return nil
+	})
+}
+
+const photoColumns = `id, user_id, kind, content_type, width, height, size, file_key, thumbnail_key, taken_on, created_at`
+
+const GetPhotoByID = `SELECT ` + photoColumns + ` FROM photos WHERE id = $1`
+
+func (r *PhotoRepo) GetByID(ctx context.Context, id string) (*photo.Photo, error) {
+	p, err := r.scan(conn(ctx, r.db).QueryRowContext(ctx, GetPhotoByID, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ports.ErrPhotoNotFound
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+const GetPhotosByUserID = `SELECT ` + photoColumns + ` FROM photos WHERE user_id = $1 AND kind = $2 ORDER BY taken_on DESC NULLS LAST, created_at DESC`
+
+func (r *PhotoRepo) GetByUserID(ctx context.Context, userID string, kind photo.Kind) ([]*photo.Photo, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, GetPhotosByUserID, userID, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var photos []*photo.Photo
+	for rows.Next() {
+		p, err := r.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		photos = append(photos, p)
+	}
+	return photos, rows.Err()
+}
+
+const DeletePhoto = `DELETE FROM photos WHERE id = $1`
+
+func (r *PhotoRepo) Delete(ctx context.Context, id string) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
+		result, err := tx.ExecContext(ctx, DeletePhoto, id)
+		if err != nil {
+			return err
+		}
+
+		n, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ports.ErrPhotoNotFound
+		}
+
+		return nil
+	})
+}
+
+func (r *PhotoRepo) scan(row scanner) (*photo.Photo, error) {
+	var p photo.Photo
+
+	err := row.Scan(
+		&p.ID,
+		&p.UserID,
+		&p.Kind,
+		&p.ContentType,
+		&p.Width,
+		&p.Height,
+		&p.Size,
+		&p.FileKey,
+		&p.ThumbnailKey,
+		&p.TakenOn,
+		&p.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
@@ -0,0 +1,94 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/postgres"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+)
+
+// testDB connects to the database in DB_TEST_CONN_STRING and migrates it to
+// the latest version, tests are skipped when it is not set.
+func testDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	connString := os.Getenv("DB_TEST_CONN_STRING")
+	if connString == "" {
+		t.Skip("DB_TEST_CONN_STRING is not set")
+	}
+
+	db, err := postgres.NewPostgresConn(postgres.Config{ConnString: connString})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrations, err := postgres.Migrations()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if _, err := postgres.NewMigrator(db, migrations).Up(context.Background()); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	return db
+}
+
+// newTestUser builds a user with a unique username and email, so tests can
+// share one database.
+func newTestUser(t *testing.T) user.User {
+	t.Helper()
+
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+
+	username, err := user.NewUsername("test_" + suffix)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	email, err := user.NewEmail(suffix + "@example.com")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	password, err := user.NewPassword("Secret123!")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	return user.New(username, "Test User", email, user.Roles{user.RoleUser}, password, user.NewStats(), user.NewSubscription(), user.NewSettings())
+}
+
+// addTestUser stores a new user with its stats, subscription and settings.
+func addTestUser(t *testing.T, db *sql.DB) user.User {
+	t.Helper()
+
+	u := newTestUser(t)
+	repo, err := postgres.NewUserRepo(db)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	ctx := context.Background()
+	err = postgres.NewUnitOfWork(db).Do(ctx, func(ctx context.Context) error {
+		if err := repo.Add(ctx, u); err != nil {
+			return err
+		}
+		if err := repo.AddStats(ctx, u.Stats, u.ID.String()); err != nil {
+			return err
+		}
+		if err := repo.AddSubscription(ctx, u.Subscription, u.ID.String()); err != nil {
+			return err
+		}
+		return repo.AddSettings(ctx, u.Settings, u.ID.String())
+	})
+	if err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+
+	return u
+}
@@ -7,7 +7,36 @@ import (
 	"fmt"
 )
 
-func WithTransaction(ctx context.Context, db *sql.DB, fn func(*sql.Tx) error) error {
+type txKey struct{}
+
+// querier is what repositories run statements on, the pool or the
+// transaction of the current unit of work.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn returns the transaction of the unit of work running in ctx, or db when
+// there is none. Statements run on it are traced.
+func conn(ctx context.Context, db *sql.DB) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return traced(tx)
+	}
+	return traced(db)
+}
+
+// WithTransaction runs fn in a transaction. Inside a unit of work fn joins its
+// transaction, which is then committed or rolled back by the unit of work.
+func WithTransaction(ctx context.Context, db *sql.DB, fn func(tx querier) error) error {
+	return inTransaction(ctx, db, func(tx *sql.Tx) error { return fn(traced(tx)) })
+}
+
+func inTransaction(ctx context.Context, db *sql.DB, fn func(*sql.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(tx)
+	}
+
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -33,3 +62,18 @@ func WithTransaction(ctx context.Context, db *sql.DB, fn func(*sql.Tx) error) er
 
 	return nil
 }
+
+// UnitOfWork runs several repository calls in one transaction.
+type UnitOfWork struct {
+	db *sql.DB
+}
+
+func NewUnitOfWork(db *sql.DB) *UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return inTransaction(ctx, u.db, func(tx *sql.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
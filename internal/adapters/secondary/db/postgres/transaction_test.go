@@ -0,0 +1,64 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/postgres"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+var errSubscriptionFailed = errors.New("subscription insert failed")
+
+// failingSubscriptionRepo fails the third write of an account creation,
+// after the user and its stats were added, and keeps the ID of the user.
+type failingSubscriptionRepo struct {
+	*postgres.UserRepo
+	userID *string
+}
+
+func (r failingSubscriptionRepo) AddSubscription(ctx context.Context, us user.Subscription, id string) error {
+	*r.userID = id
+	return errSubscriptionFailed
+}
+
+func TestUnitOfWork_RollsBackPartialAccount(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	repo, err := postgres.NewUserRepo(db)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	u := newTestUser(t)
+	var userID string
+	svc := users.NewService(failingSubscriptionRepo{UserRepo: repo, userID: &userID}, postgres.NewUnitOfWork(db))
+
+	_, err = svc.CreateAccount(ctx, users.CreateAccountReq{
+		Username:  string(u.Username),
+		Email:     string(u.Email),
+		FirstName: "Test",
+		LastName:  "User",
+		Password:  "Secret123!",
+	})
+	if !errors.Is(err, errSubscriptionFailed) {
+		t.Fatalf("expected %v, got: %v", errSubscriptionFailed, err)
+	}
+
+	// The user and its stats were written before the failure
+	for _, query := range []string{
+		`SELECT count(*) FROM users WHERE id = $1`,
+		`SELECT count(*) FROM user_stats WHERE user_id = $1`,
+	} {
+		var n int
+		if err := db.QueryRowContext(ctx, query, userID).Scan(&n); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if n != 0 {
+			t.Errorf("expected no partial account, %q found %d rows", query, n)
+		}
+	}
+}
@@ -4,8 +4,13 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/cheezecakee/logr"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
@@ -24,13 +29,13 @@ func NewUserRepo(db *sql.DB) (*UserRepo, error) {
 	}, nil
 }
 
-const CreateUser = `INSERT INTO users (id, username, full_name, email, roles, password_hash, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+const CreateUser = `INSERT INTO users (id, username, full_name, email, roles, password_hash, email_verified_at, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
 
 func (r *UserRepo) Add(ctx context.Context, u user.User) error {
-	return WithTransaction(ctx, r.db, func(tx *sql.Tx) error {
-		_, err := tx.ExecContext(ctx, CreateUser, u.ID, u.Username, u.FullName, u.Email, u.Roles, u.Password, u.CreatedAt, u.UpdatedAt)
+	return WithTransaction(ctx, r.db, func(tx querier) error {
+		_, err := tx.ExecContext(ctx, CreateUser, u.ID, u.Username, u.FullName, u.Email, u.Roles, u.Password, u.Status.EmailVerifiedAt, u.CreatedAt, u.UpdatedAt)
 		if err != nil {
-			return err
+			return duplicateUser(err)
 		}
 
 		logr.Get().Info("New user created!")
@@ -39,84 +44,79 @@ func (r *UserRepo) Add(ctx context.Context, u user.User) error {
 	})
 }
 
-const GetByUserID = `SELECT id, username, email, full_name, roles,password_hash, created_at, updated_at from users WHERE id = $1`
-
-func (r *UserRepo) GetByID(ctx context.Context, id string) (*ports.User, error) {
-	var row ports.User
-
-	var rolesArray pgtype.Array[string]
+// duplicateUser reports which unique column of users a write collided on,
+// the checks before the write can lose a race with another request.
+func duplicateUser(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != uniqueViolation {
+		return err
+	}
 
-	err := r.db.QueryRowContext(ctx, GetByUserID, id).Scan(
-		&row.ID,
-		&row.Username,
-		&row.Email,
-		&row.FullName,
-		r.typeMap.SQLScanner(&rolesArray),
-		&row.PasswordHash,
-		&row.CreatedAt,
-		&row.UpdatedAt,
-	)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, ports.ErrUserNotFound
-		}
-		return nil, err
+	switch pgErr.ConstraintName {
+	case "users_username_key":
+		return ports.ErrDuplicateUsername
+	case "users_email_key":
+		return ports.ErrDuplicateEmail
+	default:
+		return err
 	}
+}
 
-	row.Roles = rolesArray.Elements
+const userColumns = `id, username, email, full_name, roles, password_hash, email_verified_at, suspended_at, suspension_reason, deletion_requested_at, bio, birth_date, sex, experience_level, preferred_gym, created_at, updated_at`
 
-	return &row, nil
+const GetByUserID = `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+
+func (r *UserRepo) GetByID(ctx context.Context, id string) (*ports.User, error) {
+	return r.getOne(ctx, GetByUserID, id)
 }
 
-const GetByUsername = `SELECT id, username, email, full_name, roles, password_hash, created_at, updated_at from users WHERE username = $1`
+const GetByUsername = `SELECT ` + userColumns + ` FROM users WHERE username = $1`
 
 func (r *UserRepo) GetByUsername(ctx context.Context, username string) (*ports.User, error) {
-	var row ports.User
+	return r.getOne(ctx, GetByUsername, username)
+}
 
-	var rolesArray pgtype.Array[string]
+const GetByUserEmail = `SELECT ` + userColumns + ` FROM users WHERE email = $1`
 
-	err := r.db.QueryRowContext(ctx, GetByUsername, username).Scan(
-		&row.ID,
-		&row.Username,
-		&row.Email,
-		&row.FullName,
-		r.typeMap.SQLScanner(&rolesArray),
-		&row.PasswordHash,
-		&row.CreatedAt,
-		&row.UpdatedAt,
-	)
+func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*ports.User, error) {
+	return r.getOne(ctx, GetByUserEmail, email)
+}
+
+func (r *UserRepo) getOne(ctx context.Context, query string, arg string) (*ports.User, error) {
+	row, err := r.scan(conn(ctx, r.db).QueryRowContext(ctx, query, arg))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ports.ErrUserNotFound
 		}
 		return nil, err
 	}
-
-	row.Roles = rolesArray.Elements
-
-	return &row, nil
+	return row, nil
 }
 
-const GetByUserEmail = `SELECT id, username, email, full_name, roles,password_hash, created_at, updated_at from users WHERE email = $1`
-
-func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*ports.User, error) {
+func (r *UserRepo) scan(s scanner) (*ports.User, error) {
 	var row ports.User
 	var rolesArray pgtype.Array[string]
 
-	err := r.db.QueryRowContext(ctx, GetByUserEmail, email).Scan(
+	err := s.Scan(
 		&row.ID,
 		&row.Username,
 		&row.Email,
 		&row.FullName,
 		r.typeMap.SQLScanner(&rolesArray),
 		&row.PasswordHash,
+		&row.Status.EmailVerifiedAt,
+		&row.Status.SuspendedAt,
+		&row.Status.SuspensionReason,
+		&row.Status.DeletionRequestedAt,
+		&row.Profile.Bio,
+		&row.Profile.BirthDate,
+		&row.Profile.Sex,
+		&row.Profile.ExperienceLevel,
+		&row.Profile.PreferredGym,
 		&row.CreatedAt,
 		&row.UpdatedAt,
 	)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, ports.ErrUserNotFound
-		}
 		return nil, err
 	}
 
@@ -125,21 +125,27 @@ func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*ports.User, e
 	return &row, nil
 }
 
-// Password and roles will be updated separately at a later date
+// Password and roles are updated separately
 
 const UpdateUser = `UPDATE users 
 	SET username = $2, 
 		full_name = $3, 
 		email = $4,
-		updated_at = $5
+		bio = $5,
+		birth_date = $6,
+		sex = $7,
+		experience_level = $8,
+		preferred_gym = $9,
+		updated_at = $10
 	WHERE id = $1
 `
 
 func (r *UserRepo) Update(ctx context.Context, u user.User) error {
-	return WithTransaction(ctx, r.db, func(tx *sql.Tx) error {
-		result, err := tx.ExecContext(ctx, UpdateUser, u.ID, u.Username, u.FullName, u.Email, u.UpdatedAt)
+	return WithTransaction(ctx, r.db, func(tx querier) error {
+		result, err := tx.ExecContext(ctx, UpdateUser, u.ID, u.Username, u.FullName, u.Email,
+			u.Profile.Bio, u.Profile.BirthDate, u.Profile.Sex, u.Profile.ExperienceLevel, u.Profile.PreferredGym, u.UpdatedAt)
 		if err != nil {
-			return err
+			return duplicateUser(err)
 		}
 
 		rowsAffected, err := result.RowsAffected()
@@ -156,10 +162,174 @@ func (r *UserRepo) Update(ctx context.Context, u user.User) error {
 	})
 }
 
-const DeleteUser = `Delete from users WHERE id = $1`
+const UpdateUserRoles = `UPDATE users SET roles = $2, updated_at = $3 WHERE id = $1`
+
+func (r *UserRepo) UpdateRoles(ctx context.Context, id string, roles user.Roles, updatedAt time.Time) error {
+	return r.updateOne(ctx, UpdateUserRoles, id, roles.ToStrings(), updatedAt)
+}
+
+const UpdateUserPassword = `UPDATE users SET password_hash = $2, updated_at = $3 WHERE id = $1`
+
+func (r *UserRepo) UpdatePassword(ctx context.Context, id string, password user.Password, updatedAt time.Time) error {
+	return r.updateOne(ctx, UpdateUserPassword, id, password, updatedAt)
+}
+
+const UpdateUserStatus = `UPDATE users
+	SET email_verified_at = $2,
+		suspended_at = $3,
+		suspension_reason = $4,
+		deletion_requested_at = $5,
+		updated_at = $6
+	WHERE id = $1
+`
+
+func (r *UserRepo) UpdateStatus(ctx context.Context, id string, status user.AccountStatus, updatedAt time.Time) error {
+	return r.updateOne(ctx, UpdateUserStatus, id, status.EmailVerifiedAt, status.SuspendedAt, status.SuspensionReason, status.DeletionRequestedAt, updatedAt)
+}
+
+// updateOne runs an update of the user with the id in args[0].
+func (r *UserRepo) updateOne(ctx context.Context, query string, args ...any) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
+		result, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return ports.ErrUserNotFound
+		}
+
+		return nil
+	})
+}
+
+// likeEscaper stops user input from adding LIKE wildcards.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *UserRepo) Search(ctx context.Context, filter ports.UserFilter) ([]*ports.User, int, error) {
+	var (
+		where []string
+		args  []any
+	)
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+
+	if filter.Username != "" {
+		add(`username ILIKE $%d`, "%"+likeEscaper.Replace(filter.Username)+"%")
+	}
+	if filter.Email != "" {
+		add(`email ILIKE $%d`, "%"+likeEscaper.Replace(filter.Email)+"%")
+	}
+	if filter.CreatedAfter != nil {
+		add(`created_at >= $%d`, *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		add(`created_at < $%d`, *filter.CreatedBefore)
+	}
+
+	conditions := ""
+	if len(where) > 0 {
+		conditions = " WHERE " + strings.Join(where, " AND ")
+	}
+
+	var total int
+	if err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT count(*) FROM users`+conditions, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`SELECT `+userColumns+` FROM users%s ORDER BY created_at DESC, id LIMIT $%d OFFSET $%d`,
+		conditions, len(args)+1, len(args)+2)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var found []*ports.User
+	for rows.Next() {
+		row, err := r.scan(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		found = append(found, row)
+	}
+
+	return found, total, rows.Err()
+}
+
+// GetUsersDueForPurge only lists the accounts, two purge runs are kept apart
+// by LockUserForPurge inside the unit of work that purges each one.
+const GetUsersDueForPurge = `SELECT ` + userColumns + ` FROM users WHERE deletion_requested_at <= $1 ORDER BY deletion_requested_at LIMIT $2`
+
+func (r *UserRepo) GetDueForPurge(ctx context.Context, requestedBefore time.Time, limit int) ([]*ports.User, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, GetUsersDueForPurge, requestedBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var found []*ports.User
+	for rows.Next() {
+		row, err := r.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, row)
+	}
+
+	return found, rows.Err()
+}
+
+const LockUserForPurge = `SELECT id FROM users WHERE id = $1 AND deletion_requested_at <= $2 FOR UPDATE`
+
+func (r *UserRepo) LockForPurge(ctx context.Context, id string, requestedBefore time.Time) error {
+	var locked string
+	if err := conn(ctx, r.db).QueryRowContext(ctx, LockUserForPurge, id, requestedBefore).Scan(&locked); err != nil {
+		if err == sql.ErrNoRows {
+			return ports.ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteUserData removes the rows that belong to a user, children first.
+// The foreign keys cascade too, but the purge does not rely on it. The login
+// counter of the account goes first, it is found through the username. The
+// counters of the IPs it signed in from are shared with every other account
+// behind them and expire on their own. The audit events are kept and
+// stripped of the user by AuditRepo.Erase.
+var DeleteUserData = []string{
+	`DELETE FROM login_attempts WHERE key = 'user:' || (SELECT username FROM users WHERE id = $1)`,
+	`DELETE FROM refresh_tokens WHERE user_id = $1`,
+	`DELETE FROM api_keys WHERE user_id = $1`,
+	`DELETE FROM user_identities WHERE user_id = $1`,
+	`DELETE FROM account_unlock_tokens WHERE user_id = $1`,
+	`DELETE FROM data_exports WHERE user_id = $1`,
+	`DELETE FROM username_changes WHERE user_id = $1`,
+	`DELETE FROM photos WHERE user_id = $1`,
+	`DELETE FROM user_settings WHERE user_id = $1`,
+	`DELETE FROM user_subscription WHERE user_id = $1`,
+	`DELETE FROM user_stats WHERE user_id = $1`,
+}
+
+const DeleteUser = `DELETE FROM users WHERE id = $1`
 
 func (r *UserRepo) Delete(ctx context.Context, id string) error {
-	return WithTransaction(ctx, r.db, func(tx *sql.Tx) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
+		for _, query := range DeleteUserData {
+			if _, err := tx.ExecContext(ctx, query, id); err != nil {
+				return err
+			}
+		}
+
 		result, err := tx.ExecContext(ctx, DeleteUser, id)
 		if err != nil {
 			return err
@@ -179,10 +349,63 @@ func (r *UserRepo) Delete(ctx context.Context, id string) error {
 	})
 }
 
+const CreateUsernameChange = `INSERT INTO username_changes (user_id, old_username, new_username, changed_at) VALUES ($1, $2, $3, $4)`
+
+func (r *UserRepo) AddUsernameChange(ctx context.Context, change user.UsernameChange) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
+		_, err := tx.ExecContext(ctx, CreateUsernameChange, change.UserID, change.OldUsername, change.NewUsername, change.ChangedAt)
+		return err
+	})
+}
+
+const usernameChangeColumns = `user_id, old_username, new_username, changed_at`
+
+const GetUsernameChanges = `SELECT ` + usernameChangeColumns + ` FROM username_changes WHERE user_id = $1 ORDER BY changed_at DESC`
+
+func (r *UserRepo) GetUsernameChanges(ctx context.Context, userID string) ([]user.UsernameChange, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, GetUsernameChanges, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []user.UsernameChange
+	for rows.Next() {
+		change, err := scanUsernameChange(rows)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, *change)
+	}
+
+	return changes, rows.Err()
+}
+
+const GetUsernameReservation = `SELECT ` + usernameChangeColumns + ` FROM username_changes WHERE old_username = $1 AND changed_at > $2 ORDER BY changed_at DESC LIMIT 1`
+
+func (r *UserRepo) GetUsernameReservation(ctx context.Context, username string, changedAfter time.Time) (*user.UsernameChange, error) {
+	change, err := scanUsernameChange(conn(ctx, r.db).QueryRowContext(ctx, GetUsernameReservation, username, changedAfter))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ports.ErrNotReserved
+		}
+		return nil, err
+	}
+	return change, nil
+}
+
+func scanUsernameChange(s scanner) (*user.UsernameChange, error) {
+	var change user.UsernameChange
+	if err := s.Scan(&change.UserID, &change.OldUsername, &change.NewUsername, &change.ChangedAt); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
 const CreateUserSettings = `INSERT INTO user_settings (user_id, preferred_weight_unit, preferred_height_unit, theme, profile_visibility, email_notifications, push_notifications, workout_reminders, streak_reminders, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
 
 func (r *UserRepo) AddSettings(ctx context.Context, us user.Settings, id string) error {
-	return WithTransaction(ctx, r.db, func(tx *sql.Tx) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
 		_, err := tx.ExecContext(ctx, CreateUserSettings, id, us.WeightUnit, us.HeightUnit, us.Theme, us.Visibility, us.EmailNotif, us.PushNotif, us.WorkoutReminder, us.StreakReminder, us.CreatedAt, us.UpdatedAt)
 		if err != nil {
 			return err
@@ -197,7 +420,7 @@ func (r *UserRepo) AddSettings(ctx context.Context, us user.Settings, id string)
 const CreateUserStats = `INSERT INTO user_stats (user_id, rest_days, current_streak, longest_streak,  total_workouts, total_lifted, total_time_minutes, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
 
 func (r *UserRepo) AddStats(ctx context.Context, us user.Stats, id string) error {
-	return WithTransaction(ctx, r.db, func(tx *sql.Tx) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
 		_, err := tx.ExecContext(ctx, CreateUserStats, id, us.Streak.RestDays, us.Streak.Current, us.Streak.Longest, us.Totals.Workouts, us.Totals.Lifted, us.Totals.Time, us.CreatedAt, us.UpdatedAt)
 		if err != nil {
 			return err
@@ -212,7 +435,7 @@ func (r *UserRepo) AddStats(ctx context.Context, us user.Stats, id string) error
 const CreateUserSubscription = `INSERT INTO user_subscription (user_id, plan, billing_period, started_at, auto_renew, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`
 
 func (r *UserRepo) AddSubscription(ctx context.Context, us user.Subscription, id string) error {
-	return WithTransaction(ctx, r.db, func(tx *sql.Tx) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
 		_, err := tx.ExecContext(ctx, CreateUserSubscription, id, us.Plan, us.BillingPeriod, us.StartedAt, us.AutoRenew, us.CreatedAt, us.UpdatedAt)
 		if err != nil {
 			return err
@@ -229,7 +452,7 @@ const GetUserStats = `SELECT weight, height, body_fat_percent, rest_days, curren
 func (r *UserRepo) GetStatsByID(ctx context.Context, userID string) (*user.Stats, error) {
 	var row user.Stats
 
-	err := r.db.QueryRowContext(ctx, GetUserStats, userID).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, GetUserStats, userID).Scan(
 		&row.Weight,
 		&row.Height,
 		&row.BFP,
@@ -258,7 +481,7 @@ const GetUserSettings = `SELECT preferred_weight_unit, preferred_height_unit, th
 func (r *UserRepo) GetSettingsByID(ctx context.Context, userID string) (*user.Settings, error) {
 	var row user.Settings
 
-	err := r.db.QueryRowContext(ctx, GetUserSettings, userID).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, GetUserSettings, userID).Scan(
 		&row.WeightUnit,
 		&row.HeightUnit,
 		&row.Theme,
@@ -285,7 +508,7 @@ const GetUserSubscription = `SELECT plan, billing_period, started_at, expires_at
 func (r *UserRepo) GetSubscriptionByID(ctx context.Context, userID string) (*user.Subscription, error) {
 	var row user.Subscription
 
-	err := r.db.QueryRowContext(ctx, GetUserSubscription, userID).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, GetUserSubscription, userID).Scan(
 		&row.Plan,
 		&row.BillingPeriod,
 		&row.StartedAt,
@@ -325,7 +548,7 @@ const UpdateUserSubscription = `UPDATE user_subscription
 `
 
 func (r *UserRepo) UpdateSubscription(ctx context.Context, sub user.Subscription, userID string) error {
-	return WithTransaction(ctx, r.db, func(tx *sql.Tx) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
 		result, err := tx.ExecContext(ctx, UpdateUserSubscription, userID, sub.Plan, sub.BillingPeriod, sub.StartedAt, sub.ExpiresAt, sub.AutoRenew, sub.CancelledAt, sub.LastPaymentAt, sub.LastPaymentAmount, sub.LastPaymentCurrency, sub.TrialEndsAt, sub.UpdatedAt)
 		if err != nil {
 			return err
@@ -359,7 +582,7 @@ const UpdateUserSettings = `UPDATE user_settings
 `
 
 func (r *UserRepo) UpdateSettings(ctx context.Context, settings user.Settings, userID string) error {
-	return WithTransaction(ctx, r.db, func(tx *sql.Tx) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
 		result, err := tx.ExecContext(ctx, UpdateUserSettings, userID, settings.WeightUnit, settings.HeightUnit, settings.Theme, settings.Visibility, settings.EmailNotif, settings.PushNotif, settings.WorkoutReminder, settings.StreakReminder, settings.UpdatedAt)
 		if err != nil {
 			return err
@@ -388,7 +611,7 @@ const UpdateBodyMetrics = `UPDATE user_stats
 `
 
 func (r *UserRepo) UpdateBodyMetrics(ctx context.Context, stats ports.UpdateBodyMetrics, userID string) error {
-	return WithTransaction(ctx, r.db, func(tx *sql.Tx) error {
+	return WithTransaction(ctx, r.db, func(tx querier) error {
 		result, err := tx.ExecContext(ctx, UpdateBodyMetrics, userID, stats.WeightValue, stats.HeightValue, stats.BFP, stats.UpdatedAt)
 		if err != nil {
 			return err
@@ -0,0 +1,126 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/postgres"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+func TestPurgeDeleted_ErasesPersonalData(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	u := addTestUser(t, db)
+	admin := uuid.New()
+	const ip = "198.51.100.23"
+
+	userRepo, err := postgres.NewUserRepo(db)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	auditRepo, err := postgres.NewAuditRepo(db)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	attempts, err := postgres.NewLoginAttemptRepo(db)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	requested := time.Now().Add(-user.DeletionGracePeriod - time.Hour)
+	status, err := u.Status.ScheduleDeletion(requested)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := userRepo.UpdateStatus(ctx, u.ID.String(), status, requested); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	client := audit.Client{IP: ip, UserAgent: "curl/8.0"}
+	for _, e := range []struct {
+		action        audit.Action
+		actor, target uuid.UUID
+	}{
+		{audit.ActionLoginFailed, u.ID, u.ID},
+		{audit.ActionAdminUserSuspended, admin, u.ID},
+	} {
+		event, err := audit.NewEvent(e.action, e.actor, e.target, map[string]string{"username": string(u.Username)})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if err := auditRepo.Record(ctx, event.WithClient(client)); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+
+	accountKey, ipKey := auth.AccountAttemptKey(string(u.Username)), auth.IPAttemptKey(ip)
+	for _, key := range []string{accountKey, ipKey} {
+		if _, _, err := attempts.Reserve(ctx, key, auth.LockoutPolicy{}, time.Now()); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+
+	svc := users.NewService(userRepo, postgres.NewUnitOfWork(db), users.WithAuditLog(auditRepo))
+	if _, err := svc.PurgeDeleted(ctx); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	count := func(query string, args ...any) int {
+		t.Helper()
+		var n int
+		if err := db.QueryRowContext(ctx, query, args...).Scan(&n); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		return n
+	}
+
+	if n := count(`SELECT count(*) FROM users WHERE id = $1`, u.ID); n != 0 {
+		t.Errorf("expected the user to be deleted, %d rows left", n)
+	}
+	if n := count(`SELECT count(*) FROM audit_events WHERE actor_id = $1 OR target_id = $1 OR ip = $2 OR metadata::text LIKE '%' || $3 || '%'`, u.ID, ip, string(u.Username)); n != 0 {
+		t.Errorf("expected no audit event to carry the user, %d left", n)
+	}
+	if n := count(`SELECT count(*) FROM login_attempts WHERE key = $1`, accountKey); n != 0 {
+		t.Errorf("expected the account's login counter to be deleted, %d left", n)
+	}
+	// Other accounts behind the IP are still throttled by its counter
+	if n := count(`SELECT count(*) FROM login_attempts WHERE key = $1`, ipKey); n != 1 {
+		t.Errorf("expected the IP's login counter to be kept, got %d", n)
+	}
+	if n := count(`SELECT count(*) FROM audit_events WHERE actor_id = $1`, admin); n != 1 {
+		t.Errorf("expected the admin's event to be kept, got %d", n)
+	}
+}
+
+func TestAuditEvents_AppendOnly(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	auditRepo, err := postgres.NewAuditRepo(db)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	event, err := audit.NewEvent(audit.ActionLoginSucceeded, uuid.New(), uuid.New(), nil)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := auditRepo.Record(ctx, event.WithClient(audit.Client{IP: "198.51.100.24"})); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	// Without the erasure setting, even a stripping update is refused
+	if _, err := db.ExecContext(ctx, `UPDATE audit_events SET ip = '', user_agent = '', metadata = '{}' WHERE id = $1`, event.ID); err == nil {
+		t.Error("expected the update to be refused")
+	}
+	if _, err := db.ExecContext(ctx, `DELETE FROM audit_events WHERE id = $1`, event.ID); err == nil {
+		t.Error("expected the delete to be refused")
+	}
+}
@@ -0,0 +1,89 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// JWK is the public part of a signing key as described in RFC 7517.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// OKP (Ed25519)
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns every public key that is currently valid for verification.
+// Symmetric key sets never publish their secret.
+func (k *KeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	if k.method.IsSymmetric() {
+		return jwks
+	}
+
+	k.mu.RLock()
+	active := k.active
+	keys := make([]*signingKey, 0, len(k.keys))
+	for _, key := range k.keys {
+		if key.retiredAt != nil && time.Since(*key.retiredAt) > k.retention {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	k.mu.RUnlock()
+
+	// Active first so clients that only try the first key pick it, then newest
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i] == active || keys[j] == active {
+			return keys[i] == active
+		}
+		return keys[i].createdAt.After(keys[j].createdAt)
+	})
+
+	for _, key := range keys {
+		if jwk, ok := toJWK(key, k.method); ok {
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
+	}
+
+	return jwks
+}
+
+func toJWK(key *signingKey, method SigningMethod) (JWK, bool) {
+	jwk := JWK{
+		Kid: key.id,
+		Use: "sig",
+		Alg: string(method),
+	}
+
+	switch public := key.public.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	default:
+		return JWK{}, false
+	}
+
+	return jwk, true
+}
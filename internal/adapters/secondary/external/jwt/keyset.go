@@ -0,0 +1,394 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrEmptySecret           = errors.New("jwt secret is empty")
+	ErrUnknownSigningMethod  = errors.New("unknown signing method")
+	ErrUnknownKeyID          = errors.New("unknown key id")
+	ErrRotationNotSupported  = errors.New("key rotation is not supported for symmetric signing")
+	ErrUnsupportedPrivateKey = errors.New("unsupported private key type")
+	ErrNoKeys                = errors.New("key directory holds no keys")
+	ErrMixedKeyTypes         = errors.New("keys of different types in one key directory")
+)
+
+type SigningMethod string
+
+const (
+	HS256 SigningMethod = "HS256" // Shared secret, local development only
+	RS256 SigningMethod = "RS256"
+	EdDSA SigningMethod = "EdDSA"
+)
+
+const rsaKeyBits = 2048
+
+func NewSigningMethod(method string) (SigningMethod, error) {
+	switch method {
+	case "", string(RS256):
+		return RS256, nil
+	case string(EdDSA):
+		return EdDSA, nil
+	case string(HS256):
+		return HS256, nil
+	default:
+		return "", ErrUnknownSigningMethod
+	}
+}
+
+func (m SigningMethod) jwtMethod() jwt.SigningMethod {
+	switch m {
+	case RS256:
+		return jwt.SigningMethodRS256
+	case EdDSA:
+		return jwt.SigningMethodEdDSA
+	default:
+		return jwt.SigningMethodHS256
+	}
+}
+
+func (m SigningMethod) IsSymmetric() bool {
+	return m == HS256
+}
+
+type signingKey struct {
+	id        string
+	private   any
+	public    any
+	createdAt time.Time
+	retiredAt *time.Time
+}
+
+// KeySet holds the active signing key and the retired keys that are still
+// accepted for verification until their retention window passes.
+type KeySet struct {
+	// method is fixed when the set is created and read without the lock,
+	// reloads refuse keys of another type.
+	method SigningMethod
+
+	mu        sync.RWMutex
+	active    *signingKey
+	keys      map[string]*signingKey
+	retention time.Duration
+	// dir holds the keys shared by every replica, a key generated by one of
+	// them would be unknown to the others. Sets loaded from it rotate by
+	// reloading it instead of generating keys.
+	dir string
+}
+
+func NewKeySet(method SigningMethod, retention time.Duration) (*KeySet, error) {
+	if method.IsSymmetric() {
+		return nil, ErrRotationNotSupported
+	}
+
+	ks := &KeySet{
+		method:    method,
+		keys:      make(map[string]*signingKey),
+		retention: retention,
+	}
+
+	if err := ks.Rotate(); err != nil {
+		return nil, err
+	}
+
+	return ks, nil
+}
+
+// NewKeySetFromDir loads the PKCS#8 private keys stored as .pem files in
+// dir. Files are named so they sort from oldest to newest, the newest key
+// signs and all of them verify. Rotate reloads the directory.
+func NewKeySetFromDir(dir string, retention time.Duration) (*KeySet, error) {
+	method, loaded, err := loadKeyDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := &KeySet{
+		method:    method,
+		keys:      make(map[string]*signingKey),
+		retention: retention,
+		dir:       dir,
+	}
+	ks.sync(loaded)
+
+	return ks, nil
+}
+
+func newSymmetricKeySet(secret []byte) (*KeySet, error) {
+	if len(secret) == 0 {
+		return nil, ErrEmptySecret
+	}
+
+	sum := sha256.Sum256(secret)
+	key := &signingKey{
+		id:        hex.EncodeToString(sum[:8]),
+		private:   secret,
+		public:    secret,
+		createdAt: time.Now(),
+	}
+
+	return &KeySet{
+		method: HS256,
+		keys:   map[string]*signingKey{key.id: key},
+		active: key,
+	}, nil
+}
+
+// Rotate generates a new active key, or reloads the key directory of sets
+// loaded from one. The previous key is retired but stays valid for
+// verification until the retention window has passed.
+func (k *KeySet) Rotate() error {
+	if k.method.IsSymmetric() {
+		return ErrRotationNotSupported
+	}
+	if k.dir != "" {
+		return k.reload()
+	}
+
+	private, err := generatePrivateKey(k.method)
+	if err != nil {
+		return fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	key, err := newSigningKey(private)
+	if err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := time.Now()
+	if k.active != nil {
+		k.active.retiredAt = &now
+	}
+
+	k.keys[key.id] = key
+	k.active = key
+	k.prune(now)
+
+	return nil
+}
+
+// reload syncs the set with the key directory, the keys must stay of the
+// type the set was created with.
+func (k *KeySet) reload() error {
+	method, loaded, err := loadKeyDir(k.dir)
+	if err != nil {
+		return err
+	}
+	if method != k.method {
+		return ErrMixedKeyTypes
+	}
+
+	k.sync(loaded)
+	return nil
+}
+
+// sync makes loaded the keys of the set. A key seen for the first time only
+// verifies until the next reload, so every replica knows it before any of
+// them signs with it. Keys no longer loaded are retired.
+func (k *KeySet) sync(loaded []*signingKey) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := time.Now()
+	present := make(map[string]bool, len(loaded))
+	var active *signingKey
+
+	for _, key := range loaded {
+		present[key.id] = true
+
+		if known, ok := k.keys[key.id]; ok {
+			known.retiredAt = nil
+			active = known
+			continue
+		}
+		k.keys[key.id] = key
+	}
+
+	// On startup, or when every known key was replaced, no key has been
+	// published yet and the newest one signs right away.
+	if active == nil {
+		active = k.keys[loaded[len(loaded)-1].id]
+	}
+
+	for kid, key := range k.keys {
+		if !present[kid] && key.retiredAt == nil {
+			key.retiredAt = &now
+		}
+	}
+
+	k.active = active
+	k.prune(now)
+}
+
+// Rotates reports whether the set can change its signing key, which only
+// symmetric sets cannot.
+func (k *KeySet) Rotates() bool {
+	return !k.method.IsSymmetric()
+}
+
+func (k *KeySet) Method() SigningMethod {
+	return k.method
+}
+
+func (k *KeySet) signingKey() *signingKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.active
+}
+
+func (k *KeySet) verificationKey(kid string) (*signingKey, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+
+	if key.retiredAt != nil && time.Since(*key.retiredAt) > k.retention {
+		return nil, ErrUnknownKeyID
+	}
+
+	return key, nil
+}
+
+// prune drops retired keys whose retention window has passed. Callers must
+// hold the write lock.
+func (k *KeySet) prune(now time.Time) {
+	for kid, key := range k.keys {
+		if key.retiredAt != nil && now.Sub(*key.retiredAt) > k.retention {
+			delete(k.keys, kid)
+		}
+	}
+}
+
+// Helper functions
+
+// loadKeyDir parses the .pem files of dir in file name order.
+func loadKeyDir(dir string) (SigningMethod, []*signingKey, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read key directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".pem") {
+			names = append(names, entry.Name())
+		}
+	}
+	if len(names) == 0 {
+		return "", nil, ErrNoKeys
+	}
+	sort.Strings(names)
+
+	var method SigningMethod
+	keys := make([]*signingKey, 0, len(names))
+
+	for _, name := range names {
+		pemBytes, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+
+		keyMethod, key, err := parsePrivateKey(pemBytes)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if method != "" && keyMethod != method {
+			return "", nil, ErrMixedKeyTypes
+		}
+
+		method = keyMethod
+		keys = append(keys, key)
+	}
+
+	return method, keys, nil
+}
+
+func parsePrivateKey(pemBytes []byte) (SigningMethod, *signingKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return "", nil, fmt.Errorf("failed to decode pem block")
+	}
+
+	private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	var method SigningMethod
+	switch private.(type) {
+	case *rsa.PrivateKey:
+		method = RS256
+	case ed25519.PrivateKey:
+		method = EdDSA
+	default:
+		return "", nil, ErrUnsupportedPrivateKey
+	}
+
+	key, err := newSigningKey(private)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return method, key, nil
+}
+
+func generatePrivateKey(method SigningMethod) (any, error) {
+	switch method {
+	case RS256:
+		return rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case EdDSA:
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		return private, err
+	default:
+		return nil, ErrUnknownSigningMethod
+	}
+}
+
+func newSigningKey(private any) (*signingKey, error) {
+	signer, ok := private.(crypto.Signer)
+	if !ok {
+		return nil, ErrUnsupportedPrivateKey
+	}
+
+	public := signer.Public()
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+
+	// The kid is a thumbprint of the public key so it is stable across restarts
+	// when the same key is loaded from disk.
+	sum := sha256.Sum256(der)
+
+	return &signingKey{
+		id:        hex.EncodeToString(sum[:8]),
+		private:   private,
+		public:    public,
+		createdAt: time.Now(),
+	}, nil
+}
@@ -2,6 +2,7 @@
 package jwt
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -9,19 +10,32 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
 )
 
 var ErrInvalidToken = errors.New("invalid token")
 
+// issuer marks the tokens minted by MakeJWT, ValidateJWT rejects tokens the
+// same keys signed for anything else.
+const issuer = "fitrkr"
+
+const (
+	defaultExpiresIn      = 15 * time.Minute
+	defaultRotationPeriod = 24 * time.Hour
+)
+
 type JWT interface {
 	MakeJWT(userID uuid.UUID, roles []string) (string, error)
 	ValidateJWT(tokenString string) (*AuthenticatedUser, error)
+	JWKS() JWKS
 }
 
 type AuthenticatedUser struct {
 	UserID uuid.UUID
 	Roles  user.Roles
+	// Scopes limits what an API key caller may do, sessions are unscoped.
+	Scopes auth.Scopes
 }
 
 type UserClaims struct {
@@ -30,31 +44,77 @@ type UserClaims struct {
 }
 
 type JWTManager struct {
-	SecretKey []byte
 	ExpiresIn time.Duration
-	Claims    UserClaims
+	keys      *KeySet
+}
+
+type Option func(j *JWTManager)
+
+func WithExpiresIn(expiresIn time.Duration) Option {
+	return func(j *JWTManager) {
+		if expiresIn > 0 {
+			j.ExpiresIn = expiresIn
+		}
+	}
+}
+
+func WithKeySet(keys *KeySet) Option {
+	return func(j *JWTManager) { j.keys = keys }
+}
+
+// NewJWTManager signs with a rotating asymmetric key set. Retired keys stay
+// valid for verification for at least one token lifetime.
+func NewJWTManager(method SigningMethod, opts ...Option) (*JWTManager, error) {
+	j := &JWTManager{ExpiresIn: defaultExpiresIn}
+
+	for _, applyOption := range opts {
+		applyOption(j)
+	}
+
+	if j.keys == nil {
+		keys, err := NewKeySet(method, j.ExpiresIn)
+		if err != nil {
+			return nil, err
+		}
+		j.keys = keys
+	}
+
+	// A retired key must outlive every token it signed
+	if j.keys.retention < j.ExpiresIn {
+		j.keys.retention = j.ExpiresIn
+	}
+
+	return j, nil
 }
 
-func NewJWTManager(secretKey string, expiresIn time.Duration) JWT {
-	if expiresIn == 0 {
-		expiresIn = 15 * time.Minute
+// NewHS256Manager signs with a single shared secret. Meant for local
+// development, the secret can not be published through JWKS.
+func NewHS256Manager(secretKey string, opts ...Option) (*JWTManager, error) {
+	keys, err := newSymmetricKeySet([]byte(secretKey))
+	if err != nil {
+		return nil, err
 	}
-	return &JWTManager{SecretKey: []byte(secretKey), ExpiresIn: expiresIn}
+
+	return NewJWTManager(HS256, append(opts, WithKeySet(keys))...)
 }
 
 func (j *JWTManager) MakeJWT(userID uuid.UUID, roles []string) (string, error) {
 	claims := &UserClaims{
 		Roles: roles,
 		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:    "fitrkr",
+			Issuer:    issuer,
 			Subject:   userID.String(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.ExpiresIn)),
 			IssuedAt:  jwt.NewNumericDate(time.Now().UTC()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	ss, err := token.SignedString([]byte(j.SecretKey))
+	key := j.keys.signingKey()
+
+	token := jwt.NewWithClaims(j.keys.method.jwtMethod(), claims)
+	token.Header["kid"] = key.id
+
+	ss, err := token.SignedString(key.private)
 	if err != nil {
 		logr.Get().Errorf("err signing token: %v", err)
 		return "", err
@@ -67,8 +127,22 @@ func (j *JWTManager) ValidateJWT(tokenString string) (*AuthenticatedUser, error)
 	var userClaims UserClaims
 
 	token, err := jwt.ParseWithClaims(tokenString, &userClaims, func(token *jwt.Token) (any, error) {
-		return []byte(j.SecretKey), nil
-	})
+		kid, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, ErrUnknownKeyID
+		}
+
+		key, err := j.keys.verificationKey(kid)
+		if err != nil {
+			return nil, err
+		}
+
+		return key.public, nil
+	},
+		jwt.WithValidMethods([]string{j.keys.method.jwtMethod().Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -86,3 +160,63 @@ func (j *JWTManager) ValidateJWT(tokenString string) (*AuthenticatedUser, error)
 	logr.Get().Info("Token validated")
 	return &AuthenticatedUser{UserID: userID, Roles: user.StringsToRoles(userClaims.Roles)}, err
 }
+
+func (j *JWTManager) JWKS() JWKS {
+	return j.keys.JWKS()
+}
+
+// Rotate replaces the active signing key, or reloads the key directory.
+func (j *JWTManager) Rotate() error {
+	if err := j.keys.Rotate(); err != nil {
+		return err
+	}
+
+	logr.Get().Info("JWT signing key rotated")
+	return nil
+}
+
+// StartRotation rotates the signing key every interval until ctx is done.
+// Keys loaded from a directory are reloaded instead, so a key added to it
+// starts signing within two intervals. The returned channel is closed once
+// the rotation has stopped. Symmetric managers have nothing to rotate and
+// return a closed channel.
+func (j *JWTManager) StartRotation(ctx context.Context, interval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+
+	if !j.keys.Rotates() {
+		close(done)
+		return done
+	}
+
+	if interval <= 0 {
+		interval = defaultRotationPeriod
+	}
+
+	// Keep the previous key around for a full interval so tokens signed right
+	// before a rotation survive until they expire.
+	j.keys.mu.Lock()
+	if j.keys.retention < interval {
+		j.keys.retention = interval
+	}
+	j.keys.mu.Unlock()
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := j.Rotate(); err != nil {
+					logr.Get().Errorf("failed to rotate signing key: %v", err)
+				}
+			}
+		}
+	}()
+
+	return done
+}
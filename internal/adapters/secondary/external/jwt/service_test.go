@@ -0,0 +1,483 @@
+package jwt_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/jwt"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+)
+
+func TestMain(m *testing.M) {
+	logr.Init(&logr.PlainTextFormatter{}, logr.LevelInfo, nil)
+
+	os.Exit(m.Run())
+}
+
+func TestMakeAndValidateJWT(t *testing.T) {
+	tests := []struct {
+		name   string
+		method jwt.SigningMethod
+	}{
+		{"RS256", jwt.RS256},
+		{"EdDSA", jwt.EdDSA},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, err := jwt.NewJWTManager(tt.method)
+			if err != nil {
+				t.Fatalf("NewJWTManager() error = %v", err)
+			}
+
+			userID := uuid.New()
+			token, err := manager.MakeJWT(userID, []string{"user", "admin"})
+			if err != nil {
+				t.Fatalf("MakeJWT() error = %v", err)
+			}
+
+			authUser, err := manager.ValidateJWT(token)
+			if err != nil {
+				t.Fatalf("ValidateJWT() error = %v", err)
+			}
+
+			if authUser.UserID != userID {
+				t.Errorf("UserID = %v, want %v", authUser.UserID, userID)
+			}
+			if !authUser.Roles.Contains(user.RoleAdmin) {
+				t.Errorf("Roles = %v, want admin included", authUser.Roles)
+			}
+		})
+	}
+}
+
+func TestRotationKeepsRetiredKeysValid(t *testing.T) {
+	manager, err := jwt.NewJWTManager(jwt.EdDSA)
+	if err != nil {
+		t.Fatalf("NewJWTManager() error = %v", err)
+	}
+
+	oldToken, err := manager.MakeJWT(uuid.New(), nil)
+	if err != nil {
+		t.Fatalf("MakeJWT() error = %v", err)
+	}
+
+	if err := manager.Rotate(); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+
+	if _, err := manager.ValidateJWT(oldToken); err != nil {
+		t.Errorf("token signed before rotation rejected: %v", err)
+	}
+
+	jwks := manager.JWKS()
+	if len(jwks.Keys) != 2 {
+		t.Fatalf("JWKS() returned %d keys, want 2", len(jwks.Keys))
+	}
+
+	newToken, err := manager.MakeJWT(uuid.New(), nil)
+	if err != nil {
+		t.Fatalf("MakeJWT() error = %v", err)
+	}
+	if _, err := manager.ValidateJWT(newToken); err != nil {
+		t.Errorf("token signed after rotation rejected: %v", err)
+	}
+}
+
+func TestStartRotation_StopsWithContext(t *testing.T) {
+	manager, err := jwt.NewJWTManager(jwt.EdDSA)
+	if err != nil {
+		t.Fatalf("NewJWTManager() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := manager.StartRotation(ctx, time.Hour)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("rotation did not stop after cancel")
+	}
+}
+
+func writeKey(t *testing.T, dir, name string, private any) {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey() error = %v", err)
+	}
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, name), pemBytes, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	return private
+}
+
+func newDirManager(t *testing.T, dir string) *jwt.JWTManager {
+	t.Helper()
+
+	keys, err := jwt.NewKeySetFromDir(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("NewKeySetFromDir() error = %v", err)
+	}
+	manager, err := jwt.NewJWTManager(keys.Method(), jwt.WithKeySet(keys))
+	if err != nil {
+		t.Fatalf("NewJWTManager() error = %v", err)
+	}
+	return manager
+}
+
+// kid returns the key id in the header of token.
+func kid(t *testing.T, token string) string {
+	t.Helper()
+
+	parsed, _, err := new(gojwt.Parser).ParseUnverified(token, gojwt.MapClaims{})
+	if err != nil {
+		t.Fatalf("ParseUnverified() error = %v", err)
+	}
+	id, _ := parsed.Header["kid"].(string)
+	return id
+}
+
+func TestKeySetFromDir(t *testing.T) {
+	t.Run("replicas accept each other's tokens", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKey(t, dir, "0001.pem", newEd25519Key(t))
+
+		token, _ := newDirManager(t, dir).MakeJWT(uuid.New(), nil)
+		if _, err := newDirManager(t, dir).ValidateJWT(token); err != nil {
+			t.Errorf("ValidateJWT() error = %v", err)
+		}
+	})
+
+	t.Run("newest key signs and every key verifies", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKey(t, dir, "0001.pem", newEd25519Key(t))
+		oldToken, _ := newDirManager(t, dir).MakeJWT(uuid.New(), nil)
+
+		writeKey(t, dir, "0002.pem", newEd25519Key(t))
+		manager := newDirManager(t, dir)
+
+		if _, err := manager.ValidateJWT(oldToken); err != nil {
+			t.Errorf("token of the older key rejected: %v", err)
+		}
+
+		newToken, _ := manager.MakeJWT(uuid.New(), nil)
+		if kid(t, newToken) == kid(t, oldToken) {
+			t.Error("expected the newest key to sign")
+		}
+
+		keys := manager.JWKS().Keys
+		if len(keys) != 2 {
+			t.Fatalf("JWKS() returned %d keys, want 2", len(keys))
+		}
+		if keys[0].Kid != kid(t, newToken) {
+			t.Errorf("JWKS() first key = %s, want the active key %s", keys[0].Kid, kid(t, newToken))
+		}
+	})
+
+	t.Run("rollover keeps tokens of the old key valid", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKey(t, dir, "0001.pem", newEd25519Key(t))
+		manager := newDirManager(t, dir)
+
+		oldToken, _ := manager.MakeJWT(uuid.New(), nil)
+
+		// A new key is published for a full interval before it signs
+		writeKey(t, dir, "0002.pem", newEd25519Key(t))
+		if err := manager.Rotate(); err != nil {
+			t.Fatalf("Rotate() error = %v", err)
+		}
+		if len(manager.JWKS().Keys) != 2 {
+			t.Fatalf("JWKS() returned %d keys, want 2", len(manager.JWKS().Keys))
+		}
+		token, _ := manager.MakeJWT(uuid.New(), nil)
+		if kid(t, token) != kid(t, oldToken) {
+			t.Error("expected the new key not to sign before the next reload")
+		}
+
+		if err := manager.Rotate(); err != nil {
+			t.Fatalf("Rotate() error = %v", err)
+		}
+		newToken, _ := manager.MakeJWT(uuid.New(), nil)
+		if kid(t, newToken) == kid(t, oldToken) {
+			t.Error("expected the new key to sign after the next reload")
+		}
+
+		// The old key stays valid through its retention once removed
+		if err := os.Remove(filepath.Join(dir, "0001.pem")); err != nil {
+			t.Fatalf("Remove() error = %v", err)
+		}
+		if err := manager.Rotate(); err != nil {
+			t.Fatalf("Rotate() error = %v", err)
+		}
+
+		for name, token := range map[string]string{"old": oldToken, "new": newToken} {
+			if _, err := manager.ValidateJWT(token); err != nil {
+				t.Errorf("%s token rejected after rollover: %v", name, err)
+			}
+		}
+	})
+
+	t.Run("rotation reloads the directory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKey(t, dir, "0001.pem", newEd25519Key(t))
+		manager := newDirManager(t, dir)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := manager.StartRotation(ctx, time.Hour)
+		cancel()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("rotation did not stop after cancel")
+		}
+	})
+
+	t.Run("reload runs alongside signing and the JWKS", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKey(t, dir, "0001.pem", newEd25519Key(t))
+		writeKey(t, dir, "0002.pem", newEd25519Key(t))
+		manager := newDirManager(t, dir)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 20 {
+				if err := manager.Rotate(); err != nil {
+					t.Errorf("Rotate() error = %v", err)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 20 {
+				token, err := manager.MakeJWT(uuid.New(), nil)
+				if err != nil {
+					t.Errorf("MakeJWT() error = %v", err)
+					continue
+				}
+				if _, err := manager.ValidateJWT(token); err != nil {
+					t.Errorf("ValidateJWT() error = %v", err)
+				}
+				manager.JWKS()
+			}
+		}()
+		wg.Wait()
+	})
+
+	t.Run("error - reload with keys of another type", func(t *testing.T) {
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatalf("GenerateKey() error = %v", err)
+		}
+
+		dir := t.TempDir()
+		writeKey(t, dir, "0001.pem", newEd25519Key(t))
+		manager := newDirManager(t, dir)
+
+		if err := os.Remove(filepath.Join(dir, "0001.pem")); err != nil {
+			t.Fatalf("Remove() error = %v", err)
+		}
+		writeKey(t, dir, "0002.pem", rsaKey)
+
+		if err := manager.Rotate(); !errors.Is(err, jwt.ErrMixedKeyTypes) {
+			t.Errorf("Rotate() error = %v, want %v", err, jwt.ErrMixedKeyTypes)
+		}
+		if _, err := manager.MakeJWT(uuid.New(), nil); err != nil {
+			t.Errorf("expected the loaded keys to keep signing, got %v", err)
+		}
+	})
+
+	t.Run("error - empty directory", func(t *testing.T) {
+		if _, err := jwt.NewKeySetFromDir(t.TempDir(), time.Hour); !errors.Is(err, jwt.ErrNoKeys) {
+			t.Errorf("NewKeySetFromDir() error = %v, want %v", err, jwt.ErrNoKeys)
+		}
+	})
+
+	t.Run("error - mixed key types", func(t *testing.T) {
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatalf("GenerateKey() error = %v", err)
+		}
+
+		dir := t.TempDir()
+		writeKey(t, dir, "0001.pem", newEd25519Key(t))
+		writeKey(t, dir, "0002.pem", rsaKey)
+
+		if _, err := jwt.NewKeySetFromDir(dir, time.Hour); !errors.Is(err, jwt.ErrMixedKeyTypes) {
+			t.Errorf("NewKeySetFromDir() error = %v, want %v", err, jwt.ErrMixedKeyTypes)
+		}
+	})
+}
+
+func TestJWKS(t *testing.T) {
+	t.Run("RSA keys expose modulus and exponent", func(t *testing.T) {
+		manager, _ := jwt.NewJWTManager(jwt.RS256)
+		keys := manager.JWKS().Keys
+		if len(keys) != 1 {
+			t.Fatalf("got %d keys, want 1", len(keys))
+		}
+		key := keys[0]
+		if key.Kty != "RSA" || key.Alg != "RS256" || key.N == "" || key.E == "" || key.Kid == "" {
+			t.Errorf("unexpected RSA jwk: %+v", key)
+		}
+	})
+
+	t.Run("Ed25519 keys expose curve point", func(t *testing.T) {
+		manager, _ := jwt.NewJWTManager(jwt.EdDSA)
+		key := manager.JWKS().Keys[0]
+		if key.Kty != "OKP" || key.Crv != "Ed25519" || key.X == "" {
+			t.Errorf("unexpected OKP jwk: %+v", key)
+		}
+	})
+
+	t.Run("HS256 never publishes the secret", func(t *testing.T) {
+		manager, _ := jwt.NewHS256Manager("local-secret")
+		if keys := manager.JWKS().Keys; len(keys) != 0 {
+			t.Errorf("got %d keys, want none", len(keys))
+		}
+	})
+}
+
+func TestHS256Manager(t *testing.T) {
+	t.Run("empty secret is rejected", func(t *testing.T) {
+		_, err := jwt.NewHS256Manager("")
+		if !errors.Is(err, jwt.ErrEmptySecret) {
+			t.Errorf("error = %v, want %v", err, jwt.ErrEmptySecret)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		manager, err := jwt.NewHS256Manager("local-secret")
+		if err != nil {
+			t.Fatalf("NewHS256Manager() error = %v", err)
+		}
+		token, _ := manager.MakeJWT(uuid.New(), nil)
+		if _, err := manager.ValidateJWT(token); err != nil {
+			t.Errorf("ValidateJWT() error = %v", err)
+		}
+	})
+
+	t.Run("rotation is not supported", func(t *testing.T) {
+		manager, _ := jwt.NewHS256Manager("local-secret")
+		if err := manager.Rotate(); !errors.Is(err, jwt.ErrRotationNotSupported) {
+			t.Errorf("Rotate() error = %v, want %v", err, jwt.ErrRotationNotSupported)
+		}
+	})
+}
+
+func TestValidateJWT_RejectsForeignTokens(t *testing.T) {
+	manager, _ := jwt.NewJWTManager(jwt.RS256)
+
+	t.Run("token from another key set", func(t *testing.T) {
+		other, _ := jwt.NewJWTManager(jwt.RS256)
+		token, _ := other.MakeJWT(uuid.New(), nil)
+		if _, err := manager.ValidateJWT(token); err == nil {
+			t.Error("expected token signed by another key set to be rejected")
+		}
+	})
+
+	t.Run("HS256 token is not accepted by an RS256 manager", func(t *testing.T) {
+		hs, _ := jwt.NewHS256Manager("local-secret")
+		token, _ := hs.MakeJWT(uuid.New(), nil)
+		if _, err := manager.ValidateJWT(token); err == nil {
+			t.Error("expected algorithm mismatch to be rejected")
+		}
+	})
+}
+
+func TestValidateJWT_RejectsTokensOfTheSameKey(t *testing.T) {
+	private := newEd25519Key(t)
+	dir := t.TempDir()
+	writeKey(t, dir, "0001.pem", private)
+	manager := newDirManager(t, dir)
+
+	issued, _ := manager.MakeJWT(uuid.New(), nil)
+
+	sign := func(claims gojwt.RegisteredClaims) string {
+		t.Helper()
+
+		token := gojwt.NewWithClaims(gojwt.SigningMethodEdDSA, claims)
+		token.Header["kid"] = kid(t, issued)
+		signed, err := token.SignedString(private)
+		if err != nil {
+			t.Fatalf("SignedString() error = %v", err)
+		}
+		return signed
+	}
+
+	tests := []struct {
+		name   string
+		claims gojwt.RegisteredClaims
+	}{
+		{
+			name: "another issuer",
+			claims: gojwt.RegisteredClaims{
+				Issuer:    "fitrkr-exports",
+				Subject:   uuid.NewString(),
+				ExpiresAt: gojwt.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+		},
+		{
+			name: "no issuer",
+			claims: gojwt.RegisteredClaims{
+				Subject:   uuid.NewString(),
+				ExpiresAt: gojwt.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+		},
+		{
+			name: "no expiry",
+			claims: gojwt.RegisteredClaims{
+				Issuer:  "fitrkr",
+				Subject: uuid.NewString(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := manager.ValidateJWT(sign(tt.claims)); err == nil {
+				t.Error("expected token to be rejected")
+			}
+		})
+	}
+
+	t.Run("token with issuer and expiry is accepted", func(t *testing.T) {
+		token := sign(gojwt.RegisteredClaims{
+			Issuer:    "fitrkr",
+			Subject:   uuid.NewString(),
+			ExpiresAt: gojwt.NewNumericDate(time.Now().Add(time.Minute)),
+		})
+		if _, err := manager.ValidateJWT(token); err != nil {
+			t.Errorf("ValidateJWT() error = %v", err)
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/cheezecakee/logr"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/oidc"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/oidc/oidctest"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+)
+
+func TestMain(m *testing.M) {
+	logr.Init(&logr.PlainTextFormatter{}, logr.LevelInfo, nil)
+
+	os.Exit(m.Run())
+}
+
+func newProvider(t *testing.T, server *oidctest.Server) *oidc.Provider {
+	t.Helper()
+
+	provider, err := oidc.NewProvider(oidc.Config{
+		Name:        "mock",
+		IssuerURL:   server.URL,
+		ClientID:    server.ClientID,
+		RedirectURL: "http://localhost:8000/api/v1/auth/oauth/mock/callback",
+	})
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	return provider
+}
+
+func authorize(t *testing.T, server *oidctest.Server, provider *oidc.Provider, pkce auth.PKCE, nonce string) string {
+	t.Helper()
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state-123", nonce, pkce.Challenge)
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+
+	code, state, err := server.Authorize(authURL)
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	if state != "state-123" {
+		t.Fatalf("state = %q, want state-123", state)
+	}
+	return code
+}
+
+func TestProvider_AuthorizationCodeFlow(t *testing.T) {
+	server := oidctest.NewServer("fitrkr-test")
+	defer server.Close()
+
+	provider := newProvider(t, server)
+	pkce, _ := auth.NewPKCE()
+
+	code := authorize(t, server, provider, pkce, "nonce-123")
+
+	identity, err := provider.Exchange(context.Background(), code, pkce.Verifier, "nonce-123")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+
+	if identity.Subject != "oidctest-subject" {
+		t.Errorf("Subject = %q, want oidctest-subject", identity.Subject)
+	}
+	if identity.Email != "jane.doe@example.com" || !identity.EmailVerified {
+		t.Errorf("unexpected email claims: %+v", identity)
+	}
+	if identity.FirstName != "Jane" || identity.LastName != "Doe" {
+		t.Errorf("unexpected name claims: %+v", identity)
+	}
+}
+
+func TestProvider_Exchange_Errors(t *testing.T) {
+	server := oidctest.NewServer("fitrkr-test")
+	defer server.Close()
+
+	provider := newProvider(t, server)
+
+	tests := []struct {
+		name     string
+		verifier func(pkce auth.PKCE) string
+		nonce    string
+		wantErr  error
+	}{
+		{
+			name:     "wrong code verifier",
+			verifier: func(auth.PKCE) string { return "not-the-verifier" },
+			nonce:    "nonce-123",
+			wantErr:  oidc.ErrTokenExchange,
+		},
+		{
+			name:     "nonce mismatch",
+			verifier: func(p auth.PKCE) string { return p.Verifier },
+			nonce:    "other-nonce",
+			wantErr:  oidc.ErrNonceMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkce, _ := auth.NewPKCE()
+			code := authorize(t, server, provider, pkce, "nonce-123")
+
+			_, err := provider.Exchange(context.Background(), code, tt.verifier(pkce), tt.nonce)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Exchange() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("code can only be used once", func(t *testing.T) {
+		pkce, _ := auth.NewPKCE()
+		code := authorize(t, server, provider, pkce, "nonce-123")
+
+		if _, err := provider.Exchange(context.Background(), code, pkce.Verifier, "nonce-123"); err != nil {
+			t.Fatalf("first Exchange() error = %v", err)
+		}
+		if _, err := provider.Exchange(context.Background(), code, pkce.Verifier, "nonce-123"); !errors.Is(err, oidc.ErrTokenExchange) {
+			t.Errorf("second Exchange() error = %v, want %v", err, oidc.ErrTokenExchange)
+		}
+	})
+}
+
+func TestProvider_DiscoveryIssuerMismatch(t *testing.T) {
+	server := oidctest.NewServer("fitrkr-test")
+	defer server.Close()
+
+	provider, _ := oidc.NewProvider(oidc.Config{
+		Name:        "mock",
+		IssuerURL:   server.URL + "/other",
+		ClientID:    server.ClientID,
+		RedirectURL: "http://localhost/callback",
+	})
+
+	if _, err := provider.AuthCodeURL(context.Background(), "s", "n", "c"); !errors.Is(err, oidc.ErrDiscovery) {
+		t.Errorf("AuthCodeURL() error = %v, want %v", err, oidc.ErrDiscovery)
+	}
+}
@@ -0,0 +1,183 @@
+// Package s3 stores objects in an S3 bucket or any store that speaks the S3
+// API, such as MinIO or Cloudflare R2.
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+var (
+	ErrMissingConfig = errors.New("s3 object store config incomplete")
+	ErrRequest       = errors.New("s3 request failed")
+)
+
+const (
+	defaultHTTPTimeout = 30 * time.Second
+	maxErrorBodySize   = 64 << 10
+)
+
+type Config struct {
+	// Endpoint is the base URL of the API, e.g.
+	// https://s3.eu-west-1.amazonaws.com.
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PathStyle puts the bucket in the path instead of the host name, most
+	// S3 compatible stores need it.
+	PathStyle  bool
+	HTTPClient *http.Client
+}
+
+// ObjectStore keeps every object in one bucket. Bodies are buffered before
+// they are sent, the payload hash is part of the signature.
+type ObjectStore struct {
+	cfg      Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+var _ ports.ObjectStore = (*ObjectStore)(nil)
+
+func NewObjectStore(cfg Config) (*ObjectStore, error) {
+	if cfg.Endpoint == "" || cfg.Region == "" || cfg.Bucket == "" || cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, ErrMissingConfig
+	}
+
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("%w: invalid endpoint %q", ErrMissingConfig, cfg.Endpoint)
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+
+	return &ObjectStore{cfg: cfg, endpoint: endpoint, client: client}, nil
+}
+
+func (s *ObjectStore) Put(ctx context.Context, key, contentType string, body io.Reader) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.do(ctx, http.MethodPut, key, contentType, data)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return responseError(res)
+	}
+	return nil
+}
+
+func (s *ObjectStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	res, err := s.do(ctx, http.MethodGet, key, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		return nil, responseError(res)
+	}
+	return res.Body, nil
+}
+
+func (s *ObjectStore) Delete(ctx context.Context, key string) error {
+	res, err := s.do(ctx, http.MethodDelete, key, "", nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	}
+
+	if err := responseError(res); !errors.Is(err, ports.ErrObjectNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *ObjectStore) do(ctx context.Context, method, key, contentType string, body []byte) (*http.Response, error) {
+	u, err := s.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	Sign(req, s.cfg.AccessKeyID, s.cfg.SecretAccessKey, s.cfg.Region, PayloadHash(body), time.Now())
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRequest, err)
+	}
+	return res, nil
+}
+
+// objectURL addresses key in the bucket, keys are slash separated paths
+// without empty, dot or dot-dot elements.
+func (s *ObjectStore) objectURL(key string) (*url.URL, error) {
+	if !fs.ValidPath(key) || key == "." {
+		return nil, fmt.Errorf("%w: %q", ports.ErrInvalidKey, key)
+	}
+
+	u := *s.endpoint
+	if s.cfg.PathStyle {
+		u.Path += "/" + s.cfg.Bucket + "/" + key
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+		u.Path += "/" + key
+	}
+	u.RawPath = uriEncode(u.Path, false)
+	return &u, nil
+}
+
+type errorResponse struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// responseError reads the error document of res. A missing key is reported
+// as ports.ErrObjectNotFound, a missing bucket is a configuration error.
+func responseError(res *http.Response) error {
+	var e errorResponse
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+	_ = xml.Unmarshal(body, &e)
+
+	if res.StatusCode == http.StatusNotFound && (e.Code == "NoSuchKey" || e.Code == "") {
+		return ports.ErrObjectNotFound
+	}
+
+	if e.Code == "" {
+		return fmt.Errorf("%w: %s", ErrRequest, res.Status)
+	}
+	return fmt.Errorf("%w: %s: %s: %s", ErrRequest, res.Status, e.Code, e.Message)
+}
@@ -0,0 +1,170 @@
+// Package s3test runs a local S3 stand-in for tests. It serves the object
+// calls of one path style bucket and rejects requests that are not signed
+// with its credentials.
+package s3test
+
+import (
+	"crypto/hmac"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/s3"
+)
+
+// maxClockSkew is how far a request date may be from the server clock, as
+// enforced by S3.
+const maxClockSkew = 15 * time.Minute
+
+type object struct {
+	contentType string
+	data        []byte
+}
+
+type Server struct {
+	*httptest.Server
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+
+	mu      sync.Mutex
+	objects map[string]object
+}
+
+func NewServer(bucket string) *Server {
+	s := &Server{
+		Bucket:          bucket,
+		Region:          "us-east-1",
+		AccessKeyID:     "s3test-access-key",
+		SecretAccessKey: "s3test-secret-key",
+		objects:         make(map[string]object),
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Config returns the store config that reaches the server.
+func (s *Server) Config() s3.Config {
+	return s3.Config{
+		Endpoint:        s.URL,
+		Region:          s.Region,
+		Bucket:          s.Bucket,
+		AccessKeyID:     s.AccessKeyID,
+		SecretAccessKey: s.SecretAccessKey,
+		PathStyle:       true,
+		HTTPClient:      s.Client(),
+	}
+}
+
+// Object returns the object stored under key.
+func (s *Server) Object(key string) (data []byte, contentType string, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.objects[key]
+	return o.data, o.contentType, ok
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+
+	if !s.verify(r, body) {
+		writeError(w, http.StatusForbidden, "SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided.")
+		return
+	}
+
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+s.Bucket+"/")
+	if !ok || key == "" {
+		writeError(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		s.objects[key] = object{contentType: r.Header.Get("Content-Type"), data: body}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		o, ok := s.objects[key]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+			return
+		}
+		w.Header().Set("Content-Type", o.contentType)
+		w.Write(o.data)
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+	}
+}
+
+// verify signs a copy of r made of the headers it claims to sign and
+// compares the result, like S3 does.
+func (s *Server) verify(r *http.Request, body []byte) bool {
+	credential, signedHeaders, ok := parseAuthorization(r.Header.Get("Authorization"))
+	if !ok || !strings.HasPrefix(credential, s.AccessKeyID+"/") {
+		return false
+	}
+
+	date, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil || time.Since(date).Abs() > maxClockSkew {
+		return false
+	}
+
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash != s3.PayloadHash(body) {
+		return false
+	}
+
+	signed := &http.Request{Method: r.Method, URL: r.URL, Host: r.Host, Header: http.Header{}}
+	for _, name := range strings.Split(signedHeaders, ";") {
+		if name != "host" {
+			signed.Header[http.CanonicalHeaderKey(name)] = r.Header.Values(name)
+		}
+	}
+	s3.Sign(signed, s.AccessKeyID, s.SecretAccessKey, s.Region, payloadHash, date)
+
+	return hmac.Equal([]byte(signed.Header.Get("Authorization")), []byte(r.Header.Get("Authorization")))
+}
+
+func parseAuthorization(header string) (credential, signedHeaders string, ok bool) {
+	params, ok := strings.CutPrefix(header, "AWS4-HMAC-SHA256 ")
+	if !ok {
+		return "", "", false
+	}
+
+	for _, param := range strings.Split(params, ", ") {
+		name, value, _ := strings.Cut(param, "=")
+		switch name {
+		case "Credential":
+			credential = value
+		case "SignedHeaders":
+			signedHeaders = value
+		}
+	}
+	return credential, signedHeaders, credential != "" && signedHeaders != ""
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string   `xml:"Code"`
+		Message string   `xml:"Message"`
+	}{Code: code, Message: message})
+}
@@ -0,0 +1,27 @@
+// Package filesystem keeps objects and background jobs in local directories.
+// It suits a single instance, or several sharing a network file system.
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// writeFile replaces name atomically, readers never see a partial file.
+func writeFile(name string, write func(f *os.File) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
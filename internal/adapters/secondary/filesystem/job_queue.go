@@ -0,0 +1,241 @@
+package filesystem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+// JobQueue keeps one JSON file per job in three directories:
+//
+//	ready/<run at>-<id>.json   waiting, named so they sort by due time
+//	running/<id>.json          claimed by a worker
+//	failed/<id>.json           given up on, with the reason
+//
+// Claims move a file from ready to running with a rename, so two workers
+// never claim the same job.
+type JobQueue struct {
+	ready   string
+	running string
+	failed  string
+
+	mu sync.Mutex
+}
+
+var _ ports.JobQueue = (*JobQueue)(nil)
+
+type jobFile struct {
+	ID          uuid.UUID `json:"id"`
+	Kind        string    `json:"kind"`
+	Payload     []byte    `json:"payload"`
+	Attempts    int       `json:"attempts"`
+	MaxAttempts int       `json:"max_attempts"`
+	RunAt       time.Time `json:"run_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// NewJobQueue opens the queue under root. Jobs left running by a previous
+// process are put back, it must be the only process using root.
+func NewJobQueue(root string) (*JobQueue, error) {
+	q := &JobQueue{
+		ready:   filepath.Join(root, "ready"),
+		running: filepath.Join(root, "running"),
+		failed:  filepath.Join(root, "failed"),
+	}
+
+	for _, dir := range []string{q.ready, q.running, q.failed} {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create job queue %s: %w", dir, err)
+		}
+	}
+
+	if err := q.recover(); err != nil {
+		return nil, fmt.Errorf("failed to recover running jobs: %w", err)
+	}
+
+	return q, nil
+}
+
+func (q *JobQueue) recover() error {
+	entries, err := os.ReadDir(q.running)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if !isJobFile(entry.Name()) {
+			continue
+		}
+
+		job, err := readJob(filepath.Join(q.running, entry.Name()))
+		if err != nil {
+			return err
+		}
+		if err := q.move(job, q.running, q.readyName(job)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *JobQueue) Enqueue(ctx context.Context, job ports.Job) error {
+	return writeJob(q.readyName(job), toJobFile(job, ""))
+}
+
+func (q *JobQueue) Claim(ctx context.Context) (*ports.Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	entries, err := os.ReadDir(q.ready)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, entry := range entries {
+		name := entry.Name()
+		if !isJobFile(name) {
+			continue
+		}
+
+		runAt, id, ok := parseReadyName(name)
+		if !ok {
+			continue
+		}
+		if runAt.After(now) {
+			// Names sort by due time, nothing after this is due either
+			break
+		}
+
+		claimed := filepath.Join(q.running, id+".json")
+		if err := os.Rename(filepath.Join(q.ready, name), claimed); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue // Claimed by another worker
+			}
+			return nil, err
+		}
+
+		job, err := readJob(claimed)
+		if err != nil {
+			return nil, err
+		}
+		job.Attempts++
+		if err := writeJob(claimed, toJobFile(job, "")); err != nil {
+			return nil, err
+		}
+
+		return &job, nil
+	}
+
+	return nil, ports.ErrNoJob
+}
+
+func (q *JobQueue) Complete(ctx context.Context, job ports.Job) error {
+	err := os.Remove(q.runningName(job))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (q *JobQueue) Retry(ctx context.Context, job ports.Job) error {
+	if err := writeJob(q.readyName(job), toJobFile(job, "")); err != nil {
+		return err
+	}
+	return q.Complete(ctx, job)
+}
+
+func (q *JobQueue) Fail(ctx context.Context, job ports.Job, reason string) error {
+	if err := writeJob(filepath.Join(q.failed, job.ID.String()+".json"), toJobFile(job, reason)); err != nil {
+		return err
+	}
+	return q.Complete(ctx, job)
+}
+
+// move rewrites job from dir to name, then removes it from dir.
+func (q *JobQueue) move(job ports.Job, dir, name string) error {
+	if err := writeJob(name, toJobFile(job, "")); err != nil {
+		return err
+	}
+	return os.Remove(filepath.Join(dir, job.ID.String()+".json"))
+}
+
+func (q *JobQueue) readyName(job ports.Job) string {
+	return filepath.Join(q.ready, fmt.Sprintf("%020d-%s.json", job.RunAt.UnixNano(), job.ID))
+}
+
+func (q *JobQueue) runningName(job ports.Job) string {
+	return filepath.Join(q.running, job.ID.String()+".json")
+}
+
+func parseReadyName(name string) (time.Time, string, bool) {
+	nanos, id, ok := strings.Cut(strings.TrimSuffix(name, ".json"), "-")
+	if !ok {
+		return time.Time{}, "", false
+	}
+
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+	return time.Unix(0, n), id, true
+}
+
+// isJobFile skips the temporary files of writes in progress.
+func isJobFile(name string) bool {
+	return strings.HasSuffix(name, ".json") && !strings.HasPrefix(name, ".")
+}
+
+func toJobFile(job ports.Job, reason string) jobFile {
+	return jobFile{
+		ID:          job.ID,
+		Kind:        job.Kind,
+		Payload:     job.Payload,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt,
+		CreatedAt:   job.CreatedAt,
+		Error:       reason,
+	}
+}
+
+func writeJob(name string, job jobFile) error {
+	return writeFile(name, func(f *os.File) error {
+		return json.NewEncoder(f).Encode(job)
+	})
+}
+
+func readJob(name string) (ports.Job, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return ports.Job{}, err
+	}
+
+	var job jobFile
+	if err := json.Unmarshal(data, &job); err != nil {
+		return ports.Job{}, fmt.Errorf("failed to decode job %s: %w", name, err)
+	}
+
+	return ports.Job{
+		ID:          job.ID,
+		Kind:        job.Kind,
+		Payload:     job.Payload,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt,
+		CreatedAt:   job.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,124 @@
+package filesystem_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/filesystem"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+func newJob(kind string, runAt time.Time) ports.Job {
+	return ports.Job{
+		ID:          uuid.New(),
+		Kind:        kind,
+		Payload:     []byte(`{"id":1}`),
+		MaxAttempts: 3,
+		RunAt:       runAt,
+		CreatedAt:   runAt,
+	}
+}
+
+func TestJobQueue_ClaimsDueJobsInOrder(t *testing.T) {
+	ctx := context.Background()
+	queue, err := filesystem.NewJobQueue(t.TempDir())
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	now := time.Now()
+	later := newJob("later", now.Add(time.Hour))
+	second := newJob("second", now.Add(-time.Minute))
+	first := newJob("first", now.Add(-time.Hour))
+	for _, job := range []ports.Job{later, second, first} {
+		if err := queue.Enqueue(ctx, job); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+
+	for _, want := range []ports.Job{first, second} {
+		job, err := queue.Claim(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if job.ID != want.ID || job.Kind != want.Kind || string(job.Payload) != `{"id":1}` {
+			t.Errorf("expected %s job, got %+v", want.Kind, job)
+		}
+		if job.Attempts != 1 {
+			t.Errorf("expected the claim counted, got %d attempts", job.Attempts)
+		}
+		if err := queue.Complete(ctx, *job); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+
+	if _, err := queue.Claim(ctx); !errors.Is(err, ports.ErrNoJob) {
+		t.Errorf("expected ErrNoJob before the last job is due, got: %v", err)
+	}
+}
+
+func TestJobQueue_RetryAndFail(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	queue, _ := filesystem.NewJobQueue(root)
+
+	job := newJob("export", time.Now().Add(-time.Second))
+	if err := queue.Enqueue(ctx, job); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	claimed, _ := queue.Claim(ctx)
+	if err := queue.Retry(ctx, *claimed); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	claimed, err := queue.Claim(ctx)
+	if err != nil {
+		t.Fatalf("expected the retried job back, got: %v", err)
+	}
+	if claimed.Attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", claimed.Attempts)
+	}
+
+	if err := queue.Fail(ctx, *claimed, "boom"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "failed", job.ID.String()+".json")); err != nil {
+		t.Errorf("expected the job set aside, got: %v", err)
+	}
+	if _, err := queue.Claim(ctx); !errors.Is(err, ports.ErrNoJob) {
+		t.Errorf("expected ErrNoJob, got: %v", err)
+	}
+}
+
+func TestJobQueue_RecoversRunningJobs(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	queue, _ := filesystem.NewJobQueue(root)
+
+	job := newJob("export", time.Now().Add(-time.Second))
+	_ = queue.Enqueue(ctx, job)
+	if _, err := queue.Claim(ctx); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	// The process stops before the job finishes
+	reopened, err := filesystem.NewJobQueue(root)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	claimed, err := reopened.Claim(ctx)
+	if err != nil {
+		t.Fatalf("expected the running job back in the queue, got: %v", err)
+	}
+	if claimed.ID != job.ID || claimed.Attempts != 2 {
+		t.Errorf("expected job %s on its second attempt, got %s on attempt %d", job.ID, claimed.ID, claimed.Attempts)
+	}
+}
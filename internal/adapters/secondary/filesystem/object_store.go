@@ -0,0 +1,81 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+// ObjectStore keeps every object as a file under its root directory. Content
+// types are not stored, callers know what they put.
+type ObjectStore struct {
+	root string
+}
+
+var _ ports.ObjectStore = (*ObjectStore)(nil)
+
+func NewObjectStore(root string) (*ObjectStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create object store %s: %w", root, err)
+	}
+	return &ObjectStore{root: root}, nil
+}
+
+// path maps key into the root, keys cannot climb out of it.
+func (s *ObjectStore) path(key string) (string, error) {
+	if key == "" || path.Clean(key) != key || !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("%w: %q", ports.ErrInvalidKey, key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+func (s *ObjectStore) Put(ctx context.Context, key, contentType string, body io.Reader) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return err
+	}
+
+	return writeFile(name, func(f *os.File) error {
+		_, err := io.Copy(f, body)
+		return err
+	})
+}
+
+func (s *ObjectStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ports.ErrObjectNotFound
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *ObjectStore) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package filesystem_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/filesystem"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+func TestObjectStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := filesystem.NewObjectStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if err := store.Put(ctx, "exports/user/archive.zip", "application/zip", strings.NewReader("first")); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := store.Put(ctx, "exports/user/archive.zip", "application/zip", strings.NewReader("second")); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	body, err := store.Get(ctx, "exports/user/archive.zip")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "second" {
+		t.Errorf("expected the object replaced, got %q", data)
+	}
+
+	if err := store.Delete(ctx, "exports/user/archive.zip"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if _, err := store.Get(ctx, "exports/user/archive.zip"); !errors.Is(err, ports.ErrObjectNotFound) {
+		t.Errorf("expected ErrObjectNotFound, got: %v", err)
+	}
+	if err := store.Delete(ctx, "exports/user/archive.zip"); err != nil {
+		t.Errorf("expected deleting a missing object to succeed, got: %v", err)
+	}
+}
+
+func TestObjectStore_RejectsKeysOutsideRoot(t *testing.T) {
+	ctx := context.Background()
+	store, _ := filesystem.NewObjectStore(t.TempDir())
+
+	for _, key := range []string{"", "../escape", "/etc/passwd", "a/../../b", "a//b", "./a"} {
+		if err := store.Put(ctx, key, "text/plain", strings.NewReader("x")); !errors.Is(err, ports.ErrInvalidKey) {
+			t.Errorf("Put(%q) error = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
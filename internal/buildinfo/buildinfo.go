@@ -0,0 +1,53 @@
+// Package buildinfo describes the running binary. Release builds set the
+// variables at link time:
+//
+//	go build -ldflags "-X github.com/cheezecakee/fitrkr-athena/internal/buildinfo.Version=v1.4.0
+//		-X github.com/cheezecakee/fitrkr-athena/internal/buildinfo.Commit=$(git rev-parse HEAD)
+//		-X github.com/cheezecakee/fitrkr-athena/internal/buildinfo.BuildTime=$(date -u +%FT%TZ)" ./cmd/web
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the link time values. Builds without them fall back to the
+// VCS stamp the go tool embeds.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			}
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,752 @@
+// Package config loads the settings of the service from the environment and
+// checks them before anything starts.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/joho/godotenv"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+	"github.com/cheezecakee/fitrkr-athena/internal/logging"
+	"github.com/cheezecakee/fitrkr-athena/internal/telemetry"
+)
+
+var ErrInvalidConfig = errors.New("invalid config")
+
+const redacted = "[REDACTED]"
+
+type Env string
+
+const (
+	Development Env = "development"
+	Production  Env = "production"
+)
+
+type Config struct {
+	Env       Env
+	Port      int
+	LogLevel  logr.Level
+	LogFormat logging.Format
+	// AccessLog writes a JSON line per request to stdout.
+	AccessLog bool
+
+	Server   ServerConfig
+	DB       DBConfig
+	JWT      JWTConfig
+	CORS     CORSConfig
+	Cookies  CookieConfig
+	OAuth    OAuthConfig
+	Lockout  LockoutConfig
+	SMTP     SMTPConfig
+	Storage  StorageConfig
+	Jobs     JobsConfig
+	Exports  ExportsConfig
+	Photos   PhotosConfig
+	Accounts AccountsConfig
+
+	Telemetry TelemetryConfig
+
+	settings []setting
+}
+
+type ServerConfig struct {
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	// ShutdownTimeout is how long in-flight requests get to finish.
+	ShutdownTimeout time.Duration
+	// DrainDelay is how long readiness fails before shutdown starts, at least
+	// one readiness probe period of the load balancer.
+	DrainDelay time.Duration
+	// TLSCertFile and TLSKeyFile serve HTTPS when both are set.
+	TLSCertFile string
+	TLSKeyFile  string
+	// TrustedProxies are the CIDRs of the load balancers whose forwarded
+	// headers name the client. Empty trusts no one.
+	TrustedProxies []string
+}
+
+type DBConfig struct {
+	ConnString      string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+type JWTConfig struct {
+	// SigningMethod is "RS256", "EdDSA" or "HS256".
+	SigningMethod string
+	Secret        string
+	// KeyDir holds the PKCS#8 signing keys as .pem files named to sort from
+	// oldest to newest, it is reloaded every RotationInterval.
+	KeyDir           string
+	AccessTokenTTL   time.Duration
+	RefreshTokenTTL  time.Duration
+	RotationInterval time.Duration
+}
+
+// CORSConfig is the cross origin policy, empty lists keep the defaults of
+// the server.
+type CORSConfig struct {
+	// AllowedOrigins may start the host with *. to allow its subdomains.
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	ExposedHeaders []string
+	MaxAge         time.Duration
+}
+
+type CookieConfig struct {
+	// Secure keeps session cookies off plain HTTP, required in production.
+	Secure   bool
+	SameSite http.SameSite
+	// Domain shares the cookies with subdomains when set.
+	Domain string
+}
+
+type OAuthConfig struct {
+	RedirectBaseURL string
+	// FlowSecret MACs the flow cookie, generated per process when empty.
+	FlowSecret string
+	Google     OIDCClient
+	Apple      OIDCClient
+}
+
+// OIDCClient is an identity provider registration, it is disabled while
+// ClientID is empty.
+type OIDCClient struct {
+	ClientID     string
+	ClientSecret string
+}
+
+type LockoutConfig struct {
+	UnlockURL string
+	// AttemptStore is "postgres", or "memory" for a single local instance.
+	AttemptStore string
+}
+
+// SMTPConfig sends mail through Host when it is set, mail is logged
+// otherwise.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// StorageConfig sets where stored files, such as export archives and
+// photos, are kept. Backend is "filesystem", keeping them under Dir, or "s3".
+type StorageConfig struct {
+	Backend string
+	Dir     string
+	S3      S3Config
+}
+
+// S3Config addresses a bucket of any S3 compatible store.
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PathStyle puts the bucket in the path, most self hosted stores need it.
+	PathStyle bool
+}
+
+// JobsConfig keeps the background job queue under Dir.
+type JobsConfig struct {
+	Dir          string
+	PollInterval time.Duration
+}
+
+type PhotosConfig struct {
+	// MaxSize is the largest upload accepted, in bytes.
+	MaxSize int
+}
+
+type ExportsConfig struct {
+	// TTL is how long a finished archive can be downloaded.
+	TTL           time.Duration
+	PurgeInterval time.Duration
+}
+
+// AccountsConfig sets how often deleted accounts past their grace period are
+// purged.
+type AccountsConfig struct {
+	PurgeInterval time.Duration
+}
+
+type TelemetryConfig struct {
+	Exporter    telemetry.Exporter
+	ServiceName string
+	// OTLPEndpoint is the collector base URL, such as http://localhost:4318.
+	OTLPEndpoint string
+	SampleRatio  float64
+}
+
+// Lookup returns the raw value of a setting and whether it is set.
+type Lookup func(key string) (string, bool)
+
+// Load reads the process environment, then .env, then the file named by
+// CONFIG_FILE, both in dotenv format. The first source that sets a key wins,
+// so the environment always overrides the files.
+func Load() (*Config, error) {
+	lookup, err := sources()
+	if err != nil {
+		return nil, err
+	}
+	return Parse(lookup)
+}
+
+// LoadMigrate reads the same sources as Load but only the settings the
+// migrate command needs, so migrations run without the server settings.
+func LoadMigrate() (*MigrateConfig, error) {
+	lookup, err := sources()
+	if err != nil {
+		return nil, err
+	}
+	return ParseMigrate(lookup)
+}
+
+func sources() (Lookup, error) {
+	sources := []Lookup{os.LookupEnv}
+
+	dotenv, err := godotenv.Read()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read .env: %w", err)
+	}
+	sources = append(sources, mapLookup(dotenv))
+
+	file, ok := os.LookupEnv("CONFIG_FILE")
+	if !ok {
+		file = dotenv["CONFIG_FILE"]
+	}
+	if file != "" {
+		values, err := godotenv.Read(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", file, err)
+		}
+		sources = append(sources, mapLookup(values))
+	}
+
+	return func(key string) (string, bool) {
+		for _, lookup := range sources {
+			if value, ok := lookup(key); ok {
+				return value, true
+			}
+		}
+		return "", false
+	}, nil
+}
+
+func mapLookup(values map[string]string) Lookup {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+// Parse builds the config from lookup and validates it, reporting every
+// invalid setting at once.
+func Parse(lookup Lookup) (*Config, error) {
+	r := &reader{lookup: lookup}
+
+	cfg := &Config{
+		Env:      Env(r.string("APP_ENV", string(Development))),
+		Port:     r.int("PORT", 8000),
+		LogLevel: r.logLevel("LOG_LEVEL", logr.LevelInfo),
+		Server: ServerConfig{
+			ReadHeaderTimeout: r.duration("SERVER_READ_HEADER_TIMEOUT", 5*time.Second),
+			ReadTimeout:       r.duration("SERVER_READ_TIMEOUT", 15*time.Second),
+			WriteTimeout:      r.duration("SERVER_WRITE_TIMEOUT", 30*time.Second),
+			IdleTimeout:       r.duration("SERVER_IDLE_TIMEOUT", 2*time.Minute),
+			ShutdownTimeout:   r.duration("SERVER_SHUTDOWN_TIMEOUT", 20*time.Second),
+			DrainDelay:        r.duration("SERVER_DRAIN_DELAY", 5*time.Second),
+			TLSCertFile:       r.string("TLS_CERT_FILE", ""),
+			TLSKeyFile:        r.string("TLS_KEY_FILE", ""),
+			TrustedProxies:    r.list("TRUSTED_PROXIES", nil),
+		},
+		DB: r.db(),
+		JWT: JWTConfig{
+			SigningMethod:    r.string("JWT_SIGNING_METHOD", "RS256"),
+			Secret:           r.secret("JWT_SECRET"),
+			KeyDir:           r.string("JWT_KEY_DIR", ""),
+			AccessTokenTTL:   r.duration("JWT_ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL:  r.duration("JWT_REFRESH_TOKEN_TTL", auth.DefaultRefreshTokenTTL),
+			RotationInterval: r.duration("JWT_ROTATION_INTERVAL", 24*time.Hour),
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: r.list("CORS_ALLOWED_ORIGINS", nil),
+			AllowedMethods: r.list("CORS_ALLOWED_METHODS", nil),
+			AllowedHeaders: r.list("CORS_ALLOWED_HEADERS", nil),
+			ExposedHeaders: r.list("CORS_EXPOSED_HEADERS", nil),
+			MaxAge:         r.duration("CORS_MAX_AGE", 24*time.Hour),
+		},
+		Cookies: CookieConfig{
+			SameSite: r.sameSite("COOKIE_SAMESITE"),
+			Domain:   r.string("COOKIE_DOMAIN", ""),
+		},
+		OAuth: OAuthConfig{
+			RedirectBaseURL: r.string("OAUTH_REDIRECT_BASE_URL", "http://localhost:8000/api/v1/auth/oauth"),
+			FlowSecret:      r.secret("OAUTH_FLOW_SECRET"),
+			Google: OIDCClient{
+				ClientID:     r.string("OIDC_GOOGLE_CLIENT_ID", ""),
+				ClientSecret: r.secret("OIDC_GOOGLE_CLIENT_SECRET"),
+			},
+			Apple: OIDCClient{
+				ClientID:     r.string("OIDC_APPLE_CLIENT_ID", ""),
+				ClientSecret: r.secret("OIDC_APPLE_CLIENT_SECRET"),
+			},
+		},
+		Lockout: LockoutConfig{
+			UnlockURL:    r.string("ACCOUNT_UNLOCK_URL", "http://localhost:8000/api/v1/auth/unlock"),
+			AttemptStore: r.string("LOGIN_ATTEMPT_STORE", "postgres"),
+		},
+		SMTP: SMTPConfig{
+			Host:     r.string("SMTP_HOST", ""),
+			Port:     r.int("SMTP_PORT", 587),
+			Username: r.string("SMTP_USERNAME", ""),
+			Password: r.secret("SMTP_PASSWORD"),
+			From:     r.string("SMTP_FROM", ""),
+		},
+		Storage: StorageConfig{
+			Backend: r.string("STORAGE_BACKEND", "filesystem"),
+			Dir:     r.string("STORAGE_DIR", "data/objects"),
+			S3: S3Config{
+				Endpoint:        r.string("S3_ENDPOINT", ""),
+				Region:          r.string("S3_REGION", ""),
+				Bucket:          r.string("S3_BUCKET", ""),
+				AccessKeyID:     r.string("S3_ACCESS_KEY_ID", ""),
+				SecretAccessKey: r.secret("S3_SECRET_ACCESS_KEY"),
+				PathStyle:       r.bool("S3_PATH_STYLE", false),
+			},
+		},
+		Jobs: JobsConfig{
+			Dir:          r.string("JOB_QUEUE_DIR", "data/jobs"),
+			PollInterval: r.duration("JOB_POLL_INTERVAL", 5*time.Second),
+		},
+		Exports: ExportsConfig{
+			TTL:           r.duration("EXPORT_TTL", export.DefaultTTL),
+			PurgeInterval: r.duration("EXPORT_PURGE_INTERVAL", time.Hour),
+		},
+		Photos: PhotosConfig{
+			MaxSize: r.int("PHOTO_MAX_SIZE", photo.DefaultMaxSize),
+		},
+		Accounts: AccountsConfig{
+			PurgeInterval: r.duration("ACCOUNT_PURGE_INTERVAL", time.Hour),
+		},
+		Telemetry: TelemetryConfig{
+			Exporter:     r.exporter("OTEL_EXPORTER"),
+			ServiceName:  r.string("OTEL_SERVICE_NAME", "fitrkr-athena"),
+			OTLPEndpoint: r.string("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+			SampleRatio:  r.float("OTEL_TRACES_SAMPLE_RATIO", 1),
+		},
+	}
+	cfg.Cookies.Secure = r.bool("COOKIE_SECURE", cfg.Env == Production)
+	cfg.LogFormat = r.logFormat("LOG_FORMAT", cfg.Env)
+	cfg.AccessLog = r.bool("ACCESS_LOG", true)
+	cfg.settings = r.settings
+
+	errs := append(r.errs, cfg.validate()...)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, errors.Join(errs...))
+	}
+
+	return cfg, nil
+}
+
+// MigrateConfig is the part of Config the migrate command uses.
+type MigrateConfig struct {
+	LogLevel  logr.Level
+	LogFormat logging.Format
+	DB        DBConfig
+}
+
+// ParseMigrate builds the migrate config from lookup and validates it, the
+// settings of the server are neither read nor checked.
+func ParseMigrate(lookup Lookup) (*MigrateConfig, error) {
+	r := &reader{lookup: lookup}
+
+	env := Env(r.string("APP_ENV", string(Development)))
+	cfg := &MigrateConfig{
+		LogLevel:  r.logLevel("LOG_LEVEL", logr.LevelInfo),
+		LogFormat: r.logFormat("LOG_FORMAT", env),
+		DB:        r.db(),
+	}
+
+	var errs []error
+	check := collect(&errs)
+	checkEnv(check, env)
+	cfg.DB.validate(check)
+
+	errs = append(r.errs, errs...)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, errors.Join(errs...))
+	}
+
+	return cfg, nil
+}
+
+// check records an error built from format and args unless ok.
+type check func(ok bool, format string, args ...any)
+
+func collect(errs *[]error) check {
+	return func(ok bool, format string, args ...any) {
+		if !ok {
+			*errs = append(*errs, fmt.Errorf(format, args...))
+		}
+	}
+}
+
+func checkEnv(check check, env Env) {
+	check(env == Development || env == Production, "APP_ENV: must be %s or %s", Development, Production)
+}
+
+func (c DBConfig) validate(check check) {
+	check(c.ConnString != "", "DB_CONN_STRING: is required")
+	check(c.MaxOpenConns >= 0, "DB_MAX_OPEN_CONNS: must not be negative")
+	check(c.MaxIdleConns >= 0, "DB_MAX_IDLE_CONNS: must not be negative")
+	check(c.MaxOpenConns == 0 || c.MaxIdleConns <= c.MaxOpenConns, "DB_MAX_IDLE_CONNS: must not exceed DB_MAX_OPEN_CONNS")
+	check(c.ConnMaxLifetime >= 0, "DB_CONN_MAX_LIFETIME: must not be negative")
+	check(c.ConnMaxIdleTime >= 0, "DB_CONN_MAX_IDLE_TIME: must not be negative")
+}
+
+func (c *Config) validate() []error {
+	var errs []error
+	check := collect(&errs)
+
+	checkEnv(check, c.Env)
+	check(c.Port > 0 && c.Port <= 65535, "PORT: must be between 1 and 65535")
+
+	check(c.Server.ReadHeaderTimeout > 0, "SERVER_READ_HEADER_TIMEOUT: must be positive")
+	check(c.Server.ReadTimeout > 0, "SERVER_READ_TIMEOUT: must be positive")
+	check(c.Server.WriteTimeout > 0, "SERVER_WRITE_TIMEOUT: must be positive")
+	check(c.Server.IdleTimeout > 0, "SERVER_IDLE_TIMEOUT: must be positive")
+	check(c.Server.ShutdownTimeout > 0, "SERVER_SHUTDOWN_TIMEOUT: must be positive")
+	check(c.Server.DrainDelay > 0, "SERVER_DRAIN_DELAY: must be positive")
+	check((c.Server.TLSCertFile == "") == (c.Server.TLSKeyFile == ""), "TLS_CERT_FILE: must be set together with TLS_KEY_FILE")
+	for _, proxy := range c.Server.TrustedProxies {
+		check(validProxy(proxy), "TRUSTED_PROXIES: %q is not an address or CIDR", proxy)
+	}
+
+	c.DB.validate(check)
+
+	switch c.JWT.SigningMethod {
+	case "HS256":
+		check(c.JWT.Secret != "", "JWT_SECRET: is required for HS256")
+		check(c.Env != Production, "JWT_SIGNING_METHOD: HS256 is for local development only")
+	case "RS256", "EdDSA":
+		// Generated keys die with the process and differ per replica
+		check(c.JWT.KeyDir != "" || c.Env != Production, "JWT_KEY_DIR: is required in production")
+	default:
+		check(false, "JWT_SIGNING_METHOD: must be RS256, EdDSA or HS256")
+	}
+	check(c.JWT.AccessTokenTTL > 0, "JWT_ACCESS_TOKEN_TTL: must be positive")
+	check(c.JWT.RefreshTokenTTL > c.JWT.AccessTokenTTL, "JWT_REFRESH_TOKEN_TTL: must be longer than JWT_ACCESS_TOKEN_TTL")
+	check(c.JWT.RotationInterval > 0, "JWT_ROTATION_INTERVAL: must be positive")
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		check(validOrigin(origin), "CORS_ALLOWED_ORIGINS: %q is not an origin", origin)
+	}
+	for _, method := range c.CORS.AllowedMethods {
+		check(method == strings.ToUpper(method) && !strings.ContainsAny(method, " \t"), "CORS_ALLOWED_METHODS: %q is not a method", method)
+	}
+	check(c.CORS.MaxAge >= 0, "CORS_MAX_AGE: must not be negative")
+
+	check(c.Cookies.Secure || c.Env != Production, "COOKIE_SECURE: must be true in production")
+	check(c.Cookies.Secure || c.Cookies.SameSite != http.SameSiteNoneMode, "COOKIE_SAMESITE: none requires COOKIE_SECURE")
+	check(!strings.ContainsAny(c.Cookies.Domain, "/: "), "COOKIE_DOMAIN: must be a bare domain")
+
+	check(validURL(c.OAuth.RedirectBaseURL), "OAUTH_REDIRECT_BASE_URL: must be an absolute URL")
+	if c.OAuth.FlowSecret != "" {
+		check(len(c.OAuth.FlowSecret) >= 32, "OAUTH_FLOW_SECRET: must be at least 32 bytes")
+	} else {
+		check(c.Env != Production, "OAUTH_FLOW_SECRET: is required in production")
+	}
+	check(validURL(c.Lockout.UnlockURL), "ACCOUNT_UNLOCK_URL: must be an absolute URL")
+	check(c.Lockout.AttemptStore == "postgres" || c.Lockout.AttemptStore == "memory", "LOGIN_ATTEMPT_STORE: must be postgres or memory")
+
+	if c.SMTP.Host != "" {
+		check(c.SMTP.Port > 0 && c.SMTP.Port <= 65535, "SMTP_PORT: must be between 1 and 65535")
+		check(c.SMTP.From != "", "SMTP_FROM: is required when SMTP_HOST is set")
+	}
+
+	switch c.Storage.Backend {
+	case "filesystem":
+		check(c.Storage.Dir != "", "STORAGE_DIR: is required")
+	case "s3":
+		check(validURL(c.Storage.S3.Endpoint), "S3_ENDPOINT: must be an absolute URL")
+		check(c.Storage.S3.Region != "", "S3_REGION: is required")
+		check(c.Storage.S3.Bucket != "", "S3_BUCKET: is required")
+		check(c.Storage.S3.AccessKeyID != "" && c.Storage.S3.SecretAccessKey != "", "S3_ACCESS_KEY_ID: must be set together with S3_SECRET_ACCESS_KEY")
+	default:
+		check(false, "STORAGE_BACKEND: must be filesystem or s3")
+	}
+	check(c.Jobs.Dir != "", "JOB_QUEUE_DIR: is required")
+	check(c.Jobs.PollInterval > 0, "JOB_POLL_INTERVAL: must be positive")
+	check(c.Exports.TTL > 0, "EXPORT_TTL: must be positive")
+	check(c.Exports.PurgeInterval > 0, "EXPORT_PURGE_INTERVAL: must be positive")
+	check(c.Photos.MaxSize > 0, "PHOTO_MAX_SIZE: must be positive")
+	check(c.Accounts.PurgeInterval > 0, "ACCOUNT_PURGE_INTERVAL: must be positive")
+
+	check(c.Telemetry.ServiceName != "", "OTEL_SERVICE_NAME: is required")
+	check(c.Telemetry.OTLPEndpoint == "" || validURL(c.Telemetry.OTLPEndpoint), "OTEL_EXPORTER_OTLP_ENDPOINT: must be an absolute URL")
+	check(c.Telemetry.SampleRatio >= 0 && c.Telemetry.SampleRatio <= 1, "OTEL_TRACES_SAMPLE_RATIO: must be between 0 and 1")
+
+	return errs
+}
+
+func validURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// String lists the effective settings one per line, with secrets and
+// database passwords redacted.
+func (c *Config) String() string {
+	var b strings.Builder
+	for _, s := range c.settings {
+		fmt.Fprintf(&b, "%s=%s\n", s.key, s.display())
+	}
+	return b.String()
+}
+
+type settingKind int
+
+const (
+	plain settingKind = iota
+	secret
+	dsn
+)
+
+type setting struct {
+	key   string
+	value string
+	kind  settingKind
+}
+
+func (s setting) display() string {
+	if s.value == "" {
+		return ""
+	}
+
+	switch s.kind {
+	case secret:
+		return redacted
+	case dsn:
+		return redactDSN(s.value)
+	default:
+		return s.value
+	}
+}
+
+var dsnPassword = regexp.MustCompile(`(password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// redactDSN hides the password of a URL or a key=value connection string.
+func redactDSN(conn string) string {
+	if u, err := url.Parse(conn); err == nil && u.Scheme != "" {
+		// The marker is swapped in after encoding, URLs would escape it
+		const placeholder = "__redacted__"
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), placeholder)
+		}
+		q := u.Query()
+		if q.Has("password") {
+			q.Set("password", placeholder)
+			u.RawQuery = q.Encode()
+		}
+		return strings.ReplaceAll(u.String(), placeholder, redacted)
+	}
+
+	return dsnPassword.ReplaceAllString(conn, "${1}"+redacted)
+}
+
+// reader records every setting it reads and collects parse errors instead of
+// stopping at the first one.
+type reader struct {
+	lookup   Lookup
+	settings []setting
+	errs     []error
+}
+
+func (r *reader) read(key, fallback string, kind settingKind) string {
+	value, ok := r.lookup(key)
+	value = strings.TrimSpace(value)
+	if !ok || value == "" {
+		value = fallback
+	}
+
+	r.settings = append(r.settings, setting{key: key, value: value, kind: kind})
+	return value
+}
+
+func (r *reader) fail(key string, err error) {
+	r.errs = append(r.errs, fmt.Errorf("%s: %w", key, err))
+}
+
+func (r *reader) string(key, fallback string) string {
+	return r.read(key, fallback, plain)
+}
+
+func (r *reader) secret(key string) string {
+	return r.read(key, "", secret)
+}
+
+func (r *reader) dsn(key string) string {
+	return r.read(key, "", dsn)
+}
+
+func (r *reader) int(key string, fallback int) int {
+	raw := r.read(key, strconv.Itoa(fallback), plain)
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		r.fail(key, fmt.Errorf("%q is not a number", raw))
+		return fallback
+	}
+	return n
+}
+
+func (r *reader) float(key string, fallback float64) float64 {
+	raw := r.read(key, strconv.FormatFloat(fallback, 'g', -1, 64), plain)
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		r.fail(key, fmt.Errorf("%q is not a number", raw))
+		return fallback
+	}
+	return f
+}
+
+func (r *reader) bool(key string, fallback bool) bool {
+	raw := r.read(key, strconv.FormatBool(fallback), plain)
+	b, err := strconv.ParseBool(raw)
+	if err != nil {
+		r.fail(key, fmt.Errorf("%q is not a boolean", raw))
+		return fallback
+	}
+	return b
+}
+
+func (r *reader) duration(key string, fallback time.Duration) time.Duration {
+	raw := r.read(key, fallback.String(), plain)
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		r.fail(key, fmt.Errorf("%q is not a duration", raw))
+		return fallback
+	}
+	return d
+}
+
+// list reads a comma separated list, empty items are dropped.
+func (r *reader) list(key string, fallback []string) []string {
+	raw := r.read(key, strings.Join(fallback, ","), plain)
+
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (r *reader) db() DBConfig {
+	return DBConfig{
+		ConnString:      r.dsn("DB_CONN_STRING"),
+		MaxOpenConns:    r.int("DB_MAX_OPEN_CONNS", 25),
+		MaxIdleConns:    r.int("DB_MAX_IDLE_CONNS", 5),
+		ConnMaxLifetime: r.duration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+		ConnMaxIdleTime: r.duration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+	}
+}
+
+func (r *reader) logLevel(key string, fallback logr.Level) logr.Level {
+	raw := r.read(key, strings.ToLower(fallback.String()), plain)
+	for _, level := range []logr.Level{logr.LevelDebug, logr.LevelInfo, logr.LevelWarn, logr.LevelError} {
+		if strings.EqualFold(raw, level.String()) {
+			return level
+		}
+	}
+	r.fail(key, fmt.Errorf("%q is not one of debug, info, warn or error", raw))
+	return fallback
+}
+
+func (r *reader) exporter(key string) telemetry.Exporter {
+	raw := r.read(key, string(telemetry.None), plain)
+	exporter, err := telemetry.NewExporter(raw)
+	if err != nil {
+		r.fail(key, fmt.Errorf("%q: %w", raw, err))
+		return telemetry.None
+	}
+	return exporter
+}
+
+// logFormat defaults to JSON in production, for log shippers, and to text
+// otherwise.
+func (r *reader) logFormat(key string, env Env) logging.Format {
+	fallback := logging.Text
+	if env == Production {
+		fallback = logging.JSON
+	}
+
+	raw := r.read(key, string(fallback), plain)
+	format, err := logging.NewFormat(strings.ToLower(raw))
+	if err != nil {
+		r.fail(key, fmt.Errorf("%q: %w", raw, err))
+		return fallback
+	}
+	return format
+}
+
+func (r *reader) sameSite(key string) http.SameSite {
+	raw := r.read(key, "lax", plain)
+	switch strings.ToLower(raw) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		r.fail(key, fmt.Errorf("%q is not one of lax, strict or none", raw))
+		return http.SameSiteLaxMode
+	}
+}
+
+// validProxy accepts a single address or a CIDR.
+func validProxy(proxy string) bool {
+	if strings.Contains(proxy, "/") {
+		_, err := netip.ParsePrefix(proxy)
+		return err == nil
+	}
+	_, err := netip.ParseAddr(proxy)
+	return err == nil
+}
+
+// validOrigin accepts scheme://host[:port], where the host may start with *.
+// to match its subdomains.
+func validOrigin(origin string) bool {
+	scheme, rest, ok := strings.Cut(origin, "://")
+	if !ok || scheme == "" || rest == "" || strings.ContainsAny(rest, "/?#@") {
+		return false
+	}
+	rest = strings.TrimPrefix(rest, "*.")
+
+	u, err := url.Parse(scheme + "://" + rest)
+	return err == nil && u.Hostname() != "" && !strings.Contains(u.Hostname(), "*")
+}
@@ -0,0 +1,22 @@
+package audit
+
+import "context"
+
+type clientKey struct{}
+
+// Client is where a request came from. The web layer puts it in the request
+// context so events can be attributed without every request type carrying it.
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+func ContextWithClient(ctx context.Context, c Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, c)
+}
+
+// ClientFromContext returns the zero Client outside of a request.
+func ClientFromContext(ctx context.Context) Client {
+	c, _ := ctx.Value(clientKey{}).(Client)
+	return c
+}
@@ -0,0 +1,116 @@
+// Package audit records who did what to which account.
+package audit
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrEmptyAction = errors.New("empty audit action")
+
+type Action string
+
+// Admin actions, the actor is the admin and the target the user they changed.
+const (
+	ActionAdminUserUpdated     Action = "admin.user_updated"
+	ActionAdminPasswordReset   Action = "admin.password_reset"
+	ActionAdminEmailVerified   Action = "admin.email_verified"
+	ActionAdminUserSuspended   Action = "admin.user_suspended"
+	ActionAdminUserUnsuspended Action = "admin.user_unsuspended"
+	ActionAdminSessionsRevoked Action = "admin.sessions_revoked"
+	ActionAdminRoleGranted     Action = "admin.role_granted"
+	ActionAdminRoleRevoked     Action = "admin.role_revoked"
+)
+
+// Sign in actions, the actor and target are the user signing in. Failures
+// are only recorded against existing accounts.
+const (
+	ActionLoginSucceeded Action = "auth.login_succeeded"
+	ActionLoginFailed    Action = "auth.login_failed"
+	ActionTokenRefreshed Action = "auth.token_refreshed"
+	ActionTokenRevoked   Action = "auth.token_revoked"
+)
+
+// Account and billing actions, the actor and target are the account owner.
+const (
+	ActionSubscriptionUpgraded  Action = "subscription.upgraded"
+	ActionPaymentRecorded       Action = "subscription.payment_recorded"
+	ActionSubscriptionCancelled Action = "subscription.cancelled"
+	ActionDeletionScheduled     Action = "user.deletion_scheduled"
+	ActionAccountRestored       Action = "user.account_restored"
+	ActionAccountDeleted        Action = "user.account_deleted"
+)
+
+// securityActions are what users are shown as their security activity.
+var securityActions = []Action{
+	ActionLoginSucceeded,
+	ActionLoginFailed,
+	ActionTokenRevoked,
+	ActionDeletionScheduled,
+	ActionAccountRestored,
+	ActionAdminPasswordReset,
+	ActionAdminEmailVerified,
+	ActionAdminUserSuspended,
+	ActionAdminUserUnsuspended,
+	ActionAdminSessionsRevoked,
+	ActionAdminRoleGranted,
+	ActionAdminRoleRevoked,
+}
+
+// SecurityActions lists the actions that change how an account is accessed.
+// Token refreshes are left out, there is one every few minutes.
+func SecurityActions() []Action {
+	return slices.Clone(securityActions)
+}
+
+// Event is one entry of the audit log. Events are only ever appended, purging
+// an account strips it from its events but keeps them.
+type Event struct {
+	ID       uuid.UUID         `json:"id"`
+	Action   Action            `json:"action"`
+	ActorID  uuid.UUID         `json:"actor_id"`
+	TargetID uuid.UUID         `json:"target_id"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	IP        string `json:"ip,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewEvent(action Action, actorID, targetID uuid.UUID, metadata map[string]string) (Event, error) {
+	if action == "" {
+		return Event{}, ErrEmptyAction
+	}
+
+	return Event{
+		ID:        uuid.New(),
+		Action:    action,
+		ActorID:   actorID,
+		TargetID:  targetID,
+		Metadata:  metadata,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// maxUserAgentLength bounds what a client can make us store.
+const maxUserAgentLength = 512
+
+// WithClient returns the event attributed to the client that caused it.
+func (e Event) WithClient(c Client) Event {
+	e.IP = c.IP
+	e.UserAgent = c.UserAgent
+	if len(e.UserAgent) > maxUserAgentLength {
+		e.UserAgent = strings.ToValidUTF8(e.UserAgent[:maxUserAgentLength], "")
+	}
+	return e
+}
+
+// BySelf reports whether the target acted on their own account.
+func (e Event) BySelf() bool {
+	return e.ActorID == e.TargetID
+}
@@ -0,0 +1,95 @@
+package audit_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+)
+
+func TestNewEvent(t *testing.T) {
+	actor, target := uuid.New(), uuid.New()
+
+	event, err := audit.NewEvent(audit.ActionAdminUserSuspended, actor, target, map[string]string{"reason": "spam"})
+	if err != nil {
+		t.Fatalf("NewEvent() unexpected error = %v", err)
+	}
+
+	if event.ID == uuid.Nil {
+		t.Error("expected generated UUID, got nil")
+	}
+	if event.ActorID != actor || event.TargetID != target {
+		t.Errorf("expected actor %v and target %v, got %v and %v", actor, target, event.ActorID, event.TargetID)
+	}
+	if event.Metadata["reason"] != "spam" {
+		t.Errorf("expected metadata kept, got %v", event.Metadata)
+	}
+	if time.Since(event.CreatedAt) > 2*time.Second {
+		t.Errorf("expected CreatedAt to be recent, got %v", event.CreatedAt)
+	}
+}
+
+func TestNewEvent_EmptyAction(t *testing.T) {
+	if _, err := audit.NewEvent("", uuid.New(), uuid.New(), nil); err != audit.ErrEmptyAction {
+		t.Errorf("expected ErrEmptyAction, got %v", err)
+	}
+}
+
+func TestEvent_WithClient(t *testing.T) {
+	user := uuid.New()
+
+	event, err := audit.NewEvent(audit.ActionLoginSucceeded, user, user, nil)
+	if err != nil {
+		t.Fatalf("NewEvent() unexpected error = %v", err)
+	}
+
+	ctx := audit.ContextWithClient(context.Background(), audit.Client{IP: "203.0.113.7", UserAgent: "curl/8.0"})
+	event = event.WithClient(audit.ClientFromContext(ctx))
+
+	if event.IP != "203.0.113.7" || event.UserAgent != "curl/8.0" {
+		t.Errorf("expected client attributed, got %q and %q", event.IP, event.UserAgent)
+	}
+	if !event.BySelf() {
+		t.Error("expected event by the target themselves")
+	}
+}
+
+func TestClientFromContext_Missing(t *testing.T) {
+	if c := audit.ClientFromContext(context.Background()); c != (audit.Client{}) {
+		t.Errorf("expected zero client, got %+v", c)
+	}
+}
+
+func TestSecurityActions(t *testing.T) {
+	tests := []struct {
+		action audit.Action
+		want   bool
+	}{
+		{audit.ActionLoginFailed, true},
+		{audit.ActionAdminSessionsRevoked, true},
+		{audit.ActionDeletionScheduled, true},
+		{audit.ActionTokenRefreshed, false},
+		{audit.ActionPaymentRecorded, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.action), func(t *testing.T) {
+			if got := slices.Contains(audit.SecurityActions(), tt.action); got != tt.want {
+				t.Errorf("SecurityActions() contains %s = %v, want %v", tt.action, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvent_WithClientTruncatesUserAgent(t *testing.T) {
+	event := audit.Event{}.WithClient(audit.Client{UserAgent: strings.Repeat("a", 2000)})
+
+	if len(event.UserAgent) != 512 {
+		t.Errorf("expected user agent cut to 512 bytes, got %d", len(event.UserAgent))
+	}
+}
@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidScope      = errors.New("invalid scope")
+	ErrNoScopes          = errors.New("at least one scope is required")
+	ErrEmptyAPIKeyName   = errors.New("empty api key name")
+	ErrAPIKeyNameTooLong = errors.New("api key name too long")
+	ErrExpiryInPast      = errors.New("expiry must be in the future")
+	ErrMalformedAPIKey   = errors.New("malformed api key")
+)
+
+type (
+	Scope  string
+	Scopes []Scope
+)
+
+const (
+	ScopeProfileRead   Scope = "profile:read"
+	ScopeStatsRead     Scope = "stats:read"
+	ScopeStatsWrite    Scope = "stats:write"
+	ScopeWorkoutsRead  Scope = "workouts:read"
+	ScopeWorkoutsWrite Scope = "workouts:write"
+)
+
+const (
+	apiKeyPrefix     = "fk"
+	apiKeyIDBytes    = 6
+	apiKeySecret     = 32
+	apiKeyNameLength = 50
+)
+
+func NewScopes(strs []string) (Scopes, error) {
+	if len(strs) == 0 {
+		return nil, ErrNoScopes
+	}
+
+	scopes := make(Scopes, 0, len(strs))
+	for _, s := range strs {
+		scope := Scope(strings.ToLower(strings.TrimSpace(s)))
+		switch scope {
+		case ScopeProfileRead, ScopeStatsRead, ScopeStatsWrite, ScopeWorkoutsRead, ScopeWorkoutsWrite:
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		default:
+			return nil, ErrInvalidScope
+		}
+	}
+
+	return scopes, nil
+}
+
+func (s Scopes) ContainsAll(scopes ...Scope) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(s, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Scopes) ToStrings() []string {
+	out := make([]string, len(s))
+	for i, scope := range s {
+		out[i] = string(scope)
+	}
+	return out
+}
+
+// APIKey is a personal access key for scripts and integrations. Only the
+// SHA-256 hash of the secret is stored, the key is shown once on creation.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Hash       string     `json:"-"`
+	Scopes     Scopes     `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// NewAPIKey returns the key to store and the plaintext key for the user.
+// Keys look like fk_<prefix>_<secret>, the prefix is used for lookups.
+func NewAPIKey(userID uuid.UUID, name string, scopes Scopes, expiresAt *time.Time) (APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return APIKey{}, "", ErrEmptyAPIKeyName
+	}
+	if len(name) > apiKeyNameLength {
+		return APIKey{}, "", ErrAPIKeyNameTooLong
+	}
+
+	if len(scopes) == 0 {
+		return APIKey{}, "", ErrNoScopes
+	}
+
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return APIKey{}, "", ErrExpiryInPast
+	}
+
+	prefixBytes := make([]byte, apiKeyIDBytes)
+	secretBytes := make([]byte, apiKeySecret)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return APIKey{}, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	if _, err := rand.Read(secretBytes); err != nil {
+		return APIKey{}, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	prefix := hex.EncodeToString(prefixBytes)
+	plaintext := fmt.Sprintf("%s_%s_%s", apiKeyPrefix, prefix, base64.RawURLEncoding.EncodeToString(secretBytes))
+
+	return APIKey{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		Hash:      HashAPIKey(plaintext),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, plaintext, nil
+}
+
+// ParseAPIKeyPrefix extracts the lookup prefix from a plaintext key.
+func ParseAPIKeyPrefix(plaintext string) (string, error) {
+	parts := strings.SplitN(plaintext, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || len(parts[1]) != apiKeyIDBytes*2 || parts[2] == "" {
+		return "", ErrMalformedAPIKey
+	}
+	return parts[1], nil
+}
+
+func HashAPIKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+func (k *APIKey) Verify(plaintext string) bool {
+	return subtle.ConstantTimeCompare([]byte(k.Hash), []byte(HashAPIKey(plaintext))) == 1
+}
+
+func (k *APIKey) IsExpired() bool {
+	return k.ExpiresAt != nil && time.Now().After(*k.ExpiresAt)
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func (k *APIKey) IsActive() bool {
+	return !k.IsExpired() && !k.IsRevoked()
+}
+
+func (k *APIKey) Revoke() {
+	now := time.Now()
+	k.RevokedAt = &now
+	k.UpdatedAt = now
+}
+
+// MarkUsed records the use and reports whether it is worth persisting, uses
+// within the same minute are not written again.
+func (k *APIKey) MarkUsed(now time.Time) bool {
+	if k.LastUsedAt != nil && now.Sub(*k.LastUsedAt) < time.Minute {
+		return false
+	}
+	k.LastUsedAt = &now
+	k.UpdatedAt = now
+	return true
+}
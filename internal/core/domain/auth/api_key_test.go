@@ -0,0 +1,156 @@
+package auth_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+)
+
+func TestNewScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		scopes  []string
+		want    auth.Scopes
+		wantErr error
+	}{
+		{"single scope", []string{"stats:read"}, auth.Scopes{auth.ScopeStatsRead}, nil},
+		{"normalizes and dedupes", []string{" Workouts:Read", "workouts:read", "workouts:write"}, auth.Scopes{auth.ScopeWorkoutsRead, auth.ScopeWorkoutsWrite}, nil},
+		{"empty", nil, nil, auth.ErrNoScopes},
+		{"unknown scope", []string{"admin:all"}, nil, auth.ErrInvalidScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := auth.NewScopes(tt.scopes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewScopes() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewScopes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopes_ContainsAll(t *testing.T) {
+	scopes := auth.Scopes{auth.ScopeStatsRead, auth.ScopeWorkoutsRead}
+
+	if !scopes.ContainsAll(auth.ScopeStatsRead) {
+		t.Error("expected stats:read to be contained")
+	}
+	if scopes.ContainsAll(auth.ScopeStatsRead, auth.ScopeWorkoutsWrite) {
+		t.Error("expected workouts:write to be missing")
+	}
+}
+
+func TestNewAPIKey(t *testing.T) {
+	userID := uuid.New()
+	scopes := auth.Scopes{auth.ScopeStatsRead}
+
+	key, plaintext, err := auth.NewAPIKey(userID, "  import script ", scopes, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if !strings.HasPrefix(plaintext, "fk_"+key.Prefix+"_") {
+		t.Errorf("plaintext %q does not carry prefix %q", plaintext, key.Prefix)
+	}
+
+	if key.Name != "import script" {
+		t.Errorf("expected trimmed name, got %q", key.Name)
+	}
+
+	if key.Hash == plaintext || strings.Contains(key.Hash, key.Prefix) {
+		t.Error("expected only a hash of the key to be stored")
+	}
+
+	if !key.Verify(plaintext) {
+		t.Error("expected plaintext to verify")
+	}
+
+	if key.Verify(plaintext + "x") {
+		t.Error("expected modified key to fail verification")
+	}
+
+	prefix, err := auth.ParseAPIKeyPrefix(plaintext)
+	if err != nil || prefix != key.Prefix {
+		t.Errorf("ParseAPIKeyPrefix() = %q, %v, want %q", prefix, err, key.Prefix)
+	}
+
+	_, other, _ := auth.NewAPIKey(userID, "other", scopes, nil)
+	if other == plaintext {
+		t.Error("expected unique keys")
+	}
+}
+
+func TestNewAPIKey_Errors(t *testing.T) {
+	userID := uuid.New()
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		keyName string
+		scopes  auth.Scopes
+		expiry  *time.Time
+		wantErr error
+	}{
+		{"empty name", " ", auth.Scopes{auth.ScopeStatsRead}, nil, auth.ErrEmptyAPIKeyName},
+		{"name too long", strings.Repeat("a", 51), auth.Scopes{auth.ScopeStatsRead}, nil, auth.ErrAPIKeyNameTooLong},
+		{"no scopes", "script", nil, nil, auth.ErrNoScopes},
+		{"expiry in past", "script", auth.Scopes{auth.ScopeStatsRead}, &past, auth.ErrExpiryInPast},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := auth.NewAPIKey(userID, tt.keyName, tt.scopes, tt.expiry)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewAPIKey() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseAPIKeyPrefix_Malformed(t *testing.T) {
+	for _, raw := range []string{"", "fk_abc", "xx_0123456789ab_secret", "fk_0123456789ab_", "fk_short_secret"} {
+		if _, err := auth.ParseAPIKeyPrefix(raw); !errors.Is(err, auth.ErrMalformedAPIKey) {
+			t.Errorf("ParseAPIKeyPrefix(%q) error = %v, want %v", raw, err, auth.ErrMalformedAPIKey)
+		}
+	}
+}
+
+func TestAPIKey_Lifecycle(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	key, _, _ := auth.NewAPIKey(uuid.New(), "script", auth.Scopes{auth.ScopeStatsRead}, &future)
+
+	if !key.IsActive() {
+		t.Fatal("expected new key to be active")
+	}
+
+	now := time.Now()
+	if !key.MarkUsed(now) {
+		t.Error("expected first use to be persisted")
+	}
+	if key.MarkUsed(now.Add(10 * time.Second)) {
+		t.Error("expected use within a minute to be skipped")
+	}
+	if !key.MarkUsed(now.Add(2 * time.Minute)) {
+		t.Error("expected later use to be persisted")
+	}
+
+	key.Revoke()
+	if key.IsActive() || !key.IsRevoked() {
+		t.Error("expected revoked key to be inactive")
+	}
+
+	expired := time.Now().Add(-time.Minute)
+	key.ExpiresAt = &expired
+	if !key.IsExpired() {
+		t.Error("expected key past expiry to be expired")
+	}
+}
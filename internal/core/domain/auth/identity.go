@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEmptyProvider = errors.New("empty identity provider")
+	ErrEmptySubject  = errors.New("empty identity subject")
+)
+
+// Identity links a user to an account at an external identity provider.
+type Identity struct {
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"-"`
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewIdentity(provider, subject string, userID uuid.UUID, email string) (Identity, error) {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if provider == "" {
+		return Identity{}, ErrEmptyProvider
+	}
+
+	if subject == "" {
+		return Identity{}, ErrEmptySubject
+	}
+
+	now := time.Now()
+	return Identity{
+		Provider:  provider,
+		Subject:   subject,
+		UserID:    userID,
+		Email:     strings.TrimSpace(email),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+func (i *Identity) Touch() {
+	i.UpdatedAt = time.Now()
+}
@@ -0,0 +1,49 @@
+package auth_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+)
+
+func TestNewIdentity(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name     string
+		provider string
+		subject  string
+		want     string
+		wantErr  error
+	}{
+		{"valid identity", "google", "1234", "google", nil},
+		{"provider is normalized", "  Apple ", "abcd", "apple", nil},
+		{"empty provider", " ", "1234", "", auth.ErrEmptyProvider},
+		{"empty subject", "google", "", "", auth.ErrEmptySubject},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := auth.NewIdentity(tt.provider, tt.subject, userID, "john@example.com")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewIdentity() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if identity.Provider != tt.want {
+				t.Errorf("Provider = %q, want %q", identity.Provider, tt.want)
+			}
+			if identity.UserID != userID {
+				t.Errorf("UserID = %v, want %v", identity.UserID, userID)
+			}
+			if !identity.UpdatedAt.Equal(identity.CreatedAt) {
+				t.Error("expected updatedAt to equal createdAt")
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginAttempts counts consecutive failed logins for one key, either an
+// account or a client IP.
+type LoginAttempts struct {
+	Key         string    `json:"key"`
+	Failures    int       `json:"failures"`
+	LastFailure time.Time `json:"last_failure"`
+}
+
+func AccountAttemptKey(username string) string {
+	return "user:" + strings.ToLower(strings.TrimSpace(username))
+}
+
+func IPAttemptKey(ip string) string {
+	return "ip:" + ip
+}
+
+// LockoutPolicy describes how failures slow down further attempts. The first
+// FreeAttempts failures cost nothing, then every failure doubles the delay up
+// to MaxDelay, and LockoutThreshold failures lock the key for LockoutDuration.
+type LockoutPolicy struct {
+	FreeAttempts     int
+	BaseDelay        time.Duration
+	MaxDelay         time.Duration
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+	// ResetAfter forgets failures that are older than this.
+	ResetAfter time.Duration
+}
+
+func DefaultAccountPolicy() LockoutPolicy {
+	return LockoutPolicy{
+		FreeAttempts:     3,
+		BaseDelay:        time.Second,
+		MaxDelay:         5 * time.Minute,
+		LockoutThreshold: 10,
+		LockoutDuration:  30 * time.Minute,
+		ResetAfter:       24 * time.Hour,
+	}
+}
+
+// DefaultIPPolicy is looser than the account policy since many users can
+// share an address.
+func DefaultIPPolicy() LockoutPolicy {
+	return LockoutPolicy{
+		FreeAttempts:     20,
+		BaseDelay:        time.Second,
+		MaxDelay:         time.Minute,
+		LockoutThreshold: 100,
+		LockoutDuration:  time.Hour,
+		ResetAfter:       time.Hour,
+	}
+}
+
+func (p LockoutPolicy) Delay(failures int) time.Duration {
+	if failures >= p.LockoutThreshold {
+		return p.LockoutDuration
+	}
+	if failures < p.FreeAttempts {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeAttempts; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay)
+}
+
+// BlockedUntil is the earliest time the next attempt is allowed.
+func (p LockoutPolicy) BlockedUntil(a LoginAttempts) time.Time {
+	if a.Failures == 0 {
+		return time.Time{}
+	}
+	return a.LastFailure.Add(p.Delay(a.Failures))
+}
+
+func (p LockoutPolicy) IsStale(a LoginAttempts, now time.Time) bool {
+	return a.Failures > 0 && p.ResetAfter > 0 && now.Sub(a.LastFailure) > p.ResetAfter
+}
+
+// Reserve counts an attempt against a, forgetting stale failures first. A
+// blocked attempt is not counted, a is returned as is with the time it is
+// blocked until. Counted attempts return the zero time.
+func (p LockoutPolicy) Reserve(a LoginAttempts, now time.Time) (LoginAttempts, time.Time) {
+	if p.IsStale(a, now) {
+		a.Failures = 0
+	}
+
+	if until := p.BlockedUntil(a); now.Before(until) {
+		return a, until
+	}
+
+	a.Failures++
+	a.LastFailure = now
+	return a, time.Time{}
+}
+
+func (p LockoutPolicy) IsLockout(failures int) bool {
+	return failures == p.LockoutThreshold
+}
+
+// UnlockToken lets the owner of a locked account lift the lockout from the
+// link in their email. Only the hash of the token is stored.
+type UnlockToken struct {
+	Hash      string    `json:"-"`
+	UserID    uuid.UUID `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewUnlockToken(userID uuid.UUID, ttl time.Duration) (UnlockToken, string, error) {
+	token, err := MakeRefreshToken()
+	if err != nil {
+		return UnlockToken{}, "", fmt.Errorf("failed to generate unlock token: %w", err)
+	}
+
+	now := time.Now()
+	return UnlockToken{
+		Hash:      HashUnlockToken(token),
+		UserID:    userID,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, token, nil
+}
+
+func HashUnlockToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (t *UnlockToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
@@ -0,0 +1,105 @@
+package auth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+)
+
+func TestLockoutPolicy_Delay(t *testing.T) {
+	policy := auth.LockoutPolicy{
+		FreeAttempts:     3,
+		BaseDelay:        time.Second,
+		MaxDelay:         10 * time.Second,
+		LockoutThreshold: 8,
+		LockoutDuration:  time.Hour,
+	}
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0},
+		{2, 0},
+		{3, time.Second},
+		{4, 2 * time.Second},
+		{5, 4 * time.Second},
+		{6, 8 * time.Second},
+		{7, 10 * time.Second},
+		{8, time.Hour},
+		{12, time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Delay(tt.failures); got != tt.want {
+			t.Errorf("Delay(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestLockoutPolicy_BlockedUntil(t *testing.T) {
+	policy := auth.DefaultAccountPolicy()
+	now := time.Now()
+
+	if got := policy.BlockedUntil(auth.LoginAttempts{}); !got.IsZero() {
+		t.Errorf("expected no block without failures, got %v", got)
+	}
+
+	attempts := auth.LoginAttempts{Failures: policy.LockoutThreshold, LastFailure: now}
+	if got := policy.BlockedUntil(attempts); !got.Equal(now.Add(policy.LockoutDuration)) {
+		t.Errorf("expected lockout until %v, got %v", now.Add(policy.LockoutDuration), got)
+	}
+
+	stale := auth.LoginAttempts{Failures: 5, LastFailure: now.Add(-policy.ResetAfter - time.Minute)}
+	if !policy.IsStale(stale, now) {
+		t.Error("expected old failures to be stale")
+	}
+}
+
+func TestLockoutPolicy_Reserve(t *testing.T) {
+	policy := auth.DefaultAccountPolicy()
+	now := time.Now()
+
+	attempts, blockedUntil := policy.Reserve(auth.LoginAttempts{Failures: policy.FreeAttempts - 1, LastFailure: now.Add(-time.Minute)}, now)
+	if !blockedUntil.IsZero() || attempts.Failures != policy.FreeAttempts || !attempts.LastFailure.Equal(now) {
+		t.Errorf("expected the attempt to be counted, got %+v blocked until %v", attempts, blockedUntil)
+	}
+
+	attempts, blockedUntil = policy.Reserve(attempts, now)
+	if blockedUntil.IsZero() || attempts.Failures != policy.FreeAttempts {
+		t.Errorf("expected a blocked attempt not to be counted, got %+v", attempts)
+	}
+
+	stale := auth.LoginAttempts{Failures: policy.LockoutThreshold, LastFailure: now.Add(-policy.ResetAfter - time.Minute)}
+	attempts, blockedUntil = policy.Reserve(stale, now)
+	if !blockedUntil.IsZero() || attempts.Failures != 1 {
+		t.Errorf("expected stale failures to be forgotten, got %+v blocked until %v", attempts, blockedUntil)
+	}
+}
+
+func TestAttemptKeys(t *testing.T) {
+	if auth.AccountAttemptKey(" JaneDoe ") != auth.AccountAttemptKey("janedoe") {
+		t.Error("expected account keys to ignore case and spaces")
+	}
+	if auth.AccountAttemptKey("127.0.0.1") == auth.IPAttemptKey("127.0.0.1") {
+		t.Error("expected account and ip keys not to collide")
+	}
+}
+
+func TestNewUnlockToken(t *testing.T) {
+	token, plaintext, err := auth.NewUnlockToken(uuid.New(), time.Hour)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if token.Hash != auth.HashUnlockToken(plaintext) || token.Hash == plaintext {
+		t.Error("expected only the token hash to be stored")
+	}
+
+	if token.IsExpired() {
+		t.Error("expected fresh token not to be expired")
+	}
+}
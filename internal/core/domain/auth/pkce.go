@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+)
+
+// PKCE holds the verifier kept by the client and the S256 challenge sent with
+// the authorization request (RFC 7636).
+type PKCE struct {
+	Verifier  string
+	Challenge string
+	Method    string
+}
+
+func NewPKCE() (PKCE, error) {
+	verifier, err := randomURLString(32)
+	if err != nil {
+		return PKCE{}, fmt.Errorf("failed to generate code verifier: %w", err)
+	}
+
+	return PKCE{
+		Verifier:  verifier,
+		Challenge: S256Challenge(verifier),
+		Method:    "S256",
+	}, nil
+}
+
+func S256Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// MakeState returns an unguessable value for the OAuth state and nonce
+// parameters.
+func MakeState() (string, error) {
+	return randomURLString(24)
+}
+
+func StateMatches(expected, actual string) bool {
+	if expected == "" || actual == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(actual)) == 1
+}
+
+// Helper function
+
+func randomURLString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
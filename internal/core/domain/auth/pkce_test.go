@@ -0,0 +1,63 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+)
+
+func TestNewPKCE(t *testing.T) {
+	pkce, err := auth.NewPKCE()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(pkce.Verifier) < 43 || len(pkce.Verifier) > 128 {
+		t.Errorf("verifier length %d outside RFC 7636 bounds", len(pkce.Verifier))
+	}
+
+	if pkce.Method != "S256" {
+		t.Errorf("expected S256 method, got %q", pkce.Method)
+	}
+
+	if pkce.Challenge != auth.S256Challenge(pkce.Verifier) {
+		t.Error("expected challenge to be derived from verifier")
+	}
+}
+
+func TestS256Challenge(t *testing.T) {
+	// Test vector from RFC 7636 appendix B
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	if got := auth.S256Challenge(verifier); got != want {
+		t.Errorf("S256Challenge() = %q, want %q", got, want)
+	}
+}
+
+func TestStateMatches(t *testing.T) {
+	state, err := auth.MakeState()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		expected string
+		actual   string
+		want     bool
+	}{
+		{"same state", state, state, true},
+		{"different state", state, state + "x", false},
+		{"empty expected", "", state, false},
+		{"both empty", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := auth.StateMatches(tt.expected, tt.actual); got != tt.want {
+				t.Errorf("StateMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// DefaultRefreshTokenTTL is how long a session lasts without activity.
+const DefaultRefreshTokenTTL = 7 * 24 * time.Hour
+
 type RefreshToken struct {
 	Token     string     `json:"token"`
 	UserID    uuid.UUID  `json:"user_id"`
@@ -20,7 +23,7 @@ type RefreshToken struct {
 	UpdatedAt time.Time  `json:"updated_at"`
 }
 
-func NewRefreshToken(userID uuid.UUID) (RefreshToken, error) {
+func NewRefreshToken(userID uuid.UUID, ttl time.Duration) (RefreshToken, error) {
 	token, err := MakeRefreshToken()
 	if err != nil {
 		return RefreshToken{}, fmt.Errorf("failed to generate refresh token: %w", err)
@@ -30,7 +33,7 @@ func NewRefreshToken(userID uuid.UUID) (RefreshToken, error) {
 		Token:     token,
 		UserID:    userID,
 		IsRevoked: false,
-		ExpiresAt: now.Add(7 * 24 * time.Hour),
+		ExpiresAt: now.Add(ttl),
 		CreatedAt: now,
 		UpdatedAt: now,
 	}, nil
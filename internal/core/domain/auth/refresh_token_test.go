@@ -14,7 +14,7 @@ func TestNewRefreshToken(t *testing.T) {
 	userID := uuid.New()
 	beforeCreation := time.Now()
 
-	rf, err := auth.NewRefreshToken(userID)
+	rf, err := auth.NewRefreshToken(userID, auth.DefaultRefreshTokenTTL)
 
 	afterCreation := time.Now()
 
@@ -96,8 +96,8 @@ func TestNewRefreshToken(t *testing.T) {
 func TestNewRefreshToken_UniqueTokens(t *testing.T) {
 	userID := uuid.New()
 
-	rf1, _ := auth.NewRefreshToken(userID)
-	rf2, _ := auth.NewRefreshToken(userID)
+	rf1, _ := auth.NewRefreshToken(userID, auth.DefaultRefreshTokenTTL)
+	rf2, _ := auth.NewRefreshToken(userID, auth.DefaultRefreshTokenTTL)
 
 	if rf1.Token == rf2.Token {
 		t.Error("expected different tokens for each call")
@@ -121,7 +121,7 @@ func TestMakeRefreshToken(t *testing.T) {
 
 func TestIsExpired(t *testing.T) {
 	userID := uuid.New()
-	rf, _ := auth.NewRefreshToken(userID)
+	rf, _ := auth.NewRefreshToken(userID, auth.DefaultRefreshTokenTTL)
 
 	t.Run("not expired immediately after creation", func(t *testing.T) {
 		if rf.IsExpired() {
@@ -140,7 +140,7 @@ func TestIsExpired(t *testing.T) {
 
 func TestRevoke(t *testing.T) {
 	userID := uuid.New()
-	rf, _ := auth.NewRefreshToken(userID)
+	rf, _ := auth.NewRefreshToken(userID, auth.DefaultRefreshTokenTTL)
 	beforeRevoke := time.Now()
 
 	rf.Revoke()
@@ -166,7 +166,7 @@ func TestRevoke(t *testing.T) {
 
 func TestTouch(t *testing.T) {
 	userID := uuid.New()
-	rf, _ := auth.NewRefreshToken(userID)
+	rf, _ := auth.NewRefreshToken(userID, auth.DefaultRefreshTokenTTL)
 
 	// Small delay to ensure time advances
 	time.Sleep(10 * time.Millisecond)
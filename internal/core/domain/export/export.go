@@ -0,0 +1,112 @@
+// Package export tracks the archives users request of the data held about
+// them.
+package export
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotReady = errors.New("export is not ready")
+	ErrExpired  = errors.New("export has expired")
+)
+
+// DefaultTTL is how long a finished archive can be downloaded.
+const DefaultTTL = 7 * 24 * time.Hour
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	// StatusExpired exports had their archive deleted.
+	StatusExpired Status = "expired"
+)
+
+// Export is one request for an archive. It moves from pending to running to
+// completed or failed, and completed archives expire after their TTL.
+type Export struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+	Status Status    `json:"status"`
+
+	// FileKey names the archive in the object store once it is written.
+	FileKey string `json:"-"`
+	Size    int64  `json:"size"`
+
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func New(userID uuid.UUID, now time.Time) Export {
+	return Export{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Status:    StatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IsActive reports whether the archive is still being prepared.
+func (e Export) IsActive() bool {
+	return e.Status == StatusPending || e.Status == StatusRunning
+}
+
+func (e Export) IsExpired(now time.Time) bool {
+	return e.Status == StatusExpired || (e.ExpiresAt != nil && !now.Before(*e.ExpiresAt))
+}
+
+// Downloadable reports why the archive cannot be downloaded, if it cannot.
+func (e Export) Downloadable(now time.Time) error {
+	if e.IsExpired(now) {
+		return ErrExpired
+	}
+	if e.Status != StatusCompleted {
+		return ErrNotReady
+	}
+	return nil
+}
+
+func (e Export) Start(now time.Time) Export {
+	e.Status = StatusRunning
+	e.UpdatedAt = now
+	return e
+}
+
+// Complete records the archive at fileKey, downloadable for ttl.
+func (e Export) Complete(fileKey string, size int64, ttl time.Duration, now time.Time) Export {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	expiresAt := now.Add(ttl)
+
+	e.Status = StatusCompleted
+	e.FileKey = fileKey
+	e.Size = size
+	e.CompletedAt = &now
+	e.ExpiresAt = &expiresAt
+	e.UpdatedAt = now
+	return e
+}
+
+func (e Export) Fail(now time.Time) Export {
+	e.Status = StatusFailed
+	e.UpdatedAt = now
+	return e
+}
+
+// Expire forgets the archive once it has been deleted.
+func (e Export) Expire(now time.Time) Export {
+	e.Status = StatusExpired
+	e.FileKey = ""
+	e.UpdatedAt = now
+	return e
+}
@@ -0,0 +1,75 @@
+package export_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+)
+
+func TestExport_Lifecycle(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+
+	e := export.New(userID, now)
+	if e.Status != export.StatusPending || !e.IsActive() || e.UserID != userID {
+		t.Fatalf("unexpected new export: %+v", e)
+	}
+
+	e = e.Start(now)
+	if e.Status != export.StatusRunning || !e.IsActive() {
+		t.Fatalf("expected a running export, got %s", e.Status)
+	}
+
+	e = e.Complete("exports/archive.zip", 42, time.Hour, now)
+	if e.IsActive() || e.FileKey != "exports/archive.zip" || e.Size != 42 {
+		t.Fatalf("unexpected completed export: %+v", e)
+	}
+	if !e.CompletedAt.Equal(now) || !e.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected completion at %v expiring an hour later, got %v and %v", now, e.CompletedAt, e.ExpiresAt)
+	}
+
+	e = e.Expire(now.Add(time.Hour))
+	if e.Status != export.StatusExpired || e.FileKey != "" {
+		t.Errorf("expected the archive forgotten, got %+v", e)
+	}
+}
+
+func TestExport_CompleteDefaultsTTL(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	e := export.New(uuid.New(), now).Complete("key", 1, 0, now)
+	if !e.ExpiresAt.Equal(now.Add(export.DefaultTTL)) {
+		t.Errorf("ExpiresAt = %v, want %v", e.ExpiresAt, now.Add(export.DefaultTTL))
+	}
+}
+
+func TestExport_Downloadable(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	pending := export.New(uuid.New(), now)
+	completed := pending.Complete("key", 1, time.Hour, now)
+
+	tests := []struct {
+		name   string
+		export export.Export
+		at     time.Time
+		want   error
+	}{
+		{name: "pending", export: pending, at: now, want: export.ErrNotReady},
+		{name: "running", export: pending.Start(now), at: now, want: export.ErrNotReady},
+		{name: "failed", export: pending.Fail(now), at: now, want: export.ErrNotReady},
+		{name: "completed", export: completed, at: now.Add(time.Minute), want: nil},
+		{name: "past its expiry", export: completed, at: now.Add(time.Hour), want: export.ErrExpired},
+		{name: "archive deleted", export: completed.Expire(now), at: now, want: export.ErrExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.export.Downloadable(tt.at); got != tt.want {
+				t.Errorf("Downloadable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package photo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/gif" // registered for image.Decode
+	"image/jpeg"
+	"image/png"
+)
+
+var (
+	ErrUnsupportedType = errors.New("unsupported image type, use JPEG, PNG or GIF")
+	ErrTooLarge        = errors.New("image is too large")
+	ErrInvalidImage    = errors.New("image cannot be read")
+)
+
+const (
+	// MaxPixels bounds what is decoded, a small file can claim huge
+	// dimensions. Each pixel takes 4 bytes and an upload is held up to three
+	// times while turned upright, so this keeps one under 200MB. Phone
+	// cameras stay under it.
+	MaxPixels = 16_000_000
+	// ThumbnailSize is the longest side of thumbnails, in pixels.
+	ThumbnailSize = 256
+
+	jpegQuality = 90
+)
+
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+// Image is an upload decoded and encoded again, which leaves its EXIF and
+// other metadata behind. GIFs keep their first frame and become PNGs.
+type Image struct {
+	ContentType string
+	Width       int
+	Height      int
+	Data        []byte
+	Thumbnail   []byte
+}
+
+// Process checks what data really holds, whatever the client claimed, and
+// returns it cleaned up with its thumbnail. JPEGs are turned upright as
+// their EXIF orientation asks, since the orientation is dropped with the
+// rest.
+func Process(data []byte) (Image, error) {
+	contentType := sniff(data)
+	if contentType == "" {
+		return Image{}, ErrUnsupportedType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Image{}, ErrInvalidImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return Image{}, ErrInvalidImage
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > MaxPixels {
+		return Image{}, ErrTooLarge
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Image{}, ErrInvalidImage
+	}
+
+	img := toRGBA(decoded)
+	if contentType == "image/jpeg" {
+		img = orient(img, exifOrientation(data))
+	} else {
+		contentType = "image/png"
+	}
+
+	full, err := encode(img, contentType)
+	if err != nil {
+		return Image{}, err
+	}
+	thumbnail, err := encode(fit(img, ThumbnailSize), contentType)
+	if err != nil {
+		return Image{}, err
+	}
+
+	return Image{
+		ContentType: contentType,
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+		Data:        full,
+		Thumbnail:   thumbnail,
+	}, nil
+}
+
+// sniff returns the type of the formats accepted, empty for anything else.
+func sniff(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("\xff\xd8\xff")):
+		return "image/jpeg"
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return "image/png"
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return "image/gif"
+	}
+	return ""
+}
+
+func encode(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	var err error
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+	return rgba
+}
+
+// fit scales img down so its longest side is at most size, averaging the
+// pixels each one covers. Smaller images are returned as they are.
+func fit(img *image.RGBA, size int) *image.RGBA {
+	sw, sh := img.Bounds().Dx(), img.Bounds().Dy()
+	if sw <= size && sh <= size {
+		return img
+	}
+
+	dw, dh := size, size
+	if sw > sh {
+		dh = max(1, sh*size/sw)
+	} else {
+		dw = max(1, sw*size/sh)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for dy := range dh {
+		y0, y1 := dy*sh/dh, max((dy+1)*sh/dh, dy*sh/dh+1)
+		for dx := range dw {
+			x0, x1 := dx*sw/dw, max((dx+1)*sw/dw, dx*sw/dw+1)
+
+			var sum [4]int
+			for y := y0; y < y1; y++ {
+				row := img.Pix[y*img.Stride+x0*4 : y*img.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+
+			n := (x1 - x0) * (y1 - y0)
+			o := dy*dst.Stride + dx*4
+			for c := range sum {
+				dst.Pix[o+c] = uint8(sum[c] / n)
+			}
+		}
+	}
+	return dst
+}
+
+// orient applies an EXIF orientation, 1 to 8, so the image shows upright.
+func orient(img *image.RGBA, orientation int) *image.RGBA {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+
+	// source returns the pixel of img drawn at x, y of the result
+	var source func(x, y int) (int, int)
+	switch orientation {
+	case 2:
+		source = func(x, y int) (int, int) { return w - 1 - x, y }
+	case 3:
+		source = func(x, y int) (int, int) { return w - 1 - x, h - 1 - y }
+	case 4:
+		source = func(x, y int) (int, int) { return x, h - 1 - y }
+	case 5:
+		source = func(x, y int) (int, int) { return y, x }
+	case 6:
+		source = func(x, y int) (int, int) { return y, h - 1 - x }
+	case 7:
+		source = func(x, y int) (int, int) { return w - 1 - y, h - 1 - x }
+	case 8:
+		source = func(x, y int) (int, int) { return w - 1 - y, x }
+	default:
+		return img
+	}
+
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := range dh {
+		for x := range dw {
+			sx, sy := source(x, y)
+			copy(dst.Pix[y*dst.Stride+x*4:y*dst.Stride+x*4+4], img.Pix[sy*img.Stride+sx*4:])
+		}
+	}
+	return dst
+}
+
+// exifOrientation reads the orientation tag of a JPEG, 1 when it has none.
+func exifOrientation(data []byte) int {
+	const orientationTag = 0x0112
+
+	for i := 2; i+4 <= len(data) && data[i] == 0xff; {
+		marker := data[i+1]
+		// The image data starts at SOS, metadata comes before it
+		if marker == 0xda || marker == 0xd9 {
+			break
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			break
+		}
+
+		segment := data[i+4 : end]
+		if marker == 0xe1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			if v, ok := tiffTag(segment[6:], orientationTag); ok && v >= 1 && v <= 8 {
+				return v
+			}
+			return 1
+		}
+		i = end
+	}
+	return 1
+}
+
+// tiffTag reads a short valued tag of the first IFD of a TIFF header.
+func tiffTag(tiff []byte, tag uint16) (int, bool) {
+	if len(tiff) < 8 {
+		return 0, false
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0, false
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0, false
+	}
+
+	entries := int(order.Uint16(tiff[ifd:]))
+	for k := range entries {
+		entry := ifd + 2 + k*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:]) == tag {
+			return int(order.Uint16(tiff[entry+8:])), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,187 @@
+package photo_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+)
+
+var red = color.RGBA{R: 255, A: 255}
+
+// testImage is w by h, black with a red 16 pixel square in its top left.
+func testImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			c := color.RGBA{A: 255}
+			if x < 16 && y < 16 {
+				c = red
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("failed to encode jpeg: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withOrientation inserts an EXIF segment carrying orientation after the
+// start of a JPEG.
+func withOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xff, 0xe1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	return append(append(append([]byte{}, data[:2]...), app1...), data[2:]...)
+}
+
+func decode(t *testing.T, data []byte) image.Image {
+	t.Helper()
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to decode the result: %v", err)
+	}
+	return img
+}
+
+func isRed(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return r > 0xc000 && g < 0x4000 && b < 0x4000
+}
+
+func TestProcess(t *testing.T) {
+	var gifData bytes.Buffer
+	if err := gif.Encode(&gifData, testImage(40, 30), nil); err != nil {
+		t.Fatalf("failed to encode gif: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		data         []byte
+		wantType     string
+		wantW, wantH int
+		wantThumbW   int
+		wantThumbH   int
+	}{
+		{name: "jpeg", data: encodeJPEG(t, testImage(640, 480)), wantType: "image/jpeg", wantW: 640, wantH: 480, wantThumbW: 256, wantThumbH: 192},
+		{name: "png", data: encodePNG(t, testImage(300, 600)), wantType: "image/png", wantW: 300, wantH: 600, wantThumbW: 128, wantThumbH: 256},
+		{name: "gif becomes png", data: gifData.Bytes(), wantType: "image/png", wantW: 40, wantH: 30, wantThumbW: 40, wantThumbH: 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := photo.Process(tt.data)
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+
+			if img.ContentType != tt.wantType || img.Width != tt.wantW || img.Height != tt.wantH {
+				t.Errorf("got %s %dx%d, want %s %dx%d", img.ContentType, img.Width, img.Height, tt.wantType, tt.wantW, tt.wantH)
+			}
+
+			thumb := decode(t, img.Thumbnail).Bounds()
+			if thumb.Dx() != tt.wantThumbW || thumb.Dy() != tt.wantThumbH {
+				t.Errorf("thumbnail is %dx%d, want %dx%d", thumb.Dx(), thumb.Dy(), tt.wantThumbW, tt.wantThumbH)
+			}
+		})
+	}
+}
+
+func TestProcess_StripsEXIF(t *testing.T) {
+	data := withOrientation(encodeJPEG(t, testImage(64, 32)), 6)
+
+	img, err := photo.Process(data)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if bytes.Contains(img.Data, []byte("Exif")) || bytes.Contains(img.Thumbnail, []byte("Exif")) {
+		t.Error("expected the EXIF segment left out")
+	}
+
+	// Orientation 6 turns the image a quarter clockwise, the red corner
+	// moves to the top right
+	if img.Width != 32 || img.Height != 64 {
+		t.Fatalf("expected the image turned to 32x64, got %dx%d", img.Width, img.Height)
+	}
+	turned := decode(t, img.Data)
+	if !isRed(turned.At(28, 4)) || isRed(turned.At(4, 4)) {
+		t.Errorf("expected the red corner at the top right, got %v at the right and %v at the left", turned.At(28, 4), turned.At(4, 4))
+	}
+}
+
+// hugePNG claims dimensions in its header it does not have the data for.
+func hugePNG(t *testing.T) []byte {
+	t.Helper()
+
+	data := encodePNG(t, testImage(1, 1))
+	// The IHDR chunk follows the 8 byte signature, its data after the
+	// length and type
+	ihdr := data[16:29]
+	binary.BigEndian.PutUint32(ihdr[0:], 100_000)
+	binary.BigEndian.PutUint32(ihdr[4:], 100_000)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestProcess_Rejects(t *testing.T) {
+	jpegData := encodeJPEG(t, testImage(64, 64))
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{name: "empty", data: nil, want: photo.ErrUnsupportedType},
+		{name: "text", data: []byte("<svg xmlns='http://www.w3.org/2000/svg'/>"), want: photo.ErrUnsupportedType},
+		{name: "webp", data: []byte("RIFF\x24\x00\x00\x00WEBPVP8 "), want: photo.ErrUnsupportedType},
+		{name: "truncated jpeg", data: jpegData[:len(jpegData)/2], want: photo.ErrInvalidImage},
+		{name: "corrupt header", data: []byte("\x89PNG\r\n\x1a\nnot really"), want: photo.ErrInvalidImage},
+		{name: "too many pixels", data: hugePNG(t), want: photo.ErrTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := photo.Process(tt.data); !errors.Is(err, tt.want) {
+				t.Errorf("Process() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Package photo holds the pictures users upload: the avatar shown on their
+// profile and the progress photos only they can see.
+package photo
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrTakenInFuture = errors.New("photo date is in the future")
+
+// DefaultMaxSize is the largest upload accepted, in bytes.
+const DefaultMaxSize = 10 << 20
+
+type Kind string
+
+const (
+	// KindAvatar is the profile picture, signed in users can see it.
+	KindAvatar Kind = "avatar"
+	// KindProgress photos are private to their owner whatever the profile
+	// visibility is.
+	KindProgress Kind = "progress"
+)
+
+// Photo is a stored upload. The image and its thumbnail are kept in the
+// object store under FileKey and ThumbnailKey.
+type Photo struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Kind        Kind      `json:"kind"`
+	ContentType string    `json:"content_type"`
+	Width       int       `json:"width"`
+	Height      int       `json:"height"`
+	Size        int64     `json:"size"`
+
+	FileKey      string `json:"-"`
+	ThumbnailKey string `json:"-"`
+
+	// TakenOn is the day of the body measurements a progress photo goes
+	// with, nil for avatars.
+	TakenOn   *time.Time `json:"taken_on,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func NewAvatar(userID uuid.UUID, img Image, now time.Time) Photo {
+	return newPhoto(userID, KindAvatar, img, now)
+}
+
+// NewProgress records a progress photo for the day takenOn, today when it is
+// zero. Days up to one ahead of UTC are accepted for users east of it.
+func NewProgress(userID uuid.UUID, img Image, takenOn, now time.Time) (Photo, error) {
+	if takenOn.IsZero() {
+		takenOn = now
+	}
+
+	day := Day(takenOn)
+	if day.After(Day(now).AddDate(0, 0, 1)) {
+		return Photo{}, ErrTakenInFuture
+	}
+
+	p := newPhoto(userID, KindProgress, img, now)
+	p.TakenOn = &day
+	return p, nil
+}
+
+func newPhoto(userID uuid.UUID, kind Kind, img Image, now time.Time) Photo {
+	id := uuid.New()
+	ext := extensions[img.ContentType]
+
+	return Photo{
+		ID:           id,
+		UserID:       userID,
+		Kind:         kind,
+		ContentType:  img.ContentType,
+		Width:        img.Width,
+		Height:       img.Height,
+		Size:         int64(len(img.Data)),
+		FileKey:      fmt.Sprintf("photos/%s/%s%s", userID, id, ext),
+		ThumbnailKey: fmt.Sprintf("photos/%s/%s_thumb%s", userID, id, ext),
+		CreatedAt:    now,
+	}
+}
+
+// Day is t as a calendar date, midnight UTC.
+func Day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func (p Photo) IsOwnedBy(userID uuid.UUID) bool {
+	return p.UserID == userID
+}
+
+// Key returns the key of the image, or of its thumbnail.
+func (p Photo) Key(thumbnail bool) string {
+	if thumbnail {
+		return p.ThumbnailKey
+	}
+	return p.FileKey
+}
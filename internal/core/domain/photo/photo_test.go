@@ -0,0 +1,69 @@
+package photo_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+)
+
+func TestNewAvatar(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	img := photo.Image{ContentType: "image/png", Width: 4, Height: 3, Data: []byte("data")}
+
+	p := photo.NewAvatar(userID, img, now)
+
+	if p.Kind != photo.KindAvatar || p.TakenOn != nil || !p.IsOwnedBy(userID) {
+		t.Fatalf("unexpected avatar: %+v", p)
+	}
+	if p.Size != 4 || p.Width != 4 || p.Height != 3 || p.ContentType != "image/png" {
+		t.Errorf("expected the image details copied, got %+v", p)
+	}
+	if !strings.HasPrefix(p.FileKey, "photos/"+userID.String()+"/") || !strings.HasSuffix(p.FileKey, ".png") {
+		t.Errorf("unexpected file key %q", p.FileKey)
+	}
+	if p.ThumbnailKey == p.FileKey || p.Key(true) != p.ThumbnailKey || p.Key(false) != p.FileKey {
+		t.Errorf("expected distinct image and thumbnail keys, got %q and %q", p.FileKey, p.ThumbnailKey)
+	}
+	if p.IsOwnedBy(uuid.New()) {
+		t.Error("expected the avatar not owned by another user")
+	}
+}
+
+func TestNewProgress(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	img := photo.Image{ContentType: "image/jpeg", Data: []byte("data")}
+
+	tests := []struct {
+		name    string
+		takenOn time.Time
+		want    time.Time
+		wantErr error
+	}{
+		{name: "defaults to today", want: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "earlier day", takenOn: time.Date(2026, 2, 14, 18, 30, 0, 0, time.UTC), want: time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)},
+		{name: "tomorrow east of UTC", takenOn: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), want: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "future", takenOn: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC), wantErr: photo.ErrTakenInFuture},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := photo.NewProgress(uuid.New(), img, tt.takenOn, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewProgress() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if p.Kind != photo.KindProgress || p.TakenOn == nil || !p.TakenOn.Equal(tt.want) {
+				t.Errorf("expected a progress photo taken on %v, got %+v", tt.want, p)
+			}
+		})
+	}
+}
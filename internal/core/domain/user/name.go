@@ -10,6 +10,7 @@ var (
 	ErrNameTooShort   = errors.New("name too short")
 	ErrNameHasDigit   = errors.New("name contains digit")
 	ErrNameHasSpecial = errors.New("name contains special characters")
+	ErrIncompleteName = errors.New("first and last name must be provided together")
 )
 
 func NewName(firstName, lastName string) (string, error) {
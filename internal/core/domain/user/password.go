@@ -1,7 +1,9 @@
 package user
 
 import (
+	"crypto/rand"
 	"errors"
+	"math/big"
 	"regexp"
 
 	"github.com/cheezecakee/logr"
@@ -78,3 +80,60 @@ func HashPassword(password string) (string, error) {
 	}
 	return string(hashedPassword), nil
 }
+
+const (
+	temporaryPasswordLength = 20
+
+	upperChars   = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	lowerChars   = "abcdefghijkmnopqrstuvwxyz"
+	digitChars   = "23456789"
+	specialChars = "!@#$%^&*-_+="
+)
+
+// NewTemporaryPassword generates a random password that meets the rules of
+// NewPassword, for support staff to hand to a locked out user. It returns the
+// plaintext, which is never stored, and its hash.
+func NewTemporaryPassword() (string, Password, error) {
+	sets := []string{upperChars, lowerChars, digitChars, specialChars}
+	all := upperChars + lowerChars + digitChars + specialChars
+
+	chars := make([]byte, temporaryPasswordLength)
+	for i := range chars {
+		// The first characters cover every required class
+		set := all
+		if i < len(sets) {
+			set = sets[i]
+		}
+
+		c, err := randomChar(set)
+		if err != nil {
+			return "", "", err
+		}
+		chars[i] = c
+	}
+
+	// Shuffle so the required classes are not always up front
+	for i := len(chars) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", "", err
+		}
+		chars[i], chars[j.Int64()] = chars[j.Int64()], chars[i]
+	}
+
+	plaintext := string(chars)
+	password, err := NewPassword(plaintext)
+	if err != nil {
+		return "", "", err
+	}
+
+	return plaintext, password, nil
+}
+
+func randomChar(set string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(set))))
+	if err != nil {
+		return 0, err
+	}
+	return set[n.Int64()], nil
+}
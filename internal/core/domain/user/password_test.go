@@ -139,3 +139,25 @@ func TestPassword_UniqueHashes(t *testing.T) {
 		t.Error("hash2 doesn't match original password")
 	}
 }
+
+func TestNewTemporaryPassword(t *testing.T) {
+	seen := map[string]bool{}
+
+	for range 5 {
+		plaintext, hash, err := user.NewTemporaryPassword()
+		if err != nil {
+			t.Fatalf("NewTemporaryPassword() unexpected error = %v", err)
+		}
+
+		if _, err := user.NewPassword(plaintext); err != nil {
+			t.Errorf("temporary password %q breaks the password rules: %v", plaintext, err)
+		}
+		if !hash.Verify(plaintext) {
+			t.Errorf("expected the hash to verify %q", plaintext)
+		}
+		if seen[plaintext] {
+			t.Errorf("temporary password %q repeated", plaintext)
+		}
+		seen[plaintext] = true
+	}
+}
@@ -0,0 +1,39 @@
+package user
+
+import "slices"
+
+// Permission is an action a role allows. Routes check permissions, never
+// roles, so what a role may do is decided here alone.
+type Permission string
+
+const (
+	PermUsersReadAny        Permission = "users:read_any"
+	PermUsersManage         Permission = "users:manage"
+	PermUsersManageRoles    Permission = "users:manage_roles"
+	PermContentModerate     Permission = "content:moderate"
+	PermSubscriptionsManage Permission = "subscriptions:manage"
+	PermAuditRead           Permission = "audit:read"
+)
+
+// rolePermissions is the permission matrix. Regular users act on their own
+// account, which needs no permission.
+var rolePermissions = map[Role][]Permission{
+	RoleUser:      {},
+	RoleModerator: {PermUsersReadAny, PermContentModerate},
+	RoleAdmin:     {PermUsersReadAny, PermUsersManage, PermUsersManageRoles, PermContentModerate, PermSubscriptionsManage, PermAuditRead},
+}
+
+// Permissions returns what the role allows, nothing for unknown roles.
+func (r Role) Permissions() []Permission {
+	return slices.Clone(rolePermissions[r])
+}
+
+// Can reports whether any of the roles allows p.
+func (r Roles) Can(p Permission) bool {
+	for _, role := range r {
+		if slices.Contains(rolePermissions[role], p) {
+			return true
+		}
+	}
+	return false
+}
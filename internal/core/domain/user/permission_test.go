@@ -0,0 +1,47 @@
+package user_test
+
+import (
+	"testing"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+)
+
+func TestRoles_Can(t *testing.T) {
+	tests := []struct {
+		name  string
+		roles user.Roles
+		perm  user.Permission
+		want  bool
+	}{
+		{name: "user cannot read other users", roles: user.Roles{user.RoleUser}, perm: user.PermUsersReadAny, want: false},
+		{name: "moderator reads other users", roles: user.Roles{user.RoleUser, user.RoleModerator}, perm: user.PermUsersReadAny, want: true},
+		{name: "moderator moderates content", roles: user.Roles{user.RoleModerator}, perm: user.PermContentModerate, want: true},
+		{name: "moderator cannot manage roles", roles: user.Roles{user.RoleModerator}, perm: user.PermUsersManageRoles, want: false},
+		{name: "moderator cannot manage subscriptions", roles: user.Roles{user.RoleModerator}, perm: user.PermSubscriptionsManage, want: false},
+		{name: "moderator cannot manage users", roles: user.Roles{user.RoleModerator}, perm: user.PermUsersManage, want: false},
+		{name: "admin manages users", roles: user.Roles{user.RoleAdmin}, perm: user.PermUsersManage, want: true},
+		{name: "admin manages roles", roles: user.Roles{user.RoleUser, user.RoleAdmin}, perm: user.PermUsersManageRoles, want: true},
+		{name: "admin manages subscriptions", roles: user.Roles{user.RoleAdmin}, perm: user.PermSubscriptionsManage, want: true},
+		{name: "admin reads the audit log", roles: user.Roles{user.RoleAdmin}, perm: user.PermAuditRead, want: true},
+		{name: "moderator cannot read the audit log", roles: user.Roles{user.RoleModerator}, perm: user.PermAuditRead, want: false},
+		{name: "unknown role grants nothing", roles: user.Roles{"root"}, perm: user.PermUsersReadAny, want: false},
+		{name: "no roles", roles: nil, perm: user.PermUsersReadAny, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.roles.Can(tt.perm); got != tt.want {
+				t.Errorf("Can(%s) = %v, want %v", tt.perm, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRole_PermissionsAreCopies(t *testing.T) {
+	perms := user.RoleAdmin.Permissions()
+	perms[0] = "tampered"
+
+	if user.RoleAdmin.Permissions()[0] == "tampered" {
+		t.Error("expected Permissions to return a copy of the matrix")
+	}
+}
@@ -0,0 +1,162 @@
+package user
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrBioTooLong             = errors.New("bio is too long")
+	ErrInvalidBirthDate       = errors.New("birth date must be a date such as 1990-04-21")
+	ErrBirthDateOutOfRange    = errors.New("birth date is out of range")
+	ErrInvalidSex             = errors.New("invalid sex")
+	ErrInvalidExperienceLevel = errors.New("invalid experience level")
+	ErrPreferredGymTooLong    = errors.New("preferred gym is too long")
+)
+
+const (
+	MaxBioLength          = 500
+	MaxPreferredGymLength = 100
+
+	// MinAge and MaxAge bound the birth date, users must be old enough to
+	// sign up and the estimates are meaningless past MaxAge.
+	MinAge = 13
+	MaxAge = 120
+)
+
+// Profile holds the optional details the user shares about themselves. They
+// feed body fat estimates, strength standards and calorie calculations, the
+// zero value of each field means unknown.
+type Profile struct {
+	Bio             string          `json:"bio,omitempty"`
+	BirthDate       *time.Time      `json:"birth_date,omitempty"`
+	Sex             Sex             `json:"sex,omitempty"`
+	ExperienceLevel ExperienceLevel `json:"experience_level,omitempty"`
+	PreferredGym    string          `json:"preferred_gym,omitempty"`
+}
+
+// Age returns the age in whole years on now, false when the birth date is
+// unknown.
+func (p Profile) Age(now time.Time) (int, bool) {
+	if p.BirthDate == nil {
+		return 0, false
+	}
+
+	born := p.BirthDate.UTC()
+	now = now.UTC()
+	age := now.Year() - born.Year()
+	if now.Month() < born.Month() || (now.Month() == born.Month() && now.Day() < born.Day()) {
+		age--
+	}
+	return age, true
+}
+
+// PublicProfile is what other users see of a profile. Birth date and sex
+// only feed the calculations, they are never shown.
+type PublicProfile struct {
+	Bio             string          `json:"bio,omitempty"`
+	ExperienceLevel ExperienceLevel `json:"experience_level,omitempty"`
+	PreferredGym    string          `json:"preferred_gym,omitempty"`
+}
+
+// Shared returns what other users see under visibility, nothing while the
+// profile is private.
+func (p Profile) Shared(visibility Visibility) PublicProfile {
+	if visibility != Public {
+		return PublicProfile{}
+	}
+	return PublicProfile{
+		Bio:             p.Bio,
+		ExperienceLevel: p.ExperienceLevel,
+		PreferredGym:    p.PreferredGym,
+	}
+}
+
+// Sex is the sex used by the body composition and calorie formulas.
+type Sex string
+
+const (
+	Male   Sex = "male"
+	Female Sex = "female"
+)
+
+// NewSex returns the empty Sex, unknown, for an empty value.
+func NewSex(sex string) (Sex, error) {
+	sex = strings.ToLower(strings.TrimSpace(sex))
+
+	switch sex {
+	case "":
+		return "", nil
+	case "male":
+		return Male, nil
+	case "female":
+		return Female, nil
+	default:
+		return "", ErrInvalidSex
+	}
+}
+
+// ExperienceLevel is how long the user has trained, as used by strength
+// standards.
+type ExperienceLevel string
+
+const (
+	Beginner     ExperienceLevel = "beginner"
+	Novice       ExperienceLevel = "novice"
+	Intermediate ExperienceLevel = "intermediate"
+	Advanced     ExperienceLevel = "advanced"
+	Elite        ExperienceLevel = "elite"
+)
+
+// NewExperienceLevel returns the empty ExperienceLevel, unknown, for an empty
+// value.
+func NewExperienceLevel(level string) (ExperienceLevel, error) {
+	level = strings.ToLower(strings.TrimSpace(level))
+
+	switch ExperienceLevel(level) {
+	case "":
+		return "", nil
+	case Beginner, Novice, Intermediate, Advanced, Elite:
+		return ExperienceLevel(level), nil
+	default:
+		return "", ErrInvalidExperienceLevel
+	}
+}
+
+func NewBio(bio string) (string, error) {
+	bio = strings.TrimSpace(bio)
+	if utf8.RuneCountInString(bio) > MaxBioLength {
+		return "", ErrBioTooLong
+	}
+	return bio, nil
+}
+
+func NewPreferredGym(gym string) (string, error) {
+	gym = strings.TrimSpace(gym)
+	if utf8.RuneCountInString(gym) > MaxPreferredGymLength {
+		return "", ErrPreferredGymTooLong
+	}
+	return gym, nil
+}
+
+// NewBirthDate parses a date such as 1990-04-21, nil for an empty value. The
+// user must be between MinAge and MaxAge on now.
+func NewBirthDate(date string, now time.Time) (*time.Time, error) {
+	date = strings.TrimSpace(date)
+	if date == "" {
+		return nil, nil
+	}
+
+	born, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return nil, ErrInvalidBirthDate
+	}
+
+	age, _ := Profile{BirthDate: &born}.Age(now)
+	if age < MinAge || age > MaxAge {
+		return nil, ErrBirthDateOutOfRange
+	}
+	return &born, nil
+}
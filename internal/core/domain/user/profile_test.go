@@ -0,0 +1,155 @@
+package user_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+)
+
+func TestSex(t *testing.T) {
+	tests := []struct {
+		name    string
+		sex     string
+		want    user.Sex
+		wantErr bool
+	}{
+		{name: "valid sex", sex: "female", want: user.Female},
+		{name: "valid sex - uppercase with whitespace", sex: " MALE ", want: user.Male},
+		{name: "valid sex - empty is unknown", sex: "", want: ""},
+		{name: "invalid sex", sex: "robot", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := user.NewSex(tt.sex)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSex() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NewSex() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExperienceLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   string
+		want    user.ExperienceLevel
+		wantErr bool
+	}{
+		{name: "valid level", level: "intermediate", want: user.Intermediate},
+		{name: "valid level - uppercase", level: "Elite", want: user.Elite},
+		{name: "valid level - empty is unknown", level: "", want: ""},
+		{name: "invalid level", level: "expert", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := user.NewExperienceLevel(tt.level)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewExperienceLevel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NewExperienceLevel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBioAndPreferredGym(t *testing.T) {
+	if bio, err := user.NewBio("  Lifting since 2015  "); err != nil || bio != "Lifting since 2015" {
+		t.Errorf("NewBio() = %q, %v", bio, err)
+	}
+	if _, err := user.NewBio(strings.Repeat("é", user.MaxBioLength)); err != nil {
+		t.Errorf("expected %d characters accepted, got: %v", user.MaxBioLength, err)
+	}
+	if _, err := user.NewBio(strings.Repeat("a", user.MaxBioLength+1)); !errors.Is(err, user.ErrBioTooLong) {
+		t.Errorf("expected ErrBioTooLong, got: %v", err)
+	}
+	if _, err := user.NewPreferredGym(strings.Repeat("a", user.MaxPreferredGymLength+1)); !errors.Is(err, user.ErrPreferredGymTooLong) {
+		t.Errorf("expected ErrPreferredGymTooLong, got: %v", err)
+	}
+}
+
+func TestBirthDate(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		date    string
+		wantNil bool
+		wantErr error
+	}{
+		{name: "valid birth date", date: "1990-04-21"},
+		{name: "valid birth date - turns 13 today", date: "2013-06-15"},
+		{name: "valid birth date - empty is unknown", date: "", wantNil: true},
+		{name: "invalid birth date - 13 tomorrow", date: "2013-06-16", wantErr: user.ErrBirthDateOutOfRange},
+		{name: "invalid birth date - in the future", date: "2030-01-01", wantErr: user.ErrBirthDateOutOfRange},
+		{name: "invalid birth date - too old", date: "1900-01-01", wantErr: user.ErrBirthDateOutOfRange},
+		{name: "invalid birth date - not a date", date: "21/04/1990", wantErr: user.ErrInvalidBirthDate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := user.NewBirthDate(tt.date, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewBirthDate() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (got == nil) != tt.wantNil {
+				t.Errorf("NewBirthDate() = %v, wantNil %v", got, tt.wantNil)
+			}
+		})
+	}
+}
+
+func TestProfile_Age(t *testing.T) {
+	born := time.Date(1990, 4, 21, 0, 0, 0, 0, time.UTC)
+	p := user.Profile{BirthDate: &born}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want int
+	}{
+		{name: "day before birthday", now: time.Date(2026, 4, 20, 23, 0, 0, 0, time.UTC), want: 35},
+		{name: "on birthday", now: time.Date(2026, 4, 21, 0, 0, 0, 0, time.UTC), want: 36},
+		{name: "later that year", now: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), want: 36},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := p.Age(tt.now)
+			if !ok || got != tt.want {
+				t.Errorf("Age() = %d, %v, want %d", got, ok, tt.want)
+			}
+		})
+	}
+
+	if _, ok := (user.Profile{}).Age(time.Now()); ok {
+		t.Error("expected no age without a birth date")
+	}
+}
+
+func TestProfile_Shared(t *testing.T) {
+	born := time.Date(1990, 4, 21, 0, 0, 0, 0, time.UTC)
+	profile := user.Profile{
+		Bio:             "Powerlifter",
+		BirthDate:       &born,
+		Sex:             user.Female,
+		ExperienceLevel: user.Advanced,
+		PreferredGym:    "Iron Temple",
+	}
+
+	want := user.PublicProfile{Bio: "Powerlifter", ExperienceLevel: user.Advanced, PreferredGym: "Iron Temple"}
+	if got := profile.Shared(user.Public); got != want {
+		t.Errorf("Shared(public) = %+v, want %+v", got, want)
+	}
+	if got := profile.Shared(user.Private); got != (user.PublicProfile{}) {
+		t.Errorf("Shared(private) = %+v, want nothing", got)
+	}
+}
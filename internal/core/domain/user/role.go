@@ -16,7 +16,20 @@ const (
 	RoleModerator Role = "moderator"
 )
 
-var ErrInvalidRole = errors.New("invalid role")
+var (
+	ErrInvalidRole = errors.New("invalid role")
+	ErrBaseRole    = errors.New("every user keeps the user role")
+)
+
+// NewRole parses a single role.
+func NewRole(s string) (Role, error) {
+	switch role := Role(s); role {
+	case RoleUser, RoleAdmin, RoleModerator:
+		return role, nil
+	default:
+		return "", ErrInvalidRole
+	}
+}
 
 func NewRoles(strs []string) (Roles, error) {
 	if strs == nil {
@@ -73,3 +86,20 @@ func (r Roles) ContainsAny(roles ...Role) bool {
 func (r Roles) Contains(role Role) bool {
 	return slices.Contains(r, role)
 }
+
+// Grant returns the roles with role added, unchanged when it is already held.
+func (r Roles) Grant(role Role) Roles {
+	if r.Contains(role) {
+		return r
+	}
+	return append(slices.Clone(r), role)
+}
+
+// Revoke returns the roles without role. The user role is the base of every
+// account and cannot be revoked.
+func (r Roles) Revoke(role Role) (Roles, error) {
+	if role == RoleUser {
+		return nil, ErrBaseRole
+	}
+	return slices.DeleteFunc(slices.Clone(r), func(held Role) bool { return held == role }), nil
+}
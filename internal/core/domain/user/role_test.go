@@ -107,3 +107,46 @@ func TestRoles_FromStrings(t *testing.T) {
 		t.Errorf("StringsToRoles() = %v, want %v", got, want)
 	}
 }
+
+func TestNewRole(t *testing.T) {
+	if role, err := user.NewRole("moderator"); err != nil || role != user.RoleModerator {
+		t.Errorf("NewRole(moderator) = %v, %v", role, err)
+	}
+	if _, err := user.NewRole("root"); err != user.ErrInvalidRole {
+		t.Errorf("expected ErrInvalidRole, got %v", err)
+	}
+}
+
+func TestRoles_Grant(t *testing.T) {
+	roles := user.Roles{user.RoleUser}
+
+	got := roles.Grant(user.RoleAdmin)
+	if !reflect.DeepEqual(got, user.Roles{user.RoleUser, user.RoleAdmin}) {
+		t.Errorf("Grant() = %v", got)
+	}
+	if !reflect.DeepEqual(got.Grant(user.RoleAdmin), got) {
+		t.Errorf("expected granting a held role to change nothing, got %v", got.Grant(user.RoleAdmin))
+	}
+	if len(roles) != 1 {
+		t.Errorf("expected the original roles untouched, got %v", roles)
+	}
+}
+
+func TestRoles_Revoke(t *testing.T) {
+	roles := user.Roles{user.RoleUser, user.RoleModerator, user.RoleAdmin}
+
+	got, err := roles.Revoke(user.RoleModerator)
+	if err != nil {
+		t.Fatalf("Revoke() unexpected error = %v", err)
+	}
+	if !reflect.DeepEqual(got, user.Roles{user.RoleUser, user.RoleAdmin}) {
+		t.Errorf("Revoke() = %v", got)
+	}
+	if len(roles) != 3 {
+		t.Errorf("expected the original roles untouched, got %v", roles)
+	}
+
+	if _, err := roles.Revoke(user.RoleUser); err != user.ErrBaseRole {
+		t.Errorf("expected ErrBaseRole, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package user
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptySuspensionReason = errors.New("empty suspension reason")
+	ErrDeletionScheduled     = errors.New("account deletion already scheduled")
+)
+
+// DeletionGracePeriod is how long a deleted account can still be restored
+// before it is purged.
+const DeletionGracePeriod = 30 * 24 * time.Hour
+
+// AccountStatus is what support staff change about an account besides its
+// profile. Suspended accounts cannot sign in or refresh their session, and
+// neither can accounts pending deletion until they are restored.
+type AccountStatus struct {
+	EmailVerifiedAt     *time.Time `json:"email_verified_at,omitempty"`
+	SuspendedAt         *time.Time `json:"suspended_at,omitempty"`
+	SuspensionReason    string     `json:"suspension_reason,omitempty"`
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at,omitempty"`
+}
+
+func (s AccountStatus) IsSuspended() bool {
+	return s.SuspendedAt != nil
+}
+
+func (s AccountStatus) IsEmailVerified() bool {
+	return s.EmailVerifiedAt != nil
+}
+
+// VerifyEmail marks the email verified, keeping the first verification time.
+func (s AccountStatus) VerifyEmail(now time.Time) AccountStatus {
+	if s.EmailVerifiedAt == nil {
+		s.EmailVerifiedAt = &now
+	}
+	return s
+}
+
+// UnverifyEmail drops the verification when the email changes, the new
+// address has not been proven to belong to the user.
+func (s AccountStatus) UnverifyEmail() AccountStatus {
+	s.EmailVerifiedAt = nil
+	return s
+}
+
+// Suspend records why the account is suspended. Suspending again only
+// updates the reason.
+func (s AccountStatus) Suspend(reason string, now time.Time) (AccountStatus, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return s, ErrEmptySuspensionReason
+	}
+
+	if s.SuspendedAt == nil {
+		s.SuspendedAt = &now
+	}
+	s.SuspensionReason = reason
+	return s, nil
+}
+
+func (s AccountStatus) Unsuspend() AccountStatus {
+	s.SuspendedAt = nil
+	s.SuspensionReason = ""
+	return s
+}
+
+func (s AccountStatus) IsPendingDeletion() bool {
+	return s.DeletionRequestedAt != nil
+}
+
+// ScheduleDeletion starts the grace period after which the account is purged.
+func (s AccountStatus) ScheduleDeletion(now time.Time) (AccountStatus, error) {
+	if s.DeletionRequestedAt != nil {
+		return s, ErrDeletionScheduled
+	}
+
+	s.DeletionRequestedAt = &now
+	return s, nil
+}
+
+// PurgeAt is when an account pending deletion is purged, the zero time for
+// other accounts.
+func (s AccountStatus) PurgeAt() time.Time {
+	if s.DeletionRequestedAt == nil {
+		return time.Time{}
+	}
+	return s.DeletionRequestedAt.Add(DeletionGracePeriod)
+}
+
+// CancelDeletion restores an account pending deletion.
+func (s AccountStatus) CancelDeletion() AccountStatus {
+	s.DeletionRequestedAt = nil
+	return s
+}
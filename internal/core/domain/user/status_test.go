@@ -0,0 +1,115 @@
+package user_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+)
+
+func TestAccountStatus_VerifyEmail(t *testing.T) {
+	first := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	status := user.AccountStatus{}.VerifyEmail(first)
+	if !status.IsEmailVerified() || !status.EmailVerifiedAt.Equal(first) {
+		t.Fatalf("expected email verified at %v, got %v", first, status.EmailVerifiedAt)
+	}
+
+	status = status.VerifyEmail(first.Add(time.Hour))
+	if !status.EmailVerifiedAt.Equal(first) {
+		t.Errorf("expected the first verification time kept, got %v", status.EmailVerifiedAt)
+	}
+}
+
+func TestAccountStatus_UnverifyEmail(t *testing.T) {
+	status := user.AccountStatus{}.VerifyEmail(time.Now()).UnverifyEmail()
+	if status.IsEmailVerified() {
+		t.Errorf("expected email unverified, got %v", status.EmailVerifiedAt)
+	}
+}
+
+func TestAccountStatus_Suspend(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		status  user.AccountStatus
+		reason  string
+		wantErr error
+		wantAt  time.Time
+		wantWhy string
+	}{
+		{name: "suspends", reason: "chargeback fraud", wantAt: now, wantWhy: "chargeback fraud"},
+		{name: "trims the reason", reason: "  spam  ", wantAt: now, wantWhy: "spam"},
+		{
+			name:    "suspending again updates the reason",
+			status:  user.AccountStatus{SuspendedAt: &earlier, SuspensionReason: "spam"},
+			reason:  "abuse",
+			wantAt:  earlier,
+			wantWhy: "abuse",
+		},
+		{name: "empty reason", reason: "  ", wantErr: user.ErrEmptySuspensionReason},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.status.Suspend(tt.reason, now)
+			if err != tt.wantErr {
+				t.Fatalf("Suspend() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if got.IsSuspended() {
+					t.Error("expected a failed suspension to leave the account active")
+				}
+				return
+			}
+			if !got.IsSuspended() || !got.SuspendedAt.Equal(tt.wantAt) {
+				t.Errorf("SuspendedAt = %v, want %v", got.SuspendedAt, tt.wantAt)
+			}
+			if got.SuspensionReason != tt.wantWhy {
+				t.Errorf("SuspensionReason = %q, want %q", got.SuspensionReason, tt.wantWhy)
+			}
+		})
+	}
+}
+
+func TestAccountStatus_Unsuspend(t *testing.T) {
+	now := time.Now()
+	status := user.AccountStatus{SuspendedAt: &now, SuspensionReason: "spam", EmailVerifiedAt: &now}.Unsuspend()
+
+	if status.IsSuspended() || status.SuspensionReason != "" {
+		t.Errorf("expected the suspension cleared, got %+v", status)
+	}
+	if !status.IsEmailVerified() {
+		t.Error("expected the email verification kept")
+	}
+}
+
+func TestAccountStatus_ScheduleDeletion(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	status, err := user.AccountStatus{}.ScheduleDeletion(now)
+	if err != nil {
+		t.Fatalf("ScheduleDeletion() error = %v", err)
+	}
+	if !status.IsPendingDeletion() || !status.DeletionRequestedAt.Equal(now) {
+		t.Fatalf("expected deletion requested at %v, got %v", now, status.DeletionRequestedAt)
+	}
+	if want := now.Add(user.DeletionGracePeriod); !status.PurgeAt().Equal(want) {
+		t.Errorf("PurgeAt() = %v, want %v", status.PurgeAt(), want)
+	}
+
+	again, err := status.ScheduleDeletion(now.Add(time.Hour))
+	if err != user.ErrDeletionScheduled {
+		t.Fatalf("ScheduleDeletion() again error = %v, want %v", err, user.ErrDeletionScheduled)
+	}
+	if !again.DeletionRequestedAt.Equal(now) {
+		t.Errorf("expected the first request kept, got %v", again.DeletionRequestedAt)
+	}
+
+	restored := status.CancelDeletion()
+	if restored.IsPendingDeletion() || !restored.PurgeAt().IsZero() {
+		t.Errorf("expected the deletion cancelled, got %+v", restored)
+	}
+}
@@ -168,6 +168,18 @@ func (s *Subscription) HasExpired() bool {
 	return time.Now().After(*s.ExpiresAt)
 }
 
+// EffectivePlan is the plan the user is entitled to right now. An expired
+// subscription falls back to Basic and a running trial counts as Premium.
+func (s *Subscription) EffectivePlan() Plan {
+	if s.TrialEndsAt != nil && time.Now().Before(*s.TrialEndsAt) {
+		return Premium
+	}
+	if s.HasExpired() {
+		return Basic
+	}
+	return s.Plan
+}
+
 func (s *Subscription) getNextBillingDuration() time.Duration {
 	if s.BillingPeriod == nil {
 		return 0
@@ -556,3 +556,28 @@ func TestSubscription_OptionalFieldsSetForPremium(t *testing.T) {
 		t.Error("LastPaymentCurrency should be set after payment")
 	}
 }
+
+func TestSubscription_EffectivePlan(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name string
+		sub  user.Subscription
+		want user.Plan
+	}{
+		{"basic", user.Subscription{Plan: user.Basic}, user.Basic},
+		{"active premium", user.Subscription{Plan: user.Premium, ExpiresAt: &future}, user.Premium},
+		{"expired premium", user.Subscription{Plan: user.Premium, ExpiresAt: &past}, user.Basic},
+		{"running trial", user.Subscription{Plan: user.Basic, TrialEndsAt: &future}, user.Premium},
+		{"ended trial", user.Subscription{Plan: user.Basic, TrialEndsAt: &past}, user.Basic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sub.EffectivePlan(); got != tt.want {
+				t.Errorf("EffectivePlan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,17 +8,19 @@ import (
 )
 
 type User struct {
-	ID           uuid.UUID    `json:"id"`
-	Username     Username     `json:"username"`
-	FullName     string       `json:"full_name"`
-	Email        Email        `json:"email"`
-	Password     Password     `json:"-"`
-	Roles        Roles        `json:"roles"`
-	CreatedAt    time.Time    `json:"created_at"`
-	UpdatedAt    time.Time    `json:"updated_at"`
-	Stats        Stats        `json:"stats"`
-	Subscription Subscription `json:"subscription"`
-	Settings     Settings     `json:"settings"`
+	ID           uuid.UUID     `json:"id"`
+	Username     Username      `json:"username"`
+	FullName     string        `json:"full_name"`
+	Email        Email         `json:"email"`
+	Password     Password      `json:"-"`
+	Roles        Roles         `json:"roles"`
+	Status       AccountStatus `json:"status"`
+	Profile      Profile       `json:"profile"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+	Stats        Stats         `json:"stats"`
+	Subscription Subscription  `json:"subscription"`
+	Settings     Settings      `json:"settings"`
 }
 
 func New(username Username, fullName string, email Email, roles Roles, password Password, stats Stats, subscription Subscription, settings Settings) User {
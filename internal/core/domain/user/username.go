@@ -11,13 +11,30 @@ var (
 	ErrUsernameTooShort     = errors.New("username too short")
 	ErrUsernameTooLong      = errors.New("username too long")
 	ErrUsernameInvalidChars = errors.New("username contains invalid characters")
+	ErrUsernameReserved     = errors.New("username is reserved")
 )
 
+// reservedUsernames cannot be taken by users, they name staff, the service
+// itself or routes of the web app.
+var reservedUsernames = map[string]bool{
+	"admin": true, "administrator": true, "root": true, "system": true, "staff": true,
+	"support": true, "help": true, "moderator": true, "official": true, "security": true,
+	"billing": true, "api": true, "www": true, "mail": true, "noreply": true,
+	"null": true, "undefined": true, "anonymous": true, "user": true, "users": true,
+	"settings": true, "login": true, "logout": true, "signup": true, "register": true,
+	"fitrkr": true, "athena": true,
+}
+
 type Username string
 
+// NormalizeUsername puts username in the form usernames are stored in, so
+// lookups match however the name was typed.
+func NormalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
 func NewUsername(username string) (Username, error) {
-	username = strings.TrimSpace(username)
-	username = strings.ToLower(username)
+	username = NormalizeUsername(username)
 
 	if username == "" {
 		return "", ErrEmptyUsername
@@ -36,5 +53,9 @@ func NewUsername(username string) (Username, error) {
 		return "", ErrUsernameInvalidChars
 	}
 
+	if reservedUsernames[username] {
+		return "", ErrUsernameReserved
+	}
+
 	return Username(username), nil
 }
@@ -0,0 +1,73 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrUsernameCooldown = errors.New("username changed too recently")
+
+const (
+	// UsernameCooldown is how long a user waits between username changes.
+	UsernameCooldown = 30 * 24 * time.Hour
+	// UsernameReservation is how long an old username stays with its owner,
+	// so links to it keep working and nobody else can take it.
+	UsernameReservation = 180 * 24 * time.Hour
+)
+
+// UsernameChange records a user moving from one username to another.
+type UsernameChange struct {
+	UserID      uuid.UUID `json:"-"`
+	OldUsername Username  `json:"old_username"`
+	NewUsername Username  `json:"new_username"`
+	ChangedAt   time.Time `json:"changed_at"`
+}
+
+func NewUsernameChange(userID uuid.UUID, oldUsername, newUsername Username, now time.Time) UsernameChange {
+	return UsernameChange{
+		UserID:      userID,
+		OldUsername: oldUsername,
+		NewUsername: newUsername,
+		ChangedAt:   now,
+	}
+}
+
+// ReservedUntil is when the old username becomes free for others.
+func (c UsernameChange) ReservedUntil() time.Time {
+	return c.ChangedAt.Add(UsernameReservation)
+}
+
+func (c UsernameChange) IsReserved(now time.Time) bool {
+	return now.Before(c.ReservedUntil())
+}
+
+// NextChangeAt is when the user may change their username again.
+func (c UsernameChange) NextChangeAt() time.Time {
+	return c.ChangedAt.Add(UsernameCooldown)
+}
+
+// AllowsChange returns a UsernameCooldownError while the cooldown after this
+// change is running.
+func (c UsernameChange) AllowsChange(now time.Time) error {
+	if now.Before(c.NextChangeAt()) {
+		return &UsernameCooldownError{Until: c.NextChangeAt()}
+	}
+	return nil
+}
+
+// UsernameCooldownError is returned for username changes made before the
+// cooldown is over.
+type UsernameCooldownError struct {
+	Until time.Time
+}
+
+func (e *UsernameCooldownError) Error() string {
+	return fmt.Sprintf("%v, next change allowed at %s", ErrUsernameCooldown, e.Until.UTC().Format(time.RFC3339))
+}
+
+func (e *UsernameCooldownError) Unwrap() error {
+	return ErrUsernameCooldown
+}
@@ -0,0 +1,58 @@
+package user_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+)
+
+func TestUsernameChange_AllowsChange(t *testing.T) {
+	changedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	change := user.NewUsernameChange(uuid.New(), "janedoe", "jane", changedAt)
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		wantErr bool
+	}{
+		{name: "right after", now: changedAt.Add(time.Hour), wantErr: true},
+		{name: "just before the cooldown ends", now: changedAt.Add(user.UsernameCooldown - time.Second), wantErr: true},
+		{name: "cooldown over", now: changedAt.Add(user.UsernameCooldown)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := change.AllowsChange(tt.now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AllowsChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+
+			var cooldown *user.UsernameCooldownError
+			if !errors.As(err, &cooldown) || !errors.Is(err, user.ErrUsernameCooldown) {
+				t.Fatalf("expected a cooldown error, got %v", err)
+			}
+			if !cooldown.Until.Equal(change.NextChangeAt()) {
+				t.Errorf("Until = %v, want %v", cooldown.Until, change.NextChangeAt())
+			}
+		})
+	}
+}
+
+func TestUsernameChange_IsReserved(t *testing.T) {
+	changedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	change := user.NewUsernameChange(uuid.New(), "janedoe", "jane", changedAt)
+
+	if !change.IsReserved(changedAt.Add(user.UsernameReservation - time.Second)) {
+		t.Error("expected the old username reserved within the period")
+	}
+	if change.IsReserved(change.ReservedUntil()) {
+		t.Error("expected the old username free once the period is over")
+	}
+}
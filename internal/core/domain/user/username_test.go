@@ -67,6 +67,11 @@ func TestUsername(t *testing.T) {
 			username: "cheeze cake",
 			wantErr:  true,
 		},
+		{
+			name:     "invalid username - reserved",
+			username: "Admin",
+			wantErr:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -109,3 +114,13 @@ func TestNewUsername_ValidCases(t *testing.T) {
 		})
 	}
 }
+
+func TestNewUsername_Reserved(t *testing.T) {
+	for _, username := range []string{"admin", " Support ", "fitrkr"} {
+		t.Run(username, func(t *testing.T) {
+			if _, err := user.NewUsername(username); err != user.ErrUsernameReserved {
+				t.Errorf("NewUsername() error = %v, want %v", err, user.ErrUsernameReserved)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package user
+
+import "strings"
+
+// FieldError is the error of the value constructor of one request field.
+// Err is the sentinel of the constructor, it doubles as the error code.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError holds every invalid field of a request.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Error())
+	}
+	return "invalid fields: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap lets errors.Is match the sentinel of any field.
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		errs = append(errs, f)
+	}
+	return errs
+}
+
+// Validation collects field errors so a request reports all of its invalid
+// fields at once instead of stopping at the first.
+type Validation struct {
+	fields []FieldError
+}
+
+// Check records err against field, nil errors are ignored.
+func (v *Validation) Check(field string, err error) {
+	if err != nil {
+		v.fields = append(v.fields, FieldError{Field: field, Err: err})
+	}
+}
+
+// Err returns a *ValidationError when any field failed, nil otherwise.
+func (v *Validation) Err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
@@ -0,0 +1,72 @@
+package user_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+)
+
+func TestValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     map[string]error
+		wantFields []string
+	}{
+		{
+			name:   "valid - no checks",
+			checks: map[string]error{},
+		},
+		{
+			name:   "valid - nil errors are ignored",
+			checks: map[string]error{"username": nil, "email": nil},
+		},
+		{
+			name:       "invalid - one field",
+			checks:     map[string]error{"username": user.ErrUsernameTooShort, "email": nil},
+			wantFields: []string{"username"},
+		},
+		{
+			name:       "invalid - every field is kept",
+			checks:     map[string]error{"username": user.ErrUsernameTooShort, "email": user.ErrInvalidEmail},
+			wantFields: []string{"email", "username"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v user.Validation
+			for _, field := range []string{"email", "username"} {
+				if err, ok := tt.checks[field]; ok {
+					v.Check(field, err)
+				}
+			}
+
+			err := v.Err()
+			if len(tt.wantFields) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			var validation *user.ValidationError
+			if !errors.As(err, &validation) {
+				t.Fatalf("expected *ValidationError, got %v", err)
+			}
+
+			if len(validation.Fields) != len(tt.wantFields) {
+				t.Fatalf("expected %d fields, got %d", len(tt.wantFields), len(validation.Fields))
+			}
+
+			for i, f := range validation.Fields {
+				if f.Field != tt.wantFields[i] {
+					t.Errorf("field %d: expected %s, got %s", i, tt.wantFields[i], f.Field)
+				}
+				if !errors.Is(err, tt.checks[f.Field]) {
+					t.Errorf("expected error to match %v", tt.checks[f.Field])
+				}
+			}
+		})
+	}
+}
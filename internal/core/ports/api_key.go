@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+)
+
+var ErrAPIKeyNotFound = errors.New("api key does not exist")
+
+type APIKeyRepo interface {
+	Add(ctx context.Context, key auth.APIKey) error
+	GetByPrefix(ctx context.Context, prefix string) (*auth.APIKey, error)
+	GetByID(ctx context.Context, id string) (*auth.APIKey, error)
+	GetByUserID(ctx context.Context, userID string) ([]*auth.APIKey, error)
+	// Touch records a use of the key, revoked keys are left untouched.
+	Touch(ctx context.Context, id string, lastUsedAt time.Time) error
+	// Revoke marks the key revoked, the first revocation time is kept.
+	Revoke(ctx context.Context, id string, at time.Time) error
+}
@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+)
+
+// AuditFilter selects audit events, unset fields match every event. Since is
+// inclusive and Until exclusive.
+type AuditFilter struct {
+	ActorID  *uuid.UUID
+	TargetID *uuid.UUID
+	Actions  []audit.Action
+	Since    *time.Time
+	Until    *time.Time
+
+	Limit  int
+	Offset int
+}
+
+// AuditLog stores audit events. Record joins the unit of work in ctx, so an
+// action and its event are committed together.
+type AuditLog interface {
+	Record(ctx context.Context, event audit.Event) error
+	// Search returns a page of matching events, newest first, and how many
+	// events match in total.
+	Search(ctx context.Context, filter AuditFilter) ([]audit.Event, int, error)
+	// Erase strips the client and metadata from the events of a purged user
+	// and puts tombstone in place of their ID. It is the only change made to
+	// recorded events.
+	Erase(ctx context.Context, userID, tombstone uuid.UUID) error
+}
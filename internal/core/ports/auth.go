@@ -3,6 +3,7 @@ package ports
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
 )
@@ -14,5 +15,8 @@ type AuthRepo interface {
 	GetByToken(ctx context.Context, token string) (*auth.RefreshToken, error)
 	GetByID(ctx context.Context, userID string) ([]*auth.RefreshToken, error)
 	Update(ctx context.Context, refreshToken auth.RefreshToken) error
+	// RevokeAll revokes every active refresh token of the user, ending all
+	// their sessions once their access tokens expire.
+	RevokeAll(ctx context.Context, userID string, revokedAt time.Time) error
 	Delete(ctx context.Context, token string) error
 }
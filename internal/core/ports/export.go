@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+)
+
+var (
+	ErrExportNotFound   = errors.New("export does not exist")
+	ErrExportInProgress = errors.New("export already in progress")
+)
+
+type ExportRepo interface {
+	// Add returns ErrExportInProgress when the user already has an export
+	// pending or running.
+	Add(ctx context.Context, e export.Export) error
+	GetByID(ctx context.Context, id string) (*export.Export, error)
+	// GetByUserID returns the exports of the user, newest first.
+	GetByUserID(ctx context.Context, userID string) ([]*export.Export, error)
+	Update(ctx context.Context, e export.Export) error
+	// GetExpired returns the completed exports whose archive expired before
+	// now.
+	GetExpired(ctx context.Context, now time.Time) ([]*export.Export, error)
+}
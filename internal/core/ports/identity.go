@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+)
+
+var (
+	ErrIdentityNotFound = errors.New("identity does not exist")
+	ErrIdentityLinked   = errors.New("identity already linked")
+)
+
+// ExternalIdentity is the verified result of a login at an identity provider.
+type ExternalIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+}
+
+// IdentityProvider runs the OIDC authorization code flow with PKCE against an
+// external provider such as Google or Apple.
+type IdentityProvider interface {
+	Name() string
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*ExternalIdentity, error)
+}
+
+type IdentityRepo interface {
+	Add(ctx context.Context, identity auth.Identity) error
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*auth.Identity, error)
+	GetByUserID(ctx context.Context, userID string) ([]*auth.Identity, error)
+}
@@ -0,0 +1,46 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrNoJob = errors.New("no job is ready")
+
+// Job is background work handled outside of the request that asked for it.
+// Payload is the JSON input of the handler registered for Kind.
+type Job struct {
+	ID      uuid.UUID
+	Kind    string
+	Payload []byte
+	// Attempts counts the claims so far, including the current one.
+	Attempts    int
+	MaxAttempts int
+	// RunAt is the earliest time the job may be claimed.
+	RunAt     time.Time
+	CreatedAt time.Time
+}
+
+// LastAttempt reports whether a failure now fails the job for good.
+func (j Job) LastAttempt() bool {
+	return j.Attempts >= j.MaxAttempts
+}
+
+// JobQueue hands out jobs to workers. A claimed job is not handed out again
+// until it is retried, and handlers should be idempotent since a crash can
+// run a job twice.
+type JobQueue interface {
+	Enqueue(ctx context.Context, job Job) error
+	// Claim takes the oldest job that is due and counts the attempt, or
+	// returns ErrNoJob.
+	Claim(ctx context.Context) (*Job, error)
+	// Complete removes a finished job.
+	Complete(ctx context.Context, job Job) error
+	// Retry returns a claimed job to the queue, due at job.RunAt.
+	Retry(ctx context.Context, job Job) error
+	// Fail sets a claimed job aside with the reason it failed.
+	Fail(ctx context.Context, job Job, reason string) error
+}
@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+)
+
+var ErrUnlockTokenNotFound = errors.New("unlock token does not exist")
+
+// LoginAttemptRepo keeps failed login counters per account and per client IP.
+type LoginAttemptRepo interface {
+	// Get returns zero attempts for unknown keys.
+	Get(ctx context.Context, key string) (auth.LoginAttempts, error)
+	// Reserve checks the counter for key against policy and counts the attempt
+	// in one atomic step, so concurrent attempts cannot all pass the check
+	// before any of them is counted. A blocked attempt is not counted and
+	// returns the time it is blocked until, counted ones the zero time.
+	Reserve(ctx context.Context, key string, policy auth.LockoutPolicy, now time.Time) (auth.LoginAttempts, time.Time, error)
+	// Release gives back an attempt reserved by a login that succeeded.
+	Release(ctx context.Context, key string) error
+	Reset(ctx context.Context, key string) error
+
+	AddUnlockToken(ctx context.Context, token auth.UnlockToken) error
+	// ConsumeUnlockToken returns the token and deletes it, tokens are single use.
+	ConsumeUnlockToken(ctx context.Context, hash string) (*auth.UnlockToken, error)
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	ErrObjectNotFound = errors.New("object does not exist")
+	ErrInvalidKey     = errors.New("invalid object key")
+)
+
+// ObjectStore keeps files by key. Keys are slash separated relative paths,
+// such as exports/<user id>/<export id>.zip.
+type ObjectStore interface {
+	// Put stores body under key, replacing any object already there.
+	Put(ctx context.Context, key, contentType string, body io.Reader) error
+	// Get opens the object, the caller closes it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object, deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+)
+
+var ErrPhotoNotFound = errors.New("photo does not exist")
+
+type PhotoRepo interface {
+	Add(ctx context.Context, p photo.Photo) error
+	GetByID(ctx context.Context, id string) (*photo.Photo, error)
+	// GetByUserID returns the photos of a kind of the user. Progress photos
+	// come newest day first, others newest upload first.
+	GetByUserID(ctx context.Context, userID string, kind photo.Kind) ([]*photo.Photo, error)
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "context"
+
+// UnitOfWork runs several repository calls atomically. Calls made with the
+// ctx given to fn share one transaction, which is committed when fn returns
+// nil and rolled back otherwise.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
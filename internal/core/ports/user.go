@@ -15,6 +15,7 @@ var (
 	ErrUserNotFound      = errors.New("user does not exist")
 	ErrDuplicateEmail    = errors.New("email already exists")
 	ErrDuplicateUsername = errors.New("username already exists")
+	ErrNotReserved       = errors.New("username is not reserved")
 )
 
 type User struct {
@@ -24,10 +25,25 @@ type User struct {
 	PasswordHash user.Password
 	Email        user.Email
 	Roles        []string
+	Status       user.AccountStatus
+	Profile      user.Profile
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
 
+// UserFilter narrows a user search. Username and Email match any part of the
+// value, ignoring case, and the creation range is inclusive of After and
+// exclusive of Before. Empty fields do not filter.
+type UserFilter struct {
+	Username      string
+	Email         string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+
+	Limit  int
+	Offset int
+}
+
 type UpdateBodyMetrics struct {
 	WeightValue *user.WeightValue
 	HeightValue *user.HeightValue
@@ -41,8 +57,31 @@ type UserRepo interface {
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	GetByID(ctx context.Context, id string) (*User, error)
 	Update(ctx context.Context, user user.User) error
+	UpdateRoles(ctx context.Context, id string, roles user.Roles, updatedAt time.Time) error
+	UpdatePassword(ctx context.Context, id string, password user.Password, updatedAt time.Time) error
+	UpdateStatus(ctx context.Context, id string, status user.AccountStatus, updatedAt time.Time) error
+	// Search returns a page of users, newest first, and how many match in
+	// total.
+	Search(ctx context.Context, filter UserFilter) ([]*User, int, error)
+	// GetDueForPurge returns up to limit accounts whose deletion was
+	// requested before requestedBefore, oldest request first.
+	GetDueForPurge(ctx context.Context, requestedBefore time.Time, limit int) ([]*User, error)
+	// LockForPurge locks the account until the unit of work ends if its
+	// deletion is still requested before requestedBefore, or returns
+	// ErrUserNotFound once it was restored or purged.
+	LockForPurge(ctx context.Context, id string, requestedBefore time.Time) error
+	// Delete removes the user and every row that belongs to them in one
+	// transaction.
 	Delete(ctx context.Context, id string) error
 
+	AddUsernameChange(ctx context.Context, change user.UsernameChange) error
+	// GetUsernameChanges returns the username changes of a user, newest
+	// first.
+	GetUsernameChanges(ctx context.Context, userID string) ([]user.UsernameChange, error)
+	// GetUsernameReservation returns the newest change away from username
+	// made after changedAfter, or ErrNotReserved.
+	GetUsernameReservation(ctx context.Context, username string, changedAfter time.Time) (*user.UsernameChange, error)
+
 	AddStats(ctx context.Context, stats user.Stats, userID string) error
 	GetStatsByID(ctx context.Context, userID string) (*user.Stats, error)
 	UpdateBodyMetrics(ctx context.Context, stats UpdateBodyMetrics, userID string) error
@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+func (s *Service) VerifyEmail(ctx context.Context, req UserActionReq) error {
+	return s.act(ctx, req, audit.ActionAdminEmailVerified, func(ctx context.Context, u *ports.User) (map[string]string, error) {
+		return nil, s.updateStatus(ctx, u.ID, u.Status.VerifyEmail(time.Now()))
+	})
+}
+
+type SuspendUserReq struct {
+	UserActionReq
+	Reason string
+}
+
+// SuspendUser blocks sign in and refresh, and ends every session of the user.
+func (s *Service) SuspendUser(ctx context.Context, req SuspendUserReq) error {
+	if req.ActorID == req.UserID {
+		logr.Get().Errorf("admin %s tried to suspend themselves", req.ActorID)
+		return ErrSelfSuspension
+	}
+
+	return s.act(ctx, req.UserActionReq, audit.ActionAdminUserSuspended, func(ctx context.Context, u *ports.User) (map[string]string, error) {
+		status, err := u.Status.Suspend(req.Reason, time.Now())
+		if err != nil {
+			logr.Get().Errorf("invalid suspension: %v", err)
+			return nil, err
+		}
+
+		if err := s.updateStatus(ctx, u.ID, status); err != nil {
+			return nil, err
+		}
+		if err := s.endSessions(ctx, u); err != nil {
+			return nil, err
+		}
+
+		return map[string]string{"reason": status.SuspensionReason}, nil
+	})
+}
+
+func (s *Service) UnsuspendUser(ctx context.Context, req UserActionReq) error {
+	return s.act(ctx, req, audit.ActionAdminUserUnsuspended, func(ctx context.Context, u *ports.User) (map[string]string, error) {
+		return nil, s.updateStatus(ctx, u.ID, u.Status.Unsuspend())
+	})
+}
+
+// ForceLogout ends every session of the user.
+func (s *Service) ForceLogout(ctx context.Context, req UserActionReq) error {
+	return s.act(ctx, req, audit.ActionAdminSessionsRevoked, func(ctx context.Context, u *ports.User) (map[string]string, error) {
+		return nil, s.endSessions(ctx, u)
+	})
+}
+
+func (s *Service) updateStatus(ctx context.Context, id uuid.UUID, status user.AccountStatus) error {
+	if err := s.userRepo.UpdateStatus(ctx, id.String(), status, time.Now()); err != nil {
+		logr.Get().Errorf("failed to update account status: %v", err)
+		return fmt.Errorf("failed to update account status: %w", err)
+	}
+	return nil
+}
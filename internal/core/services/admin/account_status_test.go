@@ -0,0 +1,92 @@
+package admin_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
+)
+
+func TestVerifyEmail(t *testing.T) {
+	f := newFixture()
+	f.expectTarget()
+	f.users.On("UpdateStatus", mock.Anything, f.target.ID.String(), mock.MatchedBy(func(s user.AccountStatus) bool {
+		return s.IsEmailVerified() && !s.IsSuspended()
+	}), mock.Anything).Return(nil)
+
+	require.NoError(t, f.svc.VerifyEmail(context.Background(), f.req()))
+
+	f.assertAudited(t, audit.ActionAdminEmailVerified)
+	f.users.AssertExpectations(t)
+}
+
+func TestSuspendUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success - suspends and ends sessions", func(t *testing.T) {
+		f := newFixture()
+		id := f.target.ID.String()
+		f.expectTarget()
+		f.users.On("UpdateStatus", mock.Anything, id, mock.MatchedBy(func(s user.AccountStatus) bool {
+			return s.IsSuspended() && s.SuspensionReason == "chargeback fraud"
+		}), mock.Anything).Return(nil)
+		f.auth.On("RevokeAll", mock.Anything, id, mock.Anything).Return(nil)
+
+		err := f.svc.SuspendUser(ctx, admin.SuspendUserReq{UserActionReq: f.req(), Reason: "chargeback fraud"})
+		require.NoError(t, err)
+
+		event := f.assertAudited(t, audit.ActionAdminUserSuspended)
+		assert.Equal(t, "chargeback fraud", event.Metadata["reason"])
+		f.users.AssertExpectations(t)
+		f.auth.AssertExpectations(t)
+	})
+
+	t.Run("error - no reason", func(t *testing.T) {
+		f := newFixture()
+		f.expectTarget()
+
+		err := f.svc.SuspendUser(ctx, admin.SuspendUserReq{UserActionReq: f.req()})
+
+		assert.ErrorIs(t, err, user.ErrEmptySuspensionReason)
+		assert.Empty(t, f.audit.Events())
+	})
+
+	t.Run("error - admin suspends themselves", func(t *testing.T) {
+		f := newFixture()
+		req := admin.SuspendUserReq{UserActionReq: admin.UserActionReq{ActorID: f.adminID, UserID: f.adminID}, Reason: "oops"}
+
+		assert.ErrorIs(t, f.svc.SuspendUser(ctx, req), admin.ErrSelfSuspension)
+		f.users.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUnsuspendUser(t *testing.T) {
+	f := newFixture()
+	now := time.Now()
+	f.target.Status = user.AccountStatus{SuspendedAt: &now, SuspensionReason: "spam"}
+	f.expectTarget()
+	f.users.On("UpdateStatus", mock.Anything, f.target.ID.String(), user.AccountStatus{}, mock.Anything).Return(nil)
+
+	require.NoError(t, f.svc.UnsuspendUser(context.Background(), f.req()))
+
+	f.assertAudited(t, audit.ActionAdminUserUnsuspended)
+	f.users.AssertExpectations(t)
+}
+
+func TestForceLogout(t *testing.T) {
+	f := newFixture()
+	f.expectTarget()
+	f.auth.On("RevokeAll", mock.Anything, f.target.ID.String(), mock.Anything).Return(nil)
+
+	require.NoError(t, f.svc.ForceLogout(context.Background(), f.req()))
+
+	f.assertAudited(t, audit.ActionAdminSessionsRevoked)
+	f.auth.AssertExpectations(t)
+}
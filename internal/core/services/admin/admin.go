@@ -0,0 +1,125 @@
+// Package admin implements what support staff do to user accounts. Every
+// action is written to the audit log, with the acting admin, in the same unit
+// of work as the change itself.
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+var (
+	// ErrSelfDemotion stops admins from locking themselves out.
+	ErrSelfDemotion      = errors.New("admins cannot revoke their own admin role")
+	ErrSelfSuspension    = errors.New("admins cannot suspend themselves")
+	ErrInvalidDateRange  = errors.New("created_after must be before created_before")
+	ErrInvalidAuditRange = errors.New("since must be before until")
+)
+
+type AdminService interface {
+	SearchUsers(ctx context.Context, req SearchUsersReq) (*SearchUsersResp, error)
+	GetUser(ctx context.Context, req GetUserReq) (*GetUserResp, error)
+	UpdateUser(ctx context.Context, req UpdateUserReq) error
+
+	ResetPassword(ctx context.Context, req UserActionReq) (*ResetPasswordResp, error)
+	VerifyEmail(ctx context.Context, req UserActionReq) error
+	SuspendUser(ctx context.Context, req SuspendUserReq) error
+	UnsuspendUser(ctx context.Context, req UserActionReq) error
+	ForceLogout(ctx context.Context, req UserActionReq) error
+
+	GrantRole(ctx context.Context, req ManageRoleReq) (*ManageRoleResp, error)
+	RevokeRole(ctx context.Context, req ManageRoleReq) (*ManageRoleResp, error)
+
+	SearchAuditEvents(ctx context.Context, req SearchAuditEventsReq) (*SearchAuditEventsResp, error)
+}
+
+// AccountUpdater edits profile fields under the rules users are held to.
+type AccountUpdater interface {
+	Update(ctx context.Context, req users.UpdateUserReq) error
+}
+
+type Service struct {
+	userRepo ports.UserRepo
+	authRepo ports.AuthRepo
+	auditLog ports.AuditLog
+	uow      ports.UnitOfWork
+	accounts AccountUpdater
+}
+
+func NewService(userRepo ports.UserRepo, authRepo ports.AuthRepo, auditLog ports.AuditLog, uow ports.UnitOfWork, accounts AccountUpdater) *Service {
+	return &Service{
+		userRepo: userRepo,
+		authRepo: authRepo,
+		auditLog: auditLog,
+		uow:      uow,
+		accounts: accounts,
+	}
+}
+
+// UserActionReq names the admin acting and the user they act on.
+type UserActionReq struct {
+	ActorID uuid.UUID
+	UserID  uuid.UUID
+}
+
+// act loads the target user and runs change on it, then records action with
+// the metadata change returns. Nothing is kept unless all of it succeeds.
+func (s *Service) act(ctx context.Context, req UserActionReq, action audit.Action, change func(ctx context.Context, u *ports.User) (map[string]string, error)) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		u, err := s.getUser(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+
+		metadata, err := change(ctx, u)
+		if err != nil {
+			return err
+		}
+
+		event, err := audit.NewEvent(action, req.ActorID, req.UserID, metadata)
+		if err != nil {
+			logr.Get().Errorf("failed to create audit event: %v", err)
+			return fmt.Errorf("failed to create audit event: %w", err)
+		}
+
+		if err := s.auditLog.Record(ctx, event.WithClient(audit.ClientFromContext(ctx))); err != nil {
+			logr.Get().Errorf("failed to record audit event: %v", err)
+			return fmt.Errorf("failed to record audit event: %w", err)
+		}
+
+		logr.Get().Infof("admin %s: %s on user %s", req.ActorID, action, req.UserID)
+		return nil
+	})
+}
+
+func (s *Service) getUser(ctx context.Context, id uuid.UUID) (*ports.User, error) {
+	u, err := s.userRepo.GetByID(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, ports.ErrUserNotFound) {
+			logr.Get().Error("user not found")
+			return nil, users.ErrUserNotFound
+		}
+		logr.Get().Errorf("failed to get user: %v", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return u, nil
+}
+
+// endSessions revokes every refresh token of the user. Access tokens already
+// issued stay valid until they expire.
+func (s *Service) endSessions(ctx context.Context, u *ports.User) error {
+	if err := s.authRepo.RevokeAll(ctx, u.ID.String(), time.Now()); err != nil {
+		logr.Get().Errorf("failed to revoke sessions: %v", err)
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
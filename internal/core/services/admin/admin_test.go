@@ -0,0 +1,310 @@
+package admin_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+type MockUserRepo struct {
+	mock.Mock
+}
+
+func (m *MockUserRepo) Add(ctx context.Context, u user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetByUsername(ctx context.Context, username string) (*ports.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ports.User), args.Error(1)
+}
+
+func (m *MockUserRepo) GetByEmail(ctx context.Context, email string) (*ports.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ports.User), args.Error(1)
+}
+
+func (m *MockUserRepo) GetByID(ctx context.Context, id string) (*ports.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ports.User), args.Error(1)
+}
+
+func (m *MockUserRepo) Update(ctx context.Context, u user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) UpdateRoles(ctx context.Context, id string, roles user.Roles, updatedAt time.Time) error {
+	args := m.Called(ctx, id, roles, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) UpdatePassword(ctx context.Context, id string, password user.Password, updatedAt time.Time) error {
+	args := m.Called(ctx, id, password, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) UpdateStatus(ctx context.Context, id string, status user.AccountStatus, updatedAt time.Time) error {
+	args := m.Called(ctx, id, status, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) Search(ctx context.Context, filter ports.UserFilter) ([]*ports.User, int, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*ports.User), args.Int(1), args.Error(2)
+}
+
+func (m *MockUserRepo) GetDueForPurge(ctx context.Context, requestedBefore time.Time, limit int) ([]*ports.User, error) {
+	args := m.Called(ctx, requestedBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*ports.User), args.Error(1)
+}
+
+func (m *MockUserRepo) LockForPurge(ctx context.Context, id string, requestedBefore time.Time) error {
+	args := m.Called(ctx, id, requestedBefore)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) AddUsernameChange(ctx context.Context, change user.UsernameChange) error {
+	return m.Called(ctx, change).Error(0)
+}
+
+func (m *MockUserRepo) GetUsernameChanges(ctx context.Context, userID string) ([]user.UsernameChange, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]user.UsernameChange), args.Error(1)
+}
+
+func (m *MockUserRepo) GetUsernameReservation(ctx context.Context, username string, changedAfter time.Time) (*user.UsernameChange, error) {
+	args := m.Called(ctx, username, changedAfter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.UsernameChange), args.Error(1)
+}
+
+func (m *MockUserRepo) AddStats(ctx context.Context, stats user.Stats, userID string) error {
+	args := m.Called(ctx, stats, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetStatsByID(ctx context.Context, userID string) (*user.Stats, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.Stats), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateBodyMetrics(ctx context.Context, stats ports.UpdateBodyMetrics, userID string) error {
+	args := m.Called(ctx, stats, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) AddSubscription(ctx context.Context, sub user.Subscription, userID string) error {
+	args := m.Called(ctx, sub, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetSubscriptionByID(ctx context.Context, userID string) (*user.Subscription, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.Subscription), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateSubscription(ctx context.Context, sub user.Subscription, userID string) error {
+	args := m.Called(ctx, sub, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) AddSettings(ctx context.Context, settings user.Settings, userID string) error {
+	args := m.Called(ctx, settings, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetSettingsByID(ctx context.Context, userID string) (*user.Settings, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.Settings), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateSettings(ctx context.Context, settings user.Settings, userID string) error {
+	args := m.Called(ctx, settings, userID)
+	return args.Error(0)
+}
+
+type MockAuthRepo struct {
+	mock.Mock
+}
+
+func (m *MockAuthRepo) Add(ctx context.Context, refreshToken auth.RefreshToken) error {
+	args := m.Called(ctx, refreshToken)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepo) GetByToken(ctx context.Context, token string) (*auth.RefreshToken, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.RefreshToken), args.Error(1)
+}
+
+func (m *MockAuthRepo) GetByID(ctx context.Context, userID string) ([]*auth.RefreshToken, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*auth.RefreshToken), args.Error(1)
+}
+
+func (m *MockAuthRepo) Update(ctx context.Context, refreshToken auth.RefreshToken) error {
+	args := m.Called(ctx, refreshToken)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepo) RevokeAll(ctx context.Context, userID string, revokedAt time.Time) error {
+	args := m.Called(ctx, userID, revokedAt)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepo) Delete(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+type MockAccountUpdater struct {
+	mock.Mock
+}
+
+func (m *MockAccountUpdater) Update(ctx context.Context, req users.UpdateUserReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func TestMain(m *testing.M) {
+	logr.Init(&logr.PlainTextFormatter{}, logr.LevelInfo, nil)
+
+	exitCode := m.Run()
+
+	os.Exit(exitCode)
+}
+
+type fixture struct {
+	users    *MockUserRepo
+	auth     *MockAuthRepo
+	accounts *MockAccountUpdater
+	audit    *memory.AuditLog
+	uow      *memory.UnitOfWork
+	svc      *admin.Service
+
+	adminID uuid.UUID
+	target  *ports.User
+}
+
+func newFixture() *fixture {
+	f := &fixture{
+		users:    new(MockUserRepo),
+		auth:     new(MockAuthRepo),
+		accounts: new(MockAccountUpdater),
+		audit:    memory.NewAuditLog(),
+		uow:      memory.NewUnitOfWork(),
+		adminID:  uuid.New(),
+		target: &ports.User{
+			ID:        uuid.New(),
+			Username:  "janedoe",
+			Email:     "jane.doe@example.com",
+			FullName:  "Jane Doe",
+			Roles:     []string{"user"},
+			CreatedAt: time.Now().Add(-24 * time.Hour),
+		},
+	}
+	f.svc = admin.NewService(f.users, f.auth, f.audit, f.uow, f.accounts)
+	return f
+}
+
+func (f *fixture) req() admin.UserActionReq {
+	return admin.UserActionReq{ActorID: f.adminID, UserID: f.target.ID}
+}
+
+func (f *fixture) expectTarget() {
+	f.users.On("GetByID", mock.Anything, f.target.ID.String()).Return(f.target, nil)
+}
+
+// assertAudited checks the only event recorded is action by the admin on the
+// target.
+func (f *fixture) assertAudited(t *testing.T, action audit.Action) audit.Event {
+	t.Helper()
+
+	events := f.audit.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, action, events[0].Action)
+	assert.Equal(t, f.adminID, events[0].ActorID)
+	assert.Equal(t, f.target.ID, events[0].TargetID)
+	return events[0]
+}
+
+func TestAct_UnknownUser(t *testing.T) {
+	f := newFixture()
+	f.users.On("GetByID", mock.Anything, f.target.ID.String()).Return(nil, ports.ErrUserNotFound)
+
+	err := f.svc.ForceLogout(context.Background(), f.req())
+
+	assert.ErrorIs(t, err, users.ErrUserNotFound)
+	assert.Empty(t, f.audit.Events())
+	assert.Equal(t, 1, f.uow.Rollbacks())
+}
+
+func TestAct_AttributesClient(t *testing.T) {
+	f := newFixture()
+	f.expectTarget()
+	f.auth.On("RevokeAll", mock.Anything, f.target.ID.String(), mock.Anything).Return(nil)
+
+	ctx := audit.ContextWithClient(context.Background(), audit.Client{IP: "203.0.113.7", UserAgent: "Mozilla/5.0"})
+	require.NoError(t, f.svc.ForceLogout(ctx, f.req()))
+
+	event := f.assertAudited(t, audit.ActionAdminSessionsRevoked)
+	assert.Equal(t, "203.0.113.7", event.IP)
+	assert.Equal(t, "Mozilla/5.0", event.UserAgent)
+}
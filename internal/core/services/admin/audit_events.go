@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+// SearchAuditEventsReq filters on who acted, on whom, the action and when,
+// from Since up to but excluding Until.
+type SearchAuditEventsReq struct {
+	ActorID  *uuid.UUID
+	TargetID *uuid.UUID
+	Action   string
+	Since    *time.Time
+	Until    *time.Time
+
+	Limit  int
+	Offset int
+}
+
+type SearchAuditEventsResp struct {
+	Events []audit.Event `json:"events"`
+	Total  int           `json:"total"`
+	Limit  int           `json:"limit"`
+	Offset int           `json:"offset"`
+}
+
+func (s *Service) SearchAuditEvents(ctx context.Context, req SearchAuditEventsReq) (*SearchAuditEventsResp, error) {
+	if req.Since != nil && req.Until != nil && !req.Since.Before(*req.Until) {
+		logr.Get().Errorf("invalid audit range: %v to %v", req.Since, req.Until)
+		return nil, ErrInvalidAuditRange
+	}
+
+	filter := ports.AuditFilter{
+		ActorID:  req.ActorID,
+		TargetID: req.TargetID,
+		Since:    req.Since,
+		Until:    req.Until,
+	}
+	if req.Action != "" {
+		filter.Actions = []audit.Action{audit.Action(req.Action)}
+	}
+	filter.Limit, filter.Offset = page(req.Limit, req.Offset)
+
+	events, total, err := s.auditLog.Search(ctx, filter)
+	if err != nil {
+		logr.Get().Errorf("failed to search audit events: %v", err)
+		return nil, fmt.Errorf("failed to search audit events: %w", err)
+	}
+
+	if events == nil {
+		events = []audit.Event{}
+	}
+
+	return &SearchAuditEventsResp{
+		Events: events,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package admin_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
+)
+
+func TestSearchAuditEvents(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+
+	jane, john := uuid.New(), uuid.New()
+	record := func(action audit.Action, actor, target uuid.UUID) {
+		event, err := audit.NewEvent(action, actor, target, nil)
+		require.NoError(t, err)
+		require.NoError(t, f.audit.Record(ctx, event))
+	}
+	record(audit.ActionLoginSucceeded, jane, jane)
+	record(audit.ActionLoginFailed, john, john)
+	record(audit.ActionAdminUserSuspended, f.adminID, john)
+
+	t.Run("filters by target", func(t *testing.T) {
+		resp, err := f.svc.SearchAuditEvents(ctx, admin.SearchAuditEventsReq{TargetID: &john})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, resp.Total)
+		assert.Equal(t, admin.DefaultPageSize, resp.Limit)
+		require.Len(t, resp.Events, 2)
+		assert.Equal(t, audit.ActionAdminUserSuspended, resp.Events[0].Action)
+	})
+
+	t.Run("filters by actor and action", func(t *testing.T) {
+		resp, err := f.svc.SearchAuditEvents(ctx, admin.SearchAuditEventsReq{
+			ActorID: &f.adminID,
+			Action:  string(audit.ActionAdminUserSuspended),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.Total)
+	})
+
+	t.Run("no match is an empty page", func(t *testing.T) {
+		resp, err := f.svc.SearchAuditEvents(ctx, admin.SearchAuditEventsReq{Action: "unknown"})
+		require.NoError(t, err)
+		assert.NotNil(t, resp.Events)
+		assert.Empty(t, resp.Events)
+	})
+
+	t.Run("rejects an empty range", func(t *testing.T) {
+		now := time.Now()
+		_, err := f.svc.SearchAuditEvents(ctx, admin.SearchAuditEventsReq{Since: &now, Until: &now})
+		assert.ErrorIs(t, err, admin.ErrInvalidAuditRange)
+	})
+}
@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+)
+
+type GetUserReq struct {
+	UserID uuid.UUID
+}
+
+// GetUserResp is the whole aggregate. The password hash is never included.
+type GetUserResp struct {
+	User user.User `json:"user"`
+}
+
+func (s *Service) GetUser(ctx context.Context, req GetUserReq) (*GetUserResp, error) {
+	u, err := s.getUser(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	id := u.ID.String()
+
+	stats, err := s.userRepo.GetStatsByID(ctx, id)
+	if err != nil {
+		logr.Get().Errorf("failed to get stats: %v", err)
+		return nil, fmt.Errorf("failed to get stats: %w", err)
+	}
+
+	sub, err := s.userRepo.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		logr.Get().Errorf("failed to get subscription: %v", err)
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	settings, err := s.userRepo.GetSettingsByID(ctx, id)
+	if err != nil {
+		logr.Get().Errorf("failed to get settings: %v", err)
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	return &GetUserResp{User: user.User{
+		ID:           u.ID,
+		Username:     u.Username,
+		FullName:     u.FullName,
+		Email:        u.Email,
+		Roles:        user.StringsToRoles(u.Roles),
+		Status:       u.Status,
+		Profile:      u.Profile,
+		CreatedAt:    u.CreatedAt,
+		UpdatedAt:    u.UpdatedAt,
+		Stats:        *stats,
+		Subscription: *sub,
+		Settings:     *settings,
+	}}, nil
+}
@@ -0,0 +1,51 @@
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
+)
+
+func TestGetUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success - whole aggregate", func(t *testing.T) {
+		f := newFixture()
+		id := f.target.ID.String()
+		f.target.PasswordHash = "hash"
+		stats, sub, settings := user.NewStats(), user.NewSubscription(), user.NewSettings()
+
+		f.expectTarget()
+		f.users.On("GetStatsByID", mock.Anything, id).Return(&stats, nil)
+		f.users.On("GetSubscriptionByID", mock.Anything, id).Return(&sub, nil)
+		f.users.On("GetSettingsByID", mock.Anything, id).Return(&settings, nil)
+
+		resp, err := f.svc.GetUser(ctx, admin.GetUserReq{UserID: f.target.ID})
+		require.NoError(t, err)
+
+		assert.Equal(t, f.target.ID, resp.User.ID)
+		assert.Equal(t, f.target.Username, resp.User.Username)
+		assert.Equal(t, user.Roles{user.RoleUser}, resp.User.Roles)
+		assert.Equal(t, stats, resp.User.Stats)
+		assert.Equal(t, sub, resp.User.Subscription)
+		assert.Equal(t, settings, resp.User.Settings)
+		assert.Empty(t, resp.User.Password)
+		f.users.AssertExpectations(t)
+	})
+
+	t.Run("error - stats fail", func(t *testing.T) {
+		f := newFixture()
+		f.expectTarget()
+		f.users.On("GetStatsByID", mock.Anything, f.target.ID.String()).Return(nil, errors.New("db error"))
+
+		_, err := f.svc.GetUser(ctx, admin.GetUserReq{UserID: f.target.ID})
+		assert.EqualError(t, err, "failed to get stats: db error")
+	})
+}
@@ -0,0 +1,69 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cheezecakee/logr"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+// ManageRoleReq grants or revokes one role.
+type ManageRoleReq struct {
+	UserActionReq
+	Role string
+}
+
+// ManageRoleResp holds the roles after the change. Access tokens already
+// issued keep the old roles until the next refresh re-reads them.
+type ManageRoleResp struct {
+	Roles user.Roles `json:"roles"`
+}
+
+func (s *Service) GrantRole(ctx context.Context, req ManageRoleReq) (*ManageRoleResp, error) {
+	return s.changeRoles(ctx, req, audit.ActionAdminRoleGranted, func(roles user.Roles, role user.Role) (user.Roles, error) {
+		return roles.Grant(role), nil
+	})
+}
+
+func (s *Service) RevokeRole(ctx context.Context, req ManageRoleReq) (*ManageRoleResp, error) {
+	return s.changeRoles(ctx, req, audit.ActionAdminRoleRevoked, func(roles user.Roles, role user.Role) (user.Roles, error) {
+		if role == user.RoleAdmin && req.ActorID == req.UserID {
+			return nil, ErrSelfDemotion
+		}
+		return roles.Revoke(role)
+	})
+}
+
+func (s *Service) changeRoles(ctx context.Context, req ManageRoleReq, action audit.Action, change func(user.Roles, user.Role) (user.Roles, error)) (*ManageRoleResp, error) {
+	role, err := user.NewRole(req.Role)
+	if err != nil {
+		logr.Get().Errorf("failed to change roles: %v", err)
+		return nil, err
+	}
+
+	var roles user.Roles
+	err = s.act(ctx, req.UserActionReq, action, func(ctx context.Context, u *ports.User) (map[string]string, error) {
+		roles, err = change(user.StringsToRoles(u.Roles), role)
+		if err != nil {
+			logr.Get().Errorf("failed to change roles: %v", err)
+			return nil, err
+		}
+
+		if err := s.userRepo.UpdateRoles(ctx, u.ID.String(), roles, time.Now()); err != nil {
+			logr.Get().Errorf("failed to update roles: %v", err)
+			return nil, fmt.Errorf("failed to update roles: %w", err)
+		}
+
+		return map[string]string{"role": string(role)}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ManageRoleResp{Roles: roles}, nil
+}
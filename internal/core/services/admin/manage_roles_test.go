@@ -0,0 +1,153 @@
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+func TestGrantRole(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		role        string
+		setupMock   func(*fixture)
+		expected    user.Roles
+		expectedErr error
+	}{
+		{
+			name: "success - role granted",
+			role: "moderator",
+			setupMock: func(f *fixture) {
+				f.expectTarget()
+				f.users.On("UpdateRoles", mock.Anything, f.target.ID.String(), user.Roles{user.RoleUser, user.RoleModerator}, mock.Anything).Return(nil)
+			},
+			expected: user.Roles{user.RoleUser, user.RoleModerator},
+		},
+		{
+			name: "success - held role is unchanged",
+			role: "user",
+			setupMock: func(f *fixture) {
+				f.expectTarget()
+				f.users.On("UpdateRoles", mock.Anything, f.target.ID.String(), user.Roles{user.RoleUser}, mock.Anything).Return(nil)
+			},
+			expected: user.Roles{user.RoleUser},
+		},
+		{
+			name:        "error - unknown role",
+			role:        "root",
+			setupMock:   func(f *fixture) {},
+			expectedErr: user.ErrInvalidRole,
+		},
+		{
+			name: "error - user not found",
+			role: "admin",
+			setupMock: func(f *fixture) {
+				f.users.On("GetByID", mock.Anything, f.target.ID.String()).Return(nil, ports.ErrUserNotFound)
+			},
+			expectedErr: users.ErrUserNotFound,
+		},
+		{
+			name: "error - update fails",
+			role: "admin",
+			setupMock: func(f *fixture) {
+				f.expectTarget()
+				f.users.On("UpdateRoles", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db error"))
+			},
+			expectedErr: errors.New("failed to update roles: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture()
+			tt.setupMock(f)
+
+			resp, err := f.svc.GrantRole(ctx, admin.ManageRoleReq{UserActionReq: f.req(), Role: tt.role})
+
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, resp)
+				assert.Empty(t, f.audit.Events())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, resp.Roles)
+				event := f.assertAudited(t, audit.ActionAdminRoleGranted)
+				assert.Equal(t, tt.role, event.Metadata["role"])
+			}
+
+			f.users.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRevokeRole(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		role        string
+		self        bool
+		setupMock   func(*fixture)
+		expected    user.Roles
+		expectedErr error
+	}{
+		{
+			name: "success - role revoked",
+			role: "admin",
+			setupMock: func(f *fixture) {
+				f.users.On("UpdateRoles", mock.Anything, f.target.ID.String(), user.Roles{user.RoleUser}, mock.Anything).Return(nil)
+			},
+			expected: user.Roles{user.RoleUser},
+		},
+		{
+			name:        "error - base role",
+			role:        "user",
+			setupMock:   func(f *fixture) {},
+			expectedErr: user.ErrBaseRole,
+		},
+		{
+			name:        "error - admin revokes own admin role",
+			role:        "admin",
+			self:        true,
+			setupMock:   func(f *fixture) {},
+			expectedErr: admin.ErrSelfDemotion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture()
+			f.target.Roles = []string{"user", "admin"}
+			if tt.self {
+				f.adminID = f.target.ID
+			}
+			f.expectTarget()
+			tt.setupMock(f)
+
+			resp, err := f.svc.RevokeRole(ctx, admin.ManageRoleReq{UserActionReq: f.req(), Role: tt.role})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, resp)
+				assert.Empty(t, f.audit.Events())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, resp.Roles)
+				f.assertAudited(t, audit.ActionAdminRoleRevoked)
+			}
+
+			f.users.AssertExpectations(t)
+		})
+	}
+}
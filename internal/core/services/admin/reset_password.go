@@ -0,0 +1,42 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cheezecakee/logr"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+// ResetPasswordResp holds the temporary password. It is shown once and
+// never stored in plaintext.
+type ResetPasswordResp struct {
+	TemporaryPassword string `json:"temporary_password"`
+}
+
+// ResetPassword replaces the password with a generated one and ends every
+// session of the user.
+func (s *Service) ResetPassword(ctx context.Context, req UserActionReq) (*ResetPasswordResp, error) {
+	plaintext, password, err := user.NewTemporaryPassword()
+	if err != nil {
+		logr.Get().Errorf("failed to generate temporary password: %v", err)
+		return nil, fmt.Errorf("failed to generate temporary password: %w", err)
+	}
+
+	err = s.act(ctx, req, audit.ActionAdminPasswordReset, func(ctx context.Context, u *ports.User) (map[string]string, error) {
+		if err := s.userRepo.UpdatePassword(ctx, u.ID.String(), password, time.Now()); err != nil {
+			logr.Get().Errorf("failed to update password: %v", err)
+			return nil, fmt.Errorf("failed to update password: %w", err)
+		}
+		return nil, s.endSessions(ctx, u)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResetPasswordResp{TemporaryPassword: plaintext}, nil
+}
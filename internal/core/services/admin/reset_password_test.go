@@ -0,0 +1,54 @@
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+)
+
+func TestResetPassword(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success - new password and no sessions", func(t *testing.T) {
+		f := newFixture()
+		id := f.target.ID.String()
+
+		var stored user.Password
+		f.expectTarget()
+		f.users.On("UpdatePassword", mock.Anything, id, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { stored = args.Get(2).(user.Password) }).
+			Return(nil)
+		f.auth.On("RevokeAll", mock.Anything, id, mock.Anything).Return(nil)
+
+		resp, err := f.svc.ResetPassword(ctx, f.req())
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, resp.TemporaryPassword)
+		assert.True(t, stored.Verify(resp.TemporaryPassword), "expected the hash of the temporary password stored")
+
+		event := f.assertAudited(t, audit.ActionAdminPasswordReset)
+		assert.NotContains(t, event.Metadata, "password")
+		f.auth.AssertExpectations(t)
+	})
+
+	t.Run("error - sessions not revoked", func(t *testing.T) {
+		f := newFixture()
+		f.expectTarget()
+		f.users.On("UpdatePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		f.auth.On("RevokeAll", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+		resp, err := f.svc.ResetPassword(ctx, f.req())
+
+		assert.EqualError(t, err, "failed to revoke sessions: db error")
+		assert.Nil(t, resp)
+		assert.Empty(t, f.audit.Events())
+		assert.Equal(t, 1, f.uow.Rollbacks())
+	})
+}
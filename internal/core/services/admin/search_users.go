@@ -0,0 +1,103 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// SearchUsersReq filters on any part of the username or email and on the
+// creation date, from CreatedAfter up to but excluding CreatedBefore.
+type SearchUsersReq struct {
+	Username      string
+	Email         string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+
+	Limit  int
+	Offset int
+}
+
+type UserSummary struct {
+	ID        uuid.UUID          `json:"id"`
+	Username  user.Username      `json:"username"`
+	Email     user.Email         `json:"email"`
+	FullName  string             `json:"full_name"`
+	Roles     user.Roles         `json:"roles"`
+	Status    user.AccountStatus `json:"status"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+type SearchUsersResp struct {
+	Users  []UserSummary `json:"users"`
+	Total  int           `json:"total"`
+	Limit  int           `json:"limit"`
+	Offset int           `json:"offset"`
+}
+
+func (s *Service) SearchUsers(ctx context.Context, req SearchUsersReq) (*SearchUsersResp, error) {
+	if req.CreatedAfter != nil && req.CreatedBefore != nil && !req.CreatedAfter.Before(*req.CreatedBefore) {
+		logr.Get().Errorf("invalid search range: %v to %v", req.CreatedAfter, req.CreatedBefore)
+		return nil, ErrInvalidDateRange
+	}
+
+	filter := ports.UserFilter{
+		Username:      req.Username,
+		Email:         req.Email,
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+	}
+	filter.Limit, filter.Offset = page(req.Limit, req.Offset)
+
+	found, total, err := s.userRepo.Search(ctx, filter)
+	if err != nil {
+		logr.Get().Errorf("failed to search users: %v", err)
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	resp := &SearchUsersResp{
+		Users:  make([]UserSummary, 0, len(found)),
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+	for _, u := range found {
+		resp.Users = append(resp.Users, toSummary(u))
+	}
+
+	return resp, nil
+}
+
+func toSummary(u *ports.User) UserSummary {
+	return UserSummary{
+		ID:        u.ID,
+		Username:  u.Username,
+		Email:     u.Email,
+		FullName:  u.FullName,
+		Roles:     user.StringsToRoles(u.Roles),
+		Status:    u.Status,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+}
+
+// page bounds the requested page, a missing limit means DefaultPageSize.
+func page(limit, offset int) (int, int) {
+	limit = min(limit, MaxPageSize)
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	return limit, max(offset, 0)
+}
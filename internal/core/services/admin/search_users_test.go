@@ -0,0 +1,93 @@
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
+)
+
+func TestSearchUsers(t *testing.T) {
+	ctx := context.Background()
+	after := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := after.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name        string
+		req         admin.SearchUsersReq
+		filter      ports.UserFilter
+		repoErr     error
+		expectedErr error
+	}{
+		{
+			name:   "defaults the page size",
+			req:    admin.SearchUsersReq{Username: "jane"},
+			filter: ports.UserFilter{Username: "jane", Limit: admin.DefaultPageSize},
+		},
+		{
+			name:   "caps the page size",
+			req:    admin.SearchUsersReq{Email: "example.com", Limit: 1000, Offset: 40},
+			filter: ports.UserFilter{Email: "example.com", Limit: admin.MaxPageSize, Offset: 40},
+		},
+		{
+			name:   "ignores negative offsets",
+			req:    admin.SearchUsersReq{Limit: 5, Offset: -1},
+			filter: ports.UserFilter{Limit: 5},
+		},
+		{
+			name:   "filters by creation date",
+			req:    admin.SearchUsersReq{CreatedAfter: &after, CreatedBefore: &before},
+			filter: ports.UserFilter{CreatedAfter: &after, CreatedBefore: &before, Limit: admin.DefaultPageSize},
+		},
+		{
+			name:        "error - empty date range",
+			req:         admin.SearchUsersReq{CreatedAfter: &before, CreatedBefore: &after},
+			expectedErr: admin.ErrInvalidDateRange,
+		},
+		{
+			name:        "error - repo fails",
+			req:         admin.SearchUsersReq{},
+			filter:      ports.UserFilter{Limit: admin.DefaultPageSize},
+			repoErr:     errors.New("db error"),
+			expectedErr: errors.New("failed to search users: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture()
+			if tt.expectedErr == nil || tt.repoErr != nil {
+				if tt.repoErr != nil {
+					f.users.On("Search", mock.Anything, tt.filter).Return(nil, 0, tt.repoErr)
+				} else {
+					f.users.On("Search", mock.Anything, tt.filter).Return([]*ports.User{f.target}, 41, nil)
+				}
+			}
+
+			resp, err := f.svc.SearchUsers(ctx, tt.req)
+
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, 41, resp.Total)
+				assert.Equal(t, tt.filter.Limit, resp.Limit)
+				assert.Equal(t, tt.filter.Offset, resp.Offset)
+				require.Len(t, resp.Users, 1)
+				assert.Equal(t, f.target.ID, resp.Users[0].ID)
+				assert.Equal(t, user.Roles{user.RoleUser}, resp.Users[0].Roles)
+			}
+
+			f.users.AssertExpectations(t)
+		})
+	}
+}
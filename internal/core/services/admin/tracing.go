@@ -0,0 +1,96 @@
+package admin
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
+
+// tracedService records a span around every AdminService call.
+type tracedService struct {
+	next   AdminService
+	tracer trace.Tracer
+}
+
+// NewTracedService wraps svc so every call records a span named after the
+// method, marked as failed when the call returns an error.
+func NewTracedService(svc AdminService, tp trace.TracerProvider) AdminService {
+	return &tracedService{next: svc, tracer: tp.Tracer(instrumentationName)}
+}
+
+func (s *tracedService) start(ctx context.Context, method string) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "admin.Service."+method)
+}
+
+func end(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+	return err
+}
+
+func (s *tracedService) SearchUsers(ctx context.Context, req SearchUsersReq) (*SearchUsersResp, error) {
+	ctx, span := s.start(ctx, "SearchUsers")
+	resp, err := s.next.SearchUsers(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) GetUser(ctx context.Context, req GetUserReq) (*GetUserResp, error) {
+	ctx, span := s.start(ctx, "GetUser")
+	resp, err := s.next.GetUser(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) UpdateUser(ctx context.Context, req UpdateUserReq) error {
+	ctx, span := s.start(ctx, "UpdateUser")
+	return end(span, s.next.UpdateUser(ctx, req))
+}
+
+func (s *tracedService) ResetPassword(ctx context.Context, req UserActionReq) (*ResetPasswordResp, error) {
+	ctx, span := s.start(ctx, "ResetPassword")
+	resp, err := s.next.ResetPassword(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) VerifyEmail(ctx context.Context, req UserActionReq) error {
+	ctx, span := s.start(ctx, "VerifyEmail")
+	return end(span, s.next.VerifyEmail(ctx, req))
+}
+
+func (s *tracedService) SuspendUser(ctx context.Context, req SuspendUserReq) error {
+	ctx, span := s.start(ctx, "SuspendUser")
+	return end(span, s.next.SuspendUser(ctx, req))
+}
+
+func (s *tracedService) UnsuspendUser(ctx context.Context, req UserActionReq) error {
+	ctx, span := s.start(ctx, "UnsuspendUser")
+	return end(span, s.next.UnsuspendUser(ctx, req))
+}
+
+func (s *tracedService) ForceLogout(ctx context.Context, req UserActionReq) error {
+	ctx, span := s.start(ctx, "ForceLogout")
+	return end(span, s.next.ForceLogout(ctx, req))
+}
+
+func (s *tracedService) GrantRole(ctx context.Context, req ManageRoleReq) (*ManageRoleResp, error) {
+	ctx, span := s.start(ctx, "GrantRole")
+	resp, err := s.next.GrantRole(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) RevokeRole(ctx context.Context, req ManageRoleReq) (*ManageRoleResp, error) {
+	ctx, span := s.start(ctx, "RevokeRole")
+	resp, err := s.next.RevokeRole(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) SearchAuditEvents(ctx context.Context, req SearchAuditEventsReq) (*SearchAuditEventsResp, error) {
+	ctx, span := s.start(ctx, "SearchAuditEvents")
+	resp, err := s.next.SearchAuditEvents(ctx, req)
+	return resp, end(span, err)
+}
@@ -0,0 +1,34 @@
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
+)
+
+func TestTracedService(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	f := newFixture()
+	f.users.On("GetByID", mock.Anything, f.target.ID.String()).Return(nil, ports.ErrUserNotFound)
+
+	svc := admin.NewTracedService(f.svc, tp)
+
+	err := svc.ForceLogout(context.Background(), f.req())
+	require.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "admin.Service.ForceLogout", spans[0].Name)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+}
@@ -0,0 +1,50 @@
+package admin
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+// UpdateUserReq edits profile fields, empty fields are left unchanged.
+type UpdateUserReq struct {
+	UserActionReq
+	Username  string
+	Email     string
+	FirstName string
+	LastName  string
+}
+
+func (s *Service) UpdateUser(ctx context.Context, req UpdateUserReq) error {
+	return s.act(ctx, req.UserActionReq, audit.ActionAdminUserUpdated, func(ctx context.Context, u *ports.User) (map[string]string, error) {
+		err := s.accounts.Update(ctx, users.UpdateUserReq{
+			ID:        u.ID.String(),
+			Username:  req.Username,
+			Email:     req.Email,
+			FirstName: req.FirstName,
+			LastName:  req.LastName,
+			// Staff rename accounts, such as offensive names, at any time
+			SkipCooldown: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// Only which fields changed, the values are personal data
+		var fields []string
+		if req.Username != "" {
+			fields = append(fields, "username")
+		}
+		if req.Email != "" {
+			fields = append(fields, "email")
+		}
+		if req.FirstName != "" || req.LastName != "" {
+			fields = append(fields, "name")
+		}
+
+		return map[string]string{"fields": strings.Join(fields, ",")}, nil
+	})
+}
@@ -0,0 +1,47 @@
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/admin"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+func TestUpdateUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success - audits the changed fields", func(t *testing.T) {
+		f := newFixture()
+		f.expectTarget()
+		f.accounts.On("Update", mock.Anything, users.UpdateUserReq{
+			ID:           f.target.ID.String(),
+			Email:        "new@example.com",
+			LastName:     "Smith",
+			SkipCooldown: true,
+		}).Return(nil)
+
+		err := f.svc.UpdateUser(ctx, admin.UpdateUserReq{UserActionReq: f.req(), Email: "new@example.com", LastName: "Smith"})
+		require.NoError(t, err)
+
+		event := f.assertAudited(t, audit.ActionAdminUserUpdated)
+		assert.Equal(t, map[string]string{"fields": "email,name"}, event.Metadata)
+		f.accounts.AssertExpectations(t)
+	})
+
+	t.Run("error - update refused", func(t *testing.T) {
+		f := newFixture()
+		f.expectTarget()
+		f.accounts.On("Update", mock.Anything, mock.Anything).Return(users.ErrDuplicateUsername)
+
+		err := f.svc.UpdateUser(ctx, admin.UpdateUserReq{UserActionReq: f.req(), Username: "taken"})
+
+		assert.ErrorIs(t, err, users.ErrDuplicateUsername)
+		assert.Empty(t, f.audit.Events())
+	})
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+const (
+	DefaultActivityLimit = 20
+	MaxActivityLimit     = 100
+)
+
+// record appends an event the user caused on their own account. Failures
+// are logged and otherwise ignored, see WithAuditLog.
+func (s *Service) record(ctx context.Context, action audit.Action, userID uuid.UUID, metadata map[string]string) {
+	if s.auditLog == nil {
+		return
+	}
+
+	event, err := audit.NewEvent(action, userID, userID, metadata)
+	if err != nil {
+		logr.Get().Errorf("failed to create audit event: %v", err)
+		return
+	}
+
+	if err := s.auditLog.Record(ctx, event.WithClient(audit.ClientFromContext(ctx))); err != nil {
+		logr.Get().Errorf("failed to record audit event: %v", err)
+	}
+}
+
+type SecurityActivityReq struct {
+	UserID uuid.UUID
+	Limit  int
+}
+
+type SecurityActivityResp struct {
+	Events []audit.Event
+}
+
+// SecurityActivity returns the latest security events on the user's account,
+// newest first. Where an admin acted, their address and user agent are left
+// out.
+func (s *Service) SecurityActivity(ctx context.Context, req SecurityActivityReq) (SecurityActivityResp, error) {
+	if s.auditLog == nil {
+		return SecurityActivityResp{Events: []audit.Event{}}, nil
+	}
+
+	limit := min(req.Limit, MaxActivityLimit)
+	if limit <= 0 {
+		limit = DefaultActivityLimit
+	}
+
+	events, _, err := s.auditLog.Search(ctx, ports.AuditFilter{
+		TargetID: &req.UserID,
+		Actions:  audit.SecurityActions(),
+		Limit:    limit,
+	})
+	if err != nil {
+		logr.Get().Errorf("failed to get security activity: %v", err)
+		return SecurityActivityResp{}, fmt.Errorf("failed to get security activity: %w", err)
+	}
+
+	activity := make([]audit.Event, 0, len(events))
+	for _, event := range events {
+		if !event.BySelf() {
+			event = event.WithClient(audit.Client{})
+		}
+		activity = append(activity, event)
+	}
+
+	return SecurityActivityResp{Events: activity}, nil
+}
@@ -0,0 +1,187 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	domain "github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+)
+
+func actionsOf(events []audit.Event) []audit.Action {
+	var actions []audit.Action
+	for _, event := range events {
+		actions = append(actions, event.Action)
+	}
+	return actions
+}
+
+func TestAudit_RecordsSessionEvents(t *testing.T) {
+	u := newLoginUser(t)
+	userRepo, authRepo := new(MockUserRepo), new(MockAuthRepo)
+	userRepo.On("GetByUsername", mock.Anything, "janedoe").Return(u, nil)
+	userRepo.On("GetByID", mock.Anything, u.ID.String()).Return(u, nil)
+	authRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+	authRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	session, err := domain.NewRefreshToken(u.ID, time.Hour)
+	require.NoError(t, err)
+	authRepo.On("GetByToken", mock.Anything, mock.Anything).Return(&session, nil)
+
+	auditLog := memory.NewAuditLog()
+	svc := auth.NewService(authRepo, userRepo, auth.WithAuditLog(auditLog))
+	ctx := audit.ContextWithClient(context.Background(), audit.Client{IP: "203.0.113.7", UserAgent: "Mozilla/5.0"})
+
+	_, err = svc.Login(ctx, auth.LoginReq{Username: "janedoe", Password: "wrong"})
+	require.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	// The failure is recorded in the background
+	require.Eventually(t, func() bool { return len(auditLog.Events()) == 1 }, time.Second, 5*time.Millisecond)
+
+	login, err := svc.Login(ctx, auth.LoginReq{Username: "janedoe", Password: "correct-horse"})
+	require.NoError(t, err)
+
+	refreshed, err := svc.Refresh(ctx, auth.RefreshReq{Token: login.RefreshToken})
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Logout(ctx, auth.LogoutReq{Token: refreshed.Token}))
+
+	events := auditLog.Events()
+	assert.Equal(t, []audit.Action{
+		audit.ActionLoginFailed,
+		audit.ActionLoginSucceeded,
+		audit.ActionTokenRefreshed,
+		audit.ActionTokenRevoked,
+	}, actionsOf(events))
+
+	for _, event := range events {
+		assert.Equal(t, u.ID, event.ActorID)
+		assert.Equal(t, u.ID, event.TargetID)
+		assert.Equal(t, "203.0.113.7", event.IP)
+		assert.Equal(t, "Mozilla/5.0", event.UserAgent)
+	}
+	assert.Equal(t, "invalid_password", events[0].Metadata["reason"])
+	assert.Equal(t, "password", events[1].Metadata["method"])
+	assert.Equal(t, "logout", events[3].Metadata["reason"])
+}
+
+func TestAudit_SkipsUnknownUsernames(t *testing.T) {
+	userRepo := new(MockUserRepo)
+	userRepo.On("GetByUsername", mock.Anything, "nobody").Return(nil, ports.ErrUserNotFound)
+
+	auditLog := memory.NewAuditLog()
+	svc := auth.NewService(new(MockAuthRepo), userRepo, auth.WithAuditLog(auditLog))
+
+	_, err := svc.Login(context.Background(), auth.LoginReq{Username: "nobody", Password: "wrong"})
+	require.ErrorIs(t, err, auth.ErrInvalidCredentials)
+
+	assert.Empty(t, auditLog.Events())
+}
+
+// blockingAuditLog holds every Record until release is closed.
+type blockingAuditLog struct {
+	*memory.AuditLog
+	release chan struct{}
+}
+
+func (l *blockingAuditLog) Record(ctx context.Context, event audit.Event) error {
+	<-l.release
+	return l.AuditLog.Record(ctx, event)
+}
+
+func TestAudit_WrongPasswordDoesNotWaitForTheLog(t *testing.T) {
+	u := newLoginUser(t)
+	userRepo := new(MockUserRepo)
+	userRepo.On("GetByUsername", mock.Anything, "janedoe").Return(u, nil)
+
+	auditLog := &blockingAuditLog{AuditLog: memory.NewAuditLog(), release: make(chan struct{})}
+	svc := auth.NewService(new(MockAuthRepo), userRepo, auth.WithAuditLog(auditLog))
+
+	// Unknown usernames write nothing, an existing one must not take longer
+	// for the write it causes
+	done := make(chan error, 1)
+	go func() {
+		_, err := svc.Login(context.Background(), auth.LoginReq{Username: "janedoe", Password: "wrong"})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	case <-time.After(10 * time.Second):
+		// The log never returns before release, a login waiting on it hangs
+		t.Fatal("login waited for the audit log")
+	}
+
+	close(auditLog.release)
+	require.Eventually(t, func() bool { return len(auditLog.Events()) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, audit.ActionLoginFailed, auditLog.Events()[0].Action)
+}
+
+func TestAudit_FailuresDoNotBlockLogin(t *testing.T) {
+	u := newLoginUser(t)
+	userRepo, authRepo := new(MockUserRepo), new(MockAuthRepo)
+	userRepo.On("GetByUsername", mock.Anything, "janedoe").Return(u, nil)
+	authRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+	auditLog := new(MockAuditLog)
+	auditLog.On("Record", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+
+	svc := auth.NewService(authRepo, userRepo, auth.WithAuditLog(auditLog))
+
+	_, err := svc.Login(context.Background(), auth.LoginReq{Username: "janedoe", Password: "correct-horse"})
+	assert.NoError(t, err)
+	auditLog.AssertCalled(t, "Record", mock.Anything, mock.Anything)
+}
+
+func TestSecurityActivity(t *testing.T) {
+	ctx := context.Background()
+	userID, adminID := uuid.New(), uuid.New()
+	self := audit.Client{IP: "203.0.113.7", UserAgent: "Mozilla/5.0"}
+
+	auditLog := memory.NewAuditLog()
+	record := func(action audit.Action, actor, target uuid.UUID) {
+		event, err := audit.NewEvent(action, actor, target, nil)
+		require.NoError(t, err)
+		require.NoError(t, auditLog.Record(ctx, event.WithClient(self)))
+	}
+	record(audit.ActionLoginSucceeded, userID, userID)
+	record(audit.ActionTokenRefreshed, userID, userID)
+	record(audit.ActionPaymentRecorded, userID, userID)
+	record(audit.ActionLoginSucceeded, uuid.New(), uuid.New())
+	record(audit.ActionAdminSessionsRevoked, adminID, userID)
+
+	svc := auth.NewService(new(MockAuthRepo), new(MockUserRepo), auth.WithAuditLog(auditLog))
+
+	resp, err := svc.SecurityActivity(ctx, auth.SecurityActivityReq{UserID: userID})
+	require.NoError(t, err)
+
+	// Only the user's own security events, newest first
+	require.Equal(t, []audit.Action{audit.ActionAdminSessionsRevoked, audit.ActionLoginSucceeded}, actionsOf(resp.Events))
+
+	// Whoever the admin was, where they connected from stays private
+	assert.Empty(t, resp.Events[0].IP)
+	assert.Empty(t, resp.Events[0].UserAgent)
+	assert.Equal(t, "203.0.113.7", resp.Events[1].IP)
+
+	limited, err := svc.SecurityActivity(ctx, auth.SecurityActivityReq{UserID: userID, Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, limited.Events, 1)
+}
+
+func TestSecurityActivity_WithoutAuditLog(t *testing.T) {
+	svc := auth.NewService(new(MockAuthRepo), new(MockUserRepo))
+
+	resp, err := svc.SecurityActivity(context.Background(), auth.SecurityActivityReq{UserID: uuid.New()})
+	require.NoError(t, err)
+	assert.NotNil(t, resp.Events)
+	assert.Empty(t, resp.Events)
+}
@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+type CreateAPIKeyReq struct {
+	UserID    string     `json:"-"`
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreateAPIKeyResp carries the plaintext key, it is never returned again.
+type CreateAPIKeyResp struct {
+	Key       *auth.APIKey `json:"key"`
+	Plaintext string       `json:"api_key"`
+}
+
+func (s *Service) CreateAPIKey(ctx context.Context, req CreateAPIKeyReq) (CreateAPIKeyResp, error) {
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		logr.Get().Errorf("invalid user id: %v", err)
+		return CreateAPIKeyResp{}, fmt.Errorf("invalid user id: %w", err)
+	}
+
+	scopes, err := auth.NewScopes(req.Scopes)
+	if err != nil {
+		logr.Get().Errorf("invalid scopes: %v", err)
+		return CreateAPIKeyResp{}, fmt.Errorf("invalid scopes: %w", err)
+	}
+
+	key, plaintext, err := auth.NewAPIKey(userID, req.Name, scopes, req.ExpiresAt)
+	if err != nil {
+		logr.Get().Errorf("failed to create api key: %v", err)
+		return CreateAPIKeyResp{}, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	if err := s.apiKeyRepo.Add(ctx, key); err != nil {
+		logr.Get().Errorf("failed to add api key: %v", err)
+		return CreateAPIKeyResp{}, fmt.Errorf("failed to add api key: %w", err)
+	}
+
+	return CreateAPIKeyResp{Key: &key, Plaintext: plaintext}, nil
+}
+
+type ListAPIKeysReq struct {
+	UserID string
+}
+
+type ListAPIKeysResp struct {
+	Keys []*auth.APIKey
+}
+
+func (s *Service) ListAPIKeys(ctx context.Context, req ListAPIKeysReq) (ListAPIKeysResp, error) {
+	keys, err := s.apiKeyRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		logr.Get().Errorf("failed to get api keys: %v", err)
+		return ListAPIKeysResp{}, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	return ListAPIKeysResp{Keys: keys}, nil
+}
+
+type RevokeAPIKeyReq struct {
+	UserID string
+	KeyID  string
+}
+
+func (s *Service) RevokeAPIKey(ctx context.Context, req RevokeAPIKeyReq) error {
+	key, err := s.apiKeyRepo.GetByID(ctx, req.KeyID)
+	if err != nil {
+		logr.Get().Errorf("failed to get api key: %v", err)
+		return fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	// Other users' keys are reported as missing rather than forbidden
+	if key.UserID.String() != req.UserID {
+		logr.Get().Error("api key belongs to another user")
+		return ports.ErrAPIKeyNotFound
+	}
+
+	if key.IsRevoked() {
+		return nil
+	}
+
+	if err := s.apiKeyRepo.Revoke(ctx, key.ID.String(), time.Now()); err != nil {
+		logr.Get().Errorf("failed to revoke api key: %v", err)
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return nil
+}
+
+type AuthenticateAPIKeyReq struct {
+	Key string
+}
+
+type AuthenticateAPIKeyResp struct {
+	UserID uuid.UUID
+	Roles  []string
+	Scopes auth.Scopes
+}
+
+// AuthenticateAPIKey resolves a plaintext key to its owner. Every failure is
+// reported as ErrInvalidAPIKey so callers cannot probe which keys exist.
+func (s *Service) AuthenticateAPIKey(ctx context.Context, req AuthenticateAPIKeyReq) (AuthenticateAPIKeyResp, error) {
+	prefix, err := auth.ParseAPIKeyPrefix(req.Key)
+	if err != nil {
+		logr.Get().Infof("malformed api key: %v", err)
+		return AuthenticateAPIKeyResp{}, ErrInvalidAPIKey
+	}
+
+	key, err := s.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, ports.ErrAPIKeyNotFound) {
+			logr.Get().Info("unknown api key")
+			return AuthenticateAPIKeyResp{}, ErrInvalidAPIKey
+		}
+		logr.Get().Errorf("failed to get api key: %v", err)
+		return AuthenticateAPIKeyResp{}, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	if !key.Verify(req.Key) || !key.IsActive() {
+		logr.Get().Infof("rejected api key %s", key.Prefix)
+		return AuthenticateAPIKeyResp{}, ErrInvalidAPIKey
+	}
+
+	user, err := s.getUser(ctx, key.UserID.String())
+	if err != nil {
+		return AuthenticateAPIKeyResp{}, err
+	}
+
+	if user.Status.IsSuspended() || user.Status.IsPendingDeletion() {
+		logr.Get().Infof("api key %s of suspended or deleted user refused", key.Prefix)
+		return AuthenticateAPIKeyResp{}, ErrInvalidAPIKey
+	}
+
+	if key.MarkUsed(time.Now()) {
+		// Usage tracking must not fail the request
+		if err := s.apiKeyRepo.Touch(ctx, key.ID.String(), *key.LastUsedAt); err != nil {
+			logr.Get().Errorf("failed to record api key use: %v", err)
+		}
+	}
+
+	return AuthenticateAPIKeyResp{
+		UserID: user.ID,
+		Roles:  user.Roles,
+		Scopes: key.Scopes,
+	}, nil
+}
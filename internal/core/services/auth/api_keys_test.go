@@ -0,0 +1,209 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		req         auth.CreateAPIKeyReq
+		setupMock   func(repo *MockAPIKeyRepo)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			req:  auth.CreateAPIKeyReq{UserID: userID.String(), Name: "importer", Scopes: []string{"workouts:write"}},
+			setupMock: func(repo *MockAPIKeyRepo) {
+				repo.On("Add", mock.Anything, mock.MatchedBy(func(k domain.APIKey) bool {
+					return k.UserID == userID && k.Name == "importer" && k.Hash != ""
+				})).Return(nil)
+			},
+		},
+		{
+			name:        "error - unknown scope",
+			req:         auth.CreateAPIKeyReq{UserID: userID.String(), Name: "importer", Scopes: []string{"users:delete"}},
+			setupMock:   func(repo *MockAPIKeyRepo) {},
+			expectedErr: domain.ErrInvalidScope,
+		},
+		{
+			name:        "error - no scopes",
+			req:         auth.CreateAPIKeyReq{UserID: userID.String(), Name: "importer"},
+			setupMock:   func(repo *MockAPIKeyRepo) {},
+			expectedErr: domain.ErrNoScopes,
+		},
+		{
+			name: "error - repository failure",
+			req:  auth.CreateAPIKeyReq{UserID: userID.String(), Name: "importer", Scopes: []string{"stats:read"}},
+			setupMock: func(repo *MockAPIKeyRepo) {
+				repo.On("Add", mock.Anything, mock.Anything).Return(errors.New("db down"))
+			},
+			expectedErr: errors.New("failed to add api key: db down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockAPIKeyRepo)
+			tt.setupMock(repo)
+
+			svc := auth.NewService(new(MockAuthRepo), new(MockUserRepo), auth.WithAPIKeys(repo))
+			resp, err := svc.CreateAPIKey(context.Background(), tt.req)
+
+			switch {
+			case tt.expectedErr == nil:
+				require.NoError(t, err)
+				assert.NotEmpty(t, resp.Plaintext)
+				assert.True(t, resp.Key.Verify(resp.Plaintext))
+			case errors.Is(err, tt.expectedErr):
+			default:
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	owner := &ports.User{ID: uuid.New(), Roles: []string{"user"}}
+	scopes := domain.Scopes{domain.ScopeStatsRead}
+
+	newKey := func(t *testing.T) (*domain.APIKey, string) {
+		key, plaintext, err := domain.NewAPIKey(owner.ID, "script", scopes, nil)
+		require.NoError(t, err)
+		return &key, plaintext
+	}
+
+	t.Run("success records last use", func(t *testing.T) {
+		key, plaintext := newKey(t)
+		repo := new(MockAPIKeyRepo)
+		userRepo := new(MockUserRepo)
+		repo.On("GetByPrefix", mock.Anything, key.Prefix).Return(key, nil)
+		userRepo.On("GetByID", mock.Anything, owner.ID.String()).Return(owner, nil)
+		repo.On("Touch", mock.Anything, key.ID.String(), mock.AnythingOfType("time.Time")).Return(nil)
+
+		svc := auth.NewService(new(MockAuthRepo), userRepo, auth.WithAPIKeys(repo))
+		resp, err := svc.AuthenticateAPIKey(context.Background(), auth.AuthenticateAPIKeyReq{Key: plaintext})
+
+		require.NoError(t, err)
+		assert.Equal(t, owner.ID, resp.UserID)
+		assert.Equal(t, scopes, resp.Scopes)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("last use failures do not reject the key", func(t *testing.T) {
+		key, plaintext := newKey(t)
+		repo := new(MockAPIKeyRepo)
+		userRepo := new(MockUserRepo)
+		repo.On("GetByPrefix", mock.Anything, key.Prefix).Return(key, nil)
+		userRepo.On("GetByID", mock.Anything, owner.ID.String()).Return(owner, nil)
+		repo.On("Touch", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+		svc := auth.NewService(new(MockAuthRepo), userRepo, auth.WithAPIKeys(repo))
+		_, err := svc.AuthenticateAPIKey(context.Background(), auth.AuthenticateAPIKeyReq{Key: plaintext})
+
+		assert.NoError(t, err)
+	})
+
+	rejected := []struct {
+		name  string
+		setup func(repo *MockAPIKeyRepo) string
+	}{
+		{
+			name:  "malformed key",
+			setup: func(repo *MockAPIKeyRepo) string { return "not-a-key" },
+		},
+		{
+			name: "unknown key",
+			setup: func(repo *MockAPIKeyRepo) string {
+				key, plaintext := newKey(t)
+				repo.On("GetByPrefix", mock.Anything, key.Prefix).Return(nil, ports.ErrAPIKeyNotFound)
+				return plaintext
+			},
+		},
+		{
+			name: "wrong secret",
+			setup: func(repo *MockAPIKeyRepo) string {
+				key, plaintext := newKey(t)
+				repo.On("GetByPrefix", mock.Anything, key.Prefix).Return(key, nil)
+				return plaintext[:len(plaintext)-2] + "xx"
+			},
+		},
+		{
+			name: "revoked key",
+			setup: func(repo *MockAPIKeyRepo) string {
+				key, plaintext := newKey(t)
+				key.Revoke()
+				repo.On("GetByPrefix", mock.Anything, key.Prefix).Return(key, nil)
+				return plaintext
+			},
+		},
+		{
+			name: "expired key",
+			setup: func(repo *MockAPIKeyRepo) string {
+				key, plaintext := newKey(t)
+				expired := time.Now().Add(-time.Minute)
+				key.ExpiresAt = &expired
+				repo.On("GetByPrefix", mock.Anything, key.Prefix).Return(key, nil)
+				return plaintext
+			},
+		},
+	}
+
+	for _, tt := range rejected {
+		t.Run("error - "+tt.name, func(t *testing.T) {
+			repo := new(MockAPIKeyRepo)
+			plaintext := tt.setup(repo)
+
+			svc := auth.NewService(new(MockAuthRepo), new(MockUserRepo), auth.WithAPIKeys(repo))
+			_, err := svc.AuthenticateAPIKey(context.Background(), auth.AuthenticateAPIKeyReq{Key: plaintext})
+
+			assert.ErrorIs(t, err, auth.ErrInvalidAPIKey)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	owner := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		key, _, _ := domain.NewAPIKey(owner, "script", domain.Scopes{domain.ScopeStatsRead}, nil)
+		repo := new(MockAPIKeyRepo)
+		repo.On("GetByID", mock.Anything, key.ID.String()).Return(&key, nil)
+		repo.On("Revoke", mock.Anything, key.ID.String(), mock.AnythingOfType("time.Time")).Return(nil)
+
+		svc := auth.NewService(new(MockAuthRepo), new(MockUserRepo), auth.WithAPIKeys(repo))
+		err := svc.RevokeAPIKey(context.Background(), auth.RevokeAPIKeyReq{UserID: owner.String(), KeyID: key.ID.String()})
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("error - key of another user", func(t *testing.T) {
+		key, _, _ := domain.NewAPIKey(uuid.New(), "script", domain.Scopes{domain.ScopeStatsRead}, nil)
+		repo := new(MockAPIKeyRepo)
+		repo.On("GetByID", mock.Anything, key.ID.String()).Return(&key, nil)
+
+		svc := auth.NewService(new(MockAuthRepo), new(MockUserRepo), auth.WithAPIKeys(repo))
+		err := svc.RevokeAPIKey(context.Background(), auth.RevokeAPIKeyReq{UserID: owner.String(), KeyID: key.ID.String()})
+
+		assert.ErrorIs(t, err, ports.ErrAPIKeyNotFound)
+		repo.AssertExpectations(t)
+	})
+}
@@ -4,14 +4,26 @@ package auth
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
 )
 
 var (
-	ErrPasswordIncorrect   = errors.New("incorrect password")
-	ErrRefreshTokenExpired = errors.New("refresh token expired")
-	ErrRefreshTokenRevoked = errors.New("refresh token revoked")
+	ErrInvalidCredentials     = errors.New("invalid username or password")
+	ErrTooManyAttempts        = errors.New("too many failed login attempts")
+	ErrInvalidUnlockToken     = errors.New("invalid unlock token")
+	ErrRefreshTokenExpired    = errors.New("refresh token expired")
+	ErrRefreshTokenRevoked    = errors.New("refresh token revoked")
+	ErrUnknownProvider        = errors.New("unknown identity provider")
+	ErrInvalidOAuthState      = errors.New("invalid oauth state")
+	ErrEmailNotVerified       = errors.New("provider email not verified")
+	ErrLinkRequired           = errors.New("an account with this email exists, sign in to it and link the provider")
+	ErrInvalidAPIKey          = errors.New("invalid api key")
+	ErrAccountSuspended       = errors.New("account suspended")
+	ErrAccountPendingDeletion = errors.New("account pending deletion")
 )
 
 type AuthService interface {
@@ -19,16 +31,87 @@ type AuthService interface {
 	Logout(ctx context.Context, req LogoutReq) error
 	Revoke(ctx context.Context, req RevokeTokenReq) error
 	Refresh(ctx context.Context, req RefreshReq) (RefreshResp, error)
+	UnlockAccount(ctx context.Context, req UnlockAccountReq) error
+
+	StartExternalLogin(ctx context.Context, req StartExternalLoginReq) (StartExternalLoginResp, error)
+	ExternalLogin(ctx context.Context, req ExternalLoginReq) (LoginResp, error)
+	ListIdentities(ctx context.Context, req ListIdentitiesReq) (ListIdentitiesResp, error)
+
+	CreateAPIKey(ctx context.Context, req CreateAPIKeyReq) (CreateAPIKeyResp, error)
+	ListAPIKeys(ctx context.Context, req ListAPIKeysReq) (ListAPIKeysResp, error)
+	RevokeAPIKey(ctx context.Context, req RevokeAPIKeyReq) error
+	AuthenticateAPIKey(ctx context.Context, req AuthenticateAPIKeyReq) (AuthenticateAPIKeyResp, error)
+
+	SecurityActivity(ctx context.Context, req SecurityActivityReq) (SecurityActivityResp, error)
+}
+
+// AccountProvisioner creates accounts for first time external logins.
+type AccountProvisioner interface {
+	ProvisionAccount(ctx context.Context, req users.ProvisionAccountReq) (*users.CreateAccountResp, error)
 }
 
 type Service struct {
 	authRepo ports.AuthRepo
 	userRepo ports.UserRepo
+
+	identityRepo ports.IdentityRepo
+	accounts     AccountProvisioner
+	providers    map[string]ports.IdentityProvider
+
+	apiKeyRepo ports.APIKeyRepo
+
+	lockout *lockout
+
+	auditLog ports.AuditLog
+
+	refreshTokenTTL time.Duration
 }
 
-func NewService(authRepo ports.AuthRepo, userRepo ports.UserRepo) *Service {
-	return &Service{
-		authRepo: authRepo,
-		userRepo: userRepo,
+type Option func(s *Service)
+
+// WithExternalLogin enables sign in through the given identity providers.
+func WithExternalLogin(identityRepo ports.IdentityRepo, accounts AccountProvisioner, providers ...ports.IdentityProvider) Option {
+	return func(s *Service) {
+		s.identityRepo = identityRepo
+		s.accounts = accounts
+		for _, provider := range providers {
+			s.providers[provider.Name()] = provider
+		}
 	}
 }
+
+// WithAPIKeys enables personal API keys.
+func WithAPIKeys(apiKeyRepo ports.APIKeyRepo) Option {
+	return func(s *Service) { s.apiKeyRepo = apiKeyRepo }
+}
+
+// WithAuditLog records sign ins, failed logins and token changes. Recording
+// is best effort, an audit log outage must not lock everyone out.
+func WithAuditLog(auditLog ports.AuditLog) Option {
+	return func(s *Service) { s.auditLog = auditLog }
+}
+
+// WithRefreshTokenTTL sets how long a session lasts without a refresh.
+func WithRefreshTokenTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		if ttl > 0 {
+			s.refreshTokenTTL = ttl
+		}
+	}
+}
+
+func NewService(authRepo ports.AuthRepo, userRepo ports.UserRepo, opts ...Option) *Service {
+	s := &Service{
+		authRepo:  authRepo,
+		userRepo:  userRepo,
+		providers: make(map[string]ports.IdentityProvider),
+
+		refreshTokenTTL: auth.DefaultRefreshTokenTTL,
+	}
+
+	for _, applyOption := range opts {
+		applyOption(s)
+	}
+
+	return s
+}
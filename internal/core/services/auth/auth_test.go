@@ -0,0 +1,330 @@
+package auth_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+type MockUserRepo struct {
+	mock.Mock
+}
+
+func (m *MockUserRepo) Add(ctx context.Context, u user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetByUsername(ctx context.Context, username string) (*ports.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ports.User), args.Error(1)
+}
+
+func (m *MockUserRepo) GetByEmail(ctx context.Context, email string) (*ports.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ports.User), args.Error(1)
+}
+
+func (m *MockUserRepo) GetByID(ctx context.Context, id string) (*ports.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ports.User), args.Error(1)
+}
+
+func (m *MockUserRepo) Update(ctx context.Context, u user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) UpdateRoles(ctx context.Context, id string, roles user.Roles, updatedAt time.Time) error {
+	args := m.Called(ctx, id, roles, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) UpdatePassword(ctx context.Context, id string, password user.Password, updatedAt time.Time) error {
+	args := m.Called(ctx, id, password, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) UpdateStatus(ctx context.Context, id string, status user.AccountStatus, updatedAt time.Time) error {
+	args := m.Called(ctx, id, status, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) Search(ctx context.Context, filter ports.UserFilter) ([]*ports.User, int, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*ports.User), args.Int(1), args.Error(2)
+}
+
+func (m *MockUserRepo) GetDueForPurge(ctx context.Context, requestedBefore time.Time, limit int) ([]*ports.User, error) {
+	args := m.Called(ctx, requestedBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*ports.User), args.Error(1)
+}
+
+func (m *MockUserRepo) LockForPurge(ctx context.Context, id string, requestedBefore time.Time) error {
+	args := m.Called(ctx, id, requestedBefore)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) AddUsernameChange(ctx context.Context, change user.UsernameChange) error {
+	return m.Called(ctx, change).Error(0)
+}
+
+func (m *MockUserRepo) GetUsernameChanges(ctx context.Context, userID string) ([]user.UsernameChange, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]user.UsernameChange), args.Error(1)
+}
+
+func (m *MockUserRepo) GetUsernameReservation(ctx context.Context, username string, changedAfter time.Time) (*user.UsernameChange, error) {
+	args := m.Called(ctx, username, changedAfter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.UsernameChange), args.Error(1)
+}
+
+func (m *MockUserRepo) AddStats(ctx context.Context, stats user.Stats, userID string) error {
+	args := m.Called(ctx, stats, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetStatsByID(ctx context.Context, userID string) (*user.Stats, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.Stats), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateBodyMetrics(ctx context.Context, stats ports.UpdateBodyMetrics, userID string) error {
+	args := m.Called(ctx, stats, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) AddSubscription(ctx context.Context, sub user.Subscription, userID string) error {
+	args := m.Called(ctx, sub, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetSubscriptionByID(ctx context.Context, userID string) (*user.Subscription, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.Subscription), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateSubscription(ctx context.Context, sub user.Subscription, userID string) error {
+	args := m.Called(ctx, sub, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) AddSettings(ctx context.Context, settings user.Settings, userID string) error {
+	args := m.Called(ctx, settings, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetSettingsByID(ctx context.Context, userID string) (*user.Settings, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.Settings), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateSettings(ctx context.Context, settings user.Settings, userID string) error {
+	args := m.Called(ctx, settings, userID)
+	return args.Error(0)
+}
+
+type MockAuthRepo struct {
+	mock.Mock
+}
+
+func (m *MockAuthRepo) Add(ctx context.Context, refreshToken auth.RefreshToken) error {
+	args := m.Called(ctx, refreshToken)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepo) GetByToken(ctx context.Context, token string) (*auth.RefreshToken, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.RefreshToken), args.Error(1)
+}
+
+func (m *MockAuthRepo) GetByID(ctx context.Context, userID string) ([]*auth.RefreshToken, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*auth.RefreshToken), args.Error(1)
+}
+
+func (m *MockAuthRepo) Update(ctx context.Context, refreshToken auth.RefreshToken) error {
+	args := m.Called(ctx, refreshToken)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepo) RevokeAll(ctx context.Context, userID string, revokedAt time.Time) error {
+	args := m.Called(ctx, userID, revokedAt)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepo) Delete(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+type MockIdentityRepo struct {
+	mock.Mock
+}
+
+func (m *MockIdentityRepo) Add(ctx context.Context, identity auth.Identity) error {
+	args := m.Called(ctx, identity)
+	return args.Error(0)
+}
+
+func (m *MockIdentityRepo) GetByProviderSubject(ctx context.Context, provider, subject string) (*auth.Identity, error) {
+	args := m.Called(ctx, provider, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Identity), args.Error(1)
+}
+
+func (m *MockIdentityRepo) GetByUserID(ctx context.Context, userID string) ([]*auth.Identity, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*auth.Identity), args.Error(1)
+}
+
+type MockAPIKeyRepo struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepo) Add(ctx context.Context, key auth.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepo) GetByPrefix(ctx context.Context, prefix string) (*auth.APIKey, error) {
+	args := m.Called(ctx, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepo) GetByID(ctx context.Context, id string) (*auth.APIKey, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepo) GetByUserID(ctx context.Context, userID string) ([]*auth.APIKey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*auth.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepo) Touch(ctx context.Context, id string, lastUsedAt time.Time) error {
+	args := m.Called(ctx, id, lastUsedAt)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepo) Revoke(ctx context.Context, id string, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(ctx context.Context, msg ports.Message) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
+
+type MockAccountProvisioner struct {
+	mock.Mock
+}
+
+func (m *MockAccountProvisioner) ProvisionAccount(ctx context.Context, req users.ProvisionAccountReq) (*users.CreateAccountResp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*users.CreateAccountResp), args.Error(1)
+}
+
+func TestMain(m *testing.M) {
+	logr.Init(&logr.PlainTextFormatter{}, logr.LevelInfo, nil)
+
+	exitCode := m.Run()
+
+	os.Exit(exitCode)
+}
+
+type MockAuditLog struct {
+	mock.Mock
+}
+
+func (m *MockAuditLog) Record(ctx context.Context, event audit.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockAuditLog) Search(ctx context.Context, filter ports.AuditFilter) ([]audit.Event, int, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]audit.Event), args.Int(1), args.Error(2)
+}
+
+func (m *MockAuditLog) Erase(ctx context.Context, userID, tombstone uuid.UUID) error {
+	args := m.Called(ctx, userID, tombstone)
+	return args.Error(0)
+}
@@ -0,0 +1,215 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+type StartExternalLoginReq struct {
+	Provider string
+}
+
+// StartExternalLoginResp carries the values the caller has to keep until the
+// provider redirects back, the verifier must never reach the browser URL.
+type StartExternalLoginResp struct {
+	AuthURL      string
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+func (s *Service) StartExternalLogin(ctx context.Context, req StartExternalLoginReq) (StartExternalLoginResp, error) {
+	provider, ok := s.providers[req.Provider]
+	if !ok {
+		logr.Get().Errorf("unknown identity provider: %s", req.Provider)
+		return StartExternalLoginResp{}, ErrUnknownProvider
+	}
+
+	state, err := auth.MakeState()
+	if err != nil {
+		logr.Get().Errorf("failed to generate state: %v", err)
+		return StartExternalLoginResp{}, fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	nonce, err := auth.MakeState()
+	if err != nil {
+		logr.Get().Errorf("failed to generate nonce: %v", err)
+		return StartExternalLoginResp{}, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	pkce, err := auth.NewPKCE()
+	if err != nil {
+		logr.Get().Errorf("failed to generate pkce: %v", err)
+		return StartExternalLoginResp{}, fmt.Errorf("failed to generate pkce: %w", err)
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, pkce.Challenge)
+	if err != nil {
+		logr.Get().Errorf("failed to build authorization url: %v", err)
+		return StartExternalLoginResp{}, fmt.Errorf("failed to build authorization url: %w", err)
+	}
+
+	return StartExternalLoginResp{
+		AuthURL:      authURL,
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: pkce.Verifier,
+	}, nil
+}
+
+type ExternalLoginReq struct {
+	Provider      string
+	Code          string
+	State         string
+	ExpectedState string
+	Nonce         string
+	CodeVerifier  string
+	// LinkUserID links the identity to an already signed in user instead of
+	// logging in with it.
+	LinkUserID *uuid.UUID
+	// Restore signs in to an account pending deletion and cancels the
+	// deletion.
+	Restore bool
+}
+
+func (s *Service) ExternalLogin(ctx context.Context, req ExternalLoginReq) (LoginResp, error) {
+	provider, ok := s.providers[req.Provider]
+	if !ok {
+		logr.Get().Errorf("unknown identity provider: %s", req.Provider)
+		return LoginResp{}, ErrUnknownProvider
+	}
+
+	if !auth.StateMatches(req.ExpectedState, req.State) {
+		logr.Get().Error("oauth state mismatch")
+		return LoginResp{}, ErrInvalidOAuthState
+	}
+
+	external, err := provider.Exchange(ctx, req.Code, req.CodeVerifier, req.Nonce)
+	if err != nil {
+		logr.Get().Errorf("failed to exchange authorization code: %v", err)
+		return LoginResp{}, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	user, err := s.resolveIdentity(ctx, provider.Name(), external, req.LinkUserID)
+	if err != nil {
+		return LoginResp{}, err
+	}
+
+	logr.Get().Infof("user signed in with %s", provider.Name())
+	return s.startSession(ctx, user, provider.Name(), req.Restore)
+}
+
+// resolveIdentity finds the user behind an external identity. Unknown
+// identities are linked to the signed in user, to the account with the same
+// email when both sides verified it, or to a freshly provisioned account, in
+// that order.
+func (s *Service) resolveIdentity(ctx context.Context, provider string, external *ports.ExternalIdentity, linkUserID *uuid.UUID) (*ports.User, error) {
+	identity, err := s.identityRepo.GetByProviderSubject(ctx, provider, external.Subject)
+	if err != nil && !errors.Is(err, ports.ErrIdentityNotFound) {
+		logr.Get().Errorf("failed to get identity: %v", err)
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	if identity != nil {
+		if linkUserID != nil && *linkUserID != identity.UserID {
+			logr.Get().Error("identity already linked to another user")
+			return nil, ports.ErrIdentityLinked
+		}
+		return s.getUser(ctx, identity.UserID.String())
+	}
+
+	var user *ports.User
+	switch {
+	case linkUserID != nil:
+		user, err = s.getUser(ctx, linkUserID.String())
+	default:
+		user, err = s.userForExternal(ctx, external)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	newIdentity, err := auth.NewIdentity(provider, external.Subject, user.ID, external.Email)
+	if err != nil {
+		logr.Get().Errorf("invalid identity: %v", err)
+		return nil, fmt.Errorf("invalid identity: %w", err)
+	}
+
+	if err := s.identityRepo.Add(ctx, newIdentity); err != nil {
+		logr.Get().Errorf("failed to link identity: %v", err)
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *Service) userForExternal(ctx context.Context, external *ports.ExternalIdentity) (*ports.User, error) {
+	if !external.EmailVerified {
+		// An unverified email could be used to take over an existing account
+		logr.Get().Error("provider email not verified")
+		return nil, ErrEmailNotVerified
+	}
+
+	existing, err := s.userRepo.GetByEmail(ctx, external.Email)
+	if err != nil && !errors.Is(err, ports.ErrUserNotFound) {
+		logr.Get().Errorf("failed to check email: %v", err)
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+	if existing != nil {
+		// Anyone can sign up with an email they do not own, linking to such an
+		// account would hand the identity to whoever registered first.
+		if !existing.Status.IsEmailVerified() {
+			logr.Get().Error("local account email not verified")
+			return nil, ErrLinkRequired
+		}
+		return existing, nil
+	}
+
+	resp, err := s.accounts.ProvisionAccount(ctx, users.ProvisionAccountReq{
+		Email:         external.Email,
+		FirstName:     external.FirstName,
+		LastName:      external.LastName,
+		EmailVerified: external.EmailVerified,
+	})
+	if err != nil {
+		logr.Get().Errorf("failed to provision account: %v", err)
+		return nil, fmt.Errorf("failed to provision account: %w", err)
+	}
+
+	return s.getUser(ctx, resp.UserID)
+}
+
+func (s *Service) getUser(ctx context.Context, id string) (*ports.User, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		logr.Get().Errorf("failed to get user: %v", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+type ListIdentitiesReq struct {
+	UserID string
+}
+
+type ListIdentitiesResp struct {
+	Identities []*auth.Identity
+}
+
+func (s *Service) ListIdentities(ctx context.Context, req ListIdentitiesReq) (ListIdentitiesResp, error) {
+	identities, err := s.identityRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		logr.Get().Errorf("failed to get identities: %v", err)
+		return ListIdentitiesResp{}, fmt.Errorf("failed to get identities: %w", err)
+	}
+
+	return ListIdentitiesResp{Identities: identities}, nil
+}
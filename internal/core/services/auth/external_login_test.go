@@ -0,0 +1,287 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/oidc"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/external/oidc/oidctest"
+	domain "github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+type externalLoginDeps struct {
+	authRepo     *MockAuthRepo
+	userRepo     *MockUserRepo
+	identityRepo *MockIdentityRepo
+	accounts     *MockAccountProvisioner
+}
+
+// runExternalLogin drives the full authorization code flow against the local
+// mock provider, the way the web handlers do.
+func runExternalLogin(t *testing.T, server *oidctest.Server, svc *auth.Service, linkUserID *uuid.UUID) (auth.LoginResp, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	start, err := svc.StartExternalLogin(ctx, auth.StartExternalLoginReq{Provider: "mock"})
+	require.NoError(t, err)
+
+	code, state, err := server.Authorize(start.AuthURL)
+	require.NoError(t, err)
+
+	return svc.ExternalLogin(ctx, auth.ExternalLoginReq{
+		Provider:      "mock",
+		Code:          code,
+		State:         state,
+		ExpectedState: start.State,
+		Nonce:         start.Nonce,
+		CodeVerifier:  start.CodeVerifier,
+		LinkUserID:    linkUserID,
+	})
+}
+
+func TestExternalLogin(t *testing.T) {
+	server := oidctest.NewServer("fitrkr-test")
+	defer server.Close()
+
+	provider, err := oidc.NewProvider(oidc.Config{
+		Name:        "mock",
+		IssuerURL:   server.URL,
+		ClientID:    server.ClientID,
+		RedirectURL: "http://localhost:8000/api/v1/auth/oauth/mock/callback",
+	})
+	require.NoError(t, err)
+
+	verifiedAt := time.Now().Add(-24 * time.Hour)
+	existingUser := &ports.User{
+		ID:     uuid.New(),
+		Email:  "jane.doe@example.com",
+		Roles:  []string{"user"},
+		Status: user.AccountStatus{EmailVerifiedAt: &verifiedAt},
+	}
+
+	tests := []struct {
+		name        string
+		user        oidctest.User
+		linkUserID  *uuid.UUID
+		setupMock   func(d externalLoginDeps)
+		expectedErr error
+		wantUserID  uuid.UUID
+	}{
+		{
+			name: "success - known identity logs in",
+			user: oidctest.User{Subject: "sub-1", Email: "jane.doe@example.com", EmailVerified: true},
+			setupMock: func(d externalLoginDeps) {
+				d.identityRepo.On("GetByProviderSubject", mock.Anything, "mock", "sub-1").
+					Return(&domain.Identity{Provider: "mock", Subject: "sub-1", UserID: existingUser.ID}, nil)
+				d.userRepo.On("GetByID", mock.Anything, existingUser.ID.String()).Return(existingUser, nil)
+				d.authRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantUserID: existingUser.ID,
+		},
+		{
+			name: "success - new identity links to account with same verified email",
+			user: oidctest.User{Subject: "sub-2", Email: "jane.doe@example.com", EmailVerified: true},
+			setupMock: func(d externalLoginDeps) {
+				d.identityRepo.On("GetByProviderSubject", mock.Anything, "mock", "sub-2").Return(nil, ports.ErrIdentityNotFound)
+				d.userRepo.On("GetByEmail", mock.Anything, "jane.doe@example.com").Return(existingUser, nil)
+				d.identityRepo.On("Add", mock.Anything, mock.MatchedBy(func(i domain.Identity) bool {
+					return i.Provider == "mock" && i.Subject == "sub-2" && i.UserID == existingUser.ID
+				})).Return(nil)
+				d.authRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantUserID: existingUser.ID,
+		},
+		{
+			name: "success - unknown user is provisioned with defaults",
+			user: oidctest.User{Subject: "sub-3", Email: "new.user@example.com", EmailVerified: true, GivenName: "New", FamilyName: "User"},
+			setupMock: func(d externalLoginDeps) {
+				newID := uuid.MustParse("9b0cf6c4-5d5b-4a4b-9b5f-9c3e0a6f2d11")
+				d.identityRepo.On("GetByProviderSubject", mock.Anything, "mock", "sub-3").Return(nil, ports.ErrIdentityNotFound)
+				d.userRepo.On("GetByEmail", mock.Anything, "new.user@example.com").Return(nil, ports.ErrUserNotFound)
+				d.accounts.On("ProvisionAccount", mock.Anything, users.ProvisionAccountReq{
+					Email: "new.user@example.com", FirstName: "New", LastName: "User", EmailVerified: true,
+				}).Return(&users.CreateAccountResp{UserID: newID.String()}, nil)
+				d.userRepo.On("GetByID", mock.Anything, newID.String()).Return(&ports.User{ID: newID}, nil)
+				d.identityRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+				d.authRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantUserID: uuid.MustParse("9b0cf6c4-5d5b-4a4b-9b5f-9c3e0a6f2d11"),
+		},
+		{
+			name:       "success - signed in user links a new identity",
+			user:       oidctest.User{Subject: "sub-4", Email: "other@example.com", EmailVerified: false},
+			linkUserID: &existingUser.ID,
+			setupMock: func(d externalLoginDeps) {
+				d.identityRepo.On("GetByProviderSubject", mock.Anything, "mock", "sub-4").Return(nil, ports.ErrIdentityNotFound)
+				d.userRepo.On("GetByID", mock.Anything, existingUser.ID.String()).Return(existingUser, nil)
+				d.identityRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+				d.authRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantUserID: existingUser.ID,
+		},
+		{
+			name:       "error - identity linked to another user",
+			user:       oidctest.User{Subject: "sub-5", Email: "jane.doe@example.com", EmailVerified: true},
+			linkUserID: func() *uuid.UUID { id := uuid.New(); return &id }(),
+			setupMock: func(d externalLoginDeps) {
+				d.identityRepo.On("GetByProviderSubject", mock.Anything, "mock", "sub-5").
+					Return(&domain.Identity{Provider: "mock", Subject: "sub-5", UserID: existingUser.ID}, nil)
+			},
+			expectedErr: ports.ErrIdentityLinked,
+		},
+		{
+			name: "error - unverified email is never linked by email",
+			user: oidctest.User{Subject: "sub-6", Email: "jane.doe@example.com", EmailVerified: false},
+			setupMock: func(d externalLoginDeps) {
+				d.identityRepo.On("GetByProviderSubject", mock.Anything, "mock", "sub-6").Return(nil, ports.ErrIdentityNotFound)
+			},
+			expectedErr: auth.ErrEmailNotVerified,
+		},
+		{
+			name: "error - account with unverified email is never linked by email",
+			user: oidctest.User{Subject: "sub-7", Email: "victim@example.com", EmailVerified: true},
+			setupMock: func(d externalLoginDeps) {
+				d.identityRepo.On("GetByProviderSubject", mock.Anything, "mock", "sub-7").Return(nil, ports.ErrIdentityNotFound)
+				d.userRepo.On("GetByEmail", mock.Anything, "victim@example.com").
+					Return(&ports.User{ID: uuid.New(), Email: "victim@example.com", Roles: []string{"user"}}, nil)
+			},
+			expectedErr: auth.ErrLinkRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := externalLoginDeps{
+				authRepo:     new(MockAuthRepo),
+				userRepo:     new(MockUserRepo),
+				identityRepo: new(MockIdentityRepo),
+				accounts:     new(MockAccountProvisioner),
+			}
+			tt.setupMock(deps)
+			server.SetUser(tt.user)
+
+			svc := auth.NewService(deps.authRepo, deps.userRepo,
+				auth.WithExternalLogin(deps.identityRepo, deps.accounts, provider))
+
+			resp, err := runExternalLogin(t, server, svc, tt.linkUserID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantUserID, resp.UserID)
+				assert.NotEmpty(t, resp.RefreshToken)
+			}
+
+			deps.authRepo.AssertExpectations(t)
+			deps.userRepo.AssertExpectations(t)
+			deps.identityRepo.AssertExpectations(t)
+			deps.accounts.AssertExpectations(t)
+		})
+	}
+}
+
+// An account owner moving their verified account onto someone else's email
+// must not receive that person's external logins.
+func TestExternalLogin_AfterEmailChange(t *testing.T) {
+	server := oidctest.NewServer("fitrkr-test")
+	defer server.Close()
+
+	provider, err := oidc.NewProvider(oidc.Config{
+		Name:        "mock",
+		IssuerURL:   server.URL,
+		ClientID:    server.ClientID,
+		RedirectURL: "http://localhost:8000/api/v1/auth/oauth/mock/callback",
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	verifiedAt := time.Now().Add(-24 * time.Hour)
+	stored := &ports.User{
+		ID:       uuid.New(),
+		Username: "attacker",
+		Email:    "attacker@example.com",
+		Roles:    []string{"user"},
+		Status:   user.AccountStatus{EmailVerifiedAt: &verifiedAt},
+	}
+
+	userRepo := new(MockUserRepo)
+	userRepo.On("GetByID", mock.Anything, stored.ID.String()).Return(stored, nil)
+	userRepo.On("GetByEmail", mock.Anything, "victim@example.com").Return(nil, ports.ErrUserNotFound).Once()
+	userRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	userRepo.On("UpdateStatus", mock.Anything, stored.ID.String(), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored.Status = args.Get(2).(user.AccountStatus) }).
+		Return(nil)
+
+	err = users.NewService(userRepo, memory.NewUnitOfWork()).Update(ctx, users.UpdateUserReq{
+		ID:    stored.ID.String(),
+		Email: "victim@example.com",
+	})
+	require.NoError(t, err)
+	assert.False(t, stored.Status.IsEmailVerified())
+
+	identityRepo := new(MockIdentityRepo)
+	identityRepo.On("GetByProviderSubject", mock.Anything, "mock", "victim-sub").Return(nil, ports.ErrIdentityNotFound)
+	userRepo.On("GetByEmail", mock.Anything, "victim@example.com").Return(stored, nil)
+	server.SetUser(oidctest.User{Subject: "victim-sub", Email: "victim@example.com", EmailVerified: true})
+
+	svc := auth.NewService(new(MockAuthRepo), userRepo,
+		auth.WithExternalLogin(identityRepo, new(MockAccountProvisioner), provider))
+
+	_, err = runExternalLogin(t, server, svc, nil)
+	assert.ErrorIs(t, err, auth.ErrLinkRequired)
+
+	userRepo.AssertExpectations(t)
+	identityRepo.AssertExpectations(t)
+}
+
+func TestExternalLogin_RejectsInvalidRequests(t *testing.T) {
+	server := oidctest.NewServer("fitrkr-test")
+	defer server.Close()
+
+	provider, err := oidc.NewProvider(oidc.Config{
+		Name:        "mock",
+		IssuerURL:   server.URL,
+		ClientID:    server.ClientID,
+		RedirectURL: "http://localhost:8000/api/v1/auth/oauth/mock/callback",
+	})
+	require.NoError(t, err)
+
+	svc := auth.NewService(new(MockAuthRepo), new(MockUserRepo),
+		auth.WithExternalLogin(new(MockIdentityRepo), new(MockAccountProvisioner), provider))
+	ctx := context.Background()
+
+	t.Run("unknown provider", func(t *testing.T) {
+		_, err := svc.StartExternalLogin(ctx, auth.StartExternalLoginReq{Provider: "github"})
+		assert.ErrorIs(t, err, auth.ErrUnknownProvider)
+	})
+
+	t.Run("state mismatch", func(t *testing.T) {
+		start, err := svc.StartExternalLogin(ctx, auth.StartExternalLoginReq{Provider: "mock"})
+		require.NoError(t, err)
+
+		code, _, err := server.Authorize(start.AuthURL)
+		require.NoError(t, err)
+
+		_, err = svc.ExternalLogin(ctx, auth.ExternalLoginReq{
+			Provider:      "mock",
+			Code:          code,
+			State:         "forged-state",
+			ExpectedState: start.State,
+			Nonce:         start.Nonce,
+			CodeVerifier:  start.CodeVerifier,
+		})
+		assert.ErrorIs(t, err, auth.ErrInvalidOAuthState)
+	})
+}
@@ -0,0 +1,223 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cheezecakee/logr"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+const unlockTokenTTL = 24 * time.Hour
+
+// ThrottledError is returned while further logins for an account or client
+// are backing off.
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%v, retry after %s", ErrTooManyAttempts, e.RetryAfter.Round(time.Second))
+}
+
+func (e *ThrottledError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+type lockout struct {
+	attempts  ports.LoginAttemptRepo
+	mailer    ports.Mailer
+	unlockURL string
+	account   auth.LockoutPolicy
+	ip        auth.LockoutPolicy
+}
+
+// WithLockout throttles failed logins per account and per client IP. Locked
+// accounts are sent a link to unlockURL carrying a single use token.
+func WithLockout(attempts ports.LoginAttemptRepo, mailer ports.Mailer, unlockURL string) Option {
+	return func(s *Service) {
+		s.lockout = &lockout{
+			attempts:  attempts,
+			mailer:    mailer,
+			unlockURL: unlockURL,
+			account:   auth.DefaultAccountPolicy(),
+			ip:        auth.DefaultIPPolicy(),
+		}
+	}
+}
+
+// WithLockoutPolicies overrides the default policies, it must follow WithLockout.
+func WithLockoutPolicies(account, ip auth.LockoutPolicy) Option {
+	return func(s *Service) {
+		if s.lockout != nil {
+			s.lockout.account = account
+			s.lockout.ip = ip
+		}
+	}
+}
+
+// dummyPassword is compared against for unknown usernames so that they cost
+// as much as a wrong password.
+var dummyPassword = sync.OnceValue(func() user.Password {
+	hash, err := user.HashPassword("not-a-real-password")
+	if err != nil {
+		return ""
+	}
+	return user.Password(hash)
+})
+
+type attemptKey struct {
+	key    string
+	policy auth.LockoutPolicy
+}
+
+// keys lists the account counter first, reserveAttempt returns it.
+func (l *lockout) keys(req LoginReq) []attemptKey {
+	keys := []attemptKey{{auth.AccountAttemptKey(req.Username), l.account}}
+	if req.IP != "" {
+		keys = append(keys, attemptKey{auth.IPAttemptKey(req.IP), l.ip})
+	}
+	return keys
+}
+
+// reserveAttempt counts the login against every counter before the password
+// is checked, so parallel guesses cannot all pass before one is counted. It
+// fails closed, a broken store must not open the door to unlimited guessing.
+func (s *Service) reserveAttempt(ctx context.Context, req LoginReq) (auth.LoginAttempts, error) {
+	if s.lockout == nil {
+		return auth.LoginAttempts{}, nil
+	}
+
+	now := time.Now()
+	keys := s.lockout.keys(req)
+	var account auth.LoginAttempts
+
+	for i, k := range keys {
+		attempts, blockedUntil, err := s.lockout.attempts.Reserve(ctx, k.key, k.policy, now)
+		if err != nil {
+			logr.Get().Errorf("failed to reserve login attempt: %v", err)
+			s.releaseAttempts(ctx, keys[:i])
+			return auth.LoginAttempts{}, fmt.Errorf("failed to reserve login attempt: %w", err)
+		}
+
+		if !blockedUntil.IsZero() {
+			logr.Get().Infof("login throttled for %s", k.key)
+			s.releaseAttempts(ctx, keys[:i])
+			return auth.LoginAttempts{}, &ThrottledError{RetryAfter: blockedUntil.Sub(now)}
+		}
+
+		if i == 0 {
+			account = attempts
+		}
+	}
+
+	return account, nil
+}
+
+// releaseAttempts gives back attempts that did not get to check a password.
+func (s *Service) releaseAttempts(ctx context.Context, keys []attemptKey) {
+	for _, k := range keys {
+		if err := s.lockout.attempts.Release(ctx, k.key); err != nil {
+			logr.Get().Errorf("failed to release login attempt: %v", err)
+		}
+	}
+}
+
+// recordFailure handles a failed login, its attempt was already counted by
+// reserveAttempt. u is nil when the username does not exist. The unlock
+// email is sent in the background, waiting for it would make failures on
+// existing accounts measurably slower than on unknown usernames.
+func (s *Service) recordFailure(ctx context.Context, u *ports.User, account auth.LoginAttempts) {
+	if s.lockout == nil || u == nil {
+		return
+	}
+
+	if s.lockout.account.IsLockout(account.Failures) {
+		logr.Get().Infof("account %s locked after %d failed logins", u.ID, account.Failures)
+		go s.sendUnlockEmail(context.WithoutCancel(ctx), u)
+	}
+}
+
+func (s *Service) resetFailures(ctx context.Context, req LoginReq) {
+	if s.lockout == nil {
+		return
+	}
+
+	// The account counter is cleared, the address only gets its attempt back
+	// so a successful login does not clear the budget of an address that is
+	// guessing at other accounts.
+	if err := s.lockout.attempts.Reset(ctx, auth.AccountAttemptKey(req.Username)); err != nil {
+		logr.Get().Errorf("failed to reset login attempts: %v", err)
+	}
+	if req.IP != "" {
+		s.releaseAttempts(ctx, []attemptKey{{key: auth.IPAttemptKey(req.IP)}})
+	}
+}
+
+func (s *Service) sendUnlockEmail(ctx context.Context, u *ports.User) {
+	token, plaintext, err := auth.NewUnlockToken(u.ID, unlockTokenTTL)
+	if err != nil {
+		logr.Get().Errorf("failed to create unlock token: %v", err)
+		return
+	}
+
+	if err := s.lockout.attempts.AddUnlockToken(ctx, token); err != nil {
+		logr.Get().Errorf("failed to add unlock token: %v", err)
+		return
+	}
+
+	err = s.lockout.mailer.Send(ctx, ports.Message{
+		To:      string(u.Email),
+		Subject: "Your Fitrkr account has been locked",
+		Body: fmt.Sprintf("We locked your account after too many failed sign in attempts.\n\n"+
+			"If this was you, open the link below to unlock it now, otherwise it unlocks itself later.\n\n%s?token=%s\n",
+			s.lockout.unlockURL, plaintext),
+	})
+	if err != nil {
+		logr.Get().Errorf("failed to send unlock email: %v", err)
+	}
+}
+
+type UnlockAccountReq struct {
+	Token string
+}
+
+func (s *Service) UnlockAccount(ctx context.Context, req UnlockAccountReq) error {
+	if s.lockout == nil {
+		return ErrInvalidUnlockToken
+	}
+
+	token, err := s.lockout.attempts.ConsumeUnlockToken(ctx, auth.HashUnlockToken(req.Token))
+	if err != nil {
+		if errors.Is(err, ports.ErrUnlockTokenNotFound) {
+			logr.Get().Info("unknown unlock token")
+			return ErrInvalidUnlockToken
+		}
+		logr.Get().Errorf("failed to get unlock token: %v", err)
+		return fmt.Errorf("failed to get unlock token: %w", err)
+	}
+
+	if token.IsExpired() {
+		logr.Get().Info("unlock token expired")
+		return ErrInvalidUnlockToken
+	}
+
+	u, err := s.getUser(ctx, token.UserID.String())
+	if err != nil {
+		return err
+	}
+
+	if err := s.lockout.attempts.Reset(ctx, auth.AccountAttemptKey(string(u.Username))); err != nil {
+		logr.Get().Errorf("failed to reset login attempts: %v", err)
+		return fmt.Errorf("failed to reset login attempts: %w", err)
+	}
+
+	logr.Get().Infof("account %s unlocked by email", u.ID)
+	return nil
+}
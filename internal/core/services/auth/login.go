@@ -2,17 +2,26 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/cheezecakee/logr"
 	"github.com/google/uuid"
 
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
 )
 
 type LoginReq struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	// Restore signs in to an account pending deletion and cancels the
+	// deletion.
+	Restore bool `json:"restore"`
+	// IP is the client address used for per-IP throttling.
+	IP string `json:"-"`
 }
 
 type LoginResp struct {
@@ -21,19 +30,67 @@ type LoginResp struct {
 	Roles        []string
 }
 
+// Login returns ErrInvalidCredentials for unknown usernames and wrong
+// passwords alike, and a ThrottledError while the account or client is
+// backing off. Accounts pending deletion return ErrAccountPendingDeletion unless
+// req.Restore is set.
 func (s *Service) Login(ctx context.Context, req LoginReq) (LoginResp, error) {
-	user, err := s.userRepo.GetByUsername(ctx, req.Username)
+	attempt, err := s.reserveAttempt(ctx, req)
 	if err != nil {
+		return LoginResp{}, err
+	}
+
+	user, err := s.userRepo.GetByUsername(ctx, req.Username)
+	if err != nil && !errors.Is(err, ports.ErrUserNotFound) {
 		logr.Get().Errorf("failed to get user: %v", err)
 		return LoginResp{}, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	if user == nil {
+		dummyPassword().Verify(req.Password)
+		logr.Get().Info("login for unknown username")
+		s.recordFailure(ctx, nil, attempt)
+		return LoginResp{}, ErrInvalidCredentials
+	}
+
 	if !user.PasswordHash.Verify(req.Password) {
-		logr.Get().Error("password incorrect")
-		return LoginResp{}, fmt.Errorf("%v", ErrPasswordIncorrect)
+		logr.Get().Info("password incorrect")
+		s.recordFailure(ctx, user, attempt)
+		// In the background like the unlock email, unknown usernames write
+		// nothing and waiting for the write would tell the two apart
+		go s.record(context.WithoutCancel(ctx), audit.ActionLoginFailed, user.ID, map[string]string{"reason": "invalid_password"})
+		return LoginResp{}, ErrInvalidCredentials
 	}
 
-	token, err := auth.NewRefreshToken(user.ID)
+	s.resetFailures(ctx, req)
+
+	return s.startSession(ctx, user, "password", req.Restore)
+}
+
+// startSession issues the refresh token that backs a new login. Suspended
+// accounts and accounts pending deletion are refused only after their
+// credentials checked out, so their status is not revealed to someone
+// guessing passwords. With restore set an account pending deletion is
+// restored instead. method is how the user signed in, for the audit log.
+func (s *Service) startSession(ctx context.Context, user *ports.User, method string, restore bool) (LoginResp, error) {
+	if user.Status.IsSuspended() {
+		logr.Get().Infof("login refused for suspended user %s", user.ID)
+		s.record(ctx, audit.ActionLoginFailed, user.ID, map[string]string{"reason": "account_suspended", "method": method})
+		return LoginResp{}, ErrAccountSuspended
+	}
+
+	if user.Status.IsPendingDeletion() {
+		if !restore {
+			logr.Get().Infof("login refused for user %s pending deletion", user.ID)
+			s.record(ctx, audit.ActionLoginFailed, user.ID, map[string]string{"reason": "account_pending_deletion", "method": method})
+			return LoginResp{}, ErrAccountPendingDeletion
+		}
+		if err := s.restore(ctx, user); err != nil {
+			return LoginResp{}, err
+		}
+	}
+
+	token, err := auth.NewRefreshToken(user.ID, s.refreshTokenTTL)
 	if err != nil {
 		logr.Get().Errorf("failed to generate refresh token: %v", err)
 		return LoginResp{}, fmt.Errorf("failed to generate refresh token: %w", err)
@@ -45,9 +102,24 @@ func (s *Service) Login(ctx context.Context, req LoginReq) (LoginResp, error) {
 		return LoginResp{}, fmt.Errorf("failed to create refresh token: %w", err)
 	}
 
+	s.record(ctx, audit.ActionLoginSucceeded, user.ID, map[string]string{"method": method})
+
 	return LoginResp{
 		RefreshToken: token.Token,
 		UserID:       user.ID,
 		Roles:        user.Roles,
 	}, nil
 }
+
+// restore cancels the scheduled deletion of the account.
+func (s *Service) restore(ctx context.Context, user *ports.User) error {
+	err := s.userRepo.UpdateStatus(ctx, user.ID.String(), user.Status.CancelDeletion(), time.Now())
+	if err != nil {
+		logr.Get().Errorf("failed to restore account: %v", err)
+		return fmt.Errorf("failed to restore account: %w", err)
+	}
+
+	logr.Get().Infof("user %s restored their account", user.ID)
+	s.record(ctx, audit.ActionAccountRestored, user.ID, nil)
+	return nil
+}
@@ -0,0 +1,300 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	domain "github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+)
+
+var testPolicy = domain.LockoutPolicy{
+	FreeAttempts:     2,
+	BaseDelay:        time.Minute,
+	MaxDelay:         time.Hour,
+	LockoutThreshold: 3,
+	LockoutDuration:  time.Hour,
+	ResetAfter:       24 * time.Hour,
+}
+
+func newLoginUser(t *testing.T) *ports.User {
+	t.Helper()
+	hash, err := user.HashPassword("correct-horse")
+	require.NoError(t, err)
+	return &ports.User{
+		ID:           uuid.New(),
+		Username:     "janedoe",
+		Email:        "jane.doe@example.com",
+		PasswordHash: user.Password(hash),
+		Roles:        []string{"user"},
+	}
+}
+
+func newLockoutService(userRepo *MockUserRepo, authRepo *MockAuthRepo, attempts ports.LoginAttemptRepo, mailer *MockMailer, ip domain.LockoutPolicy) *auth.Service {
+	return auth.NewService(authRepo, userRepo,
+		auth.WithLockout(attempts, mailer, "https://fitrkr.test/unlock"),
+		auth.WithLockoutPolicies(testPolicy, ip))
+}
+
+func TestLogin_DoesNotRevealUnknownUsernames(t *testing.T) {
+	u := newLoginUser(t)
+	userRepo := new(MockUserRepo)
+	userRepo.On("GetByUsername", mock.Anything, "janedoe").Return(u, nil)
+	userRepo.On("GetByUsername", mock.Anything, "nobody").Return(nil, ports.ErrUserNotFound)
+
+	svc := newLockoutService(userRepo, new(MockAuthRepo), memory.NewLoginAttemptRepo(), new(MockMailer), domain.DefaultIPPolicy())
+	ctx := context.Background()
+
+	_, wrongPassword := svc.Login(ctx, auth.LoginReq{Username: "janedoe", Password: "wrong"})
+	_, unknownUser := svc.Login(ctx, auth.LoginReq{Username: "nobody", Password: "wrong"})
+
+	assert.ErrorIs(t, wrongPassword, auth.ErrInvalidCredentials)
+	assert.ErrorIs(t, unknownUser, auth.ErrInvalidCredentials)
+	assert.Equal(t, wrongPassword.Error(), unknownUser.Error())
+}
+
+func TestLogin_BacksOffAndLocksAccount(t *testing.T) {
+	u := newLoginUser(t)
+	userRepo := new(MockUserRepo)
+	authRepo := new(MockAuthRepo)
+	mailer := new(MockMailer)
+	attempts := memory.NewLoginAttemptRepo()
+
+	// Only the attempts that get past the throttle reach the user repo
+	userRepo.On("GetByUsername", mock.Anything, "janedoe").Return(u, nil).Times(2)
+
+	// The unlock email is sent in the background, Send blocks until the
+	// login that locked the account has returned
+	var unlockLink string
+	loginReturned, sent := make(chan struct{}), make(chan struct{})
+	mailer.On("Send", mock.Anything, mock.MatchedBy(func(msg ports.Message) bool {
+		return msg.To == "jane.doe@example.com"
+	})).Run(func(args mock.Arguments) {
+		<-loginReturned
+		body := args.Get(1).(ports.Message).Body
+		unlockLink = strings.TrimSpace(body[strings.Index(body, "https://"):])
+		close(sent)
+	}).Return(nil).Once()
+
+	svc := newLockoutService(userRepo, authRepo, attempts, mailer, domain.DefaultIPPolicy())
+	ctx := context.Background()
+	req := auth.LoginReq{Username: "janedoe", Password: "wrong", IP: "203.0.113.7"}
+
+	for range 2 {
+		_, err := svc.Login(ctx, req)
+		assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	}
+
+	// Backing off, the correct password is not even checked
+	_, err := svc.Login(ctx, auth.LoginReq{Username: "janedoe", Password: "correct-horse"})
+	var throttled *auth.ThrottledError
+	require.ErrorAs(t, err, &throttled)
+	assert.ErrorIs(t, err, auth.ErrTooManyAttempts)
+	assert.InDelta(t, time.Minute.Seconds(), throttled.RetryAfter.Seconds(), 5)
+
+	// Move the earlier failures past their backoff, the third failure then
+	// locks the account and mails an unlock link
+	require.NoError(t, attempts.Reset(ctx, domain.AccountAttemptKey("janedoe")))
+	for range 2 {
+		_, _, err = attempts.Reserve(ctx, domain.AccountAttemptKey("janedoe"), domain.LockoutPolicy{}, time.Now().Add(-2*time.Minute))
+		require.NoError(t, err)
+	}
+	userRepo.On("GetByUsername", mock.Anything, "janedoe").Return(u, nil).Once()
+	_, err = svc.Login(ctx, req)
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	close(loginReturned)
+
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("unlock email was not sent")
+	}
+
+	_, err = svc.Login(ctx, auth.LoginReq{Username: "janedoe", Password: "correct-horse"})
+	require.ErrorAs(t, err, &throttled)
+	assert.InDelta(t, time.Hour.Seconds(), throttled.RetryAfter.Seconds(), 5)
+	mailer.AssertExpectations(t)
+
+	link, err := url.Parse(unlockLink)
+	require.NoError(t, err)
+
+	userRepo.On("GetByID", mock.Anything, u.ID.String()).Return(u, nil)
+	require.NoError(t, svc.UnlockAccount(ctx, auth.UnlockAccountReq{Token: link.Query().Get("token")}))
+
+	// Unlock tokens are single use
+	assert.ErrorIs(t, svc.UnlockAccount(ctx, auth.UnlockAccountReq{Token: link.Query().Get("token")}), auth.ErrInvalidUnlockToken)
+
+	userRepo.On("GetByUsername", mock.Anything, "janedoe").Return(u, nil).Once()
+	authRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+	resp, err := svc.Login(ctx, auth.LoginReq{Username: "janedoe", Password: "correct-horse"})
+	require.NoError(t, err)
+	assert.Equal(t, u.ID, resp.UserID)
+}
+
+func TestLogin_ThrottlesByIP(t *testing.T) {
+	userRepo := new(MockUserRepo)
+	userRepo.On("GetByUsername", mock.Anything, mock.Anything).Return(nil, ports.ErrUserNotFound).Times(2)
+
+	svc := newLockoutService(userRepo, new(MockAuthRepo), memory.NewLoginAttemptRepo(), new(MockMailer), testPolicy)
+	ctx := context.Background()
+
+	// Spraying different usernames from one address still trips the IP counter
+	for _, username := range []string{"alice", "bob"} {
+		_, err := svc.Login(ctx, auth.LoginReq{Username: username, Password: "guess", IP: "198.51.100.1"})
+		assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	}
+
+	_, err := svc.Login(ctx, auth.LoginReq{Username: "carol", Password: "guess", IP: "198.51.100.1"})
+	assert.ErrorIs(t, err, auth.ErrTooManyAttempts)
+
+	userRepo.AssertExpectations(t)
+}
+
+func TestLogin_ParallelGuessesAreCounted(t *testing.T) {
+	u := newLoginUser(t)
+	userRepo := new(MockUserRepo)
+	userRepo.On("GetByUsername", mock.Anything, "janedoe").Return(u, nil)
+
+	svc := newLockoutService(userRepo, new(MockAuthRepo), memory.NewLoginAttemptRepo(), new(MockMailer), domain.DefaultIPPolicy())
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := svc.Login(ctx, auth.LoginReq{Username: "janedoe", Password: "wrong"})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	// Only the free attempts get to check a password, the rest back off
+	var checked, throttled int
+	for err := range errs {
+		switch {
+		case errors.Is(err, auth.ErrInvalidCredentials):
+			checked++
+		case errors.Is(err, auth.ErrTooManyAttempts):
+			throttled++
+		}
+	}
+	assert.Equal(t, testPolicy.FreeAttempts, checked)
+	assert.Equal(t, 10-testPolicy.FreeAttempts, throttled)
+}
+
+func TestLogin_SuccessReleasesIPAttempt(t *testing.T) {
+	u := newLoginUser(t)
+	userRepo := new(MockUserRepo)
+	authRepo := new(MockAuthRepo)
+	attempts := memory.NewLoginAttemptRepo()
+	userRepo.On("GetByUsername", mock.Anything, "janedoe").Return(u, nil)
+	authRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+	svc := newLockoutService(userRepo, authRepo, attempts, new(MockMailer), testPolicy)
+	ctx := context.Background()
+
+	// More successful logins than the address has free attempts
+	for range testPolicy.LockoutThreshold + 1 {
+		_, err := svc.Login(ctx, auth.LoginReq{Username: "janedoe", Password: "correct-horse", IP: "198.51.100.1"})
+		require.NoError(t, err)
+	}
+
+	counter, err := attempts.Get(ctx, domain.IPAttemptKey("198.51.100.1"))
+	require.NoError(t, err)
+	assert.Zero(t, counter.Failures)
+}
+
+func TestLogin_SuccessResetsAccountCounter(t *testing.T) {
+	u := newLoginUser(t)
+	userRepo := new(MockUserRepo)
+	authRepo := new(MockAuthRepo)
+	attempts := memory.NewLoginAttemptRepo()
+	userRepo.On("GetByUsername", mock.Anything, "janedoe").Return(u, nil)
+	authRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+	svc := newLockoutService(userRepo, authRepo, attempts, new(MockMailer), domain.DefaultIPPolicy())
+	ctx := context.Background()
+
+	_, err := svc.Login(ctx, auth.LoginReq{Username: "janedoe", Password: "wrong"})
+	require.ErrorIs(t, err, auth.ErrInvalidCredentials)
+
+	_, err = svc.Login(ctx, auth.LoginReq{Username: "janedoe", Password: "correct-horse"})
+	require.NoError(t, err)
+
+	counter, err := attempts.Get(ctx, domain.AccountAttemptKey("janedoe"))
+	require.NoError(t, err)
+	assert.Zero(t, counter.Failures)
+}
+
+func TestLogin_RefusesSuspendedUsers(t *testing.T) {
+	u := newLoginUser(t)
+	now := time.Now()
+	u.Status.SuspendedAt = &now
+
+	userRepo := new(MockUserRepo)
+	userRepo.On("GetByUsername", mock.Anything, "janedoe").Return(u, nil)
+	authRepo := new(MockAuthRepo)
+
+	svc := newLockoutService(userRepo, authRepo, memory.NewLoginAttemptRepo(), new(MockMailer), domain.DefaultIPPolicy())
+	ctx := context.Background()
+
+	// Wrong passwords do not learn about the suspension
+	_, err := svc.Login(ctx, auth.LoginReq{Username: "janedoe", Password: "wrong"})
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+
+	_, err = svc.Login(ctx, auth.LoginReq{Username: "janedoe", Password: "correct-horse"})
+	assert.ErrorIs(t, err, auth.ErrAccountSuspended)
+	authRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+}
+
+func TestLogin_PendingDeletion(t *testing.T) {
+	u := newLoginUser(t)
+	requested := time.Now().Add(-24 * time.Hour)
+	u.Status.DeletionRequestedAt = &requested
+
+	userRepo := new(MockUserRepo)
+	userRepo.On("GetByUsername", mock.Anything, "janedoe").Return(u, nil)
+	userRepo.On("UpdateStatus", mock.Anything, u.ID.String(), mock.MatchedBy(func(s user.AccountStatus) bool {
+		return !s.IsPendingDeletion()
+	}), mock.Anything).Return(nil).Once()
+	authRepo := new(MockAuthRepo)
+	authRepo.On("Add", mock.Anything, mock.Anything).Return(nil).Once()
+
+	auditLog := memory.NewAuditLog()
+	svc := auth.NewService(authRepo, userRepo, auth.WithAuditLog(auditLog))
+	ctx := context.Background()
+
+	_, err := svc.Login(ctx, auth.LoginReq{Username: "janedoe", Password: "correct-horse"})
+	assert.ErrorIs(t, err, auth.ErrAccountPendingDeletion)
+	userRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	resp, err := svc.Login(ctx, auth.LoginReq{Username: "janedoe", Password: "correct-horse", Restore: true})
+	require.NoError(t, err)
+	assert.Equal(t, u.ID, resp.UserID)
+
+	var actions []audit.Action
+	for _, event := range auditLog.Events() {
+		actions = append(actions, event.Action)
+	}
+	assert.Equal(t, []audit.Action{audit.ActionLoginFailed, audit.ActionAccountRestored, audit.ActionLoginSucceeded}, actions)
+
+	userRepo.AssertExpectations(t)
+	authRepo.AssertExpectations(t)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/cheezecakee/logr"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
 )
 
 type LogoutReq struct {
@@ -25,6 +27,8 @@ func (s *Service) Logout(ctx context.Context, req LogoutReq) error {
 		logr.Get().Errorf("failed to update user refresh token: %v", err)
 		return fmt.Errorf("failed to update user refresh token: %w", err)
 	}
+	s.record(ctx, audit.ActionTokenRevoked, refreshToken.UserID, map[string]string{"reason": "logout"})
+
 	logr.Get().Info("User logged out")
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/cheezecakee/logr"
 	"github.com/google/uuid"
 
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
 )
 
@@ -41,8 +42,24 @@ func (s *Service) Refresh(ctx context.Context, req RefreshReq) (RefreshResp, err
 		return RefreshResp{}, fmt.Errorf("failed to update refresh token: %w", err)
 	}
 
+	user, err := s.userRepo.GetByID(ctx, currentToken.UserID.String())
+	if err != nil {
+		logr.Get().Errorf("failed to get user: %v", err)
+		return RefreshResp{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Roles and status are read again, so admin changes and deletion apply here
+	if user.Status.IsSuspended() {
+		logr.Get().Infof("refresh refused for suspended user %s", user.ID)
+		return RefreshResp{}, ErrAccountSuspended
+	}
+	if user.Status.IsPendingDeletion() {
+		logr.Get().Infof("refresh refused for user %s pending deletion", user.ID)
+		return RefreshResp{}, ErrAccountPendingDeletion
+	}
+
 	// Create a new token
-	token, err := auth.NewRefreshToken(currentToken.UserID)
+	token, err := auth.NewRefreshToken(currentToken.UserID, s.refreshTokenTTL)
 	if err != nil {
 		logr.Get().Errorf("failed to generate refresh token: %v", err)
 		return RefreshResp{}, fmt.Errorf("failed to generate refresh token: %w", err)
@@ -54,11 +71,7 @@ func (s *Service) Refresh(ctx context.Context, req RefreshReq) (RefreshResp, err
 		return RefreshResp{}, fmt.Errorf("failed to create refresh token: %w", err)
 	}
 
-	user, err := s.userRepo.GetByID(ctx, currentToken.UserID.String())
-	if err != nil {
-		logr.Get().Errorf("failed to get user: %v", err)
-		return RefreshResp{}, fmt.Errorf("failed to get user: %w", err)
-	}
+	s.record(ctx, audit.ActionTokenRefreshed, user.ID, nil)
 
 	logr.Get().Info("user token refreshed")
 	return RefreshResp{Token: token.Token, UserID: user.ID, Roles: user.Roles}, nil
@@ -0,0 +1,81 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+)
+
+func TestRefresh_ReadsCurrentRoles(t *testing.T) {
+	u := newLoginUser(t)
+	current, err := domain.NewRefreshToken(u.ID, time.Hour)
+	require.NoError(t, err)
+
+	// Granted after the session started, the new token must carry it
+	u.Roles = []string{"user", "moderator"}
+
+	userRepo, authRepo := new(MockUserRepo), new(MockAuthRepo)
+	authRepo.On("GetByToken", mock.Anything, current.Token).Return(&current, nil)
+	authRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	authRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+	userRepo.On("GetByID", mock.Anything, u.ID.String()).Return(u, nil)
+
+	svc := auth.NewService(authRepo, userRepo)
+
+	resp, err := svc.Refresh(context.Background(), auth.RefreshReq{Token: current.Token})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"user", "moderator"}, resp.Roles)
+	assert.NotEqual(t, current.Token, resp.Token)
+}
+
+func TestRefresh_RefusesSuspendedUsers(t *testing.T) {
+	u := newLoginUser(t)
+	now := time.Now()
+	u.Status.SuspendedAt = &now
+	current, err := domain.NewRefreshToken(u.ID, time.Hour)
+	require.NoError(t, err)
+
+	userRepo, authRepo := new(MockUserRepo), new(MockAuthRepo)
+	authRepo.On("GetByToken", mock.Anything, current.Token).Return(&current, nil)
+	authRepo.On("Update", mock.Anything, mock.MatchedBy(func(token domain.RefreshToken) bool { return token.IsRevoked })).Return(nil)
+	userRepo.On("GetByID", mock.Anything, u.ID.String()).Return(u, nil)
+
+	svc := auth.NewService(authRepo, userRepo)
+
+	_, err = svc.Refresh(context.Background(), auth.RefreshReq{Token: current.Token})
+	assert.ErrorIs(t, err, auth.ErrAccountSuspended)
+
+	// The presented token is spent and no new one is issued
+	authRepo.AssertExpectations(t)
+	authRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+}
+
+func TestRefresh_RefusesUsersPendingDeletion(t *testing.T) {
+	u := newLoginUser(t)
+	now := time.Now()
+	u.Status.DeletionRequestedAt = &now
+	current, err := domain.NewRefreshToken(u.ID, time.Hour)
+	require.NoError(t, err)
+
+	userRepo, authRepo := new(MockUserRepo), new(MockAuthRepo)
+	authRepo.On("GetByToken", mock.Anything, current.Token).Return(&current, nil)
+	authRepo.On("Update", mock.Anything, mock.MatchedBy(func(token domain.RefreshToken) bool { return token.IsRevoked })).Return(nil)
+	userRepo.On("GetByID", mock.Anything, u.ID.String()).Return(u, nil)
+
+	svc := auth.NewService(authRepo, userRepo)
+
+	_, err = svc.Refresh(context.Background(), auth.RefreshReq{Token: current.Token})
+	assert.ErrorIs(t, err, auth.ErrAccountPendingDeletion)
+
+	// The presented token is spent and no new one is issued
+	authRepo.AssertExpectations(t)
+	authRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+}
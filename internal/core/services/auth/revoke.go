@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/cheezecakee/logr"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
 )
 
 type RevokeTokenReq struct {
@@ -26,5 +28,7 @@ func (s *Service) Revoke(ctx context.Context, req RevokeTokenReq) error {
 		return fmt.Errorf("failed to update token: %w", err)
 	}
 
+	s.record(ctx, audit.ActionTokenRevoked, token.UserID, map[string]string{"reason": "revoked"})
+
 	return nil
 }
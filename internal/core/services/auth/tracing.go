@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/cheezecakee/fitrkr-athena/internal/core/services/auth"
+
+// tracedService records a span around every AuthService call.
+type tracedService struct {
+	next   AuthService
+	tracer trace.Tracer
+}
+
+// NewTracedService wraps svc so every call records a span named after the
+// method, marked as failed when the call returns an error.
+func NewTracedService(svc AuthService, tp trace.TracerProvider) AuthService {
+	return &tracedService{next: svc, tracer: tp.Tracer(instrumentationName)}
+}
+
+func (s *tracedService) start(ctx context.Context, method string) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "auth.Service."+method)
+}
+
+func end(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+	return err
+}
+
+func (s *tracedService) Login(ctx context.Context, req LoginReq) (LoginResp, error) {
+	ctx, span := s.start(ctx, "Login")
+	resp, err := s.next.Login(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) Logout(ctx context.Context, req LogoutReq) error {
+	ctx, span := s.start(ctx, "Logout")
+	return end(span, s.next.Logout(ctx, req))
+}
+
+func (s *tracedService) Revoke(ctx context.Context, req RevokeTokenReq) error {
+	ctx, span := s.start(ctx, "Revoke")
+	return end(span, s.next.Revoke(ctx, req))
+}
+
+func (s *tracedService) Refresh(ctx context.Context, req RefreshReq) (RefreshResp, error) {
+	ctx, span := s.start(ctx, "Refresh")
+	resp, err := s.next.Refresh(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) UnlockAccount(ctx context.Context, req UnlockAccountReq) error {
+	ctx, span := s.start(ctx, "UnlockAccount")
+	return end(span, s.next.UnlockAccount(ctx, req))
+}
+
+func (s *tracedService) StartExternalLogin(ctx context.Context, req StartExternalLoginReq) (StartExternalLoginResp, error) {
+	ctx, span := s.start(ctx, "StartExternalLogin")
+	resp, err := s.next.StartExternalLogin(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) ExternalLogin(ctx context.Context, req ExternalLoginReq) (LoginResp, error) {
+	ctx, span := s.start(ctx, "ExternalLogin")
+	resp, err := s.next.ExternalLogin(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) ListIdentities(ctx context.Context, req ListIdentitiesReq) (ListIdentitiesResp, error) {
+	ctx, span := s.start(ctx, "ListIdentities")
+	resp, err := s.next.ListIdentities(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) CreateAPIKey(ctx context.Context, req CreateAPIKeyReq) (CreateAPIKeyResp, error) {
+	ctx, span := s.start(ctx, "CreateAPIKey")
+	resp, err := s.next.CreateAPIKey(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) ListAPIKeys(ctx context.Context, req ListAPIKeysReq) (ListAPIKeysResp, error) {
+	ctx, span := s.start(ctx, "ListAPIKeys")
+	resp, err := s.next.ListAPIKeys(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) RevokeAPIKey(ctx context.Context, req RevokeAPIKeyReq) error {
+	ctx, span := s.start(ctx, "RevokeAPIKey")
+	return end(span, s.next.RevokeAPIKey(ctx, req))
+}
+
+func (s *tracedService) AuthenticateAPIKey(ctx context.Context, req AuthenticateAPIKeyReq) (AuthenticateAPIKeyResp, error) {
+	ctx, span := s.start(ctx, "AuthenticateAPIKey")
+	resp, err := s.next.AuthenticateAPIKey(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) SecurityActivity(ctx context.Context, req SecurityActivityReq) (SecurityActivityResp, error) {
+	ctx, span := s.start(ctx, "SecurityActivity")
+	resp, err := s.next.SecurityActivity(ctx, req)
+	return resp, end(span, err)
+}
@@ -0,0 +1,124 @@
+package exports
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// archive writes the files of an export into a zip. Documents are JSON and
+// lists are CSV, so they open in a spreadsheet.
+type archive struct {
+	zw       *zip.Writer
+	modified time.Time
+}
+
+func newArchive(w io.Writer, modified time.Time) *archive {
+	return &archive{zw: zip.NewWriter(w), modified: modified}
+}
+
+func (a *archive) create(name string) (io.Writer, error) {
+	return a.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: a.modified})
+}
+
+func (a *archive) writeText(name, text string) error {
+	w, err := a.create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, text)
+	return err
+}
+
+func (a *archive) writeJSON(name string, v any) error {
+	w, err := a.create(name)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	return nil
+}
+
+// writeFile copies r as it is, images are compressed already.
+func (a *archive) writeFile(name string, r io.Reader) error {
+	w, err := a.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store, Modified: a.modified})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, r)
+	return err
+}
+
+func (a *archive) writeCSV(name string, header []string, rows [][]string) error {
+	w, err := a.create(name)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+func (a *archive) Close() error {
+	return a.zw.Close()
+}
+
+// The CSV cells of optional values are empty when unset.
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatOptTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTime(*t)
+}
+
+func formatOptDay(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.DateOnly)
+}
+
+func formatBool(b bool) string {
+	return strconv.FormatBool(b)
+}
+
+const readme = `This archive holds the data we keep about your account, as of %s.
+
+profile.json          your account details, profile and status
+settings.json         your preferences
+stats.json            body measurements, workout totals and streaks; weights
+                      are in kilograms and heights in centimetres
+subscription.json     your plan and billing period
+payments.csv          recorded payments
+username_history.csv  earlier usernames and when they were changed
+sessions.csv          sign in sessions, without their tokens
+identities.csv        linked Google or Apple accounts
+api_keys.csv          personal API keys, without their secrets
+photos/               your avatar and progress photos
+photos.csv            the files in photos/ and the day each progress photo
+                      was taken
+audit_events.csv      security and billing events on your account; where
+                      support staff acted, their address is left out
+`
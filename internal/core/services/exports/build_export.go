@@ -0,0 +1,416 @@
+package exports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+// auditPageSize is how many audit events are read at a time.
+const auditPageSize = 500
+
+type BuildExportReq struct {
+	ExportID uuid.UUID
+	// LastAttempt marks the export failed if this build fails too.
+	LastAttempt bool
+}
+
+// BuildExport writes the archive of a pending export. Exports that are no
+// longer in progress are left alone, so a job that runs twice is harmless.
+func (s *Service) BuildExport(ctx context.Context, req BuildExportReq) error {
+	e, err := s.getExport(ctx, req.ExportID)
+	if err != nil {
+		if errors.Is(err, ErrExportNotFound) {
+			// The account was deleted since, there is nothing to build
+			return nil
+		}
+		return err
+	}
+
+	if !e.IsActive() {
+		logr.Get().Infof("export %s is %s, skipping", e.ID, e.Status)
+		return nil
+	}
+
+	started := e.Start(time.Now())
+	if err := s.update(ctx, started); err != nil {
+		return err
+	}
+
+	size, err := s.writeArchive(ctx, started)
+	if err != nil {
+		logr.Get().Errorf("failed to build export %s: %v", e.ID, err)
+		if req.LastAttempt {
+			if err := s.update(ctx, started.Fail(time.Now())); err != nil {
+				return err
+			}
+		}
+		return fmt.Errorf("failed to build export: %w", err)
+	}
+
+	if err := s.update(ctx, started.Complete(archiveKey(started), size, s.ttl, time.Now())); err != nil {
+		return err
+	}
+
+	logr.Get().Infof("export %s built, %d bytes", e.ID, size)
+	return nil
+}
+
+// writeArchive streams the zip into the store as it is written, photos
+// included, so an export is never held in memory.
+func (s *Service) writeArchive(ctx context.Context, e export.Export) (int64, error) {
+	pr, pw := io.Pipe()
+	counter := &countingWriter{w: pw}
+
+	built := make(chan error, 1)
+	go func() {
+		err := s.buildArchive(ctx, counter, e)
+		pw.CloseWithError(err)
+		built <- err
+	}()
+
+	putErr := s.store.Put(ctx, archiveKey(e), "application/zip", pr)
+	// Stops the build when the store gave up before reading everything
+	pr.CloseWithError(putErr)
+
+	if err := <-built; err != nil {
+		return 0, err
+	}
+	if putErr != nil {
+		return 0, fmt.Errorf("failed to store archive: %w", putErr)
+	}
+
+	return counter.n, nil
+}
+
+func (s *Service) buildArchive(ctx context.Context, w io.Writer, e export.Export) error {
+	a := newArchive(w, e.UpdatedAt)
+
+	if err := s.writeFiles(ctx, a, e.UserID); err != nil {
+		return err
+	}
+	if err := a.Close(); err != nil {
+		return fmt.Errorf("failed to close archive: %w", err)
+	}
+	return nil
+}
+
+// countingWriter counts the bytes written, the size of the archive is only
+// known once the store has it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// profile holds every column of the user but the password hash. Unset
+// optional details are null.
+type profile struct {
+	ID              uuid.UUID             `json:"id"`
+	Username        string                `json:"username"`
+	FullName        string                `json:"full_name"`
+	Email           string                `json:"email"`
+	Roles           []string              `json:"roles"`
+	Status          user.AccountStatus    `json:"status"`
+	Bio             *string               `json:"bio"`
+	BirthDate       *string               `json:"birth_date"`
+	Sex             *user.Sex             `json:"sex"`
+	ExperienceLevel *user.ExperienceLevel `json:"experience_level"`
+	PreferredGym    *string               `json:"preferred_gym"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+}
+
+func newProfile(u *ports.User) profile {
+	return profile{
+		ID:              u.ID,
+		Username:        string(u.Username),
+		FullName:        u.FullName,
+		Email:           string(u.Email),
+		Roles:           u.Roles,
+		Status:          u.Status,
+		Bio:             orNil(u.Profile.Bio),
+		BirthDate:       orNil(formatOptDay(u.Profile.BirthDate)),
+		Sex:             orNil(u.Profile.Sex),
+		ExperienceLevel: orNil(u.Profile.ExperienceLevel),
+		PreferredGym:    orNil(u.Profile.PreferredGym),
+		CreatedAt:       u.CreatedAt,
+		UpdatedAt:       u.UpdatedAt,
+	}
+}
+
+// orNil is nil for the zero value, which the core uses for unset.
+func orNil[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
+
+func (s *Service) writeFiles(ctx context.Context, a *archive, userID uuid.UUID) error {
+	id := userID.String()
+
+	u, err := s.sources.Users.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	settings, err := s.sources.Users.GetSettingsByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get settings: %w", err)
+	}
+	stats, err := s.sources.Users.GetStatsByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get stats: %w", err)
+	}
+	sub, err := s.sources.Users.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	if err := a.writeText("README.txt", fmt.Sprintf(readme, formatTime(a.modified))); err != nil {
+		return err
+	}
+	if err := a.writeJSON("profile.json", newProfile(u)); err != nil {
+		return err
+	}
+	if err := a.writeJSON("settings.json", settings); err != nil {
+		return err
+	}
+	if err := a.writeJSON("stats.json", stats); err != nil {
+		return err
+	}
+	if err := a.writeJSON("subscription.json", sub); err != nil {
+		return err
+	}
+	if err := a.writeCSV("payments.csv", []string{"paid_at", "amount", "currency"}, paymentRows(sub)); err != nil {
+		return err
+	}
+
+	writers := []func(ctx context.Context, a *archive, userID string) error{
+		s.writeUsernameHistory,
+		s.writeSessions,
+		s.writeIdentities,
+		s.writeAPIKeys,
+		s.writePhotos,
+	}
+	for _, write := range writers {
+		if err := write(ctx, a, id); err != nil {
+			return err
+		}
+	}
+
+	return s.writeAuditEvents(ctx, a, userID)
+}
+
+func (s *Service) writeUsernameHistory(ctx context.Context, a *archive, userID string) error {
+	changes, err := s.sources.Users.GetUsernameChanges(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get username changes: %w", err)
+	}
+
+	rows := make([][]string, 0, len(changes))
+	for _, c := range changes {
+		rows = append(rows, []string{string(c.OldUsername), string(c.NewUsername), formatTime(c.ChangedAt)})
+	}
+	return a.writeCSV("username_history.csv", []string{"old_username", "new_username", "changed_at"}, rows)
+}
+
+// paymentRows lists the payments on record, only the latest is kept.
+func paymentRows(sub *user.Subscription) [][]string {
+	if sub.LastPaymentAt == nil {
+		return nil
+	}
+
+	var amount, currency string
+	if sub.LastPaymentAmount != nil {
+		amount = strconv.FormatFloat(*sub.LastPaymentAmount, 'f', 2, 64)
+	}
+	if sub.LastPaymentCurrency != nil {
+		currency = string(*sub.LastPaymentCurrency)
+	}
+
+	return [][]string{{formatTime(*sub.LastPaymentAt), amount, currency}}
+}
+
+func (s *Service) writeSessions(ctx context.Context, a *archive, userID string) error {
+	if s.sources.Sessions == nil {
+		return nil
+	}
+
+	tokens, err := s.sources.Sessions.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	rows := make([][]string, 0, len(tokens))
+	for _, t := range tokens {
+		rows = append(rows, []string{formatTime(t.CreatedAt), formatTime(t.ExpiresAt), formatOptTime(t.RevokedAt)})
+	}
+	return a.writeCSV("sessions.csv", []string{"started_at", "expires_at", "revoked_at"}, rows)
+}
+
+func (s *Service) writeIdentities(ctx context.Context, a *archive, userID string) error {
+	if s.sources.Identities == nil {
+		return nil
+	}
+
+	identities, err := s.sources.Identities.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get identities: %w", err)
+	}
+
+	rows := make([][]string, 0, len(identities))
+	for _, identity := range identities {
+		rows = append(rows, []string{identity.Provider, identity.Email, formatTime(identity.CreatedAt)})
+	}
+	return a.writeCSV("identities.csv", []string{"provider", "email", "linked_at"}, rows)
+}
+
+func (s *Service) writeAPIKeys(ctx context.Context, a *archive, userID string) error {
+	if s.sources.APIKeys == nil {
+		return nil
+	}
+
+	keys, err := s.sources.APIKeys.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	rows := make([][]string, 0, len(keys))
+	for _, key := range keys {
+		scopes := make([]string, 0, len(key.Scopes))
+		for _, scope := range key.Scopes {
+			scopes = append(scopes, string(scope))
+		}
+		rows = append(rows, []string{
+			key.Name,
+			key.Prefix,
+			strings.Join(scopes, " "),
+			formatTime(key.CreatedAt),
+			formatOptTime(key.ExpiresAt),
+			formatOptTime(key.LastUsedAt),
+			formatOptTime(key.RevokedAt),
+		})
+	}
+	return a.writeCSV("api_keys.csv",
+		[]string{"name", "prefix", "scopes", "created_at", "expires_at", "last_used_at", "revoked_at"}, rows)
+}
+
+// writePhotos copies the images of the user under photos/, without their
+// thumbnails.
+func (s *Service) writePhotos(ctx context.Context, a *archive, userID string) error {
+	if s.sources.Photos == nil {
+		return nil
+	}
+
+	var rows [][]string
+	for _, kind := range []photo.Kind{photo.KindAvatar, photo.KindProgress} {
+		list, err := s.sources.Photos.GetByUserID(ctx, userID, kind)
+		if err != nil {
+			return fmt.Errorf("failed to get photos: %w", err)
+		}
+
+		for _, p := range list {
+			name := "photos/" + path.Base(p.FileKey)
+			if err := s.copyPhoto(ctx, a, name, *p); err != nil {
+				return err
+			}
+			rows = append(rows, []string{name, string(p.Kind), formatOptDay(p.TakenOn), formatTime(p.CreatedAt)})
+		}
+	}
+
+	return a.writeCSV("photos.csv", []string{"file", "kind", "taken_on", "uploaded_at"}, rows)
+}
+
+func (s *Service) copyPhoto(ctx context.Context, a *archive, name string, p photo.Photo) error {
+	body, err := s.store.Get(ctx, p.FileKey)
+	if err != nil {
+		return fmt.Errorf("failed to open photo %s: %w", p.ID, err)
+	}
+	defer body.Close()
+
+	if err := a.writeFile(name, body); err != nil {
+		return fmt.Errorf("failed to copy photo %s: %w", p.ID, err)
+	}
+	return nil
+}
+
+// writeAuditEvents lists the events on the account. Where an admin acted,
+// their address and user agent are left out.
+func (s *Service) writeAuditEvents(ctx context.Context, a *archive, userID uuid.UUID) error {
+	if s.sources.AuditLog == nil {
+		return nil
+	}
+
+	var rows [][]string
+	for offset := 0; ; offset += auditPageSize {
+		events, total, err := s.sources.AuditLog.Search(ctx, ports.AuditFilter{
+			TargetID: &userID,
+			Limit:    auditPageSize,
+			Offset:   offset,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get audit events: %w", err)
+		}
+
+		for _, event := range events {
+			row, err := auditRow(event)
+			if err != nil {
+				return err
+			}
+			rows = append(rows, row)
+		}
+
+		if len(events) == 0 || offset+len(events) >= total {
+			break
+		}
+	}
+
+	return a.writeCSV("audit_events.csv",
+		[]string{"created_at", "action", "by_admin", "ip", "user_agent", "metadata"}, rows)
+}
+
+func auditRow(event audit.Event) ([]string, error) {
+	if !event.BySelf() {
+		event = event.WithClient(audit.Client{})
+	}
+
+	metadata := ""
+	if len(event.Metadata) > 0 {
+		data, err := json.Marshal(event.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode audit metadata: %w", err)
+		}
+		metadata = string(data)
+	}
+
+	return []string{
+		formatTime(event.CreatedAt),
+		string(event.Action),
+		formatBool(!event.BySelf()),
+		event.IP,
+		event.UserAgent,
+		metadata,
+	}, nil
+}
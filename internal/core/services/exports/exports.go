@@ -0,0 +1,168 @@
+// Package exports prepares archives of everything held about a user. Export
+// requests are queued as jobs, a worker writes the archive to the object
+// store and the user downloads it until it expires.
+package exports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/jobs"
+)
+
+var (
+	ErrExportNotFound   = errors.New("export does not exist")
+	ErrExportInProgress = errors.New("an export is already in progress")
+)
+
+// JobKind names the jobs that build archives.
+const JobKind = "user_data_export"
+
+type ExportService interface {
+	RequestExport(ctx context.Context, req RequestExportReq) (*ExportResp, error)
+	GetExport(ctx context.Context, req GetExportReq) (*ExportResp, error)
+	OpenArchive(ctx context.Context, req GetExportReq) (*OpenArchiveResp, error)
+
+	BuildExport(ctx context.Context, req BuildExportReq) error
+	PurgeExpired(ctx context.Context) (*PurgeExpiredResp, error)
+	UserFiles(ctx context.Context, userID string) ([]string, error)
+	DeleteFiles(ctx context.Context, keys []string) error
+}
+
+// Sources are where the archive is read from. Users is required, the data of
+// a nil source is left out.
+type Sources struct {
+	Users      ports.UserRepo
+	Sessions   ports.AuthRepo
+	Identities ports.IdentityRepo
+	APIKeys    ports.APIKeyRepo
+	AuditLog   ports.AuditLog
+	// Photos are read from the object store of the archives.
+	Photos ports.PhotoRepo
+}
+
+type Service struct {
+	exportRepo ports.ExportRepo
+	store      ports.ObjectStore
+	queue      ports.JobQueue
+	sources    Sources
+	ttl        time.Duration
+}
+
+type Option func(s *Service)
+
+// WithTTL sets how long archives can be downloaded, export.DefaultTTL by
+// default.
+func WithTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		if ttl > 0 {
+			s.ttl = ttl
+		}
+	}
+}
+
+func NewService(exportRepo ports.ExportRepo, store ports.ObjectStore, queue ports.JobQueue, sources Sources, opts ...Option) *Service {
+	s := &Service{
+		exportRepo: exportRepo,
+		store:      store,
+		queue:      queue,
+		sources:    sources,
+		ttl:        export.DefaultTTL,
+	}
+
+	for _, applyOption := range opts {
+		applyOption(s)
+	}
+
+	return s
+}
+
+type jobPayload struct {
+	ExportID uuid.UUID `json:"export_id"`
+}
+
+// JobHandler builds the archive of a queued export. Failures are retried,
+// the export is only marked failed on the last attempt.
+func JobHandler(svc ExportService) jobs.Handler {
+	return func(ctx context.Context, job ports.Job) error {
+		var payload jobPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to decode export job: %w", err)
+		}
+
+		return svc.BuildExport(ctx, BuildExportReq{ExportID: payload.ExportID, LastAttempt: job.LastAttempt()})
+	}
+}
+
+// StartPurge deletes expired archives every interval until ctx is done. The
+// returned channel is closed once purging has stopped.
+func StartPurge(ctx context.Context, svc ExportService, interval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := svc.PurgeExpired(ctx); err != nil {
+					logr.Get().Errorf("failed to purge expired exports: %v", err)
+				}
+			}
+		}
+	}()
+
+	return done
+}
+
+func (s *Service) getExport(ctx context.Context, id uuid.UUID) (*export.Export, error) {
+	e, err := s.exportRepo.GetByID(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, ports.ErrExportNotFound) {
+			logr.Get().Error("export not found")
+			return nil, ErrExportNotFound
+		}
+		logr.Get().Errorf("failed to get export: %v", err)
+		return nil, fmt.Errorf("failed to get export: %w", err)
+	}
+	return e, nil
+}
+
+// getOwnExport hides the exports of other users as missing.
+func (s *Service) getOwnExport(ctx context.Context, req GetExportReq) (*export.Export, error) {
+	e, err := s.getExport(ctx, req.ExportID)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.UserID != req.UserID {
+		logr.Get().Errorf("user %s asked for export %s of another user", req.UserID, req.ExportID)
+		return nil, ErrExportNotFound
+	}
+	return e, nil
+}
+
+func (s *Service) update(ctx context.Context, e export.Export) error {
+	if err := s.exportRepo.Update(ctx, e); err != nil {
+		logr.Get().Errorf("failed to update export: %v", err)
+		return fmt.Errorf("failed to update export: %w", err)
+	}
+	return nil
+}
+
+func archiveKey(e export.Export) string {
+	return fmt.Sprintf("exports/%s/%s.zip", e.UserID, e.ID)
+}
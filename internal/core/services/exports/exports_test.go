@@ -0,0 +1,364 @@
+package exports_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/filesystem"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/auth"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/exports"
+)
+
+type MockUserRepo struct {
+	mock.Mock
+}
+
+func (m *MockUserRepo) Add(ctx context.Context, u user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetByUsername(ctx context.Context, username string) (*ports.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ports.User), args.Error(1)
+}
+
+func (m *MockUserRepo) GetByEmail(ctx context.Context, email string) (*ports.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ports.User), args.Error(1)
+}
+
+func (m *MockUserRepo) GetByID(ctx context.Context, id string) (*ports.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ports.User), args.Error(1)
+}
+
+func (m *MockUserRepo) Update(ctx context.Context, u user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) UpdateRoles(ctx context.Context, id string, roles user.Roles, updatedAt time.Time) error {
+	args := m.Called(ctx, id, roles, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) UpdatePassword(ctx context.Context, id string, password user.Password, updatedAt time.Time) error {
+	args := m.Called(ctx, id, password, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) UpdateStatus(ctx context.Context, id string, status user.AccountStatus, updatedAt time.Time) error {
+	args := m.Called(ctx, id, status, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) Search(ctx context.Context, filter ports.UserFilter) ([]*ports.User, int, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*ports.User), args.Int(1), args.Error(2)
+}
+
+func (m *MockUserRepo) GetDueForPurge(ctx context.Context, requestedBefore time.Time, limit int) ([]*ports.User, error) {
+	args := m.Called(ctx, requestedBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*ports.User), args.Error(1)
+}
+
+func (m *MockUserRepo) LockForPurge(ctx context.Context, id string, requestedBefore time.Time) error {
+	args := m.Called(ctx, id, requestedBefore)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) AddUsernameChange(ctx context.Context, change user.UsernameChange) error {
+	return m.Called(ctx, change).Error(0)
+}
+
+func (m *MockUserRepo) GetUsernameChanges(ctx context.Context, userID string) ([]user.UsernameChange, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]user.UsernameChange), args.Error(1)
+}
+
+func (m *MockUserRepo) GetUsernameReservation(ctx context.Context, username string, changedAfter time.Time) (*user.UsernameChange, error) {
+	args := m.Called(ctx, username, changedAfter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.UsernameChange), args.Error(1)
+}
+
+func (m *MockUserRepo) AddStats(ctx context.Context, stats user.Stats, userID string) error {
+	args := m.Called(ctx, stats, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetStatsByID(ctx context.Context, userID string) (*user.Stats, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.Stats), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateBodyMetrics(ctx context.Context, stats ports.UpdateBodyMetrics, userID string) error {
+	args := m.Called(ctx, stats, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) AddSubscription(ctx context.Context, sub user.Subscription, userID string) error {
+	args := m.Called(ctx, sub, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetSubscriptionByID(ctx context.Context, userID string) (*user.Subscription, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.Subscription), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateSubscription(ctx context.Context, sub user.Subscription, userID string) error {
+	args := m.Called(ctx, sub, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) AddSettings(ctx context.Context, settings user.Settings, userID string) error {
+	args := m.Called(ctx, settings, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetSettingsByID(ctx context.Context, userID string) (*user.Settings, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.Settings), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateSettings(ctx context.Context, settings user.Settings, userID string) error {
+	args := m.Called(ctx, settings, userID)
+	return args.Error(0)
+}
+
+type MockAuthRepo struct {
+	mock.Mock
+}
+
+func (m *MockAuthRepo) Add(ctx context.Context, refreshToken auth.RefreshToken) error {
+	args := m.Called(ctx, refreshToken)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepo) GetByToken(ctx context.Context, token string) (*auth.RefreshToken, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.RefreshToken), args.Error(1)
+}
+
+func (m *MockAuthRepo) GetByID(ctx context.Context, userID string) ([]*auth.RefreshToken, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*auth.RefreshToken), args.Error(1)
+}
+
+func (m *MockAuthRepo) Update(ctx context.Context, refreshToken auth.RefreshToken) error {
+	args := m.Called(ctx, refreshToken)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepo) RevokeAll(ctx context.Context, userID string, revokedAt time.Time) error {
+	args := m.Called(ctx, userID, revokedAt)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepo) Delete(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+type MockExportRepo struct {
+	mock.Mock
+}
+
+func (m *MockExportRepo) Add(ctx context.Context, e export.Export) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *MockExportRepo) GetByID(ctx context.Context, id string) (*export.Export, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*export.Export), args.Error(1)
+}
+
+func (m *MockExportRepo) GetByUserID(ctx context.Context, userID string) ([]*export.Export, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*export.Export), args.Error(1)
+}
+
+func (m *MockExportRepo) Update(ctx context.Context, e export.Export) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *MockExportRepo) GetExpired(ctx context.Context, now time.Time) ([]*export.Export, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*export.Export), args.Error(1)
+}
+
+type MockJobQueue struct {
+	mock.Mock
+}
+
+func (m *MockJobQueue) Enqueue(ctx context.Context, job ports.Job) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockJobQueue) Claim(ctx context.Context) (*ports.Job, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ports.Job), args.Error(1)
+}
+
+func (m *MockJobQueue) Complete(ctx context.Context, job ports.Job) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockJobQueue) Retry(ctx context.Context, job ports.Job) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockJobQueue) Fail(ctx context.Context, job ports.Job, reason string) error {
+	args := m.Called(ctx, job, reason)
+	return args.Error(0)
+}
+
+type MockPhotoRepo struct {
+	mock.Mock
+}
+
+func (m *MockPhotoRepo) Add(ctx context.Context, p photo.Photo) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockPhotoRepo) GetByID(ctx context.Context, id string) (*photo.Photo, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*photo.Photo), args.Error(1)
+}
+
+func (m *MockPhotoRepo) GetByUserID(ctx context.Context, userID string, kind photo.Kind) ([]*photo.Photo, error) {
+	args := m.Called(ctx, userID, kind)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*photo.Photo), args.Error(1)
+}
+
+func (m *MockPhotoRepo) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestMain(m *testing.M) {
+	logr.Init(&logr.PlainTextFormatter{}, logr.LevelInfo, nil)
+
+	exitCode := m.Run()
+
+	os.Exit(exitCode)
+}
+
+type fixture struct {
+	users   *MockUserRepo
+	auth    *MockAuthRepo
+	photos  *MockPhotoRepo
+	exports *MockExportRepo
+	queue   *MockJobQueue
+	audit   *memory.AuditLog
+	store   *filesystem.ObjectStore
+	svc     *exports.Service
+
+	userID uuid.UUID
+}
+
+func newFixture(t *testing.T) *fixture {
+	store, err := filesystem.NewObjectStore(t.TempDir())
+	require.NoError(t, err)
+
+	f := &fixture{
+		users:   new(MockUserRepo),
+		auth:    new(MockAuthRepo),
+		photos:  new(MockPhotoRepo),
+		exports: new(MockExportRepo),
+		queue:   new(MockJobQueue),
+		audit:   memory.NewAuditLog(),
+		store:   store,
+		userID:  uuid.New(),
+	}
+	f.svc = exports.NewService(f.exports, f.store, f.queue, exports.Sources{
+		Users:    f.users,
+		Sessions: f.auth,
+		AuditLog: f.audit,
+		Photos:   f.photos,
+	}, exports.WithTTL(time.Hour))
+	return f
+}
+
+// expectExport serves e by its ID.
+func (f *fixture) expectExport(e export.Export) {
+	f.exports.On("GetByID", mock.Anything, e.ID.String()).Return(&e, nil)
+}
@@ -0,0 +1,56 @@
+package exports
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+)
+
+// GetExportReq asks for an export of UserID, exports of other users are
+// reported missing.
+type GetExportReq struct {
+	UserID   uuid.UUID
+	ExportID uuid.UUID
+}
+
+func (s *Service) GetExport(ctx context.Context, req GetExportReq) (*ExportResp, error) {
+	e, err := s.getOwnExport(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportResp{Export: *e}, nil
+}
+
+type OpenArchiveResp struct {
+	Export export.Export
+	// Body is the zip archive, the caller closes it.
+	Body io.ReadCloser
+}
+
+// OpenArchive opens a completed archive that has not expired yet.
+func (s *Service) OpenArchive(ctx context.Context, req GetExportReq) (*OpenArchiveResp, error) {
+	e, err := s.getOwnExport(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.Downloadable(time.Now()); err != nil {
+		logr.Get().Errorf("export %s cannot be downloaded: %v", e.ID, err)
+		return nil, err
+	}
+
+	body, err := s.store.Get(ctx, e.FileKey)
+	if err != nil {
+		logr.Get().Errorf("failed to open archive: %v", err)
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+
+	return &OpenArchiveResp{Export: *e, Body: body}, nil
+}
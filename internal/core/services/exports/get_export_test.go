@@ -0,0 +1,70 @@
+package exports_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/exports"
+)
+
+func TestGetExport_OtherUsersAreHidden(t *testing.T) {
+	f := newFixture(t)
+
+	e := export.New(uuid.New(), time.Now())
+	f.expectExport(e)
+
+	_, err := f.svc.GetExport(context.Background(), exports.GetExportReq{UserID: f.userID, ExportID: e.ID})
+	assert.ErrorIs(t, err, exports.ErrExportNotFound)
+
+	_, err = f.svc.OpenArchive(context.Background(), exports.GetExportReq{UserID: f.userID, ExportID: e.ID})
+	assert.ErrorIs(t, err, exports.ErrExportNotFound)
+}
+
+func TestOpenArchive(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	pending := export.New(uuid.Nil, now)
+	completed := pending.Complete("exports/archive.zip", 7, time.Hour, now)
+	expired := pending.Complete("exports/archive.zip", 7, time.Hour, now.Add(-2*time.Hour))
+
+	tests := []struct {
+		name    string
+		export  export.Export
+		wantErr error
+	}{
+		{name: "completed", export: completed},
+		{name: "not built yet", export: pending, wantErr: export.ErrNotReady},
+		{name: "expired", export: expired, wantErr: export.ErrExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			require.NoError(t, f.store.Put(ctx, "exports/archive.zip", "application/zip", strings.NewReader("zipped")))
+
+			tt.export.UserID = f.userID
+			f.expectExport(tt.export)
+
+			resp, err := f.svc.OpenArchive(ctx, exports.GetExportReq{UserID: f.userID, ExportID: tt.export.ID})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			data, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "zipped", string(data))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package exports
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cheezecakee/logr"
+)
+
+type PurgeExpiredResp struct {
+	Purged int
+}
+
+// PurgeExpired deletes the archives past their expiry. An archive that fails
+// to delete is tried again on the next purge.
+func (s *Service) PurgeExpired(ctx context.Context) (*PurgeExpiredResp, error) {
+	now := time.Now()
+
+	expired, err := s.exportRepo.GetExpired(ctx, now)
+	if err != nil {
+		logr.Get().Errorf("failed to get expired exports: %v", err)
+		return nil, fmt.Errorf("failed to get expired exports: %w", err)
+	}
+
+	purged := 0
+	for _, e := range expired {
+		if err := s.store.Delete(ctx, e.FileKey); err != nil {
+			logr.Get().Errorf("failed to delete archive of export %s: %v", e.ID, err)
+			continue
+		}
+		if err := s.update(ctx, e.Expire(now)); err != nil {
+			return nil, err
+		}
+		purged++
+	}
+
+	if purged > 0 {
+		logr.Get().Infof("purged %d expired exports", purged)
+	}
+	return &PurgeExpiredResp{Purged: purged}, nil
+}
+
+// UserFiles lists the archives of a user whose account is being purged.
+// The export records go with the account.
+func (s *Service) UserFiles(ctx context.Context, userID string) ([]string, error) {
+	list, err := s.exportRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		logr.Get().Errorf("failed to get exports: %v", err)
+		return nil, fmt.Errorf("failed to get exports: %w", err)
+	}
+
+	var keys []string
+	for _, e := range list {
+		if e.FileKey != "" {
+			keys = append(keys, e.FileKey)
+		}
+	}
+
+	return keys, nil
+}
+
+// DeleteFiles removes the archives listed by UserFiles once the account is
+// gone.
+func (s *Service) DeleteFiles(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		if err := s.store.Delete(ctx, key); err != nil {
+			logr.Get().Errorf("failed to delete archive %s: %v", key, err)
+			return fmt.Errorf("failed to delete archive: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package exports_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+func TestPurgeExpired(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	expired := export.New(f.userID, time.Now()).Complete("exports/old.zip", 6, time.Hour, time.Now().Add(-2*time.Hour))
+	require.NoError(t, f.store.Put(ctx, "exports/old.zip", "application/zip", strings.NewReader("zipped")))
+
+	f.exports.On("GetExpired", mock.Anything, mock.Anything).Return([]*export.Export{&expired}, nil)
+	f.exports.On("Update", mock.Anything, mock.MatchedBy(func(e export.Export) bool {
+		return e.ID == expired.ID && e.Status == export.StatusExpired && e.FileKey == ""
+	})).Return(nil)
+
+	resp, err := f.svc.PurgeExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Purged)
+
+	_, err = f.store.Get(ctx, "exports/old.zip")
+	assert.ErrorIs(t, err, ports.ErrObjectNotFound)
+	f.exports.AssertExpectations(t)
+}
+
+func TestUserFiles(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	completed := export.New(f.userID, time.Now()).Complete("exports/done.zip", 6, time.Hour, time.Now())
+	pending := export.New(f.userID, time.Now())
+	require.NoError(t, f.store.Put(ctx, "exports/done.zip", "application/zip", strings.NewReader("zipped")))
+
+	f.exports.On("GetByUserID", mock.Anything, f.userID.String()).Return([]*export.Export{&completed, &pending}, nil)
+
+	keys, err := f.svc.UserFiles(ctx, f.userID.String())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"exports/done.zip"}, keys)
+
+	require.NoError(t, f.svc.DeleteFiles(ctx, keys))
+	_, err = f.store.Get(ctx, "exports/done.zip")
+	assert.ErrorIs(t, err, ports.ErrObjectNotFound)
+	f.exports.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,66 @@
+package exports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/jobs"
+)
+
+type RequestExportReq struct {
+	UserID uuid.UUID
+}
+
+type ExportResp struct {
+	Export export.Export
+}
+
+// RequestExport queues an archive of the user's data. Users have at most one
+// export in progress.
+func (s *Service) RequestExport(ctx context.Context, req RequestExportReq) (*ExportResp, error) {
+	existing, err := s.exportRepo.GetByUserID(ctx, req.UserID.String())
+	if err != nil {
+		logr.Get().Errorf("failed to get exports: %v", err)
+		return nil, fmt.Errorf("failed to get exports: %w", err)
+	}
+	for _, e := range existing {
+		if e.IsActive() {
+			logr.Get().Errorf("user %s already has export %s in progress", req.UserID, e.ID)
+			return nil, ErrExportInProgress
+		}
+	}
+
+	e := export.New(req.UserID, time.Now())
+	if err := s.exportRepo.Add(ctx, e); err != nil {
+		// Another request won the race past the check above
+		if errors.Is(err, ports.ErrExportInProgress) {
+			logr.Get().Errorf("user %s already has an export in progress", req.UserID)
+			return nil, ErrExportInProgress
+		}
+		logr.Get().Errorf("failed to add export: %v", err)
+		return nil, fmt.Errorf("failed to add export: %w", err)
+	}
+
+	job, err := jobs.NewJob(JobKind, jobPayload{ExportID: e.ID})
+	if err == nil {
+		err = s.queue.Enqueue(ctx, job)
+	}
+	if err != nil {
+		logr.Get().Errorf("failed to enqueue export: %v", err)
+		// Leave nothing in progress that no worker will pick up
+		if err := s.update(ctx, e.Fail(time.Now())); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to enqueue export: %w", err)
+	}
+
+	logr.Get().Infof("export %s requested by user %s", e.ID, req.UserID)
+	return &ExportResp{Export: e}, nil
+}
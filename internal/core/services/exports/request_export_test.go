@@ -0,0 +1,85 @@
+package exports_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/export"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/exports"
+)
+
+func TestRequestExport(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	finished := export.New(f.userID, time.Now()).Complete("key", 1, time.Hour, time.Now())
+	f.exports.On("GetByUserID", mock.Anything, f.userID.String()).Return([]*export.Export{&finished}, nil)
+	f.exports.On("Add", mock.Anything, mock.MatchedBy(func(e export.Export) bool {
+		return e.UserID == f.userID && e.Status == export.StatusPending
+	})).Return(nil)
+
+	var queued ports.Job
+	f.queue.On("Enqueue", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		queued = args.Get(1).(ports.Job)
+	}).Return(nil)
+
+	resp, err := f.svc.RequestExport(ctx, exports.RequestExportReq{UserID: f.userID})
+	require.NoError(t, err)
+	assert.Equal(t, export.StatusPending, resp.Export.Status)
+
+	assert.Equal(t, exports.JobKind, queued.Kind)
+	var payload struct {
+		ExportID string `json:"export_id"`
+	}
+	require.NoError(t, json.Unmarshal(queued.Payload, &payload))
+	assert.Equal(t, resp.Export.ID.String(), payload.ExportID)
+
+	f.exports.AssertExpectations(t)
+}
+
+func TestRequestExport_OneInProgress(t *testing.T) {
+	f := newFixture(t)
+
+	running := export.New(f.userID, time.Now()).Start(time.Now())
+	f.exports.On("GetByUserID", mock.Anything, f.userID.String()).Return([]*export.Export{&running}, nil)
+
+	_, err := f.svc.RequestExport(context.Background(), exports.RequestExportReq{UserID: f.userID})
+	assert.ErrorIs(t, err, exports.ErrExportInProgress)
+	f.exports.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+}
+
+func TestRequestExport_LosesRace(t *testing.T) {
+	f := newFixture(t)
+
+	f.exports.On("GetByUserID", mock.Anything, f.userID.String()).Return([]*export.Export{}, nil)
+	f.exports.On("Add", mock.Anything, mock.Anything).Return(ports.ErrExportInProgress)
+
+	_, err := f.svc.RequestExport(context.Background(), exports.RequestExportReq{UserID: f.userID})
+	assert.ErrorIs(t, err, exports.ErrExportInProgress)
+	f.queue.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything)
+}
+
+func TestRequestExport_QueueUnavailable(t *testing.T) {
+	f := newFixture(t)
+
+	f.exports.On("GetByUserID", mock.Anything, f.userID.String()).Return([]*export.Export{}, nil)
+	f.exports.On("Add", mock.Anything, mock.Anything).Return(nil)
+	f.queue.On("Enqueue", mock.Anything, mock.Anything).Return(errors.New("disk full"))
+	f.exports.On("Update", mock.Anything, mock.MatchedBy(func(e export.Export) bool {
+		return e.Status == export.StatusFailed
+	})).Return(nil)
+
+	_, err := f.svc.RequestExport(context.Background(), exports.RequestExportReq{UserID: f.userID})
+	assert.Error(t, err)
+
+	// Otherwise the user could never ask again
+	f.exports.AssertExpectations(t)
+}
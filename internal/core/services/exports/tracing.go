@@ -0,0 +1,75 @@
+package exports
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/cheezecakee/fitrkr-athena/internal/core/services/exports"
+
+// tracedService records a span around every ExportService call.
+type tracedService struct {
+	next   ExportService
+	tracer trace.Tracer
+}
+
+// NewTracedService wraps svc so every call records a span named after the
+// method, marked as failed when the call returns an error.
+func NewTracedService(svc ExportService, tp trace.TracerProvider) ExportService {
+	return &tracedService{next: svc, tracer: tp.Tracer(instrumentationName)}
+}
+
+func (s *tracedService) start(ctx context.Context, method string) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "exports.Service."+method)
+}
+
+func end(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+	return err
+}
+
+func (s *tracedService) RequestExport(ctx context.Context, req RequestExportReq) (*ExportResp, error) {
+	ctx, span := s.start(ctx, "RequestExport")
+	resp, err := s.next.RequestExport(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) GetExport(ctx context.Context, req GetExportReq) (*ExportResp, error) {
+	ctx, span := s.start(ctx, "GetExport")
+	resp, err := s.next.GetExport(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) OpenArchive(ctx context.Context, req GetExportReq) (*OpenArchiveResp, error) {
+	ctx, span := s.start(ctx, "OpenArchive")
+	resp, err := s.next.OpenArchive(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) BuildExport(ctx context.Context, req BuildExportReq) error {
+	ctx, span := s.start(ctx, "BuildExport")
+	return end(span, s.next.BuildExport(ctx, req))
+}
+
+func (s *tracedService) PurgeExpired(ctx context.Context) (*PurgeExpiredResp, error) {
+	ctx, span := s.start(ctx, "PurgeExpired")
+	resp, err := s.next.PurgeExpired(ctx)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) UserFiles(ctx context.Context, userID string) ([]string, error) {
+	ctx, span := s.start(ctx, "UserFiles")
+	keys, err := s.next.UserFiles(ctx, userID)
+	return keys, end(span, err)
+}
+
+func (s *tracedService) DeleteFiles(ctx context.Context, keys []string) error {
+	ctx, span := s.start(ctx, "DeleteFiles")
+	return end(span, s.next.DeleteFiles(ctx, keys))
+}
@@ -0,0 +1,36 @@
+package exports_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/exports"
+)
+
+func TestTracedService(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	f := newFixture(t)
+	exportID := uuid.New()
+	f.exports.On("GetByID", mock.Anything, exportID.String()).Return(nil, ports.ErrExportNotFound)
+
+	svc := exports.NewTracedService(f.svc, tp)
+
+	_, err := svc.GetExport(context.Background(), exports.GetExportReq{UserID: f.userID, ExportID: exportID})
+	require.ErrorIs(t, err, exports.ErrExportNotFound)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "exports.Service.GetExport", spans[0].Name)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+}
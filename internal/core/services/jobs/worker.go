@@ -0,0 +1,168 @@
+// Package jobs runs background work from a ports.JobQueue. Services register
+// a handler per job kind and enqueue jobs with NewJob.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+const (
+	DefaultMaxAttempts  = 3
+	defaultPollInterval = 5 * time.Second
+	defaultBackoff      = 30 * time.Second
+)
+
+// Handler runs one job. A returned error retries the job until it has used
+// its attempts.
+type Handler func(ctx context.Context, job ports.Job) error
+
+// NewJob encodes payload as the input of a job of kind.
+func NewJob(kind string, payload any) (ports.Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return ports.Job{}, fmt.Errorf("failed to encode %s job: %w", kind, err)
+	}
+
+	now := time.Now()
+	return ports.Job{
+		ID:          uuid.New(),
+		Kind:        kind,
+		Payload:     data,
+		MaxAttempts: DefaultMaxAttempts,
+		RunAt:       now,
+		CreatedAt:   now,
+	}, nil
+}
+
+type Worker struct {
+	queue        ports.JobQueue
+	pollInterval time.Duration
+	backoff      time.Duration
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+type Option func(w *Worker)
+
+// WithPollInterval sets how often an idle worker looks for jobs.
+func WithPollInterval(interval time.Duration) Option {
+	return func(w *Worker) {
+		if interval > 0 {
+			w.pollInterval = interval
+		}
+	}
+}
+
+// WithBackoff sets the delay before the first retry, it grows with every
+// attempt.
+func WithBackoff(backoff time.Duration) Option {
+	return func(w *Worker) {
+		if backoff >= 0 {
+			w.backoff = backoff
+		}
+	}
+}
+
+func NewWorker(queue ports.JobQueue, opts ...Option) *Worker {
+	w := &Worker{
+		queue:        queue,
+		pollInterval: defaultPollInterval,
+		backoff:      defaultBackoff,
+		handlers:     map[string]Handler{},
+	}
+
+	for _, applyOption := range opts {
+		applyOption(w)
+	}
+
+	return w
+}
+
+// Handle registers the handler of kind, replacing any earlier one.
+func (w *Worker) Handle(kind string, handler Handler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.handlers[kind] = handler
+}
+
+// RunOnce claims and runs a single job. It reports false when no job was
+// due.
+func (w *Worker) RunOnce(ctx context.Context) (bool, error) {
+	job, err := w.queue.Claim(ctx)
+	if err != nil {
+		if errors.Is(err, ports.ErrNoJob) {
+			return false, nil
+		}
+		logr.Get().Errorf("failed to claim job: %v", err)
+		return false, fmt.Errorf("failed to claim job: %w", err)
+	}
+
+	w.mu.RLock()
+	handler, ok := w.handlers[job.Kind]
+	w.mu.RUnlock()
+
+	if !ok {
+		logr.Get().Errorf("no handler for %s job %s", job.Kind, job.ID)
+		return true, w.queue.Fail(ctx, *job, "no handler for "+job.Kind)
+	}
+
+	if err := handler(ctx, *job); err != nil {
+		if job.LastAttempt() {
+			logr.Get().Errorf("%s job %s failed after %d attempts: %v", job.Kind, job.ID, job.Attempts, err)
+			return true, w.queue.Fail(ctx, *job, err.Error())
+		}
+
+		logr.Get().Warnf("%s job %s failed, retrying: %v", job.Kind, job.ID, err)
+		job.RunAt = time.Now().Add(w.backoff * time.Duration(job.Attempts))
+		return true, w.queue.Retry(ctx, *job)
+	}
+
+	logr.Get().Infof("%s job %s done", job.Kind, job.ID)
+	return true, w.queue.Complete(ctx, *job)
+}
+
+// Start runs jobs until ctx is done, draining the queue every poll
+// interval. The returned channel is closed once the worker has stopped.
+func (w *Worker) Start(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(w.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			w.drain(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return done
+}
+
+func (w *Worker) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		ran, err := w.RunOnce(ctx)
+		if err != nil || !ran {
+			return
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package jobs_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/filesystem"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/jobs"
+)
+
+func TestMain(m *testing.M) {
+	logr.Init(&logr.PlainTextFormatter{}, logr.LevelInfo, nil)
+
+	exitCode := m.Run()
+
+	os.Exit(exitCode)
+}
+
+type payload struct {
+	Name string `json:"name"`
+}
+
+func TestWorker_RunsRegisteredHandler(t *testing.T) {
+	ctx := context.Background()
+	queue, err := filesystem.NewJobQueue(t.TempDir())
+	require.NoError(t, err)
+
+	worker := jobs.NewWorker(queue)
+
+	var got payload
+	worker.Handle("greet", func(ctx context.Context, job ports.Job) error {
+		return json.Unmarshal(job.Payload, &got)
+	})
+
+	job, err := jobs.NewJob("greet", payload{Name: "jane"})
+	require.NoError(t, err)
+	require.NoError(t, queue.Enqueue(ctx, job))
+
+	ran, err := worker.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.True(t, ran)
+	assert.Equal(t, "jane", got.Name)
+
+	ran, err = worker.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.False(t, ran, "expected the queue drained")
+}
+
+func TestWorker_RetriesThenFails(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	queue, err := filesystem.NewJobQueue(root)
+	require.NoError(t, err)
+
+	worker := jobs.NewWorker(queue, jobs.WithBackoff(0))
+
+	var attempts []int
+	worker.Handle("flaky", func(ctx context.Context, job ports.Job) error {
+		attempts = append(attempts, job.Attempts)
+		return errors.New("unavailable")
+	})
+
+	job, err := jobs.NewJob("flaky", nil)
+	require.NoError(t, err)
+	require.NoError(t, queue.Enqueue(ctx, job))
+
+	for range jobs.DefaultMaxAttempts {
+		ran, err := worker.RunOnce(ctx)
+		require.NoError(t, err)
+		require.True(t, ran)
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, attempts)
+	_, err = os.Stat(filepath.Join(root, "failed", job.ID.String()+".json"))
+	assert.NoError(t, err, "expected the job set aside after its last attempt")
+
+	ran, err := worker.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.False(t, ran)
+}
+
+func TestWorker_FailsUnknownKinds(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	queue, err := filesystem.NewJobQueue(root)
+	require.NoError(t, err)
+
+	job, err := jobs.NewJob("unknown", nil)
+	require.NoError(t, err)
+	require.NoError(t, queue.Enqueue(ctx, job))
+
+	ran, err := jobs.NewWorker(queue).RunOnce(ctx)
+	require.NoError(t, err)
+	assert.True(t, ran)
+
+	_, err = os.Stat(filepath.Join(root, "failed", job.ID.String()+".json"))
+	assert.NoError(t, err)
+}
+
+func TestWorker_StopsWithContext(t *testing.T) {
+	queue, err := filesystem.NewJobQueue(t.TempDir())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := jobs.NewWorker(queue, jobs.WithPollInterval(time.Hour)).Start(ctx)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop after cancel")
+	}
+}
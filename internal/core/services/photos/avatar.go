@@ -0,0 +1,99 @@
+package photos
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+)
+
+type UploadAvatarReq struct {
+	UserID uuid.UUID
+	Body   io.Reader
+}
+
+// UploadAvatar replaces the avatar of the user. The previous one is deleted
+// once the new one is saved, a leftover is cleaned up by the next upload.
+func (s *Service) UploadAvatar(ctx context.Context, req UploadAvatarReq) (*PhotoResp, error) {
+	img, err := s.process(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.list(ctx, req.UserID.String(), photo.KindAvatar)
+	if err != nil {
+		return nil, err
+	}
+
+	p := photo.NewAvatar(req.UserID, img, time.Now())
+	if err := s.save(ctx, p, img); err != nil {
+		return nil, err
+	}
+
+	for _, old := range previous {
+		if err := s.remove(ctx, *old); err != nil {
+			logr.Get().Errorf("failed to remove previous avatar %s: %v", old.ID, err)
+		}
+	}
+
+	logr.Get().Infof("avatar %s uploaded by user %s", p.ID, req.UserID)
+	return &PhotoResp{Photo: p}, nil
+}
+
+// OpenAvatarReq asks for the avatar of UserID, any signed in user can see
+// it.
+type OpenAvatarReq struct {
+	UserID    uuid.UUID
+	Thumbnail bool
+}
+
+func (s *Service) OpenAvatar(ctx context.Context, req OpenAvatarReq) (*OpenPhotoResp, error) {
+	avatars, err := s.list(ctx, req.UserID.String(), photo.KindAvatar)
+	if err != nil {
+		return nil, err
+	}
+	if len(avatars) == 0 {
+		logr.Get().Errorf("user %s has no avatar", req.UserID)
+		return nil, ErrPhotoNotFound
+	}
+
+	return s.open(ctx, *avatars[0], req.Thumbnail)
+}
+
+type DeleteAvatarReq struct {
+	UserID uuid.UUID
+}
+
+func (s *Service) DeleteAvatar(ctx context.Context, req DeleteAvatarReq) error {
+	avatars, err := s.list(ctx, req.UserID.String(), photo.KindAvatar)
+	if err != nil {
+		return err
+	}
+	if len(avatars) == 0 {
+		logr.Get().Errorf("user %s has no avatar", req.UserID)
+		return ErrPhotoNotFound
+	}
+
+	for _, p := range avatars {
+		if err := s.remove(ctx, *p); err != nil {
+			return err
+		}
+	}
+
+	logr.Get().Infof("avatar of user %s deleted", req.UserID)
+	return nil
+}
+
+// HasAvatar lets the users service link to the avatar of a user only when
+// there is one.
+func (s *Service) HasAvatar(ctx context.Context, userID string) (bool, error) {
+	avatars, err := s.list(ctx, userID, photo.KindAvatar)
+	if err != nil {
+		return false, err
+	}
+	return len(avatars) > 0, nil
+}
@@ -0,0 +1,149 @@
+package photos_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/photos"
+)
+
+func TestUploadAvatar(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	previous := f.stored(t, f.userID, photo.KindAvatar)
+	f.photos.On("GetByUserID", mock.Anything, f.userID.String(), photo.KindAvatar).Return([]*photo.Photo{previous}, nil)
+
+	var added photo.Photo
+	f.photos.On("Add", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		added = args.Get(1).(photo.Photo)
+	}).Return(nil)
+	f.photos.On("Delete", mock.Anything, previous.ID.String()).Return(nil)
+
+	resp, err := f.svc.UploadAvatar(ctx, photos.UploadAvatarReq{UserID: f.userID, Body: bytes.NewReader(testJPEG(t, 640, 480))})
+	require.NoError(t, err)
+
+	assert.Equal(t, added, resp.Photo)
+	assert.Equal(t, photo.KindAvatar, resp.Photo.Kind)
+	assert.Equal(t, "image/jpeg", resp.Photo.ContentType)
+	assert.True(t, f.exists(resp.Photo.FileKey))
+	assert.True(t, f.exists(resp.Photo.ThumbnailKey))
+	assert.False(t, f.exists(previous.FileKey), "expected the previous avatar deleted")
+	f.photos.AssertExpectations(t)
+}
+
+func TestUploadAvatar_Rejects(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		body []byte
+		opts []photos.Option
+		want error
+	}{
+		{name: "not an image", body: []byte("#!/bin/sh\nrm -rf /"), want: photo.ErrUnsupportedType},
+		{name: "svg", body: []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`), want: photo.ErrUnsupportedType},
+		{name: "over the size limit", body: bytes.Repeat([]byte{0xff}, 2048), opts: []photos.Option{photos.WithMaxSize(1024)}, want: photo.ErrTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, tt.opts...)
+
+			_, err := f.svc.UploadAvatar(ctx, photos.UploadAvatarReq{UserID: f.userID, Body: bytes.NewReader(tt.body)})
+			assert.ErrorIs(t, err, tt.want)
+			f.photos.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUploadAvatar_CleansUpWhenNotRecorded(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	f.photos.On("GetByUserID", mock.Anything, f.userID.String(), photo.KindAvatar).Return([]*photo.Photo{}, nil)
+
+	var added photo.Photo
+	f.photos.On("Add", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		added = args.Get(1).(photo.Photo)
+	}).Return(errors.New("db error"))
+
+	_, err := f.svc.UploadAvatar(ctx, photos.UploadAvatarReq{UserID: f.userID, Body: bytes.NewReader(testJPEG(t, 32, 32))})
+	require.Error(t, err)
+
+	assert.False(t, f.exists(added.FileKey))
+	assert.False(t, f.exists(added.ThumbnailKey))
+}
+
+func TestOpenAvatar(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	avatar := f.stored(t, f.userID, photo.KindAvatar)
+	f.photos.On("GetByUserID", mock.Anything, f.userID.String(), photo.KindAvatar).Return([]*photo.Photo{avatar}, nil)
+
+	t.Run("thumbnail", func(t *testing.T) {
+		resp, err := f.svc.OpenAvatar(ctx, photos.OpenAvatarReq{UserID: f.userID, Thumbnail: true})
+		require.NoError(t, err)
+
+		img, _, err := image.Decode(bytes.NewReader(readAll(t, resp.Body)))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 64, 48), img.Bounds())
+	})
+
+	t.Run("no avatar", func(t *testing.T) {
+		other := newFixture(t)
+		other.photos.On("GetByUserID", mock.Anything, other.userID.String(), photo.KindAvatar).Return([]*photo.Photo{}, nil)
+
+		_, err := other.svc.OpenAvatar(ctx, photos.OpenAvatarReq{UserID: other.userID})
+		assert.ErrorIs(t, err, photos.ErrPhotoNotFound)
+	})
+}
+
+func TestDeleteAvatar(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	avatar := f.stored(t, f.userID, photo.KindAvatar)
+	f.photos.On("GetByUserID", mock.Anything, f.userID.String(), photo.KindAvatar).Return([]*photo.Photo{avatar}, nil).Once()
+	f.photos.On("Delete", mock.Anything, avatar.ID.String()).Return(nil)
+
+	require.NoError(t, f.svc.DeleteAvatar(ctx, photos.DeleteAvatarReq{UserID: f.userID}))
+	assert.False(t, f.exists(avatar.FileKey))
+	assert.False(t, f.exists(avatar.ThumbnailKey))
+
+	f.photos.On("GetByUserID", mock.Anything, f.userID.String(), photo.KindAvatar).Return([]*photo.Photo{}, nil)
+	err := f.svc.DeleteAvatar(ctx, photos.DeleteAvatarReq{UserID: f.userID})
+	assert.ErrorIs(t, err, photos.ErrPhotoNotFound)
+}
+
+func TestHasAvatar(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("with an avatar", func(t *testing.T) {
+		f := newFixture(t)
+		avatar := f.stored(t, f.userID, photo.KindAvatar)
+		f.photos.On("GetByUserID", mock.Anything, f.userID.String(), photo.KindAvatar).Return([]*photo.Photo{avatar}, nil)
+
+		has, err := f.svc.HasAvatar(ctx, f.userID.String())
+		require.NoError(t, err)
+		assert.True(t, has)
+	})
+
+	t.Run("without an avatar", func(t *testing.T) {
+		f := newFixture(t)
+		f.photos.On("GetByUserID", mock.Anything, f.userID.String(), photo.KindAvatar).Return([]*photo.Photo{}, nil)
+
+		has, err := f.svc.HasAvatar(ctx, f.userID.String())
+		require.NoError(t, err)
+		assert.False(t, has)
+	})
+}
@@ -0,0 +1,161 @@
+// Package photos keeps the pictures users upload. Uploads are checked and
+// re-encoded by photo.Process before anything is stored, so what is served
+// back never carries the metadata of the original.
+package photos
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cheezecakee/logr"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+var ErrPhotoNotFound = errors.New("photo does not exist")
+
+type PhotoService interface {
+	UploadAvatar(ctx context.Context, req UploadAvatarReq) (*PhotoResp, error)
+	OpenAvatar(ctx context.Context, req OpenAvatarReq) (*OpenPhotoResp, error)
+	DeleteAvatar(ctx context.Context, req DeleteAvatarReq) error
+	HasAvatar(ctx context.Context, userID string) (bool, error)
+
+	UploadProgressPhoto(ctx context.Context, req UploadProgressPhotoReq) (*PhotoResp, error)
+	ListProgressPhotos(ctx context.Context, req ListProgressPhotosReq) (*ListProgressPhotosResp, error)
+	OpenProgressPhoto(ctx context.Context, req OpenProgressPhotoReq) (*OpenPhotoResp, error)
+	DeleteProgressPhoto(ctx context.Context, req ProgressPhotoReq) error
+
+	UserFiles(ctx context.Context, userID string) ([]string, error)
+	DeleteFiles(ctx context.Context, keys []string) error
+}
+
+type Service struct {
+	photoRepo ports.PhotoRepo
+	store     ports.ObjectStore
+	maxSize   int64
+}
+
+type Option func(s *Service)
+
+// WithMaxSize sets the largest upload accepted in bytes,
+// photo.DefaultMaxSize by default.
+func WithMaxSize(size int64) Option {
+	return func(s *Service) {
+		if size > 0 {
+			s.maxSize = size
+		}
+	}
+}
+
+func NewService(photoRepo ports.PhotoRepo, store ports.ObjectStore, opts ...Option) *Service {
+	s := &Service{
+		photoRepo: photoRepo,
+		store:     store,
+		maxSize:   photo.DefaultMaxSize,
+	}
+
+	for _, applyOption := range opts {
+		applyOption(s)
+	}
+
+	return s
+}
+
+type PhotoResp struct {
+	Photo photo.Photo
+}
+
+type OpenPhotoResp struct {
+	Photo photo.Photo
+	// Body is the image or its thumbnail, the caller closes it.
+	Body io.ReadCloser
+}
+
+// process reads an upload up to the size limit and cleans it up.
+func (s *Service) process(body io.Reader) (photo.Image, error) {
+	data, err := io.ReadAll(io.LimitReader(body, s.maxSize+1))
+	if err != nil {
+		logr.Get().Errorf("failed to read upload: %v", err)
+		return photo.Image{}, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if int64(len(data)) > s.maxSize {
+		logr.Get().Errorf("upload is over %d bytes", s.maxSize)
+		return photo.Image{}, photo.ErrTooLarge
+	}
+
+	img, err := photo.Process(data)
+	if err != nil {
+		logr.Get().Errorf("upload refused: %v", err)
+		return photo.Image{}, err
+	}
+	return img, nil
+}
+
+// save stores the image and its thumbnail, then records p. Nothing is left
+// behind when a step fails.
+func (s *Service) save(ctx context.Context, p photo.Photo, img photo.Image) error {
+	err := s.store.Put(ctx, p.FileKey, p.ContentType, bytes.NewReader(img.Data))
+	if err == nil {
+		err = s.store.Put(ctx, p.ThumbnailKey, p.ContentType, bytes.NewReader(img.Thumbnail))
+	}
+	if err == nil {
+		err = s.photoRepo.Add(ctx, p)
+	}
+	if err != nil {
+		logr.Get().Errorf("failed to save photo: %v", err)
+		s.deleteFiles(ctx, p)
+		return fmt.Errorf("failed to save photo: %w", err)
+	}
+	return nil
+}
+
+// remove deletes the files before the record, so a failed removal can be
+// retried and never leaves files nothing points to.
+func (s *Service) remove(ctx context.Context, p photo.Photo) error {
+	if err := s.deleteFiles(ctx, p); err != nil {
+		return fmt.Errorf("failed to delete photo files: %w", err)
+	}
+
+	if err := s.photoRepo.Delete(ctx, p.ID.String()); err != nil && !errors.Is(err, ports.ErrPhotoNotFound) {
+		logr.Get().Errorf("failed to delete photo %s: %v", p.ID, err)
+		return fmt.Errorf("failed to delete photo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) deleteFiles(ctx context.Context, p photo.Photo) error {
+	for _, key := range []string{p.FileKey, p.ThumbnailKey} {
+		if err := s.store.Delete(ctx, key); err != nil {
+			logr.Get().Errorf("failed to delete %s: %v", key, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) open(ctx context.Context, p photo.Photo, thumbnail bool) (*OpenPhotoResp, error) {
+	body, err := s.store.Get(ctx, p.Key(thumbnail))
+	if err != nil {
+		if errors.Is(err, ports.ErrObjectNotFound) {
+			logr.Get().Errorf("files of photo %s are missing", p.ID)
+			return nil, ErrPhotoNotFound
+		}
+		logr.Get().Errorf("failed to open photo: %v", err)
+		return nil, fmt.Errorf("failed to open photo: %w", err)
+	}
+
+	return &OpenPhotoResp{Photo: p, Body: body}, nil
+}
+
+func (s *Service) list(ctx context.Context, userID string, kind photo.Kind) ([]*photo.Photo, error) {
+	photos, err := s.photoRepo.GetByUserID(ctx, userID, kind)
+	if err != nil {
+		logr.Get().Errorf("failed to get photos: %v", err)
+		return nil, fmt.Errorf("failed to get photos: %w", err)
+	}
+	return photos, nil
+}
@@ -0,0 +1,121 @@
+package photos_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/filesystem"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/photos"
+)
+
+type MockPhotoRepo struct {
+	mock.Mock
+}
+
+func (m *MockPhotoRepo) Add(ctx context.Context, p photo.Photo) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockPhotoRepo) GetByID(ctx context.Context, id string) (*photo.Photo, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*photo.Photo), args.Error(1)
+}
+
+func (m *MockPhotoRepo) GetByUserID(ctx context.Context, userID string, kind photo.Kind) ([]*photo.Photo, error) {
+	args := m.Called(ctx, userID, kind)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*photo.Photo), args.Error(1)
+}
+
+func (m *MockPhotoRepo) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestMain(m *testing.M) {
+	logr.Init(&logr.PlainTextFormatter{}, logr.LevelInfo, nil)
+
+	exitCode := m.Run()
+
+	os.Exit(exitCode)
+}
+
+type fixture struct {
+	photos *MockPhotoRepo
+	store  *filesystem.ObjectStore
+	svc    *photos.Service
+
+	userID uuid.UUID
+}
+
+func newFixture(t *testing.T, opts ...photos.Option) *fixture {
+	store, err := filesystem.NewObjectStore(t.TempDir())
+	require.NoError(t, err)
+
+	f := &fixture{
+		photos: new(MockPhotoRepo),
+		store:  store,
+		userID: uuid.New(),
+	}
+	f.svc = photos.NewService(f.photos, f.store, opts...)
+	return f
+}
+
+// stored saves a photo of kind owned by userID to the store and returns it.
+func (f *fixture) stored(t *testing.T, userID uuid.UUID, kind photo.Kind) *photo.Photo {
+	t.Helper()
+
+	img, err := photo.Process(testJPEG(t, 64, 48))
+	require.NoError(t, err)
+
+	p := photo.NewAvatar(userID, img, time.Now())
+	p.Kind = kind
+	require.NoError(t, f.store.Put(context.Background(), p.FileKey, p.ContentType, bytes.NewReader(img.Data)))
+	require.NoError(t, f.store.Put(context.Background(), p.ThumbnailKey, p.ContentType, bytes.NewReader(img.Thumbnail)))
+	return &p
+}
+
+// exists reports whether the store holds key.
+func (f *fixture) exists(key string) bool {
+	body, err := f.store.Get(context.Background(), key)
+	if err != nil {
+		return false
+	}
+	body.Close()
+	return true
+}
+
+func testJPEG(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h)), nil))
+	return buf.Bytes()
+}
+
+func readAll(t *testing.T, body io.ReadCloser) []byte {
+	t.Helper()
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	return data
+}
@@ -0,0 +1,118 @@
+package photos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+type UploadProgressPhotoReq struct {
+	UserID uuid.UUID
+	// TakenOn is the day of the measurements the photo goes with, today
+	// when zero.
+	TakenOn time.Time
+	Body    io.Reader
+}
+
+func (s *Service) UploadProgressPhoto(ctx context.Context, req UploadProgressPhotoReq) (*PhotoResp, error) {
+	img, err := s.process(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := photo.NewProgress(req.UserID, img, req.TakenOn, time.Now())
+	if err != nil {
+		logr.Get().Errorf("invalid progress photo: %v", err)
+		return nil, err
+	}
+
+	if err := s.save(ctx, p, img); err != nil {
+		return nil, err
+	}
+
+	logr.Get().Infof("progress photo %s uploaded by user %s", p.ID, req.UserID)
+	return &PhotoResp{Photo: p}, nil
+}
+
+type ListProgressPhotosReq struct {
+	UserID uuid.UUID
+}
+
+type ListProgressPhotosResp struct {
+	Photos []photo.Photo
+}
+
+// ListProgressPhotos returns the photos of the user, newest day first.
+func (s *Service) ListProgressPhotos(ctx context.Context, req ListProgressPhotosReq) (*ListProgressPhotosResp, error) {
+	list, err := s.list(ctx, req.UserID.String(), photo.KindProgress)
+	if err != nil {
+		return nil, err
+	}
+
+	photos := make([]photo.Photo, 0, len(list))
+	for _, p := range list {
+		photos = append(photos, *p)
+	}
+	return &ListProgressPhotosResp{Photos: photos}, nil
+}
+
+// ProgressPhotoReq asks for a progress photo of UserID. Progress photos are
+// only ever shown to their owner, those of other users are reported missing.
+type ProgressPhotoReq struct {
+	UserID  uuid.UUID
+	PhotoID uuid.UUID
+}
+
+type OpenProgressPhotoReq struct {
+	ProgressPhotoReq
+	Thumbnail bool
+}
+
+func (s *Service) OpenProgressPhoto(ctx context.Context, req OpenProgressPhotoReq) (*OpenPhotoResp, error) {
+	p, err := s.getOwnProgressPhoto(ctx, req.ProgressPhotoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.open(ctx, *p, req.Thumbnail)
+}
+
+func (s *Service) DeleteProgressPhoto(ctx context.Context, req ProgressPhotoReq) error {
+	p, err := s.getOwnProgressPhoto(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if err := s.remove(ctx, *p); err != nil {
+		return err
+	}
+
+	logr.Get().Infof("progress photo %s deleted by user %s", p.ID, req.UserID)
+	return nil
+}
+
+func (s *Service) getOwnProgressPhoto(ctx context.Context, req ProgressPhotoReq) (*photo.Photo, error) {
+	p, err := s.photoRepo.GetByID(ctx, req.PhotoID.String())
+	if err != nil {
+		if errors.Is(err, ports.ErrPhotoNotFound) {
+			logr.Get().Error("photo not found")
+			return nil, ErrPhotoNotFound
+		}
+		logr.Get().Errorf("failed to get photo: %v", err)
+		return nil, fmt.Errorf("failed to get photo: %w", err)
+	}
+
+	if p.Kind != photo.KindProgress || !p.IsOwnedBy(req.UserID) {
+		logr.Get().Errorf("user %s asked for photo %s they do not own", req.UserID, req.PhotoID)
+		return nil, ErrPhotoNotFound
+	}
+	return p, nil
+}
@@ -0,0 +1,121 @@
+package photos_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/photos"
+)
+
+func TestUploadProgressPhoto(t *testing.T) {
+	ctx := context.Background()
+	takenOn := time.Now().AddDate(0, 0, -3)
+
+	t.Run("success", func(t *testing.T) {
+		f := newFixture(t)
+		f.photos.On("Add", mock.Anything, mock.MatchedBy(func(p photo.Photo) bool {
+			return p.Kind == photo.KindProgress && p.UserID == f.userID && p.TakenOn.Equal(photo.Day(takenOn))
+		})).Return(nil)
+
+		resp, err := f.svc.UploadProgressPhoto(ctx, photos.UploadProgressPhotoReq{
+			UserID:  f.userID,
+			TakenOn: takenOn,
+			Body:    bytes.NewReader(testJPEG(t, 300, 400)),
+		})
+		require.NoError(t, err)
+		assert.True(t, f.exists(resp.Photo.FileKey))
+		f.photos.AssertExpectations(t)
+	})
+
+	t.Run("error - taken in the future", func(t *testing.T) {
+		f := newFixture(t)
+
+		_, err := f.svc.UploadProgressPhoto(ctx, photos.UploadProgressPhotoReq{
+			UserID:  f.userID,
+			TakenOn: time.Now().AddDate(0, 1, 0),
+			Body:    bytes.NewReader(testJPEG(t, 30, 40)),
+		})
+		assert.ErrorIs(t, err, photo.ErrTakenInFuture)
+		f.photos.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOpenProgressPhoto_Ownership(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	own := f.stored(t, f.userID, photo.KindProgress)
+	others := f.stored(t, uuid.New(), photo.KindProgress)
+	avatar := f.stored(t, f.userID, photo.KindAvatar)
+	for _, p := range []*photo.Photo{own, others, avatar} {
+		f.photos.On("GetByID", mock.Anything, p.ID.String()).Return(p, nil)
+	}
+	missing := uuid.New()
+	f.photos.On("GetByID", mock.Anything, missing.String()).Return(nil, ports.ErrPhotoNotFound)
+
+	tests := []struct {
+		name    string
+		photoID uuid.UUID
+		wantErr error
+	}{
+		{name: "own photo", photoID: own.ID},
+		{name: "photo of another user", photoID: others.ID, wantErr: photos.ErrPhotoNotFound},
+		{name: "avatar is not a progress photo", photoID: avatar.ID, wantErr: photos.ErrPhotoNotFound},
+		{name: "missing", photoID: missing, wantErr: photos.ErrPhotoNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := f.svc.OpenProgressPhoto(ctx, photos.OpenProgressPhotoReq{
+				ProgressPhotoReq: photos.ProgressPhotoReq{UserID: f.userID, PhotoID: tt.photoID},
+			})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, readAll(t, resp.Body))
+		})
+	}
+}
+
+func TestDeleteProgressPhoto(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	own := f.stored(t, f.userID, photo.KindProgress)
+	others := f.stored(t, uuid.New(), photo.KindProgress)
+	f.photos.On("GetByID", mock.Anything, own.ID.String()).Return(own, nil)
+	f.photos.On("GetByID", mock.Anything, others.ID.String()).Return(others, nil)
+	f.photos.On("Delete", mock.Anything, own.ID.String()).Return(nil)
+
+	err := f.svc.DeleteProgressPhoto(ctx, photos.ProgressPhotoReq{UserID: f.userID, PhotoID: others.ID})
+	assert.ErrorIs(t, err, photos.ErrPhotoNotFound)
+	assert.True(t, f.exists(others.FileKey), "expected the photo of another user kept")
+
+	require.NoError(t, f.svc.DeleteProgressPhoto(ctx, photos.ProgressPhotoReq{UserID: f.userID, PhotoID: own.ID}))
+	assert.False(t, f.exists(own.FileKey))
+	assert.False(t, f.exists(own.ThumbnailKey))
+	f.photos.AssertNotCalled(t, "Delete", mock.Anything, others.ID.String())
+}
+
+func TestListProgressPhotos(t *testing.T) {
+	f := newFixture(t)
+
+	p := f.stored(t, f.userID, photo.KindProgress)
+	f.photos.On("GetByUserID", mock.Anything, f.userID.String(), photo.KindProgress).Return([]*photo.Photo{p}, nil)
+
+	resp, err := f.svc.ListProgressPhotos(context.Background(), photos.ListProgressPhotosReq{UserID: f.userID})
+	require.NoError(t, err)
+	assert.Equal(t, []photo.Photo{*p}, resp.Photos)
+}
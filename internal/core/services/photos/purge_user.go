@@ -0,0 +1,41 @@
+package photos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cheezecakee/logr"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+)
+
+// UserFiles lists the files of every photo of a user whose account is being
+// purged. The photo records go with the account.
+func (s *Service) UserFiles(ctx context.Context, userID string) ([]string, error) {
+	var keys []string
+	for _, kind := range []photo.Kind{photo.KindAvatar, photo.KindProgress} {
+		photos, err := s.list(ctx, userID, kind)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range photos {
+			keys = append(keys, p.FileKey, p.ThumbnailKey)
+		}
+	}
+
+	return keys, nil
+}
+
+// DeleteFiles removes the files listed by UserFiles once the account is
+// gone.
+func (s *Service) DeleteFiles(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		if err := s.store.Delete(ctx, key); err != nil {
+			logr.Get().Errorf("failed to delete %s: %v", key, err)
+			return fmt.Errorf("failed to delete photo files: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package photos_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/photo"
+)
+
+func TestUserFiles(t *testing.T) {
+	f := newFixture(t)
+
+	avatar := f.stored(t, f.userID, photo.KindAvatar)
+	progress := f.stored(t, f.userID, photo.KindProgress)
+	f.photos.On("GetByUserID", mock.Anything, f.userID.String(), photo.KindAvatar).Return([]*photo.Photo{avatar}, nil)
+	f.photos.On("GetByUserID", mock.Anything, f.userID.String(), photo.KindProgress).Return([]*photo.Photo{progress}, nil)
+
+	keys, err := f.svc.UserFiles(context.Background(), f.userID.String())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{avatar.FileKey, avatar.ThumbnailKey, progress.FileKey, progress.ThumbnailKey}, keys)
+
+	require.NoError(t, f.svc.DeleteFiles(context.Background(), keys))
+	for _, key := range keys {
+		assert.False(t, f.exists(key), "expected %s deleted", key)
+	}
+	f.photos.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
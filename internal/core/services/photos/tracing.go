@@ -0,0 +1,92 @@
+package photos
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/cheezecakee/fitrkr-athena/internal/core/services/photos"
+
+// tracedService records a span around every PhotoService call.
+type tracedService struct {
+	next   PhotoService
+	tracer trace.Tracer
+}
+
+// NewTracedService wraps svc so every call records a span named after the
+// method, marked as failed when the call returns an error.
+func NewTracedService(svc PhotoService, tp trace.TracerProvider) PhotoService {
+	return &tracedService{next: svc, tracer: tp.Tracer(instrumentationName)}
+}
+
+func (s *tracedService) start(ctx context.Context, method string) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "photos.Service."+method)
+}
+
+func end(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+	return err
+}
+
+func (s *tracedService) UploadAvatar(ctx context.Context, req UploadAvatarReq) (*PhotoResp, error) {
+	ctx, span := s.start(ctx, "UploadAvatar")
+	resp, err := s.next.UploadAvatar(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) OpenAvatar(ctx context.Context, req OpenAvatarReq) (*OpenPhotoResp, error) {
+	ctx, span := s.start(ctx, "OpenAvatar")
+	resp, err := s.next.OpenAvatar(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) HasAvatar(ctx context.Context, userID string) (bool, error) {
+	ctx, span := s.start(ctx, "HasAvatar")
+	ok, err := s.next.HasAvatar(ctx, userID)
+	return ok, end(span, err)
+}
+
+func (s *tracedService) DeleteAvatar(ctx context.Context, req DeleteAvatarReq) error {
+	ctx, span := s.start(ctx, "DeleteAvatar")
+	return end(span, s.next.DeleteAvatar(ctx, req))
+}
+
+func (s *tracedService) UploadProgressPhoto(ctx context.Context, req UploadProgressPhotoReq) (*PhotoResp, error) {
+	ctx, span := s.start(ctx, "UploadProgressPhoto")
+	resp, err := s.next.UploadProgressPhoto(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) ListProgressPhotos(ctx context.Context, req ListProgressPhotosReq) (*ListProgressPhotosResp, error) {
+	ctx, span := s.start(ctx, "ListProgressPhotos")
+	resp, err := s.next.ListProgressPhotos(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) OpenProgressPhoto(ctx context.Context, req OpenProgressPhotoReq) (*OpenPhotoResp, error) {
+	ctx, span := s.start(ctx, "OpenProgressPhoto")
+	resp, err := s.next.OpenProgressPhoto(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) DeleteProgressPhoto(ctx context.Context, req ProgressPhotoReq) error {
+	ctx, span := s.start(ctx, "DeleteProgressPhoto")
+	return end(span, s.next.DeleteProgressPhoto(ctx, req))
+}
+
+func (s *tracedService) UserFiles(ctx context.Context, userID string) ([]string, error) {
+	ctx, span := s.start(ctx, "UserFiles")
+	keys, err := s.next.UserFiles(ctx, userID)
+	return keys, end(span, err)
+}
+
+func (s *tracedService) DeleteFiles(ctx context.Context, keys []string) error {
+	ctx, span := s.start(ctx, "DeleteFiles")
+	return end(span, s.next.DeleteFiles(ctx, keys))
+}
@@ -0,0 +1,38 @@
+package photos_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/photos"
+)
+
+func TestTracedService(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	f := newFixture(t)
+	photoID := uuid.New()
+	f.photos.On("GetByID", mock.Anything, photoID.String()).Return(nil, ports.ErrPhotoNotFound)
+
+	svc := photos.NewTracedService(f.svc, tp)
+
+	_, err := svc.OpenProgressPhoto(context.Background(), photos.OpenProgressPhotoReq{
+		ProgressPhotoReq: photos.ProgressPhotoReq{UserID: f.userID, PhotoID: photoID},
+	})
+	require.ErrorIs(t, err, photos.ErrPhotoNotFound)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "photos.Service.OpenProgressPhoto", spans[0].Name)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+}
@@ -2,6 +2,7 @@ package users
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/cheezecakee/logr"
@@ -24,78 +25,99 @@ type CreateAccountResp struct {
 }
 
 func (s *Service) CreateAccount(ctx context.Context, req CreateAccountReq) (*CreateAccountResp, error) {
+	var v user.Validation
+
 	username, err := user.NewUsername(req.Username)
-	if err != nil {
-		logr.Get().Errorf("invalid username: %v", err)
-		return nil, fmt.Errorf("invalid username: %w", err)
-	}
+	v.Check("username", err)
 
 	fullName, err := user.NewName(req.FirstName, req.LastName)
-	if err != nil {
-		logr.Get().Errorf("invalid name: %v", err)
-		return nil, fmt.Errorf("invalid name: %w", err)
-	}
+	v.Check("name", err)
 
 	email, err := user.NewEmail(req.Email)
-	if err != nil {
-		logr.Get().Errorf("invalid email: %v", err)
-		return nil, fmt.Errorf("invalid email: %w", err)
-	}
+	v.Check("email", err)
 
 	roles, err := user.NewRoles(req.Roles)
-	if err != nil {
-		logr.Get().Errorf("invalid role/s %v", err)
-		return nil, fmt.Errorf("invalid role/s %w", err)
-	}
+	v.Check("roles", err)
 
 	password, err := user.NewPassword(req.Password)
-	if err != nil {
-		logr.Get().Errorf("invalid password %v", err)
-		return nil, fmt.Errorf("invalid password %w", err)
-	}
-
-	existingUser, err := s.userRepo.GetByUsername(ctx, string(username))
-	if err != nil && err != ports.ErrUserNotFound {
-		logr.Get().Errorf("failed to check username: %v", err)
-		return nil, fmt.Errorf("failed to check username: %w", err)
-	}
-	if existingUser != nil {
-		logr.Get().Errorf("username already exists: %v", err)
-		return nil, ErrDuplicateUsername
-	}
+	v.Check("password", err)
 
-	existingUser, err = s.userRepo.GetByEmail(ctx, string(email))
-	if err != nil && err != ports.ErrUserNotFound {
-		logr.Get().Errorf("failed to check email: %v", err)
-		return nil, fmt.Errorf("failed to check email: %w", err)
-	}
-	if existingUser != nil {
-		logr.Get().Errorf("email already exists: %v", err)
-		return nil, ErrDuplicateEmail
+	if err := v.Err(); err != nil {
+		logr.Get().Errorf("invalid account: %v", err)
+		return nil, fmt.Errorf("invalid account: %w", err)
 	}
 
 	// create everything with default values
-	user := user.New(username, fullName, email, roles, password, user.NewStats(), user.NewSubscription(), user.NewSettings())
-
-	if err := s.userRepo.Add(ctx, user); err != nil {
-		logr.Get().Errorf("failed to add a user: %v", err)
-		return nil, fmt.Errorf("failed to add a user: %w", err)
-	}
-
-	if err = s.userRepo.AddStats(ctx, user.Stats, user.ID.String()); err != nil {
-		logr.Get().Errorf("failed to add user stats: %v", err)
-		return nil, fmt.Errorf("failed to add user stats: %w", err)
-	}
-
-	if err = s.userRepo.AddSubscription(ctx, user.Subscription, user.ID.String()); err != nil {
-		logr.Get().Errorf("failed to add user subscription: %v", err)
-		return nil, fmt.Errorf("failed to add user subscription: %w", err)
-	}
-	if err = s.userRepo.AddSettings(ctx, user.Settings, user.ID.String()); err != nil {
-		logr.Get().Errorf("failed to add user settings: %v", err)
-		return nil, fmt.Errorf("failed to add user settings: %w", err)
+	u := user.New(username, fullName, email, roles, password, user.NewStats(), user.NewSubscription(), user.NewSettings())
+	if err := s.createAccount(ctx, u); err != nil {
+		return nil, err
 	}
 
 	logr.Get().Info("New user account created")
-	return &CreateAccountResp{UserID: user.ID.String()}, nil
+	return &CreateAccountResp{UserID: u.ID.String()}, nil
+}
+
+// createAccount stores a new user together with its stats, subscription and
+// settings, all or nothing.
+func (s *Service) createAccount(ctx context.Context, u user.User) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		existingUser, err := s.userRepo.GetByUsername(ctx, string(u.Username))
+		if err != nil && err != ports.ErrUserNotFound {
+			logr.Get().Errorf("failed to check username: %v", err)
+			return fmt.Errorf("failed to check username: %w", err)
+		}
+		if existingUser != nil {
+			logr.Get().Errorf("username already exists: %v", err)
+			return ErrDuplicateUsername
+		}
+
+		reserved, err := s.reservation(ctx, string(u.Username), u.CreatedAt)
+		if err != nil {
+			return err
+		}
+		if reserved != nil {
+			logr.Get().Error("username is reserved by its previous owner")
+			return ErrDuplicateUsername
+		}
+
+		existingUser, err = s.userRepo.GetByEmail(ctx, string(u.Email))
+		if err != nil && err != ports.ErrUserNotFound {
+			logr.Get().Errorf("failed to check email: %v", err)
+			return fmt.Errorf("failed to check email: %w", err)
+		}
+		if existingUser != nil {
+			logr.Get().Errorf("email already exists: %v", err)
+			return ErrDuplicateEmail
+		}
+
+		if err := s.userRepo.Add(ctx, u); err != nil {
+			// A concurrent sign up took the username or email after the checks
+			switch {
+			case errors.Is(err, ports.ErrDuplicateUsername):
+				logr.Get().Error("username taken by a concurrent sign up")
+				return ErrDuplicateUsername
+			case errors.Is(err, ports.ErrDuplicateEmail):
+				logr.Get().Error("email taken by a concurrent sign up")
+				return ErrDuplicateEmail
+			}
+			logr.Get().Errorf("failed to add a user: %v", err)
+			return fmt.Errorf("failed to add a user: %w", err)
+		}
+
+		if err = s.userRepo.AddStats(ctx, u.Stats, u.ID.String()); err != nil {
+			logr.Get().Errorf("failed to add user stats: %v", err)
+			return fmt.Errorf("failed to add user stats: %w", err)
+		}
+
+		if err = s.userRepo.AddSubscription(ctx, u.Subscription, u.ID.String()); err != nil {
+			logr.Get().Errorf("failed to add user subscription: %v", err)
+			return fmt.Errorf("failed to add user subscription: %w", err)
+		}
+		if err = s.userRepo.AddSettings(ctx, u.Settings, u.ID.String()); err != nil {
+			logr.Get().Errorf("failed to add user settings: %v", err)
+			return fmt.Errorf("failed to add user settings: %w", err)
+		}
+
+		return nil
+	})
 }
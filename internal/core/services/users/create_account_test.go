@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
@@ -41,6 +44,7 @@ func TestCreateAccount(t *testing.T) {
 			setupMock: func(m *MockUserRepo) {
 				// User doesn't exist yet
 				m.On("GetByUsername", ctx, "testuser123").Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, "testuser123", mock.Anything).Return(nil, ports.ErrNotReserved)
 				m.On("GetByEmail", ctx, "test@example.com").Return(nil, ports.ErrUserNotFound)
 				// All repo calls succeed
 				m.On("Add", ctx, mock.MatchedBy(func(u user.User) bool {
@@ -165,12 +169,27 @@ func TestCreateAccount(t *testing.T) {
 			},
 			expectedErr: users.ErrDuplicateUsername,
 		},
+		{
+			name: "error - username reserved after a rename",
+			req:  validCreateAccountReq(),
+			setupMock: func(m *MockUserRepo) {
+				m.On("GetByUsername", ctx, "testuser123").Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, "testuser123", mock.Anything).Return(&user.UsernameChange{
+					UserID:      uuid.New(),
+					OldUsername: "testuser123",
+					NewUsername: "renamed",
+					ChangedAt:   time.Now().Add(-time.Hour),
+				}, nil)
+			},
+			expectedErr: users.ErrDuplicateUsername,
+		},
 		{
 			name: "error - duplicate email",
 			req:  validCreateAccountReq(),
 			setupMock: func(m *MockUserRepo) {
 				// Username is unique
 				m.On("GetByUsername", ctx, "testuser123").Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, "testuser123", mock.Anything).Return(nil, ports.ErrNotReserved)
 				// But email exists
 				m.On("GetByEmail", ctx, "test@example.com").Return(&ports.User{}, nil)
 			},
@@ -190,17 +209,41 @@ func TestCreateAccount(t *testing.T) {
 			req:  validCreateAccountReq(),
 			setupMock: func(m *MockUserRepo) {
 				m.On("GetByUsername", ctx, "testuser123").Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, "testuser123", mock.Anything).Return(nil, ports.ErrNotReserved)
 				m.On("GetByEmail", ctx, "test@example.com").Return(nil, ports.ErrUserNotFound)
 				// User creation fails
 				m.On("Add", ctx, mock.Anything).Return(errors.New("constraint violation"))
 			},
 			expectedErr: errors.New("constraint violation"),
 		},
+		{
+			name: "error - username taken by a concurrent sign up",
+			req:  validCreateAccountReq(),
+			setupMock: func(m *MockUserRepo) {
+				m.On("GetByUsername", ctx, "testuser123").Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, "testuser123", mock.Anything).Return(nil, ports.ErrNotReserved)
+				m.On("GetByEmail", ctx, "test@example.com").Return(nil, ports.ErrUserNotFound)
+				m.On("Add", ctx, mock.Anything).Return(ports.ErrDuplicateUsername)
+			},
+			expectedErr: users.ErrDuplicateUsername,
+		},
+		{
+			name: "error - email taken by a concurrent sign up",
+			req:  validCreateAccountReq(),
+			setupMock: func(m *MockUserRepo) {
+				m.On("GetByUsername", ctx, "testuser123").Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, "testuser123", mock.Anything).Return(nil, ports.ErrNotReserved)
+				m.On("GetByEmail", ctx, "test@example.com").Return(nil, ports.ErrUserNotFound)
+				m.On("Add", ctx, mock.Anything).Return(ports.ErrDuplicateEmail)
+			},
+			expectedErr: users.ErrDuplicateEmail,
+		},
 		{
 			name: "error - repo.AddStats fails",
 			req:  validCreateAccountReq(),
 			setupMock: func(m *MockUserRepo) {
 				m.On("GetByUsername", ctx, "testuser123").Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, "testuser123", mock.Anything).Return(nil, ports.ErrNotReserved)
 				m.On("GetByEmail", ctx, "test@example.com").Return(nil, ports.ErrUserNotFound)
 				m.On("Add", ctx, mock.Anything).Return(nil)
 				// Stats creation fails
@@ -213,6 +256,7 @@ func TestCreateAccount(t *testing.T) {
 			req:  validCreateAccountReq(),
 			setupMock: func(m *MockUserRepo) {
 				m.On("GetByUsername", ctx, "testuser123").Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, "testuser123", mock.Anything).Return(nil, ports.ErrNotReserved)
 				m.On("GetByEmail", ctx, "test@example.com").Return(nil, ports.ErrUserNotFound)
 				m.On("Add", ctx, mock.Anything).Return(nil)
 				m.On("AddStats", ctx, mock.Anything, mock.Anything).Return(nil)
@@ -226,6 +270,7 @@ func TestCreateAccount(t *testing.T) {
 			req:  validCreateAccountReq(),
 			setupMock: func(m *MockUserRepo) {
 				m.On("GetByUsername", ctx, "testuser123").Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, "testuser123", mock.Anything).Return(nil, ports.ErrNotReserved)
 				m.On("GetByEmail", ctx, "test@example.com").Return(nil, ports.ErrUserNotFound)
 				m.On("Add", ctx, mock.Anything).Return(nil)
 				m.On("AddStats", ctx, mock.Anything, mock.Anything).Return(nil)
@@ -244,7 +289,7 @@ func TestCreateAccount(t *testing.T) {
 			tt.setupMock(mockRepo)
 
 			// Create service with mock
-			svc := users.NewService(mockRepo)
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
 
 			// Execute
 			resp, err := svc.CreateAccount(ctx, tt.req)
@@ -265,3 +310,63 @@ func TestCreateAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateAccount_ReportsEveryInvalidField(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepo := new(MockUserRepo)
+	svc := users.NewService(mockRepo, memory.NewUnitOfWork())
+
+	_, err := svc.CreateAccount(ctx, users.CreateAccountReq{
+		Username:  "ab",
+		Email:     "not-an-email",
+		FirstName: "John",
+		LastName:  "Doe",
+		Roles:     []string{"user"},
+		Password:  "short",
+	})
+
+	var validation *user.ValidationError
+	if assert.ErrorAs(t, err, &validation) {
+		assert.Equal(t, []user.FieldError{
+			{Field: "username", Err: user.ErrUsernameTooShort},
+			{Field: "email", Err: user.ErrInvalidEmail},
+			{Field: "password", Err: user.ErrPasswordTooShort},
+		}, validation.Fields)
+	}
+
+	// Nothing is looked up until the request is valid
+	mockRepo.AssertNotCalled(t, "GetByUsername", mock.Anything, mock.Anything)
+}
+
+func TestCreateAccount_IsAtomic(t *testing.T) {
+	ctx := context.Background()
+
+	uow := memory.NewUnitOfWork()
+	inUnit := inUnitOfWork(t, uow)
+	errSettings := errors.New("settings insert failed")
+
+	mockRepo := new(MockUserRepo)
+	mockRepo.On("GetByUsername", ctx, "testuser123").Return(nil, ports.ErrUserNotFound)
+	mockRepo.On("GetUsernameReservation", ctx, "testuser123", mock.Anything).Return(nil, ports.ErrNotReserved)
+	mockRepo.On("GetByEmail", ctx, "test@example.com").Return(nil, ports.ErrUserNotFound)
+	mockRepo.On("Add", ctx, mock.Anything).Run(inUnit).Return(nil)
+	mockRepo.On("AddStats", ctx, mock.Anything, mock.Anything).Run(inUnit).Return(nil)
+	mockRepo.On("AddSubscription", ctx, mock.Anything, mock.Anything).Run(inUnit).Return(nil)
+	mockRepo.On("AddSettings", ctx, mock.Anything, mock.Anything).Run(inUnit).Return(errSettings)
+
+	svc := users.NewService(mockRepo, uow)
+
+	_, err := svc.CreateAccount(ctx, validCreateAccountReq())
+	assert.ErrorIs(t, err, errSettings)
+
+	// Every write ran in the one unit of work, which failed with the error of
+	// the failing write and nothing after it. The fake does not undo the
+	// writes, the database adapter rolls them back
+	assert.Equal(t, 0, uow.Commits())
+	if failures := uow.Failures(); assert.Len(t, failures, 1) {
+		assert.ErrorIs(t, failures[0], errSettings)
+	}
+	assert.Empty(t, callsAfter(&mockRepo.Mock, "AddSettings"))
+	mockRepo.AssertExpectations(t)
+}
@@ -2,28 +2,225 @@ package users
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
 
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
 )
 
+// purgeBatchSize is how many accounts one purge run deletes at most.
+const purgeBatchSize = 100
+
+// errNotDue is returned by purge when the account was restored, or purged
+// by another instance, since it was listed.
+var errNotDue = errors.New("account no longer due for purge")
+
 type DeleteAccountReq struct {
 	ID string
 }
 
+// Delete schedules the account for deletion. It can be restored by signing in
+// again until user.DeletionGracePeriod has passed, then PurgeDeleted removes
+// it for good.
 func (s *Service) Delete(ctx context.Context, req DeleteAccountReq) error {
-	err := s.userRepo.Delete(ctx, req.ID)
+	existing, err := s.userRepo.GetByID(ctx, req.ID)
 	if err != nil {
-		if err == ports.ErrUserNotFound {
+		if errors.Is(err, ports.ErrUserNotFound) {
 			logr.Get().Error("user not found")
 			return ErrUserNotFound
 		}
-		logr.Get().Errorf("failed to delete user: %v", err)
-		return fmt.Errorf("failed to delete user: %w", err)
+		logr.Get().Errorf("failed to get user: %v", err)
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	now := time.Now()
+	status, err := existing.Status.ScheduleDeletion(now)
+	if err != nil {
+		logr.Get().Errorf("failed to schedule deletion: %v", err)
+		return fmt.Errorf("failed to schedule deletion: %w", err)
+	}
+
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		err := s.userRepo.UpdateStatus(ctx, req.ID, status, now)
+		if err != nil {
+			if errors.Is(err, ports.ErrUserNotFound) {
+				logr.Get().Error("user not found")
+				return ErrUserNotFound
+			}
+			logr.Get().Errorf("failed to schedule deletion: %v", err)
+			return fmt.Errorf("failed to schedule deletion: %w", err)
+		}
+
+		return s.record(ctx, audit.ActionDeletionScheduled, req.ID, map[string]string{
+			"purge_at": status.PurgeAt().UTC().Format(time.RFC3339),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	logr.Get().Infof("user %s scheduled for deletion", req.ID)
+	return nil
+}
+
+type PurgeDeletedResp struct {
+	Purged int
+}
+
+// PurgeDeleted removes the accounts whose grace period has run out. Each
+// account goes in its own unit of work, so one failure does not hold back the
+// rest.
+func (s *Service) PurgeDeleted(ctx context.Context) (*PurgeDeletedResp, error) {
+	cutoff := time.Now().Add(-user.DeletionGracePeriod)
+	due, err := s.userRepo.GetDueForPurge(ctx, cutoff, purgeBatchSize)
+	if err != nil {
+		logr.Get().Errorf("failed to get accounts due for purge: %v", err)
+		return nil, fmt.Errorf("failed to get accounts due for purge: %w", err)
+	}
+
+	var purged int
+	for _, u := range due {
+		if err := s.purge(ctx, u.ID.String(), cutoff); err != nil {
+			if errors.Is(err, errNotDue) {
+				logr.Get().Infof("skipped purge of user %s, no longer due", u.ID)
+				continue
+			}
+			logr.Get().Errorf("failed to purge user %s: %v", u.ID, err)
+			continue
+		}
+		purged++
+	}
+
+	if purged > 0 {
+		logr.Get().Infof("purged %d deleted accounts", purged)
+	}
+	return &PurgeDeletedResp{Purged: purged}, nil
+}
+
+// purge locks the account and checks it is still due before anything is
+// removed, a sign in may have restored it since it was listed. Its files are
+// listed next, then an active subscription is cancelled and the account
+// deleted. The files are only deleted once that committed, they can not be
+// rolled back and the row lock is not held over slow stores. The audit
+// events of the account are kept without its personal data, a tombstone ID
+// stands in for it.
+func (s *Service) purge(ctx context.Context, userID string, cutoff time.Time) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		logr.Get().Errorf("invalid user id: %v", err)
+		return fmt.Errorf("invalid user id: %w", err)
+	}
+	tombstone := uuid.New()
+
+	files := make([][]string, len(s.purgers))
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.LockForPurge(ctx, userID, cutoff); err != nil {
+			if errors.Is(err, ports.ErrUserNotFound) {
+				return errNotDue
+			}
+			logr.Get().Errorf("failed to lock user for purge: %v", err)
+			return fmt.Errorf("failed to lock user for purge: %w", err)
+		}
+
+		for i, purger := range s.purgers {
+			keys, err := purger.UserFiles(ctx, userID)
+			if err != nil {
+				logr.Get().Errorf("failed to list user files: %v", err)
+				return fmt.Errorf("failed to list user files: %w", err)
+			}
+			files[i] = keys
+		}
+
+		if err := s.cancelForDeletion(ctx, userID); err != nil {
+			return err
+		}
+
+		if err := s.userRepo.Delete(ctx, userID); err != nil {
+			logr.Get().Errorf("failed to delete user: %v", err)
+			return fmt.Errorf("failed to delete user: %w", err)
+		}
+
+		if s.auditLog == nil {
+			return nil
+		}
+
+		if err := s.auditLog.Erase(ctx, id, tombstone); err != nil {
+			logr.Get().Errorf("failed to erase audit events: %v", err)
+			return fmt.Errorf("failed to erase audit events: %w", err)
+		}
+
+		return s.record(ctx, audit.ActionAccountDeleted, tombstone.String(), nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	// The account is gone for good, files left behind are logged to be
+	// removed by hand rather than failing the purge
+	for i, purger := range s.purgers {
+		if err := purger.DeleteFiles(ctx, files[i]); err != nil {
+			logr.Get().Errorf("failed to delete files %v of purged user %s: %v", files[i], userID, err)
+		}
 	}
 
-	logr.Get().Info("User deleted successfully")
 	return nil
 }
+
+// cancelForDeletion cancels the subscription of an account being purged, if
+// it is still paid for and renewing.
+func (s *Service) cancelForDeletion(ctx context.Context, userID string) error {
+	sub, err := s.userRepo.GetSubscriptionByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ports.ErrUserNotFound) {
+			return nil
+		}
+		logr.Get().Errorf("failed to get subscription: %v", err)
+		return fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	if sub.Plan == user.Basic || sub.CancelledAt != nil {
+		return nil
+	}
+
+	if err := sub.Cancel(); err != nil {
+		logr.Get().Errorf("failed to cancel subscription: %v", err)
+		return fmt.Errorf("failed to cancel subscription: %w", err)
+	}
+
+	return s.saveSubscription(ctx, userID, *sub, audit.ActionSubscriptionCancelled, map[string]string{
+		"plan":   string(sub.Plan),
+		"reason": "account_deleted",
+	})
+}
+
+// StartPurge removes accounts past their grace period every interval until
+// ctx is done. The returned channel is closed once purging has stopped.
+func StartPurge(ctx context.Context, svc UserService, interval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := svc.PurgeDeleted(ctx); err != nil {
+					logr.Get().Errorf("failed to purge deleted accounts: %v", err)
+				}
+			}
+		}
+	}()
+
+	return done
+}
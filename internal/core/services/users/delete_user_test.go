@@ -4,9 +4,16 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
 )
@@ -14,6 +21,7 @@ import (
 func TestDelete(t *testing.T) {
 	ctx := context.Background()
 	testID := "test-user-id"
+	requested := time.Now().Add(-time.Hour)
 
 	tests := []struct {
 		name          string
@@ -23,12 +31,15 @@ func TestDelete(t *testing.T) {
 		shouldSucceed bool
 	}{
 		{
-			name: "success - user deleted",
+			name: "success - deletion scheduled",
 			req: users.DeleteAccountReq{
 				ID: testID,
 			},
 			setupMock: func(m *MockUserRepo) {
-				m.On("Delete", ctx, testID).Return(nil)
+				m.On("GetByID", ctx, testID).Return(&ports.User{}, nil)
+				m.On("UpdateStatus", ctx, testID, mock.MatchedBy(func(s user.AccountStatus) bool {
+					return s.IsPendingDeletion()
+				}), mock.Anything).Return(nil)
 			},
 			shouldSucceed: true,
 		},
@@ -38,19 +49,30 @@ func TestDelete(t *testing.T) {
 				ID: testID,
 			},
 			setupMock: func(m *MockUserRepo) {
-				m.On("Delete", ctx, testID).Return(ports.ErrUserNotFound)
+				m.On("GetByID", ctx, testID).Return(nil, ports.ErrUserNotFound)
 			},
 			expectedErr: users.ErrUserNotFound,
 		},
 		{
-			name: "error - repo delete fails",
+			name: "error - already scheduled",
 			req: users.DeleteAccountReq{
 				ID: testID,
 			},
 			setupMock: func(m *MockUserRepo) {
-				m.On("Delete", ctx, testID).Return(errors.New("db error"))
+				m.On("GetByID", ctx, testID).Return(&ports.User{Status: user.AccountStatus{DeletionRequestedAt: &requested}}, nil)
 			},
-			expectedErr: errors.New("failed to delete user: db error"),
+			expectedErr: errors.New("failed to schedule deletion: account deletion already scheduled"),
+		},
+		{
+			name: "error - repo update fails",
+			req: users.DeleteAccountReq{
+				ID: testID,
+			},
+			setupMock: func(m *MockUserRepo) {
+				m.On("GetByID", ctx, testID).Return(&ports.User{}, nil)
+				m.On("UpdateStatus", ctx, testID, mock.Anything, mock.Anything).Return(errors.New("db error"))
+			},
+			expectedErr: errors.New("failed to schedule deletion: db error"),
 		},
 	}
 
@@ -58,7 +80,7 @@ func TestDelete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockUserRepo)
 			tt.setupMock(mockRepo)
-			svc := users.NewService(mockRepo)
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
 
 			err := svc.Delete(ctx, tt.req)
 
@@ -69,7 +91,183 @@ func TestDelete(t *testing.T) {
 				assert.EqualError(t, err, tt.expectedErr.Error())
 			}
 
+			mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
 			mockRepo.AssertExpectations(t)
 		})
 	}
 }
+
+func TestDelete_Audited(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockRepo := new(MockUserRepo)
+	mockRepo.On("GetByID", mock.Anything, userID.String()).Return(&ports.User{ID: userID}, nil)
+	mockRepo.On("UpdateStatus", mock.Anything, userID.String(), mock.Anything, mock.Anything).Return(nil)
+
+	auditLog := memory.NewAuditLog()
+	svc := users.NewService(mockRepo, memory.NewUnitOfWork(), users.WithAuditLog(auditLog))
+
+	require.NoError(t, svc.Delete(ctx, users.DeleteAccountReq{ID: userID.String()}))
+
+	events := auditLog.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, audit.ActionDeletionScheduled, events[0].Action)
+	assert.Equal(t, userID, events[0].TargetID)
+
+	purgeAt, err := time.Parse(time.RFC3339, events[0].Metadata["purge_at"])
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(user.DeletionGracePeriod), purgeAt, time.Minute)
+}
+
+// fakePurger keeps one file per user and records the commits of uow at
+// every deletion.
+type fakePurger struct {
+	uow     *memory.UnitOfWork
+	listErr map[string]error
+	deleted []string
+	commits []int
+}
+
+func (p *fakePurger) UserFiles(ctx context.Context, userID string) ([]string, error) {
+	if err := p.listErr[userID]; err != nil {
+		return nil, err
+	}
+	return []string{"files/" + userID}, nil
+}
+
+func (p *fakePurger) DeleteFiles(ctx context.Context, keys []string) error {
+	if p.uow != nil {
+		p.commits = append(p.commits, p.uow.Commits())
+	}
+	p.deleted = append(p.deleted, keys...)
+	return nil
+}
+
+func TestPurgeDeleted(t *testing.T) {
+	ctx := context.Background()
+	premiumID, basicID := uuid.New(), uuid.New()
+
+	premium := user.NewSubscription()
+	premium.Plan = user.Premium
+	basic := user.NewSubscription()
+
+	var cutoff time.Time
+	mockRepo := new(MockUserRepo)
+	mockRepo.On("GetDueForPurge", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Until(before.Add(user.DeletionGracePeriod)) < time.Minute
+	}), mock.Anything).Return([]*ports.User{{ID: premiumID}, {ID: basicID}}, nil).
+		Run(func(args mock.Arguments) { cutoff = args.Get(1).(time.Time) })
+	sameCutoff := mock.MatchedBy(func(before time.Time) bool { return before.Equal(cutoff) })
+	mockRepo.On("LockForPurge", mock.Anything, premiumID.String(), sameCutoff).Return(nil)
+	mockRepo.On("LockForPurge", mock.Anything, basicID.String(), sameCutoff).Return(nil)
+	mockRepo.On("GetSubscriptionByID", mock.Anything, premiumID.String()).Return(&premium, nil)
+	mockRepo.On("GetSubscriptionByID", mock.Anything, basicID.String()).Return(&basic, nil)
+	uow := memory.NewUnitOfWork()
+	inUnit := inUnitOfWork(t, uow)
+	mockRepo.On("UpdateSubscription", mock.Anything, mock.MatchedBy(func(s user.Subscription) bool {
+		return s.CancelledAt != nil && !s.AutoRenew
+	}), premiumID.String()).Run(inUnit).Return(nil).Once()
+	mockRepo.On("Delete", mock.Anything, premiumID.String()).Run(inUnit).Return(nil)
+	mockRepo.On("Delete", mock.Anything, basicID.String()).Run(inUnit).Return(nil)
+
+	purger := &fakePurger{uow: uow}
+
+	auditLog := memory.NewAuditLog()
+	svc := users.NewService(mockRepo, uow, users.WithAuditLog(auditLog), users.WithDataPurgers(purger))
+
+	resp, err := svc.PurgeDeleted(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, resp.Purged)
+	assert.Equal(t, []string{"files/" + premiumID.String(), "files/" + basicID.String()}, purger.deleted)
+	// Each account's files go only once its unit of work committed, the
+	// last deletion comes after the last commit
+	require.Len(t, purger.commits, 2)
+	assert.Positive(t, purger.commits[0])
+	assert.Equal(t, uow.Commits(), purger.commits[1])
+
+	// The events stay, stripped of the purged users
+	var actions []audit.Action
+	for _, event := range auditLog.Events() {
+		actions = append(actions, event.Action)
+		for _, id := range []uuid.UUID{premiumID, basicID} {
+			assert.NotEqual(t, id, event.ActorID)
+			assert.NotEqual(t, id, event.TargetID)
+		}
+		assert.Empty(t, event.Metadata)
+	}
+	assert.ElementsMatch(t, []audit.Action{
+		audit.ActionSubscriptionCancelled,
+		audit.ActionAccountDeleted,
+		audit.ActionAccountDeleted,
+	}, actions)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPurgeDeleted_SkipsFailures(t *testing.T) {
+	ctx := context.Background()
+	stuckID, failingID, okID := uuid.New(), uuid.New(), uuid.New()
+	basic := user.NewSubscription()
+
+	uow := memory.NewUnitOfWork()
+	inUnit := inUnitOfWork(t, uow)
+	errDelete := errors.New("db error")
+
+	mockRepo := new(MockUserRepo)
+	mockRepo.On("GetDueForPurge", mock.Anything, mock.Anything, mock.Anything).
+		Return([]*ports.User{{ID: stuckID}, {ID: failingID}, {ID: okID}}, nil)
+	mockRepo.On("LockForPurge", mock.Anything, mock.Anything, mock.Anything).Run(inUnit).Return(nil)
+	mockRepo.On("GetSubscriptionByID", mock.Anything, mock.Anything).Return(&basic, nil)
+	mockRepo.On("Delete", mock.Anything, failingID.String()).Run(inUnit).Return(errDelete)
+	mockRepo.On("Delete", mock.Anything, okID.String()).Run(inUnit).Return(nil)
+
+	purger := &fakePurger{uow: uow, listErr: map[string]error{stuckID.String(): errors.New("disk error")}}
+	svc := users.NewService(mockRepo, uow, users.WithDataPurgers(purger))
+
+	resp, err := svc.PurgeDeleted(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Purged)
+	// Each failing account rolled back with the error of its failing step
+	if failures := uow.Failures(); assert.Len(t, failures, 2) {
+		assert.ErrorContains(t, failures[0], "disk error")
+		assert.ErrorIs(t, failures[1], errDelete)
+	}
+
+	// Nothing more was done for the failed account, the purge moved on
+	for i, call := range mockRepo.Calls {
+		if call.Method == "Delete" && call.Arguments.String(1) == failingID.String() {
+			require.Greater(t, len(mockRepo.Calls), i+1)
+			next := mockRepo.Calls[i+1]
+			assert.Equal(t, "LockForPurge", next.Method)
+			assert.Equal(t, okID.String(), next.Arguments.String(1))
+		}
+	}
+	// The files of accounts that were rolled back are kept
+	assert.Equal(t, []string{"files/" + okID.String()}, purger.deleted)
+
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, stuckID.String())
+}
+
+func TestPurgeDeleted_SkipsRestoredAccounts(t *testing.T) {
+	ctx := context.Background()
+	restoredID := uuid.New()
+
+	mockRepo := new(MockUserRepo)
+	mockRepo.On("GetDueForPurge", mock.Anything, mock.Anything, mock.Anything).
+		Return([]*ports.User{{ID: restoredID}}, nil)
+	mockRepo.On("LockForPurge", mock.Anything, restoredID.String(), mock.Anything).Return(ports.ErrUserNotFound)
+
+	purger := &fakePurger{}
+
+	auditLog := memory.NewAuditLog()
+	svc := users.NewService(mockRepo, memory.NewUnitOfWork(), users.WithAuditLog(auditLog), users.WithDataPurgers(purger))
+
+	resp, err := svc.PurgeDeleted(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, resp.Purged)
+	assert.Empty(t, auditLog.Events())
+	assert.Empty(t, purger.deleted, "expected the files of the restored user to be kept")
+
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
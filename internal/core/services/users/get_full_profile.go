@@ -0,0 +1,56 @@
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cheezecakee/logr"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+)
+
+type GetFullProfileReq struct {
+	ID string
+}
+
+// GetFullProfileResp is everything about the user in one response. Stats
+// are in the units of Settings.
+type GetFullProfileResp struct {
+	User         GetUserResp
+	Stats        user.Stats
+	Subscription user.Subscription
+	Settings     user.Settings
+}
+
+func (s *Service) GetFullProfile(ctx context.Context, req GetFullProfileReq) (*GetFullProfileResp, error) {
+	u, err := s.userRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		logr.Get().Errorf("failed to get user by id: %v", err)
+		return nil, ErrUserNotFound
+	}
+
+	stats, err := s.userRepo.GetStatsByID(ctx, req.ID)
+	if err != nil {
+		logr.Get().Errorf("failed to get stats: %v", err)
+		return nil, fmt.Errorf("failed to get stats: %w", err)
+	}
+
+	sub, err := s.userRepo.GetSubscriptionByID(ctx, req.ID)
+	if err != nil {
+		logr.Get().Errorf("failed to get subscription: %v", err)
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	settings, err := s.userRepo.GetSettingsByID(ctx, req.ID)
+	if err != nil {
+		logr.Get().Errorf("failed to get settings: %v", err)
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	return &GetFullProfileResp{
+		User:         *s.userResponse(ctx, u),
+		Stats:        displayStats(*stats, *settings),
+		Subscription: *sub,
+		Settings:     *settings,
+	}, nil
+}
@@ -0,0 +1,105 @@
+package users_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+type MockAvatarLookup struct {
+	mock.Mock
+}
+
+func (m *MockAvatarLookup) HasAvatar(ctx context.Context, userID string) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestGetFullProfile(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	born := time.Date(1990, 4, 21, 0, 0, 0, 0, time.UTC)
+	weight := user.WeightValue(100)
+	height := user.HeightValue(182.88)
+
+	setupRepo := func(m *MockUserRepo) {
+		m.On("GetByID", ctx, id.String()).Return(&ports.User{
+			ID:       id,
+			Username: "lifter",
+			Roles:    []string{"user"},
+			Profile:  user.Profile{BirthDate: &born, Sex: user.Female, ExperienceLevel: user.Advanced},
+		}, nil)
+		m.On("GetStatsByID", ctx, id.String()).Return(&user.Stats{
+			Weight: &weight,
+			Height: &height,
+			Totals: user.Totals{Workouts: 3, Lifted: 1000, Time: 120},
+		}, nil)
+		m.On("GetSubscriptionByID", ctx, id.String()).Return(&user.Subscription{Plan: user.Premium}, nil)
+		m.On("GetSettingsByID", ctx, id.String()).Return(&user.Settings{WeightUnit: user.Lb, HeightUnit: user.Ft}, nil)
+	}
+
+	t.Run("success - stats in the units of the settings", func(t *testing.T) {
+		mockRepo, avatars := new(MockUserRepo), new(MockAvatarLookup)
+		setupRepo(mockRepo)
+		avatars.On("HasAvatar", ctx, id.String()).Return(true, nil)
+		svc := users.NewService(mockRepo, memory.NewUnitOfWork(), users.WithAvatars(avatars))
+
+		resp, err := svc.GetFullProfile(ctx, users.GetFullProfileReq{ID: id.String()})
+		require.NoError(t, err)
+
+		assert.Equal(t, user.Username("lifter"), resp.User.Username)
+		assert.Equal(t, user.Female, resp.User.Profile.Sex)
+		assert.True(t, resp.User.HasAvatar)
+		assert.InDelta(t, 220.462, float64(*resp.Stats.Weight), 0.001)
+		assert.InDelta(t, 6, float64(*resp.Stats.Height), 0.001)
+		assert.Equal(t, user.Premium, resp.Subscription.Plan)
+		assert.Equal(t, user.Lb, resp.Settings.WeightUnit)
+	})
+
+	t.Run("success - lifted total in pounds", func(t *testing.T) {
+		mockRepo, avatars := new(MockUserRepo), new(MockAvatarLookup)
+		setupRepo(mockRepo)
+		avatars.On("HasAvatar", ctx, id.String()).Return(false, nil)
+		svc := users.NewService(mockRepo, memory.NewUnitOfWork(), users.WithAvatars(avatars))
+
+		resp, err := svc.GetFullProfile(ctx, users.GetFullProfileReq{ID: id.String()})
+		require.NoError(t, err)
+
+		assert.InDelta(t, 2204.62, resp.Stats.Totals.Lifted, 0.001)
+		assert.Equal(t, 3, resp.Stats.Totals.Workouts)
+		assert.Equal(t, 120, resp.Stats.Totals.Time)
+	})
+
+	t.Run("success - failed avatar lookup reads as no avatar", func(t *testing.T) {
+		mockRepo, avatars := new(MockUserRepo), new(MockAvatarLookup)
+		setupRepo(mockRepo)
+		avatars.On("HasAvatar", ctx, id.String()).Return(false, errors.New("db down"))
+		svc := users.NewService(mockRepo, memory.NewUnitOfWork(), users.WithAvatars(avatars))
+
+		resp, err := svc.GetFullProfile(ctx, users.GetFullProfileReq{ID: id.String()})
+		require.NoError(t, err)
+		assert.False(t, resp.User.HasAvatar)
+	})
+
+	t.Run("error - user not found", func(t *testing.T) {
+		mockRepo := new(MockUserRepo)
+		mockRepo.On("GetByID", ctx, id.String()).Return(nil, ports.ErrUserNotFound)
+		svc := users.NewService(mockRepo, memory.NewUnitOfWork())
+
+		_, err := svc.GetFullProfile(ctx, users.GetFullProfileReq{ID: id.String()})
+		assert.ErrorIs(t, err, users.ErrUserNotFound)
+		mockRepo.AssertNotCalled(t, "GetStatsByID", mock.Anything, mock.Anything)
+	})
+}
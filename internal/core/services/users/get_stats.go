@@ -30,21 +30,23 @@ func (s *Service) GetStats(ctx context.Context, req GetStatsReq) (*GetStatsResp,
 		return nil, fmt.Errorf("failed to get settings: %w", err)
 	}
 
-	resp := &GetStatsResp{
-		Stats: *stats,
-	}
-
-	// Matches user settings metrics
+	return &GetStatsResp{Stats: displayStats(*stats, *settings)}, nil
+}
 
+// displayStats converts the stored metric values to the units of the user
+// settings.
+func displayStats(stats user.Stats, settings user.Settings) user.Stats {
 	if stats.Weight != nil {
 		displayValue := stats.Weight.Display(settings.WeightUnit)
-		resp.Stats.Weight = &displayValue
+		stats.Weight = &displayValue
 	}
 
 	if stats.Height != nil {
 		displayValue := stats.Height.Display(settings.HeightUnit)
-		resp.Stats.Height = &displayValue
+		stats.Height = &displayValue
 	}
 
-	return resp, nil
+	stats.Totals.Lifted = float64(user.WeightValue(stats.Totals.Lifted).Display(settings.WeightUnit))
+
+	return stats
 }
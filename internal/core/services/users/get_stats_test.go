@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
 )
@@ -143,7 +144,7 @@ func TestGetStats(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockUserRepo)
 			tt.setupMock(mockRepo)
-			svc := users.NewService(mockRepo)
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
 
 			resp, err := svc.GetStats(ctx, tt.req)
 
@@ -2,6 +2,7 @@ package users
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/cheezecakee/logr"
@@ -17,6 +18,8 @@ type GetUserResp struct {
 	Email     user.Email    `json:"email"`
 	FullName  string        `json:"full_name"`
 	Roles     user.Roles    `json:"roles"`
+	Profile   user.Profile  `json:"profile"`
+	HasAvatar bool          `json:"has_avatar"`
 	CreatedAt time.Time     `json:"created_at"`
 	UpdatedAt time.Time     `json:"updated_at"`
 }
@@ -32,21 +35,69 @@ func (s *Service) GetByID(ctx context.Context, req GetUserByIDReq) (*GetUserResp
 		return nil, ErrUserNotFound
 	}
 
-	return mapUserToResponse(u), nil
+	return s.userResponse(ctx, u), nil
+}
+
+// GetPublicUserResp is the profile other users see, without the email, roles
+// or anything the owner keeps private.
+type GetPublicUserResp struct {
+	ID        uuid.UUID          `json:"id"`
+	Username  user.Username      `json:"username"`
+	FullName  string             `json:"full_name"`
+	Profile   user.PublicProfile `json:"profile"`
+	HasAvatar bool               `json:"has_avatar"`
+	CreatedAt time.Time          `json:"created_at"`
 }
 
 type GetUserByUsernameReq struct {
 	Username string
 }
 
-func (s *Service) GetByUsername(ctx context.Context, req GetUserByUsernameReq) (*GetUserResp, error) {
-	u, err := s.userRepo.GetByUsername(ctx, req.Username)
+// GetByUsername returns the public profile of a user, it also finds them by
+// an old username while it is reserved for them, the response carries the
+// current one.
+func (s *Service) GetByUsername(ctx context.Context, req GetUserByUsernameReq) (*GetPublicUserResp, error) {
+	username := user.NormalizeUsername(req.Username)
+
+	u, err := s.userRepo.GetByUsername(ctx, username)
+	if errors.Is(err, ports.ErrUserNotFound) {
+		u, err = s.getByOldUsername(ctx, username)
+	}
 	if err != nil {
 		logr.Get().Errorf("failed to get user by username: %v", err)
 		return nil, ErrUserNotFound
 	}
 
-	return mapUserToResponse(u), nil
+	// Without the settings the profile is treated as private
+	visibility := user.Private
+	if settings, err := s.userRepo.GetSettingsByID(ctx, u.ID.String()); err != nil {
+		logr.Get().Errorf("failed to get visibility of user %s: %v", u.ID, err)
+	} else {
+		visibility = settings.Visibility
+	}
+
+	resp := s.userResponse(ctx, u)
+	return &GetPublicUserResp{
+		ID:        resp.ID,
+		Username:  resp.Username,
+		FullName:  resp.FullName,
+		Profile:   resp.Profile.Shared(visibility),
+		HasAvatar: resp.HasAvatar,
+		CreatedAt: resp.CreatedAt,
+	}, nil
+}
+
+func (s *Service) getByOldUsername(ctx context.Context, username string) (*ports.User, error) {
+	reserved, err := s.reservation(ctx, username, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if reserved == nil {
+		return nil, ports.ErrUserNotFound
+	}
+
+	logr.Get().Infof("resolved old username to user %s", reserved.UserID)
+	return s.userRepo.GetByID(ctx, reserved.UserID.String())
 }
 
 type GetUserByEmailReq struct {
@@ -60,7 +111,25 @@ func (s *Service) GetByEmail(ctx context.Context, req GetUserByEmailReq) (*GetUs
 		return nil, ErrUserNotFound
 	}
 
-	return mapUserToResponse(u), nil
+	return s.userResponse(ctx, u), nil
+}
+
+// userResponse maps u and looks up its avatar. A failed lookup is logged
+// and reported as no avatar, it should not hide the user.
+func (s *Service) userResponse(ctx context.Context, u *ports.User) *GetUserResp {
+	resp := mapUserToResponse(u)
+	if s.avatars == nil {
+		return resp
+	}
+
+	hasAvatar, err := s.avatars.HasAvatar(ctx, u.ID.String())
+	if err != nil {
+		logr.Get().Errorf("failed to look up avatar of user %s: %v", u.ID, err)
+		return resp
+	}
+
+	resp.HasAvatar = hasAvatar
+	return resp
 }
 
 func mapUserToResponse(u *ports.User) *GetUserResp {
@@ -70,6 +139,7 @@ func mapUserToResponse(u *ports.User) *GetUserResp {
 		Email:     user.Email(u.Email),
 		FullName:  u.FullName,
 		Roles:     user.StringsToRoles(u.Roles),
+		Profile:   u.Profile,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 	}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
 )
@@ -57,7 +61,7 @@ func TestGetUserByID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockUserRepo)
 			tt.setupMock(mockRepo)
-			svc := users.NewService(mockRepo)
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
 
 			resp, err := svc.GetByID(ctx, tt.req)
 
@@ -82,6 +86,9 @@ func validGetUserByUsernameReq() users.GetUserByUsernameReq {
 	}
 }
 
+// publicSettings leave the profile visible to other users.
+var publicSettings = user.NewSettings()
+
 func TestGetUserByUsername(t *testing.T) {
 	ctx := context.Background()
 
@@ -103,6 +110,7 @@ func TestGetUserByUsername(t *testing.T) {
 					FullName: "John Doe",
 					Roles:    []string{"user"},
 				}, nil)
+				m.On("GetSettingsByID", ctx, mock.Anything).Return(&publicSettings, nil)
 			},
 			shouldSucceed: true,
 		},
@@ -114,13 +122,44 @@ func TestGetUserByUsername(t *testing.T) {
 			},
 			expectedErr: users.ErrUserNotFound,
 		},
+		{
+			name: "success - old username resolves to the current user",
+			req:  validGetUserByUsernameReq(),
+			setupMock: func(m *MockUserRepo) {
+				userID := uuid.New()
+				m.On("GetByUsername", ctx, mock.Anything).Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, mock.Anything, mock.Anything).Return(&user.UsernameChange{
+					UserID:      userID,
+					OldUsername: "olduser",
+					NewUsername: "testuser",
+					ChangedAt:   time.Now().Add(-time.Hour),
+				}, nil)
+				m.On("GetByID", ctx, userID.String()).Return(&ports.User{
+					ID:       userID,
+					Username: "testuser",
+					Email:    "test@example.com",
+					Roles:    []string{"user"},
+				}, nil)
+				m.On("GetSettingsByID", ctx, userID.String()).Return(&publicSettings, nil)
+			},
+			shouldSucceed: true,
+		},
+		{
+			name: "error - old username no longer reserved",
+			req:  validGetUserByUsernameReq(),
+			setupMock: func(m *MockUserRepo) {
+				m.On("GetByUsername", ctx, mock.Anything).Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, mock.Anything, mock.Anything).Return(nil, ports.ErrNotReserved)
+			},
+			expectedErr: users.ErrUserNotFound,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockUserRepo)
 			tt.setupMock(mockRepo)
-			svc := users.NewService(mockRepo)
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
 
 			resp, err := svc.GetByUsername(ctx, tt.req)
 
@@ -139,6 +178,94 @@ func TestGetUserByUsername(t *testing.T) {
 	}
 }
 
+func TestGetUserByUsername_MixedCase(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("current username", func(t *testing.T) {
+		mockRepo := new(MockUserRepo)
+		mockRepo.On("GetByUsername", ctx, "testuser").Return(&ports.User{ID: userID, Username: "testuser"}, nil)
+		mockRepo.On("GetSettingsByID", ctx, userID.String()).Return(&publicSettings, nil)
+		svc := users.NewService(mockRepo, memory.NewUnitOfWork())
+
+		resp, err := svc.GetByUsername(ctx, users.GetUserByUsernameReq{Username: " TestUser "})
+		require.NoError(t, err)
+		assert.Equal(t, userID, resp.ID)
+	})
+
+	t.Run("old username after a rename", func(t *testing.T) {
+		mockRepo := new(MockUserRepo)
+		mockRepo.On("GetByUsername", ctx, "olduser").Return(nil, ports.ErrUserNotFound)
+		mockRepo.On("GetUsernameReservation", ctx, "olduser", mock.Anything).Return(&user.UsernameChange{
+			UserID:      userID,
+			OldUsername: "olduser",
+			NewUsername: "testuser",
+			ChangedAt:   time.Now().Add(-time.Hour),
+		}, nil)
+		mockRepo.On("GetByID", ctx, userID.String()).Return(&ports.User{ID: userID, Username: "testuser"}, nil)
+		mockRepo.On("GetSettingsByID", ctx, userID.String()).Return(&publicSettings, nil)
+		svc := users.NewService(mockRepo, memory.NewUnitOfWork())
+
+		resp, err := svc.GetByUsername(ctx, users.GetUserByUsernameReq{Username: "OldUser"})
+		require.NoError(t, err)
+		assert.Equal(t, user.Username("testuser"), resp.Username)
+	})
+}
+
+func TestGetUserByUsername_Visibility(t *testing.T) {
+	ctx := context.Background()
+	born := time.Date(1990, 4, 21, 0, 0, 0, 0, time.UTC)
+	private := user.NewSettings()
+	private.Visibility = user.Private
+
+	tests := []struct {
+		name     string
+		settings *user.Settings
+		err      error
+		want     user.PublicProfile
+	}{
+		{
+			name:     "public profile shares bio, level and gym",
+			settings: &publicSettings,
+			want:     user.PublicProfile{Bio: "Powerlifter", ExperienceLevel: user.Advanced, PreferredGym: "Iron Temple"},
+		},
+		{name: "private profile shares nothing", settings: &private},
+		{name: "unknown visibility is private", err: errors.New("db error")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New()
+			mockRepo := new(MockUserRepo)
+			mockRepo.On("GetByUsername", ctx, "testuser").Return(&ports.User{
+				ID:       id,
+				Username: "testuser",
+				Email:    "test@example.com",
+				FullName: "John Doe",
+				Roles:    []string{"user"},
+				Profile: user.Profile{
+					Bio:             "Powerlifter",
+					BirthDate:       &born,
+					Sex:             user.Female,
+					ExperienceLevel: user.Advanced,
+					PreferredGym:    "Iron Temple",
+				},
+			}, nil)
+			if tt.settings != nil {
+				mockRepo.On("GetSettingsByID", ctx, id.String()).Return(tt.settings, nil)
+			} else {
+				mockRepo.On("GetSettingsByID", ctx, id.String()).Return(nil, tt.err)
+			}
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
+
+			resp, err := svc.GetByUsername(ctx, users.GetUserByUsernameReq{Username: "testuser"})
+			require.NoError(t, err)
+			assert.Equal(t, "John Doe", resp.FullName)
+			assert.Equal(t, tt.want, resp.Profile)
+		})
+	}
+}
+
 func validGetUserByEmailReq() users.GetUserByEmailReq {
 	return users.GetUserByEmailReq{
 		Email: "test@example.com",
@@ -183,7 +310,7 @@ func TestGetUserByEmail(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockUserRepo)
 			tt.setupMock(mockRepo)
-			svc := users.NewService(mockRepo)
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
 
 			resp, err := svc.GetByEmail(ctx, tt.req)
 
@@ -0,0 +1,120 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cheezecakee/logr"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+)
+
+const provisionUsernameAttempts = 5
+
+var nonUsernameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// ProvisionAccountReq creates an account for a user that signed in through an
+// external identity provider and has no password with us.
+type ProvisionAccountReq struct {
+	Email     string
+	FirstName string
+	LastName  string
+	// EmailVerified marks the account verified when the provider vouched for
+	// the email.
+	EmailVerified bool
+}
+
+func (s *Service) ProvisionAccount(ctx context.Context, req ProvisionAccountReq) (*CreateAccountResp, error) {
+	email, err := user.NewEmail(req.Email)
+	if err != nil {
+		logr.Get().Errorf("invalid email: %v", err)
+		return nil, fmt.Errorf("invalid email: %w", err)
+	}
+
+	password, err := unusablePassword()
+	if err != nil {
+		logr.Get().Errorf("failed to generate password: %v", err)
+		return nil, fmt.Errorf("failed to generate password: %w", err)
+	}
+
+	roles, _ := user.NewRoles(nil)
+	base := usernameBase(string(email))
+
+	for range provisionUsernameAttempts {
+		username, err := candidateUsername(base)
+		if err != nil {
+			return nil, err
+		}
+
+		u := user.New(username, provisionFullName(req.FirstName, req.LastName, username), email, roles, password, user.NewStats(), user.NewSubscription(), user.NewSettings())
+		if req.EmailVerified {
+			u.Status = u.Status.VerifyEmail(u.CreatedAt)
+		}
+
+		err = s.createAccount(ctx, u)
+		if errors.Is(err, ErrDuplicateUsername) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		logr.Get().Info("New external user account provisioned")
+		return &CreateAccountResp{UserID: u.ID.String()}, nil
+	}
+
+	logr.Get().Error("failed to find a free username")
+	return nil, ErrDuplicateUsername
+}
+
+// Helper functions
+
+// usernameBase derives a valid username prefix from the email local part.
+func usernameBase(email string) string {
+	local, _, _ := strings.Cut(strings.ToLower(email), "@")
+	local = nonUsernameChars.ReplaceAllString(local, "_")
+	local = strings.Trim(local, "_")
+
+	if len(local) < 3 {
+		local = "user"
+	}
+	if len(local) > 14 {
+		local = local[:14]
+	}
+	return local
+}
+
+func candidateUsername(base string) (user.Username, error) {
+	suffix := make([]byte, 2)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate username: %w", err)
+	}
+	return user.NewUsername(base + "_" + hex.EncodeToString(suffix))
+}
+
+func provisionFullName(firstName, lastName string, username user.Username) string {
+	if fullName, err := user.NewName(firstName, lastName); err == nil {
+		return fullName
+	}
+
+	// Providers do not always share a name that passes our rules
+	if fullName := strings.TrimSpace(strings.TrimSpace(firstName) + " " + strings.TrimSpace(lastName)); fullName != "" {
+		return fullName
+	}
+	return string(username)
+}
+
+// unusablePassword hashes a random secret nobody knows, the account can only
+// be reached through its linked identity until a password is set.
+func unusablePassword() (user.Password, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return user.NewPassword("Ext!1" + hex.EncodeToString(secret))
+}
@@ -0,0 +1,143 @@
+package users_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+func TestProvisionAccount(t *testing.T) {
+	ctx := context.Background()
+	usernamePattern := regexp.MustCompile(`^john_doe_[0-9a-f]{4}$`)
+
+	tests := []struct {
+		name        string
+		req         users.ProvisionAccountReq
+		setupMock   func(*MockUserRepo)
+		expectedErr error
+		checkUser   func(t *testing.T, u user.User)
+	}{
+		{
+			name: "success - provisions with defaults and derived username",
+			req:  users.ProvisionAccountReq{Email: "John.Doe@example.com", FirstName: "John", LastName: "Doe"},
+			setupMock: func(m *MockUserRepo) {
+				m.On("GetByUsername", ctx, mock.Anything).Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, mock.Anything, mock.Anything).Return(nil, ports.ErrNotReserved)
+				m.On("GetByEmail", ctx, "John.Doe@example.com").Return(nil, ports.ErrUserNotFound)
+				m.On("Add", ctx, mock.Anything).Return(nil)
+				m.On("AddStats", ctx, mock.Anything, mock.Anything).Return(nil)
+				m.On("AddSubscription", ctx, mock.MatchedBy(func(sub user.Subscription) bool {
+					return sub.Plan == user.Basic
+				}), mock.Anything).Return(nil)
+				m.On("AddSettings", ctx, mock.Anything, mock.Anything).Return(nil)
+			},
+			checkUser: func(t *testing.T, u user.User) {
+				assert.Regexp(t, usernamePattern, string(u.Username))
+				assert.Equal(t, "John Doe", u.FullName)
+				assert.Equal(t, user.Roles{user.RoleUser}, u.Roles)
+				assert.NotEmpty(t, u.Password)
+				assert.False(t, u.Status.IsEmailVerified())
+			},
+		},
+		{
+			name: "success - email verified by the provider",
+			req:  users.ProvisionAccountReq{Email: "john.doe@example.com", FirstName: "John", LastName: "Doe", EmailVerified: true},
+			setupMock: func(m *MockUserRepo) {
+				m.On("GetByUsername", ctx, mock.Anything).Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, mock.Anything, mock.Anything).Return(nil, ports.ErrNotReserved)
+				m.On("GetByEmail", ctx, "john.doe@example.com").Return(nil, ports.ErrUserNotFound)
+				m.On("Add", ctx, mock.Anything).Return(nil)
+				m.On("AddStats", ctx, mock.Anything, mock.Anything).Return(nil)
+				m.On("AddSubscription", ctx, mock.Anything, mock.Anything).Return(nil)
+				m.On("AddSettings", ctx, mock.Anything, mock.Anything).Return(nil)
+			},
+			checkUser: func(t *testing.T, u user.User) {
+				assert.True(t, u.Status.IsEmailVerified())
+			},
+		},
+		{
+			name: "success - falls back when provider name fails validation",
+			req:  users.ProvisionAccountReq{Email: "john.doe@example.com", FirstName: "J"},
+			setupMock: func(m *MockUserRepo) {
+				m.On("GetByUsername", ctx, mock.Anything).Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, mock.Anything, mock.Anything).Return(nil, ports.ErrNotReserved)
+				m.On("GetByEmail", ctx, "john.doe@example.com").Return(nil, ports.ErrUserNotFound)
+				m.On("Add", ctx, mock.Anything).Return(nil)
+				m.On("AddStats", ctx, mock.Anything, mock.Anything).Return(nil)
+				m.On("AddSubscription", ctx, mock.Anything, mock.Anything).Return(nil)
+				m.On("AddSettings", ctx, mock.Anything, mock.Anything).Return(nil)
+			},
+			checkUser: func(t *testing.T, u user.User) {
+				assert.Equal(t, "J", u.FullName)
+			},
+		},
+		{
+			name: "success - retries when generated username is taken",
+			req:  users.ProvisionAccountReq{Email: "john.doe@example.com", FirstName: "John", LastName: "Doe"},
+			setupMock: func(m *MockUserRepo) {
+				m.On("GetByUsername", ctx, mock.Anything).Return(&ports.User{}, nil).Once()
+				m.On("GetByUsername", ctx, mock.Anything).Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, mock.Anything, mock.Anything).Return(nil, ports.ErrNotReserved)
+				m.On("GetByEmail", ctx, "john.doe@example.com").Return(nil, ports.ErrUserNotFound)
+				m.On("Add", ctx, mock.Anything).Return(nil)
+				m.On("AddStats", ctx, mock.Anything, mock.Anything).Return(nil)
+				m.On("AddSubscription", ctx, mock.Anything, mock.Anything).Return(nil)
+				m.On("AddSettings", ctx, mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:        "error - invalid email",
+			req:         users.ProvisionAccountReq{Email: "not-an-email"},
+			setupMock:   func(m *MockUserRepo) {},
+			expectedErr: user.ErrInvalidEmail,
+		},
+		{
+			name: "error - email already registered",
+			req:  users.ProvisionAccountReq{Email: "john.doe@example.com"},
+			setupMock: func(m *MockUserRepo) {
+				m.On("GetByUsername", ctx, mock.Anything).Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, mock.Anything, mock.Anything).Return(nil, ports.ErrNotReserved)
+				m.On("GetByEmail", ctx, "john.doe@example.com").Return(&ports.User{}, nil)
+			},
+			expectedErr: users.ErrDuplicateEmail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepo)
+			tt.setupMock(mockRepo)
+
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
+
+			resp, err := svc.ProvisionAccount(ctx, tt.req)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, resp)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, resp.UserID)
+
+			if tt.checkUser != nil {
+				for _, call := range mockRepo.Calls {
+					if call.Method == "Add" {
+						tt.checkUser(t, call.Arguments.Get(1).(user.User))
+					}
+				}
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package users
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+
+// tracedService records a span around every UserService call.
+type tracedService struct {
+	next   UserService
+	tracer trace.Tracer
+}
+
+// NewTracedService wraps svc so every call records a span named after the
+// method, marked as failed when the call returns an error.
+func NewTracedService(svc UserService, tp trace.TracerProvider) UserService {
+	return &tracedService{next: svc, tracer: tp.Tracer(instrumentationName)}
+}
+
+func (s *tracedService) start(ctx context.Context, method string) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "users.Service."+method)
+}
+
+func end(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+	return err
+}
+
+func (s *tracedService) CreateAccount(ctx context.Context, req CreateAccountReq) (*CreateAccountResp, error) {
+	ctx, span := s.start(ctx, "CreateAccount")
+	resp, err := s.next.CreateAccount(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) ProvisionAccount(ctx context.Context, req ProvisionAccountReq) (*CreateAccountResp, error) {
+	ctx, span := s.start(ctx, "ProvisionAccount")
+	resp, err := s.next.ProvisionAccount(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) GetByID(ctx context.Context, req GetUserByIDReq) (*GetUserResp, error) {
+	ctx, span := s.start(ctx, "GetByID")
+	resp, err := s.next.GetByID(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) GetByUsername(ctx context.Context, req GetUserByUsernameReq) (*GetPublicUserResp, error) {
+	ctx, span := s.start(ctx, "GetByUsername")
+	resp, err := s.next.GetByUsername(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) GetByEmail(ctx context.Context, req GetUserByEmailReq) (*GetUserResp, error) {
+	ctx, span := s.start(ctx, "GetByEmail")
+	resp, err := s.next.GetByEmail(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) GetFullProfile(ctx context.Context, req GetFullProfileReq) (*GetFullProfileResp, error) {
+	ctx, span := s.start(ctx, "GetFullProfile")
+	resp, err := s.next.GetFullProfile(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) Update(ctx context.Context, req UpdateUserReq) error {
+	ctx, span := s.start(ctx, "Update")
+	return end(span, s.next.Update(ctx, req))
+}
+
+func (s *tracedService) Delete(ctx context.Context, req DeleteAccountReq) error {
+	ctx, span := s.start(ctx, "Delete")
+	return end(span, s.next.Delete(ctx, req))
+}
+
+func (s *tracedService) PurgeDeleted(ctx context.Context) (*PurgeDeletedResp, error) {
+	ctx, span := s.start(ctx, "PurgeDeleted")
+	resp, err := s.next.PurgeDeleted(ctx)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) GetStats(ctx context.Context, req GetStatsReq) (*GetStatsResp, error) {
+	ctx, span := s.start(ctx, "GetStats")
+	resp, err := s.next.GetStats(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) GetSubscription(ctx context.Context, req GetSubscriptionReq) (*GetSubscriptionResp, error) {
+	ctx, span := s.start(ctx, "GetSubscription")
+	resp, err := s.next.GetSubscription(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) GetSettings(ctx context.Context, req GetSettingsReq) (*GetSettingsResp, error) {
+	ctx, span := s.start(ctx, "GetSettings")
+	resp, err := s.next.GetSettings(ctx, req)
+	return resp, end(span, err)
+}
+
+func (s *tracedService) UpgradePlan(ctx context.Context, req UpgradePlanReq) error {
+	ctx, span := s.start(ctx, "UpgradePlan")
+	return end(span, s.next.UpgradePlan(ctx, req))
+}
+
+func (s *tracedService) RecordPayment(ctx context.Context, req RecordPaymentReq) error {
+	ctx, span := s.start(ctx, "RecordPayment")
+	return end(span, s.next.RecordPayment(ctx, req))
+}
+
+func (s *tracedService) CancelSubscription(ctx context.Context, req CancelSubscriptionReq) error {
+	ctx, span := s.start(ctx, "CancelSubscription")
+	return end(span, s.next.CancelSubscription(ctx, req))
+}
+
+func (s *tracedService) StartTrial(ctx context.Context, req StartTrialReq) error {
+	ctx, span := s.start(ctx, "StartTrial")
+	return end(span, s.next.StartTrial(ctx, req))
+}
+
+func (s *tracedService) UpdateSettings(ctx context.Context, req UpdateSettingsReq) error {
+	ctx, span := s.start(ctx, "UpdateSettings")
+	return end(span, s.next.UpdateSettings(ctx, req))
+}
+
+func (s *tracedService) UpdateBodyMetrics(ctx context.Context, req UpdateBodyMetricsReq) error {
+	ctx, span := s.start(ctx, "UpdateBodyMetrics")
+	return end(span, s.next.UpdateBodyMetrics(ctx, req))
+}
@@ -0,0 +1,47 @@
+package users_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
+)
+
+func TestTracedService(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	mockRepo := new(MockUserRepo)
+	mockRepo.On("GetByID", mock.Anything, "found").Return(&ports.User{ID: uuid.New(), Username: "testuser"}, nil)
+	mockRepo.On("GetByID", mock.Anything, "missing").Return(nil, errors.New("not found"))
+
+	svc := users.NewTracedService(users.NewService(mockRepo, memory.NewUnitOfWork()), tp)
+	ctx := context.Background()
+
+	_, err := svc.GetByID(ctx, users.GetUserByIDReq{ID: "found"})
+	require.NoError(t, err)
+
+	_, err = svc.GetByID(ctx, users.GetUserByIDReq{ID: "missing"})
+	assert.ErrorIs(t, err, users.ErrUserNotFound)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "users.Service.GetByID", spans[0].Name)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+
+	assert.Equal(t, "users.Service.GetByID", spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Len(t, spans[1].Events, 1, "expected the error to be recorded")
+}
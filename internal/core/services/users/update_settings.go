@@ -30,42 +30,37 @@ func (s *Service) UpdateSettings(ctx context.Context, req UpdateSettingsReq) err
 		return fmt.Errorf("failed to get user settings: %w", err)
 	}
 
+	var v user.Validation
+
 	if req.WeightUnit != nil {
 		weightUnit, err := user.NewWeightUnit(*req.WeightUnit)
-		if err != nil {
-			logr.Get().Errorf("failed to create new weight unit: %v", err)
-			return fmt.Errorf("failed to create new weight unit: %w", err)
-		}
+		v.Check("weight_unit", err)
 		settings.WeightUnit = weightUnit
 	}
 
 	if req.HeightUnit != nil {
 		heightUnit, err := user.NewHeightUnit(*req.HeightUnit)
-		if err != nil {
-			logr.Get().Errorf("failed to create new height unit: %v", err)
-			return fmt.Errorf("failed to create new height unit: %w", err)
-		}
+		v.Check("height_unit", err)
 		settings.HeightUnit = heightUnit
 	}
 
 	if req.Theme != nil {
 		theme, err := user.NewTheme(*req.Theme)
-		if err != nil {
-			logr.Get().Errorf("failed to create new theme unit: %v", err)
-			return fmt.Errorf("failed to create new theme unit: %w", err)
-		}
+		v.Check("theme", err)
 		settings.Theme = theme
 	}
 
 	if req.Visibility != nil {
 		visibility, err := user.NewVisibility(*req.Visibility)
-		if err != nil {
-			logr.Get().Errorf("failed to create new visibility: %v", err)
-			return fmt.Errorf("failed to create new visibility: %w", err)
-		}
+		v.Check("visibility", err)
 		settings.Visibility = visibility
 	}
 
+	if err := v.Err(); err != nil {
+		logr.Get().Errorf("invalid settings: %v", err)
+		return fmt.Errorf("invalid settings: %w", err)
+	}
+
 	if req.EmailNotif != nil {
 		settings.EmailNotif = *req.EmailNotif
 	}
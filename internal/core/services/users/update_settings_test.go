@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
 )
@@ -127,7 +128,7 @@ func TestUpdateSettings(t *testing.T) {
 				}
 				m.On("GetSettingsByID", ctx, testUserID).Return(settings, nil)
 			},
-			expectedErr: errors.New("failed to create new weight unit: invalid weight unit"),
+			expectedErr: errors.New("invalid settings: invalid fields: weight_unit: invalid weight unit"),
 		},
 		{
 			name: "error - invalid height unit",
@@ -144,7 +145,7 @@ func TestUpdateSettings(t *testing.T) {
 				}
 				m.On("GetSettingsByID", ctx, testUserID).Return(settings, nil)
 			},
-			expectedErr: errors.New("failed to create new height unit: invalid height unit"),
+			expectedErr: errors.New("invalid settings: invalid fields: height_unit: invalid height unit"),
 		},
 		{
 			name: "error - invalid theme",
@@ -160,7 +161,7 @@ func TestUpdateSettings(t *testing.T) {
 				}
 				m.On("GetSettingsByID", ctx, testUserID).Return(settings, nil)
 			},
-			expectedErr: errors.New("failed to create new theme unit: invalid theme"),
+			expectedErr: errors.New("invalid settings: invalid fields: theme: invalid theme"),
 		},
 		{
 			name: "error - invalid visibility",
@@ -176,7 +177,7 @@ func TestUpdateSettings(t *testing.T) {
 				}
 				m.On("GetSettingsByID", ctx, testUserID).Return(settings, nil)
 			},
-			expectedErr: errors.New("failed to create new visibility: invalid visibility"),
+			expectedErr: errors.New("invalid settings: invalid fields: visibility: invalid visibility"),
 		},
 		{
 			name: "error - get settings fails",
@@ -212,7 +213,7 @@ func TestUpdateSettings(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockUserRepo)
 			tt.setupMock(mockRepo)
-			svc := users.NewService(mockRepo)
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
 
 			err := svc.UpdateSettings(ctx, tt.req)
 
@@ -27,36 +27,31 @@ func (s *Service) UpdateBodyMetrics(ctx context.Context, req UpdateBodyMetricsRe
 
 	var updateBodyMetrics ports.UpdateBodyMetrics
 
+	var v user.Validation
+
 	if req.WeightValue != nil {
 		weight, err := user.NewWeight(*req.WeightValue, settings.WeightUnit)
-		if err != nil {
-			logr.Get().Errorf("failed to create user weight: %v", err)
-			return fmt.Errorf("failed to create user weight: %w", err)
-		}
-
+		v.Check("weight_value", err)
 		updateBodyMetrics.WeightValue = &weight
 	}
 
 	if req.HeightValue != nil {
 		height, err := user.NewHeight(*req.HeightValue, settings.HeightUnit)
-		if err != nil {
-			logr.Get().Errorf("failed to create user height: %v", err)
-			return fmt.Errorf("failed to create user height: %w", err)
-		}
-
+		v.Check("height_value", err)
 		updateBodyMetrics.HeightValue = &height
 	}
 
 	if req.BFP != nil {
 		BFP, err := user.NewBFP(*req.BFP)
-		if err != nil {
-			logr.Get().Errorf("failed to create user BFP: %v", err)
-			return fmt.Errorf("failed to create user BFP: %w", err)
-		}
-
+		v.Check("bfp", err)
 		updateBodyMetrics.BFP = &BFP
 	}
 
+	if err := v.Err(); err != nil {
+		logr.Get().Errorf("invalid body metrics: %v", err)
+		return fmt.Errorf("invalid body metrics: %w", err)
+	}
+
 	updateBodyMetrics.UpdatedAt = time.Now()
 
 	err = s.userRepo.UpdateBodyMetrics(ctx, updateBodyMetrics, req.UserID)
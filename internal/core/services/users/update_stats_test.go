@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
@@ -72,7 +73,7 @@ func TestUpdateBodyMetrics(t *testing.T) {
 					nil,
 				)
 			},
-			expectedErr: errors.New("failed to create user weight: weight cannot be negative"),
+			expectedErr: errors.New("invalid body metrics: invalid fields: weight_value: weight cannot be negative"),
 		},
 		{
 			name: "error - invalid height (zero)",
@@ -86,7 +87,7 @@ func TestUpdateBodyMetrics(t *testing.T) {
 					nil,
 				)
 			},
-			expectedErr: errors.New("failed to create user height: height cannot be zero"),
+			expectedErr: errors.New("invalid body metrics: invalid fields: height_value: height cannot be zero"),
 		},
 		{
 			name: "error - invalid BFP (over 100)",
@@ -100,7 +101,7 @@ func TestUpdateBodyMetrics(t *testing.T) {
 					nil,
 				)
 			},
-			expectedErr: errors.New("failed to create user BFP: invalid bodyfat percentage"),
+			expectedErr: errors.New("invalid body metrics: invalid fields: bfp: invalid bodyfat percentage"),
 		},
 		{
 			name: "error - GetSettingsByID fails",
@@ -134,7 +135,7 @@ func TestUpdateBodyMetrics(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockUserRepo)
 			tt.setupMock(mockRepo)
-			svc := users.NewService(mockRepo)
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
 
 			err := svc.UpdateBodyMetrics(ctx, tt.req)
 
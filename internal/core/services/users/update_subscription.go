@@ -3,9 +3,11 @@ package users
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/cheezecakee/logr"
 
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
 )
 
@@ -39,13 +41,10 @@ func (s *Service) UpgradePlan(ctx context.Context, req UpgradePlanReq) error {
 		return fmt.Errorf("failed to update plan: %w", err)
 	}
 
-	err = s.userRepo.UpdateSubscription(ctx, *existing, req.UserID)
-	if err != nil {
-		logr.Get().Errorf("failed to update subscription: %v", err)
-		return fmt.Errorf("failed to update subscription: %w", err)
-	}
-
-	return nil
+	return s.saveSubscription(ctx, req.UserID, *existing, audit.ActionSubscriptionUpgraded, map[string]string{
+		"plan":           string(plan),
+		"billing_period": string(period),
+	})
 }
 
 type RecordPaymentReq struct {
@@ -69,12 +68,10 @@ func (s *Service) RecordPayment(ctx context.Context, req RecordPaymentReq) error
 
 	existing.ProcessPayment(req.Amount, currency)
 
-	err = s.userRepo.UpdateSubscription(ctx, *existing, req.UserID)
-	if err != nil {
-		logr.Get().Errorf("failed to update subscription: %v", err)
-		return fmt.Errorf("failed to update subscription: %w", err)
-	}
-	return nil
+	return s.saveSubscription(ctx, req.UserID, *existing, audit.ActionPaymentRecorded, map[string]string{
+		"amount":   strconv.FormatFloat(req.Amount, 'f', 2, 64),
+		"currency": string(currency),
+	})
 }
 
 type CancelSubscriptionReq struct {
@@ -94,12 +91,9 @@ func (s *Service) CancelSubscription(ctx context.Context, req CancelSubscription
 		return fmt.Errorf("failed to cancel subscription: %w", err)
 	}
 
-	err = s.userRepo.UpdateSubscription(ctx, *existing, req.UserID)
-	if err != nil {
-		logr.Get().Errorf("failed to update subscription: %v", err)
-		return fmt.Errorf("failed to update subscription: %w", err)
-	}
-	return nil
+	return s.saveSubscription(ctx, req.UserID, *existing, audit.ActionSubscriptionCancelled, map[string]string{
+		"plan": string(existing.Plan),
+	})
 }
 
 type StartTrialReq struct {
@@ -122,3 +116,16 @@ func (s *Service) StartTrial(ctx context.Context, req StartTrialReq) error {
 	}
 	return nil
 }
+
+// saveSubscription stores sub and records action in one unit of work.
+func (s *Service) saveSubscription(ctx context.Context, userID string, sub user.Subscription, action audit.Action, metadata map[string]string) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		err := s.userRepo.UpdateSubscription(ctx, sub, userID)
+		if err != nil {
+			logr.Get().Errorf("failed to update subscription: %v", err)
+			return fmt.Errorf("failed to update subscription: %w", err)
+		}
+
+		return s.record(ctx, action, userID, metadata)
+	})
+}
@@ -6,9 +6,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
 )
@@ -130,7 +134,7 @@ func TestUpdatePlan(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockUserRepo)
 			tt.setupMock(mockRepo)
-			svc := users.NewService(mockRepo)
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
 
 			err := svc.UpgradePlan(ctx, tt.req)
 
@@ -213,7 +217,7 @@ func TestRecordPayment(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockUserRepo)
 			tt.setupMock(mockRepo)
-			svc := users.NewService(mockRepo)
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
 
 			err := svc.RecordPayment(ctx, tt.req)
 
@@ -291,7 +295,7 @@ func TestCancelSubscription(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockUserRepo)
 			tt.setupMock(mockRepo)
-			svc := users.NewService(mockRepo)
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
 
 			err := svc.CancelSubscription(ctx, tt.req)
 
@@ -351,7 +355,7 @@ func TestStartTrial(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockUserRepo)
 			tt.setupMock(mockRepo)
-			svc := users.NewService(mockRepo)
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
 
 			err := svc.StartTrial(ctx, tt.req)
 
@@ -366,3 +370,54 @@ func TestStartTrial(t *testing.T) {
 		})
 	}
 }
+
+func TestSubscription_Audited(t *testing.T) {
+	ctx := audit.ContextWithClient(context.Background(), audit.Client{IP: "203.0.113.7"})
+	userID := uuid.New()
+	id := userID.String()
+
+	period := user.Monthly
+	sub := &user.Subscription{Plan: user.Premium, BillingPeriod: &period, StartedAt: time.Now()}
+
+	mockRepo := new(MockUserRepo)
+	mockRepo.On("GetSubscriptionByID", mock.Anything, id).Return(sub, nil)
+	mockRepo.On("UpdateSubscription", mock.Anything, mock.Anything, id).Return(nil)
+
+	auditLog := memory.NewAuditLog()
+	svc := users.NewService(mockRepo, memory.NewUnitOfWork(), users.WithAuditLog(auditLog))
+
+	require.NoError(t, svc.RecordPayment(ctx, users.RecordPaymentReq{UserID: id, Amount: 9.5, Currency: "USD"}))
+	require.NoError(t, svc.CancelSubscription(ctx, users.CancelSubscriptionReq{UserID: id}))
+
+	events := auditLog.Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, audit.ActionPaymentRecorded, events[0].Action)
+	assert.Equal(t, map[string]string{"amount": "9.50", "currency": "USD"}, events[0].Metadata)
+	assert.Equal(t, audit.ActionSubscriptionCancelled, events[1].Action)
+	for _, event := range events {
+		assert.Equal(t, userID, event.ActorID)
+		assert.Equal(t, userID, event.TargetID)
+		assert.Equal(t, "203.0.113.7", event.IP)
+	}
+}
+
+func TestSubscription_AuditFailureRollsBack(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.NewString()
+
+	sub := &user.Subscription{Plan: user.Basic, StartedAt: time.Now()}
+	mockRepo := new(MockUserRepo)
+	mockRepo.On("GetSubscriptionByID", mock.Anything, id).Return(sub, nil)
+	mockRepo.On("UpdateSubscription", mock.Anything, mock.Anything, id).Return(nil)
+
+	auditLog := new(MockAuditLog)
+	auditLog.On("Record", mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+	uow := memory.NewUnitOfWork()
+	svc := users.NewService(mockRepo, uow, users.WithAuditLog(auditLog))
+
+	err := svc.UpgradePlan(ctx, users.UpgradePlanReq{UserID: id, Plan: "premium", BillingPeriod: "monthly"})
+
+	assert.EqualError(t, err, "failed to record audit event: db error")
+	assert.Equal(t, 1, uow.Rollbacks())
+}
@@ -17,8 +17,22 @@ type UpdateUserReq struct {
 	Email     string `json:"email"`
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
+	// Profile fields are left as they are when nil, an empty value clears
+	// them.
+	Bio             *string `json:"bio"`
+	BirthDate       *string `json:"birth_date"`
+	Sex             *string `json:"sex"`
+	ExperienceLevel *string `json:"experience_level"`
+	PreferredGym    *string `json:"preferred_gym"`
+	// SkipCooldown lets support staff change the username within the
+	// cooldown.
+	SkipCooldown bool `json:"-"`
 }
 
+// Update edits the profile. A new username is subject to the cooldown and
+// the old one stays reserved for the user, see user.UsernameReservation. A
+// new email is no longer verified, it would otherwise let external logins
+// for that address into this account.
 func (s *Service) Update(ctx context.Context, req UpdateUserReq) error {
 	existingUser, err := s.userRepo.GetByID(ctx, req.ID)
 	if err != nil {
@@ -26,35 +40,55 @@ func (s *Service) Update(ctx context.Context, req UpdateUserReq) error {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 
+	now := time.Now()
+
+	var (
+		v        user.Validation
+		username user.Username
+		email    user.Email
+		fullName string
+	)
+
 	if req.Username != "" {
-		username, err := user.NewUsername(req.Username)
-		if err != nil {
-			logr.Get().Errorf("invalid username: %v", err)
-			return fmt.Errorf("invalid username: %w", err)
-		}
-		if string(username) != string(existingUser.Username) {
-			userWithUsername, err := s.userRepo.GetByUsername(ctx, string(username))
-			if err != nil && err != ports.ErrUserNotFound {
-				logr.Get().Errorf("failed to check username: %v", err)
-				return fmt.Errorf("failed to check username: %w", err)
-			}
-			if userWithUsername != nil && userWithUsername.ID != existingUser.ID {
-				logr.Get().Errorf("username already exists: %v", err)
-				return ErrDuplicateUsername
-			}
+		username, err = user.NewUsername(req.Username)
+		v.Check("username", err)
+	}
+
+	if req.Email != "" {
+		email, err = user.NewEmail(req.Email)
+		v.Check("email", err)
+	}
+
+	if req.FirstName != "" || req.LastName != "" {
+		if req.FirstName == "" || req.LastName == "" {
+			// The full name is stored as one value, so both parts are needed
+			v.Check("name", user.ErrIncompleteName)
+		} else {
+			fullName, err = user.NewName(req.FirstName, req.LastName)
+			v.Check("name", err)
 		}
+	}
 
-		existingUser.Username = username
+	profile := updateProfile(&v, existingUser.Profile, req, now)
+
+	if err := v.Err(); err != nil {
+		logr.Get().Errorf("invalid user update: %v", err)
+		return fmt.Errorf("invalid user update: %w", err)
 	}
 
-	if req.Email != "" {
-		email, err := user.NewEmail(req.Email)
+	var change *user.UsernameChange
+	if username != "" && username != existingUser.Username {
+		c, err := s.changeUsername(ctx, existingUser, username, req.SkipCooldown, now)
 		if err != nil {
-			logr.Get().Errorf("invalid email: %v", err)
-			return fmt.Errorf("invalid email: %w", err)
+			return err
 		}
+		change = &c
+		existingUser.Username = username
+	}
 
-		if string(email) != string(existingUser.Email) {
+	var status *user.AccountStatus
+	if email != "" {
+		if email != existingUser.Email {
 			userWithEmail, err := s.userRepo.GetByEmail(ctx, string(email))
 			if err != nil && err != ports.ErrUserNotFound {
 				logr.Get().Errorf("failed to check email: %v", err)
@@ -64,49 +98,90 @@ func (s *Service) Update(ctx context.Context, req UpdateUserReq) error {
 				logr.Get().Errorf("email already exists: %v", err)
 				return ErrDuplicateEmail
 			}
+
+			if existingUser.Status.IsEmailVerified() {
+				unverified := existingUser.Status.UnverifyEmail()
+				status = &unverified
+			}
 		}
 
 		existingUser.Email = email
 	}
 
-	if req.FirstName != "" || req.LastName != "" {
-		firstName := req.FirstName
-		lastName := req.LastName
-
-		if firstName == "" || lastName == "" {
-			// Parse existing full name to get parts
-			// For now, just require both or neither
-			if firstName == "" && lastName == "" {
-				// Keep existing
-			} else {
-				return fmt.Errorf("both first and last name must be provided together")
-			}
-		} else {
-			fullName, err := user.NewName(req.FirstName, req.LastName)
-			if err != nil {
-				logr.Get().Errorf("invalid name: %v", err)
-				return fmt.Errorf("invalid name: %w", err)
-			}
-
-			existingUser.FullName = fullName
-		}
+	if fullName != "" {
+		existingUser.FullName = fullName
 	}
 
-	existingUser.UpdatedAt = time.Now()
+	existingUser.UpdatedAt = now
 	user := &user.User{
 		ID:        existingUser.ID,
 		Username:  existingUser.Username,
 		FullName:  existingUser.FullName,
 		Email:     existingUser.Email,
+		Profile:   profile,
 		CreatedAt: existingUser.CreatedAt,
 		UpdatedAt: existingUser.UpdatedAt,
 	}
-	err = s.userRepo.Update(ctx, *user)
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		err := s.userRepo.Update(ctx, *user)
+		if err != nil {
+			logr.Get().Errorf("error update user: %v", err)
+			return fmt.Errorf("error update user: %w", err)
+		}
+
+		if status != nil {
+			if err := s.userRepo.UpdateStatus(ctx, req.ID, *status, now); err != nil {
+				logr.Get().Errorf("failed to reset email verification: %v", err)
+				return fmt.Errorf("failed to reset email verification: %w", err)
+			}
+		}
+
+		if change == nil {
+			return nil
+		}
+		if err := s.userRepo.AddUsernameChange(ctx, *change); err != nil {
+			logr.Get().Errorf("failed to record username change: %v", err)
+			return fmt.Errorf("failed to record username change: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		logr.Get().Errorf("error update user: %v", err)
-		return fmt.Errorf("error update user: %w", err)
+		return err
 	}
 
 	logr.Get().Info("User updated successfully")
 	return nil
 }
+
+// updateProfile applies the profile fields of req to p, invalid ones are
+// recorded on v.
+func updateProfile(v *user.Validation, p user.Profile, req UpdateUserReq, now time.Time) user.Profile {
+	var err error
+
+	if req.Bio != nil {
+		p.Bio, err = user.NewBio(*req.Bio)
+		v.Check("bio", err)
+	}
+
+	if req.BirthDate != nil {
+		p.BirthDate, err = user.NewBirthDate(*req.BirthDate, now)
+		v.Check("birth_date", err)
+	}
+
+	if req.Sex != nil {
+		p.Sex, err = user.NewSex(*req.Sex)
+		v.Check("sex", err)
+	}
+
+	if req.ExperienceLevel != nil {
+		p.ExperienceLevel, err = user.NewExperienceLevel(*req.ExperienceLevel)
+		v.Check("experience_level", err)
+	}
+
+	if req.PreferredGym != nil {
+		p.PreferredGym, err = user.NewPreferredGym(*req.PreferredGym)
+		v.Check("preferred_gym", err)
+	}
+
+	return p
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/services/users"
 )
@@ -40,10 +42,17 @@ func basicExistingUser() *ports.User {
 
 // Helper to setup common username/email checks
 func setupUniqueChecks(m *MockUserRepo, username, email string) {
-	m.On("GetByUsername", mock.Anything, username).Return(nil, ports.ErrUserNotFound)
+	setupUsernameChecks(m, username)
 	m.On("GetByEmail", mock.Anything, email).Return(nil, ports.ErrUserNotFound)
 }
 
+// Helper to setup a username change outside the cooldown to a free name
+func setupUsernameChecks(m *MockUserRepo, username string) {
+	m.On("GetUsernameChanges", mock.Anything, testUserID.String()).Return(nil, nil)
+	m.On("GetByUsername", mock.Anything, username).Return(nil, ports.ErrUserNotFound)
+	m.On("GetUsernameReservation", mock.Anything, username, mock.Anything).Return(nil, ports.ErrNotReserved)
+}
+
 func TestUpdateUser(t *testing.T) {
 	ctx := context.Background()
 
@@ -62,6 +71,9 @@ func TestUpdateUser(t *testing.T) {
 				m.On("GetByID", ctx, mock.Anything).Return(existing, nil)
 				setupUniqueChecks(m, "newuser", "new@example.com")
 				m.On("Update", ctx, mock.Anything).Return(nil)
+				m.On("AddUsernameChange", ctx, mock.MatchedBy(func(c user.UsernameChange) bool {
+					return c.UserID == testUserID && c.OldUsername == "olduser" && c.NewUsername == "newuser"
+				})).Return(nil)
 			},
 			shouldSucceed: true,
 		},
@@ -79,6 +91,40 @@ func TestUpdateUser(t *testing.T) {
 			},
 			shouldSucceed: true,
 		},
+		{
+			name: "success - new email drops the verification",
+			req: users.UpdateUserReq{
+				ID:    testUserID.String(),
+				Email: "partial@example.com",
+			},
+			setupMock: func(m *MockUserRepo) {
+				existing := basicExistingUser()
+				verifiedAt := time.Now().Add(-time.Hour)
+				existing.Status = user.AccountStatus{EmailVerifiedAt: &verifiedAt}
+				m.On("GetByID", ctx, mock.Anything).Return(existing, nil)
+				m.On("GetByEmail", mock.Anything, "partial@example.com").Return(nil, ports.ErrUserNotFound)
+				m.On("Update", ctx, mock.Anything).Return(nil)
+				m.On("UpdateStatus", ctx, testUserID.String(), mock.MatchedBy(func(s user.AccountStatus) bool {
+					return !s.IsEmailVerified()
+				}), mock.Anything).Return(nil)
+			},
+			shouldSucceed: true,
+		},
+		{
+			name: "success - same email keeps the verification",
+			req: users.UpdateUserReq{
+				ID:    testUserID.String(),
+				Email: "old@example.com",
+			},
+			setupMock: func(m *MockUserRepo) {
+				existing := basicExistingUser()
+				verifiedAt := time.Now().Add(-time.Hour)
+				existing.Status = user.AccountStatus{EmailVerifiedAt: &verifiedAt}
+				m.On("GetByID", ctx, mock.Anything).Return(existing, nil)
+				m.On("Update", ctx, mock.Anything).Return(nil)
+			},
+			shouldSucceed: true,
+		},
 		{
 			name: "error - user not found",
 			req:  validUpdateUserReq(),
@@ -93,6 +139,7 @@ func TestUpdateUser(t *testing.T) {
 			setupMock: func(m *MockUserRepo) {
 				existing := basicExistingUser()
 				m.On("GetByID", ctx, mock.Anything).Return(existing, nil)
+				m.On("GetUsernameChanges", ctx, testUserID.String()).Return(nil, nil)
 				m.On("GetByUsername", ctx, "newuser").Return(&ports.User{ID: uuid.New()}, nil)
 			},
 			expectedErr: users.ErrDuplicateUsername,
@@ -103,7 +150,7 @@ func TestUpdateUser(t *testing.T) {
 			setupMock: func(m *MockUserRepo) {
 				existing := basicExistingUser()
 				m.On("GetByID", ctx, mock.Anything).Return(existing, nil)
-				m.On("GetByUsername", ctx, "newuser").Return(nil, ports.ErrUserNotFound)
+				setupUsernameChecks(m, "newuser")
 				m.On("GetByEmail", ctx, "new@example.com").Return(&ports.User{ID: uuid.New()}, nil)
 			},
 			expectedErr: users.ErrDuplicateEmail,
@@ -118,7 +165,7 @@ func TestUpdateUser(t *testing.T) {
 				existing := basicExistingUser()
 				m.On("GetByID", ctx, mock.Anything).Return(existing, nil)
 			},
-			expectedErr: errors.New("invalid username: username too short"),
+			expectedErr: errors.New("invalid user update: invalid fields: username: username too short"),
 		},
 		{
 			name: "error - invalid email",
@@ -130,7 +177,7 @@ func TestUpdateUser(t *testing.T) {
 				existing := basicExistingUser()
 				m.On("GetByID", ctx, mock.Anything).Return(existing, nil)
 			},
-			expectedErr: errors.New("invalid email: invalid email"),
+			expectedErr: errors.New("invalid user update: invalid fields: email: invalid email"),
 		},
 		{
 			name: "error - incomplete full name",
@@ -142,7 +189,7 @@ func TestUpdateUser(t *testing.T) {
 				existing := basicExistingUser()
 				m.On("GetByID", ctx, mock.Anything).Return(existing, nil)
 			},
-			expectedErr: errors.New("both first and last name must be provided together"),
+			expectedErr: errors.New("invalid user update: invalid fields: name: first and last name must be provided together"),
 		},
 		{
 			name: "error - repo update fails",
@@ -161,7 +208,7 @@ func TestUpdateUser(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockUserRepo)
 			tt.setupMock(mockRepo)
-			svc := users.NewService(mockRepo)
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
 
 			err := svc.Update(ctx, tt.req)
 
@@ -176,3 +223,177 @@ func TestUpdateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateUser_UsernamePolicy(t *testing.T) {
+	ctx := context.Background()
+	recent := user.NewUsernameChange(testUserID, "older", "olduser", time.Now().Add(-24*time.Hour))
+	longAgo := user.NewUsernameChange(testUserID, "older", "olduser", time.Now().Add(-user.UsernameCooldown-time.Hour))
+
+	tests := []struct {
+		name       string
+		req        users.UpdateUserReq
+		setupMock  func(*MockUserRepo)
+		wantErr    error
+		wantChange bool
+	}{
+		{
+			name: "refused within the cooldown",
+			req:  users.UpdateUserReq{ID: testUserID.String(), Username: "newuser"},
+			setupMock: func(m *MockUserRepo) {
+				m.On("GetUsernameChanges", ctx, testUserID.String()).Return([]user.UsernameChange{recent}, nil)
+			},
+			wantErr: user.ErrUsernameCooldown,
+		},
+		{
+			name: "allowed once the cooldown is over",
+			req:  users.UpdateUserReq{ID: testUserID.String(), Username: "newuser"},
+			setupMock: func(m *MockUserRepo) {
+				m.On("GetUsernameChanges", ctx, testUserID.String()).Return([]user.UsernameChange{longAgo}, nil)
+				m.On("GetByUsername", ctx, "newuser").Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, "newuser", mock.Anything).Return(nil, ports.ErrNotReserved)
+			},
+			wantChange: true,
+		},
+		{
+			name: "staff skip the cooldown",
+			req:  users.UpdateUserReq{ID: testUserID.String(), Username: "newuser", SkipCooldown: true},
+			setupMock: func(m *MockUserRepo) {
+				m.On("GetByUsername", ctx, "newuser").Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, "newuser", mock.Anything).Return(nil, ports.ErrNotReserved)
+			},
+			wantChange: true,
+		},
+		{
+			name: "reserved for another user",
+			req:  users.UpdateUserReq{ID: testUserID.String(), Username: "newuser"},
+			setupMock: func(m *MockUserRepo) {
+				reserved := user.NewUsernameChange(uuid.New(), "newuser", "someone", time.Now().Add(-time.Hour))
+				m.On("GetUsernameChanges", ctx, testUserID.String()).Return(nil, nil)
+				m.On("GetByUsername", ctx, "newuser").Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, "newuser", mock.MatchedBy(func(after time.Time) bool {
+					return time.Until(after.Add(user.UsernameReservation)) < time.Minute
+				})).Return(&reserved, nil)
+			},
+			wantErr: users.ErrDuplicateUsername,
+		},
+		{
+			name: "own old username taken back",
+			req:  users.UpdateUserReq{ID: testUserID.String(), Username: "older"},
+			setupMock: func(m *MockUserRepo) {
+				m.On("GetUsernameChanges", ctx, testUserID.String()).Return([]user.UsernameChange{longAgo}, nil)
+				m.On("GetByUsername", ctx, "older").Return(nil, ports.ErrUserNotFound)
+				m.On("GetUsernameReservation", ctx, "older", mock.Anything).Return(&longAgo, nil)
+			},
+			wantChange: true,
+		},
+		{
+			name:    "reserved word",
+			req:     users.UpdateUserReq{ID: testUserID.String(), Username: "support"},
+			wantErr: user.ErrUsernameReserved,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepo)
+			mockRepo.On("GetByID", ctx, testUserID.String()).Return(basicExistingUser(), nil)
+			if tt.setupMock != nil {
+				tt.setupMock(mockRepo)
+			}
+			if tt.wantChange {
+				mockRepo.On("Update", ctx, mock.Anything).Return(nil)
+				mockRepo.On("AddUsernameChange", ctx, mock.MatchedBy(func(c user.UsernameChange) bool {
+					return c.OldUsername == "olduser" && string(c.NewUsername) == tt.req.Username
+				})).Return(nil)
+			}
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
+
+			err := svc.Update(ctx, tt.req)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateUser_Profile(t *testing.T) {
+	ctx := context.Background()
+	born := time.Date(1990, 4, 21, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		existing    user.Profile
+		req         users.UpdateUserReq
+		wantProfile user.Profile
+		wantErr     string
+	}{
+		{
+			name: "sets profile fields",
+			req: users.UpdateUserReq{
+				ID:              testUserID.String(),
+				Bio:             stringPtr("  Powerlifter  "),
+				BirthDate:       stringPtr("1990-04-21"),
+				Sex:             stringPtr("female"),
+				ExperienceLevel: stringPtr("intermediate"),
+				PreferredGym:    stringPtr("Iron Temple"),
+			},
+			wantProfile: user.Profile{
+				Bio:             "Powerlifter",
+				BirthDate:       &born,
+				Sex:             user.Female,
+				ExperienceLevel: user.Intermediate,
+				PreferredGym:    "Iron Temple",
+			},
+		},
+		{
+			name:     "empty strings clear fields, nil leaves them",
+			existing: user.Profile{Bio: "Powerlifter", BirthDate: &born, Sex: user.Male},
+			req: users.UpdateUserReq{
+				ID:        testUserID.String(),
+				Bio:       stringPtr(""),
+				BirthDate: stringPtr(""),
+			},
+			wantProfile: user.Profile{Sex: user.Male},
+		},
+		{
+			name: "invalid fields",
+			req: users.UpdateUserReq{
+				ID:        testUserID.String(),
+				BirthDate: stringPtr("21/04/1990"),
+				Sex:       stringPtr("other"),
+			},
+			wantErr: "invalid user update: invalid fields: birth_date: birth date must be a date such as 1990-04-21; sex: invalid sex",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := basicExistingUser()
+			existing.Profile = tt.existing
+
+			mockRepo := new(MockUserRepo)
+			mockRepo.On("GetByID", ctx, testUserID.String()).Return(existing, nil)
+			if tt.wantErr == "" {
+				mockRepo.On("Update", ctx, mock.MatchedBy(func(u user.User) bool {
+					return assert.ObjectsAreEqual(tt.wantProfile, u.Profile)
+				})).Return(nil)
+			}
+			svc := users.NewService(mockRepo, memory.NewUnitOfWork())
+
+			err := svc.Update(ctx, tt.req)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cheezecakee/logr"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
+)
+
+// reservation returns the change that keeps username for its previous
+// owner, nil when nobody holds it.
+func (s *Service) reservation(ctx context.Context, username string, now time.Time) (*user.UsernameChange, error) {
+	change, err := s.userRepo.GetUsernameReservation(ctx, username, now.Add(-user.UsernameReservation))
+	if err != nil {
+		if errors.Is(err, ports.ErrNotReserved) {
+			return nil, nil
+		}
+		logr.Get().Errorf("failed to check username reservation: %v", err)
+		return nil, fmt.Errorf("failed to check username reservation: %w", err)
+	}
+	return change, nil
+}
+
+// changeUsername checks that u may move to username and returns the change
+// to record. Support staff skip the cooldown but not the reservations.
+func (s *Service) changeUsername(ctx context.Context, u *ports.User, username user.Username, skipCooldown bool, now time.Time) (user.UsernameChange, error) {
+	if !skipCooldown {
+		changes, err := s.userRepo.GetUsernameChanges(ctx, u.ID.String())
+		if err != nil {
+			logr.Get().Errorf("failed to get username changes: %v", err)
+			return user.UsernameChange{}, fmt.Errorf("failed to get username changes: %w", err)
+		}
+		if len(changes) > 0 {
+			if err := changes[0].AllowsChange(now); err != nil {
+				logr.Get().Errorf("username change refused: %v", err)
+				return user.UsernameChange{}, err
+			}
+		}
+	}
+
+	userWithUsername, err := s.userRepo.GetByUsername(ctx, string(username))
+	if err != nil && !errors.Is(err, ports.ErrUserNotFound) {
+		logr.Get().Errorf("failed to check username: %v", err)
+		return user.UsernameChange{}, fmt.Errorf("failed to check username: %w", err)
+	}
+	if userWithUsername != nil && userWithUsername.ID != u.ID {
+		logr.Get().Error("username already exists")
+		return user.UsernameChange{}, ErrDuplicateUsername
+	}
+
+	// Users may take back their own old names
+	reserved, err := s.reservation(ctx, string(username), now)
+	if err != nil {
+		return user.UsernameChange{}, err
+	}
+	if reserved != nil && reserved.UserID != u.ID {
+		logr.Get().Error("username is reserved by its previous owner")
+		return user.UsernameChange{}, ErrDuplicateUsername
+	}
+
+	return user.NewUsernameChange(u.ID, u.Username, username, now), nil
+}
@@ -4,7 +4,12 @@ package users
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
 )
 
@@ -16,11 +21,14 @@ var (
 
 type UserService interface {
 	CreateAccount(ctx context.Context, req CreateAccountReq) (*CreateAccountResp, error)
+	ProvisionAccount(ctx context.Context, req ProvisionAccountReq) (*CreateAccountResp, error)
 	GetByID(ctx context.Context, req GetUserByIDReq) (*GetUserResp, error)
-	GetByUsername(ctx context.Context, req GetUserByUsernameReq) (*GetUserResp, error)
+	GetByUsername(ctx context.Context, req GetUserByUsernameReq) (*GetPublicUserResp, error)
 	GetByEmail(ctx context.Context, req GetUserByEmailReq) (*GetUserResp, error)
+	GetFullProfile(ctx context.Context, req GetFullProfileReq) (*GetFullProfileResp, error)
 	Update(ctx context.Context, req UpdateUserReq) error
 	Delete(ctx context.Context, req DeleteAccountReq) error
+	PurgeDeleted(ctx context.Context) (*PurgeDeletedResp, error)
 
 	GetStats(ctx context.Context, req GetStatsReq) (*GetStatsResp, error)
 	GetSubscription(ctx context.Context, req GetSubscriptionReq) (*GetSubscriptionResp, error)
@@ -36,12 +44,86 @@ type UserService interface {
 	UpdateBodyMetrics(ctx context.Context, req UpdateBodyMetricsReq) error
 }
 
+// DataPurger removes what another service keeps about a user outside the
+// database, such as stored files. UserFiles is read in the unit of work that
+// purges the account, while the rows naming the files still exist.
+// DeleteFiles runs once that unit of work committed, files can not be
+// brought back if it rolls back.
+type DataPurger interface {
+	UserFiles(ctx context.Context, userID string) ([]string, error)
+	DeleteFiles(ctx context.Context, keys []string) error
+}
+
+// AvatarLookup tells whether a user has uploaded an avatar.
+type AvatarLookup interface {
+	HasAvatar(ctx context.Context, userID string) (bool, error)
+}
+
 type Service struct {
 	userRepo ports.UserRepo
+	uow      ports.UnitOfWork
+	auditLog ports.AuditLog
+	purgers  []DataPurger
+	avatars  AvatarLookup
 }
 
-func NewService(userRepo ports.UserRepo) *Service {
-	return &Service{
+type Option func(s *Service)
+
+// WithAuditLog records subscription changes, payments and account deletion,
+// in the same unit of work as the change.
+func WithAuditLog(auditLog ports.AuditLog) Option {
+	return func(s *Service) { s.auditLog = auditLog }
+}
+
+// WithDataPurgers deletes the files of purged accounts. Failing to list them
+// leaves the account for the next run, failing to delete them once the
+// account is gone is logged.
+func WithDataPurgers(purgers ...DataPurger) Option {
+	return func(s *Service) { s.purgers = append(s.purgers, purgers...) }
+}
+
+// WithAvatars fills in GetUserResp.HasAvatar, users have no avatar without
+// it.
+func WithAvatars(avatars AvatarLookup) Option {
+	return func(s *Service) { s.avatars = avatars }
+}
+
+func NewService(userRepo ports.UserRepo, uow ports.UnitOfWork, opts ...Option) *Service {
+	s := &Service{
 		userRepo: userRepo,
+		uow:      uow,
 	}
+
+	for _, applyOption := range opts {
+		applyOption(s)
+	}
+
+	return s
+}
+
+// record appends an event the user caused on their own account. Unlike sign
+// in events, a failure here fails the change it describes.
+func (s *Service) record(ctx context.Context, action audit.Action, userID string, metadata map[string]string) error {
+	if s.auditLog == nil {
+		return nil
+	}
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		logr.Get().Errorf("invalid user id: %v", err)
+		return fmt.Errorf("invalid user id: %w", err)
+	}
+
+	event, err := audit.NewEvent(action, id, id, metadata)
+	if err != nil {
+		logr.Get().Errorf("failed to create audit event: %v", err)
+		return fmt.Errorf("failed to create audit event: %w", err)
+	}
+
+	if err := s.auditLog.Record(ctx, event.WithClient(audit.ClientFromContext(ctx))); err != nil {
+		logr.Get().Errorf("failed to record audit event: %v", err)
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+
+	return nil
 }
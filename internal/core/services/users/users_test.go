@@ -7,8 +7,12 @@ import (
 	"time"
 
 	"github.com/cheezecakee/logr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/cheezecakee/fitrkr-athena/internal/adapters/secondary/db/memory"
+	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/audit"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/domain/user"
 	"github.com/cheezecakee/fitrkr-athena/internal/core/ports"
 )
@@ -51,11 +55,67 @@ func (m *MockUserRepo) Update(ctx context.Context, u user.User) error {
 	return args.Error(0)
 }
 
+func (m *MockUserRepo) UpdateRoles(ctx context.Context, id string, roles user.Roles, updatedAt time.Time) error {
+	args := m.Called(ctx, id, roles, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) UpdatePassword(ctx context.Context, id string, password user.Password, updatedAt time.Time) error {
+	args := m.Called(ctx, id, password, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) UpdateStatus(ctx context.Context, id string, status user.AccountStatus, updatedAt time.Time) error {
+	args := m.Called(ctx, id, status, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) Search(ctx context.Context, filter ports.UserFilter) ([]*ports.User, int, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*ports.User), args.Int(1), args.Error(2)
+}
+
+func (m *MockUserRepo) GetDueForPurge(ctx context.Context, requestedBefore time.Time, limit int) ([]*ports.User, error) {
+	args := m.Called(ctx, requestedBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*ports.User), args.Error(1)
+}
+
+func (m *MockUserRepo) LockForPurge(ctx context.Context, id string, requestedBefore time.Time) error {
+	args := m.Called(ctx, id, requestedBefore)
+	return args.Error(0)
+}
+
 func (m *MockUserRepo) Delete(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockUserRepo) AddUsernameChange(ctx context.Context, change user.UsernameChange) error {
+	return m.Called(ctx, change).Error(0)
+}
+
+func (m *MockUserRepo) GetUsernameChanges(ctx context.Context, userID string) ([]user.UsernameChange, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]user.UsernameChange), args.Error(1)
+}
+
+func (m *MockUserRepo) GetUsernameReservation(ctx context.Context, username string, changedAfter time.Time) (*user.UsernameChange, error) {
+	args := m.Called(ctx, username, changedAfter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.UsernameChange), args.Error(1)
+}
+
 func (m *MockUserRepo) AddStats(ctx context.Context, stats user.Stats, userID string) error {
 	args := m.Called(ctx, stats, userID)
 	return args.Error(0)
@@ -134,3 +194,47 @@ func boolPtr(b bool) *bool {
 func ptrFloat64(v float64) *float64 {
 	return &v
 }
+
+type MockAuditLog struct {
+	mock.Mock
+}
+
+func (m *MockAuditLog) Record(ctx context.Context, event audit.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockAuditLog) Search(ctx context.Context, filter ports.AuditFilter) ([]audit.Event, int, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]audit.Event), args.Int(1), args.Error(2)
+}
+
+func (m *MockAuditLog) Erase(ctx context.Context, userID, tombstone uuid.UUID) error {
+	args := m.Called(ctx, userID, tombstone)
+	return args.Error(0)
+}
+
+// inUnitOfWork fails the test when the mocked call runs outside a unit of
+// work of uow, the memory fake does not roll back so the test has to check
+// the writes would have been.
+func inUnitOfWork(t *testing.T, uow *memory.UnitOfWork) func(mock.Arguments) {
+	return func(mock.Arguments) {
+		assert.True(t, uow.Running(), "expected the call to run in the unit of work")
+	}
+}
+
+// callsAfter returns the methods called on m after its last call of method.
+func callsAfter(m *mock.Mock, method string) []string {
+	var after []string
+	for _, call := range m.Calls {
+		if call.Method == method {
+			after = nil
+			continue
+		}
+		after = append(after, call.Method)
+	}
+	return after
+}
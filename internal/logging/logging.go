@@ -0,0 +1,103 @@
+// Package logging configures logr for the service and keeps credentials out
+// of its output.
+package logging
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/cheezecakee/logr"
+)
+
+var ErrUnknownFormat = errors.New("unknown log format")
+
+const Redacted = "[REDACTED]"
+
+type Format string
+
+const (
+	Text Format = "text"
+	JSON Format = "json"
+)
+
+func NewFormat(name string) (Format, error) {
+	switch format := Format(name); format {
+	case Text, JSON:
+		return format, nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// Init sets up the global logger. logr only accepts the first call, so the
+// level and format must be known before anything logs.
+func Init(format Format, level logr.Level) {
+	logr.Init(NewFormatter(format), level, nil)
+}
+
+// NewFormatter returns the logr formatter for format with redaction applied.
+func NewFormatter(format Format) logr.Formatter {
+	if format == JSON {
+		return RedactingFormatter{Next: logr.JSONFormatter{}}
+	}
+	return RedactingFormatter{Next: &logr.PlainTextFormatter{}}
+}
+
+// RedactingFormatter scrubs credentials from the message and metadata of
+// every entry before Next formats it.
+type RedactingFormatter struct {
+	Next logr.Formatter
+}
+
+func (f RedactingFormatter) Format(entry logr.LogEntry) string {
+	entry.Message = Redact(entry.Message)
+
+	if entry.Metadata != nil {
+		meta := logr.NewMetadata()
+		for key, value := range entry.Metadata.Data {
+			if s, ok := value.(string); ok {
+				if sensitiveKey.MatchString(key) {
+					s = Redacted
+				}
+				value = Redact(s)
+			}
+			meta.Add(key, value)
+		}
+		entry.Metadata = meta
+	}
+
+	return f.Next.Format(entry)
+}
+
+var sensitiveKey = regexp.MustCompile(`(?i)^(authorization|cookie|set-cookie|password|secret|token|api_key)$`)
+
+type rule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// rules run in order, the header rules first so a Bearer token is not left
+// half redacted by the key=value rule.
+var rules = []rule{
+	// Authorization header values
+	{regexp.MustCompile(`(?i)\b(Bearer|ApiKey|Basic)\s+[A-Za-z0-9\-._~+/]+=*`), "$1 " + Redacted},
+	// Cookie headers, as printed by %v on an http.Header or raw
+	{regexp.MustCompile(`(?i)\b((?:set-)?cookie:?\s*\[?)[^\]\n]+`), "${1}" + Redacted},
+	// JWTs anywhere
+	{regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`), Redacted},
+	// API keys keep their lookup prefix
+	{regexp.MustCompile(`\b(fk_[0-9a-f]+_)[A-Za-z0-9_-]+`), "${1}" + Redacted},
+	// Credentials in URLs, such as connection strings
+	{regexp.MustCompile(`(\b[a-zA-Z][a-zA-Z0-9+.-]*://[^:/@\s]+:)[^@\s]+@`), "${1}" + Redacted + "@"},
+	// key=value and key: value pairs, in DSNs, query strings and JSON
+	{regexp.MustCompile(`(?i)\b(password|passwd|pwd|secret|client_secret|token|access_token|refresh_token|session|api_key|apikey)("?\s*[=:]\s*"?)[^\s&"',;]+`), "${1}${2}" + Redacted},
+}
+
+// Redact replaces tokens, cookies, passwords and connection string
+// credentials in s.
+func Redact(s string) string {
+	for _, r := range rules {
+		s = r.pattern.ReplaceAllString(s, r.replacement)
+	}
+	return s
+}
@@ -0,0 +1,187 @@
+// Package telemetry sets up the OpenTelemetry trace and meter providers the
+// rest of the app reports to.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+var ErrUnknownExporter = errors.New("unknown telemetry exporter")
+
+type Exporter string
+
+const (
+	None       Exporter = "none"
+	OTLP       Exporter = "otlp"
+	Stdout     Exporter = "stdout"
+	Prometheus Exporter = "prometheus"
+)
+
+func NewExporter(name string) (Exporter, error) {
+	switch exporter := Exporter(name); exporter {
+	case "":
+		return None, nil
+	case None, OTLP, Stdout, Prometheus:
+		return exporter, nil
+	default:
+		return "", ErrUnknownExporter
+	}
+}
+
+type Config struct {
+	ServiceName    string
+	ServiceVersion string
+	Exporter       Exporter
+	// OTLPEndpoint is the collector URL, the OTEL_EXPORTER_OTLP_* variables
+	// apply when it is empty.
+	OTLPEndpoint string
+	// SampleRatio is the share of new traces recorded, requests that arrive
+	// with a sampled parent are always recorded.
+	SampleRatio float64
+}
+
+// Telemetry holds the providers built by Setup.
+type Telemetry struct {
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	// MetricsHandler serves the Prometheus scrape endpoint, it is nil for
+	// the other exporters.
+	MetricsHandler http.Handler
+
+	shutdown []func(ctx context.Context) error
+}
+
+// Setup builds the providers for cfg.Exporter and installs them as the
+// global providers, along with W3C trace context propagation. Traces are
+// only exported by OTLP and stdout, Prometheus only scrapes metrics.
+func Setup(ctx context.Context, cfg Config) (*Telemetry, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if cfg.Exporter == None || cfg.Exporter == "" {
+		t := &Telemetry{
+			TracerProvider: tracenoop.NewTracerProvider(),
+			MeterProvider:  metricnoop.NewMeterProvider(),
+		}
+		t.install()
+		return t, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.ServiceVersion(cfg.ServiceVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build telemetry resource: %w", err)
+	}
+
+	t := &Telemetry{}
+
+	spanExporter, reader, err := t.exporters(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if spanExporter != nil {
+		tp := sdktrace.NewTracerProvider(
+			sdktrace.WithResource(res),
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+			sdktrace.WithBatcher(spanExporter),
+		)
+		t.TracerProvider = tp
+		t.shutdown = append(t.shutdown, tp.Shutdown)
+	} else {
+		t.TracerProvider = tracenoop.NewTracerProvider()
+	}
+
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithResource(res), sdkmetric.WithReader(reader))
+	t.MeterProvider = mp
+	t.shutdown = append(t.shutdown, mp.Shutdown)
+
+	t.install()
+	return t, nil
+}
+
+// exporters returns the span exporter, nil when traces are not exported, and
+// the metric reader of cfg.Exporter.
+func (t *Telemetry) exporters(ctx context.Context, cfg Config) (sdktrace.SpanExporter, sdkmetric.Reader, error) {
+	switch cfg.Exporter {
+	case OTLP:
+		var traceOpts []otlptracehttp.Option
+		var metricOpts []otlpmetrichttp.Option
+		if cfg.OTLPEndpoint != "" {
+			traceOpts = append(traceOpts, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint+"/v1/traces"))
+			metricOpts = append(metricOpts, otlpmetrichttp.WithEndpointURL(cfg.OTLPEndpoint+"/v1/metrics"))
+		}
+
+		spans, err := otlptracehttp.New(ctx, traceOpts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create otlp trace exporter: %w", err)
+		}
+		metrics, err := otlpmetrichttp.New(ctx, metricOpts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create otlp metric exporter: %w", err)
+		}
+		return spans, sdkmetric.NewPeriodicReader(metrics), nil
+
+	case Stdout:
+		spans, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create stdout trace exporter: %w", err)
+		}
+		metrics, err := stdoutmetric.New(stdoutmetric.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create stdout metric exporter: %w", err)
+		}
+		return spans, sdkmetric.NewPeriodicReader(metrics), nil
+
+	case Prometheus:
+		registry := prometheus.NewRegistry()
+		reader, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create prometheus exporter: %w", err)
+		}
+		t.MetricsHandler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+		return nil, reader, nil
+
+	default:
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnknownExporter, cfg.Exporter)
+	}
+}
+
+func (t *Telemetry) install() {
+	otel.SetTracerProvider(t.TracerProvider)
+	otel.SetMeterProvider(t.MeterProvider)
+}
+
+// Shutdown flushes whatever is still buffered and stops the exporters.
+func (t *Telemetry) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, shutdown := range t.shutdown {
+		if err := shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,87 @@
+package telemetry_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cheezecakee/fitrkr-athena/internal/telemetry"
+)
+
+func TestNewExporter(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    telemetry.Exporter
+		wantErr error
+	}{
+		{name: "", want: telemetry.None},
+		{name: "none", want: telemetry.None},
+		{name: "otlp", want: telemetry.OTLP},
+		{name: "stdout", want: telemetry.Stdout},
+		{name: "prometheus", want: telemetry.Prometheus},
+		{name: "zipkin", wantErr: telemetry.ErrUnknownExporter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := telemetry.NewExporter(tt.name)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got: %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSetup_None(t *testing.T) {
+	tel, err := telemetry.Setup(context.Background(), telemetry.Config{Exporter: telemetry.None})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if tel.MetricsHandler != nil {
+		t.Error("expected no metrics handler")
+	}
+	if err := tel.Shutdown(context.Background()); err != nil {
+		t.Errorf("expected no error on shutdown, got: %v", err)
+	}
+}
+
+func TestSetup_Prometheus(t *testing.T) {
+	tel, err := telemetry.Setup(context.Background(), telemetry.Config{
+		ServiceName: "athena-test",
+		Exporter:    telemetry.Prometheus,
+		SampleRatio: 1,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	defer tel.Shutdown(context.Background())
+
+	counter, err := tel.MeterProvider.Meter("test").Int64Counter("test.requests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter.Add(context.Background(), 3)
+
+	if tel.MetricsHandler == nil {
+		t.Fatal("expected a metrics handler")
+	}
+
+	rec := httptest.NewRecorder()
+	tel.MetricsHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	body, _ := io.ReadAll(rec.Body)
+	if !strings.Contains(string(body), "test_requests_total") {
+		t.Errorf("expected the counter to be scraped, got:\n%s", body)
+	}
+}